@@ -5,7 +5,6 @@
 package beego
 
 import (
-	"bytes"
 	"errors"
 	"net/http"
 	"net/url"
@@ -88,11 +87,7 @@ func (bee *Beego) AddHandler(method, path string, handlers gctx.Handlers) {
 			c.ResponseWriter.Header().Add(key, head[0])
 		}
 		c.ResponseWriter.WriteHeader(ctx.Response.StatusCode)
-		if ctx.Response.Body != nil {
-			buf := new(bytes.Buffer)
-			_, _ = buf.ReadFrom(ctx.Response.Body)
-			c.WriteString(buf.String())
-		}
+		_ = ctx.WriteBody(c.ResponseWriter)
 	})
 }
 
@@ -1,7 +1,6 @@
 package beego2
 
 import (
-	"bytes"
 	"errors"
 	"net/http"
 	"net/url"
@@ -60,11 +59,7 @@ func (bee2 *Beego2) AddHandler(method, path string, handlers gctx.Handlers) {
 			c.ResponseWriter.Header().Add(key, head[0])
 		}
 		c.ResponseWriter.WriteHeader(ctx.Response.StatusCode)
-		if ctx.Response.Body != nil {
-			buf := new(bytes.Buffer)
-			_, _ = buf.ReadFrom(ctx.Response.Body)
-			c.WriteString(buf.String())
-		}
+		_ = ctx.WriteBody(c.ResponseWriter)
 	})
 }
 
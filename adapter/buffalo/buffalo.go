@@ -5,7 +5,6 @@
 package buffalo
 
 import (
-	"bytes"
 	"errors"
 	"net/http"
 	neturl "net/url"
@@ -103,14 +102,8 @@ func (bu *Buffalo) AddHandler(method, path string, handlers context.Handlers) {
 		for key, head := range ctx.Response.Header {
 			c.Response().Header().Set(key, head[0])
 		}
-		if ctx.Response.Body != nil {
-			buf := new(bytes.Buffer)
-			_, _ = buf.ReadFrom(ctx.Response.Body)
-			c.Response().WriteHeader(ctx.Response.StatusCode)
-			_, _ = c.Response().Write(buf.Bytes())
-		} else {
-			c.Response().WriteHeader(ctx.Response.StatusCode)
-		}
+		c.Response().WriteHeader(ctx.Response.StatusCode)
+		_ = ctx.WriteBody(c.Response())
 		return nil
 	})
 }
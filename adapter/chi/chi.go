@@ -5,7 +5,6 @@
 package chi
 
 import (
-	"bytes"
 	"errors"
 	"net/http"
 	"net/url"
@@ -110,14 +109,8 @@ func (ch *Chi) AddHandler(method, path string, handlers context.Handlers) {
 		for key, head := range ctx.Response.Header {
 			w.Header().Set(key, head[0])
 		}
-		if ctx.Response.Body != nil {
-			buf := new(bytes.Buffer)
-			_, _ = buf.ReadFrom(ctx.Response.Body)
-			w.WriteHeader(ctx.Response.StatusCode)
-			_, _ = w.Write(buf.Bytes())
-		} else {
-			w.WriteHeader(ctx.Response.StatusCode)
-		}
+		w.WriteHeader(ctx.Response.StatusCode)
+		_ = ctx.WriteBody(w)
 	})
 }
 
@@ -5,7 +5,6 @@
 package echo
 
 import (
-	"bytes"
 	"errors"
 	"net/http"
 	"net/url"
@@ -89,13 +88,8 @@ func (e *Echo) AddHandler(method, path string, handlers context.Handlers) {
 		for key, head := range ctx.Response.Header {
 			c.Response().Header().Set(key, head[0])
 		}
-		if ctx.Response.Body != nil {
-			buf := new(bytes.Buffer)
-			_, _ = buf.ReadFrom(ctx.Response.Body)
-			_ = c.String(ctx.Response.StatusCode, buf.String())
-		} else {
-			c.Response().WriteHeader(ctx.Response.StatusCode)
-		}
+		c.Response().WriteHeader(ctx.Response.StatusCode)
+		_ = ctx.WriteBody(c.Response())
 		return nil
 	})
 }
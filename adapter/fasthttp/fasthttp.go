@@ -5,7 +5,6 @@
 package fasthttp
 
 import (
-	"bytes"
 	"errors"
 	"io"
 	"net/http"
@@ -100,9 +99,8 @@ func (fast *Fasthttp) AddHandler(method, path string, handlers context.Handlers)
 			c.Response.Header.Set(key, head[0])
 		}
 		if ctx.Response.Body != nil {
-			buf := new(bytes.Buffer)
-			_, _ = buf.ReadFrom(ctx.Response.Body)
-			_, _ = c.WriteString(buf.String())
+			// the body is closed by fasthttp after it is sent.
+			c.SetBodyStream(ctx.Response.Body, -1)
 		}
 		c.Response.SetStatusCode(ctx.Response.StatusCode)
 	})
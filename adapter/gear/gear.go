@@ -8,7 +8,6 @@
 package gear
 
 import (
-	"bytes"
 	"errors"
 	"net/http"
 	"net/url"
@@ -117,10 +116,8 @@ func (gears *Gear) AddHandler(method, path string, handlers context.Handlers) {
 		}
 
 		if ctx.Response.Body != nil {
-			buf := new(bytes.Buffer)
-			_, _ = buf.ReadFrom(ctx.Response.Body)
-
-			return c.End(ctx.Response.StatusCode, buf.Bytes())
+			c.Res.WriteHeader(ctx.Response.StatusCode)
+			return ctx.WriteBody(c.Res)
 		}
 
 		c.Status(ctx.Response.StatusCode)
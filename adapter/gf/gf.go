@@ -102,7 +102,12 @@ func (gf *Gf) AddHandler(method, path string, handlers context.Handlers) {
 			c.Response.Header().Add(key, head[0])
 		}
 
-		if ctx.Response.Body != nil {
+		if ctx.Response.Body != nil && ctx.Response.StatusCode == http.StatusOK {
+			// the body is flushed as it is copied, so that a streamed body is
+			// not held in the buffer of the response.
+			c.Response.WriteHeader(http.StatusOK)
+			_ = ctx.WriteBody(flushWriter{c.Response})
+		} else if ctx.Response.Body != nil {
 			buf := new(bytes.Buffer)
 			_, _ = buf.ReadFrom(ctx.Response.Body)
 			c.Response.WriteStatus(ctx.Response.StatusCode, buf.Bytes())
@@ -179,3 +184,14 @@ func (gf *Gf) IsPjax() bool {
 func (gf *Gf) Query() url.Values {
 	return gf.ctx.Request.URL.Query()
 }
+
+// flushWriter writes to the buffer of the response and flushes it.
+type flushWriter struct {
+	res *ghttp.Response
+}
+
+func (w flushWriter) Write(p []byte) (int, error) {
+	n, err := w.res.Writer.Write(p)
+	w.res.Flush()
+	return n, err
+}
@@ -74,7 +74,12 @@ func (gf2 *GF2) AddHandler(method, path string, handlers context.Handlers) {
 			c.Response.Header().Add(key, head[0])
 		}
 
-		if ctx.Response.Body != nil {
+		if ctx.Response.Body != nil && ctx.Response.StatusCode == http.StatusOK {
+			// the body is flushed as it is copied, so that a streamed body is
+			// not held in the buffer of the response.
+			c.Response.WriteHeader(http.StatusOK)
+			_ = ctx.WriteBody(flushWriter{c.Response})
+		} else if ctx.Response.Body != nil {
 			buf := new(bytes.Buffer)
 			_, _ = buf.ReadFrom(ctx.Response.Body)
 			c.Response.WriteStatus(ctx.Response.StatusCode, buf.Bytes())
@@ -146,3 +151,14 @@ func (gf2 *GF2) SetContentType() {
 func (gf2 *GF2) Write(body []byte) {
 	gf2.ctx.Response.WriteStatus(http.StatusOK, body)
 }
+
+// flushWriter writes to the buffer of the response and flushes it.
+type flushWriter struct {
+	res *ghttp.Response
+}
+
+func (w flushWriter) Write(p []byte) (int, error) {
+	n, err := w.res.Writer.Write(p)
+	w.res.Flush()
+	return n, err
+}
@@ -5,7 +5,6 @@
 package iris
 
 import (
-	"bytes"
 	"errors"
 	"net/http"
 	"net/url"
@@ -95,11 +94,7 @@ func (is *Iris) AddHandler(method, path string, handlers context.Handlers) {
 			c.Header(key, head[0])
 		}
 		c.StatusCode(ctx.Response.StatusCode)
-		if ctx.Response.Body != nil {
-			buf := new(bytes.Buffer)
-			_, _ = buf.ReadFrom(ctx.Response.Body)
-			_, _ = c.WriteString(buf.String())
-		}
+		_ = ctx.WriteBody(c.ResponseWriter())
 	})
 }
 
@@ -138,14 +138,61 @@ func main() {
 			config = cmd.StringOpt("c config", "", "config ini path")
 			lang   = cmd.StringOpt("l language", "en", "language")
 			conn   = cmd.StringOpt("conn connection", "", "connection")
+			spec   = cmd.StringOpt("s spec", "", "yaml or json spec path, generate from the spec without prompts")
+			diff   = cmd.BoolOpt("d diff", false, "show the changes of the spec without writing, exit with 1 if any")
 		)
 
 		cmd.Action = func() {
 			setDefaultLangSet(*lang)
+			if *spec != "" {
+				generatingFromSpec(*spec, *diff)
+				return
+			}
 			generating(*config, *conn)
 		}
 	})
 
+	app.Command("migrate", "migrate the goadmin system tables", func(cmd *cli.Cmd) {
+
+		var (
+			config = cmd.StringOpt("c config", "", "config ini path")
+			lang   = cmd.StringOpt("l language", "en", "language")
+			conn   = cmd.StringOpt("conn connection", "", "connection")
+		)
+
+		cmd.Command("up", "apply the pending migrations", func(cmd *cli.Cmd) {
+			cmd.Action = func() {
+				setDefaultLangSet(*lang)
+				migrating(*config, *conn, "up", 0, "")
+			}
+		})
+
+		cmd.Command("down", "revert the latest applied migrations", func(cmd *cli.Cmd) {
+			steps := cmd.IntOpt("n steps", 1, "number of the migrations to revert")
+
+			cmd.Action = func() {
+				setDefaultLangSet(*lang)
+				migrating(*config, *conn, "down", *steps, "")
+			}
+		})
+
+		cmd.Command("status", "show the applied and pending migrations", func(cmd *cli.Cmd) {
+			cmd.Action = func() {
+				setDefaultLangSet(*lang)
+				migrating(*config, *conn, "status", 0, "")
+			}
+		})
+
+		cmd.Command("baseline", "record the migrations as applied without running, for the databases imported from the sql dump", func(cmd *cli.Cmd) {
+			version := cmd.StringOpt("version", "", "the last version to record, default all")
+
+			cmd.Action = func() {
+				setDefaultLangSet(*lang)
+				migrating(*config, *conn, "baseline", 0, *version)
+			}
+		})
+	})
+
 	app.Command("init", "generate a template project", func(cmd *cli.Cmd) {
 
 		var (
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"runtime"
@@ -15,6 +17,7 @@ import (
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/tools"
 	"github.com/mgutz/ansi"
+	"github.com/pmezard/go-difflib/difflib"
 	"github.com/schollz/progressbar"
 	"gopkg.in/ini.v1"
 )
@@ -22,9 +25,18 @@ import (
 var systemGoAdminTables = []string{
 	"goadmin_menu",
 	"goadmin_operation_log",
+	"goadmin_audit_log",
+	"goadmin_api_tokens",
+	"goadmin_saved_views",
+	"goadmin_dashboards",
+	"goadmin_dashboard_widgets",
+	"goadmin_webhooks",
+	"goadmin_webhook_deliveries",
+	"goadmin_user_identities",
 	"goadmin_permissions",
 	"goadmin_role_menu",
 	"goadmin_site",
+	"goadmin_migrations",
 	"goadmin_roles",
 	"goadmin_session",
 	"goadmin_users",
@@ -306,3 +318,62 @@ func singleSelect(msg string, options []string, def string) string {
 	return result["question"].(core.OptionAnswer).Value
 
 }
+
+// generatingFromSpec generate the table model files from the spec without
+// prompts or a database connection. With diff, the changes are printed
+// instead of written, and the exit code is 1 if there are any.
+func generatingFromSpec(specPath string, diff bool) {
+
+	spec, err := tools.LoadSpec(specPath)
+	checkError(err)
+
+	files, err := spec.Generate()
+	checkError(err)
+
+	changed := 0
+
+	for _, file := range files {
+		old, err := ioutil.ReadFile(file.Path)
+		if err != nil && !os.IsNotExist(err) {
+			panic(err)
+		}
+
+		if bytes.Equal(old, file.Content) {
+			continue
+		}
+
+		changed++
+
+		if diff {
+			a := make([]string, 0)
+			if len(old) > 0 {
+				a = difflib.SplitLines(string(old))
+			}
+			text, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+				A:        a,
+				B:        difflib.SplitLines(string(file.Content)),
+				FromFile: file.Path,
+				ToFile:   file.Path,
+				Context:  3,
+			})
+			checkError(err)
+			fmt.Print(text)
+			continue
+		}
+
+		checkError(ioutil.WriteFile(file.Path, file.Content, 0644))
+		fmt.Println(ansi.Color("✔", "green") + " " + file.Path)
+	}
+
+	if changed == 0 {
+		fmt.Println(getWord("no changes"))
+		return
+	}
+
+	if diff {
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	fmt.Println(ansi.Color(getWord("Generate data table models success~~🍺🍺"), "green"))
+}
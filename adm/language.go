@@ -48,6 +48,10 @@ var langs = map[string]map[string]string{
 		"see the docs: ": "查看文档：",
 		"visit forum: ":  "访问论坛：",
 		"generating: ":   "生成中：",
+		"no changes":     "没有变更",
+
+		"pending":             "待执行",
+		"Migrate success~~🍺🍺": "迁移成功~~🍺🍺",
 
 		"choose a theme":   "选择主题",
 		"choose language":  "选择语言",
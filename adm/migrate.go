@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/GoAdminGroup/go-admin/modules/migration"
+	"github.com/mgutz/ansi"
+	"gopkg.in/ini.v1"
+)
+
+// migrating run the action of up, down, status or baseline with the
+// migrations of the goadmin system tables.
+func migrating(cfgFile, connName, action string, steps int, version string) {
+
+	info := new(dbInfo)
+
+	if cfgFile != "" {
+		cfgModel, err := ini.Load(cfgFile)
+
+		if err != nil {
+			panic(newError("wrong config file path"))
+		}
+
+		languageCfg, err := cfgModel.GetSection("language")
+
+		if err == nil {
+			setDefaultLangSet(languageCfg.Key("language").Value())
+		}
+
+		info = getDBInfoFromINIConfig(cfgModel, connName)
+	}
+
+	migrator, err := migration.New(askForDBConnection(info))
+	checkError(err)
+
+	var versions []string
+
+	switch action {
+	case "up":
+		versions, err = migrator.Up()
+	case "down":
+		versions, err = migrator.Down(steps)
+	case "baseline":
+		versions, err = migrator.Baseline(version)
+	default:
+		status, err := migrator.Status()
+		checkError(err)
+		for _, item := range status {
+			if item.Applied {
+				fmt.Println(ansi.Color("✔", "green") + " " + item.Version + "  " + item.AppliedAt)
+			} else {
+				fmt.Println(ansi.Color("✘", "yellow") + " " + item.Version + "  " + getWord("pending"))
+			}
+		}
+		return
+	}
+
+	for _, v := range versions {
+		fmt.Println(ansi.Color("✔", "green") + " " + v)
+	}
+	checkError(err)
+
+	if len(versions) == 0 {
+		fmt.Println(getWord("no changes"))
+		return
+	}
+
+	printSuccessInfo("Migrate success~~🍺🍺")
+}
@@ -207,6 +207,28 @@ func (ctx *Context) Data(code int, contentType string, data []byte) {
 	ctx.Response.Body = ioutil.NopCloser(bytes.NewBuffer(data))
 }
 
+// DataStream sets the HTTP code and the content type, the body of the response
+// is read from the given reader.
+func (ctx *Context) DataStream(code int, contentType string, body io.ReadCloser) {
+	ctx.Response.StatusCode = code
+	ctx.SetContentType(contentType)
+	ctx.Response.Body = body
+}
+
+// WriteBody copies the body of the response to the writer without reading it
+// into memory first, so that a streamed body is sent as it is produced, and
+// then closes the body. It is used by the adapters.
+func (ctx *Context) WriteBody(w io.Writer) error {
+	if ctx.Response.Body == nil {
+		return nil
+	}
+	defer func() {
+		_ = ctx.Response.Body.Close()
+	}()
+	_, err := io.Copy(w, ctx.Response.Body)
+	return err
+}
+
 // Redirect add redirect url to header.
 func (ctx *Context) Redirect(path string) {
 	ctx.Response.StatusCode = http.StatusFound
@@ -77,6 +77,165 @@ CREATE TABLE `goadmin_operation_log` (
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 
+# Dump of table goadmin_audit_log
+# ------------------------------------------------------------
+
+DROP TABLE IF EXISTS `goadmin_audit_log`;
+
+CREATE TABLE `goadmin_audit_log` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) unsigned NOT NULL DEFAULT '0',
+  `user_name` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `table_name` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `record_id` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `action` varchar(10) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `changes` longtext COLLATE utf8mb4_unicode_ci NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `admin_audit_log_record_index` (`table_name`(100),`record_id`(100))
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+
+# Dump of table goadmin_api_tokens
+# ------------------------------------------------------------
+
+DROP TABLE IF EXISTS `goadmin_api_tokens`;
+
+CREATE TABLE `goadmin_api_tokens` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) unsigned NOT NULL DEFAULT '0',
+  `name` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `token_hash` varchar(64) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `scopes` varchar(1000) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `service_account` tinyint(1) NOT NULL DEFAULT '0',
+  `last_used_at` timestamp NULL DEFAULT NULL,
+  `expires_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `admin_api_tokens_token_hash_unique` (`token_hash`),
+  KEY `admin_api_tokens_user_id_index` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+
+# Dump of table goadmin_saved_views
+# ------------------------------------------------------------
+
+DROP TABLE IF EXISTS `goadmin_saved_views`;
+
+CREATE TABLE `goadmin_saved_views` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) unsigned NOT NULL DEFAULT '0',
+  `prefix` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `name` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `params` varchar(2000) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `role_id` int(11) DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `admin_saved_views_user_id_prefix_index` (`user_id`,`prefix`),
+  KEY `admin_saved_views_role_id_prefix_index` (`role_id`,`prefix`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+
+# Dump of table goadmin_dashboards
+# ------------------------------------------------------------
+
+DROP TABLE IF EXISTS `goadmin_dashboards`;
+
+CREATE TABLE `goadmin_dashboards` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `title` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `description` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+
+# Dump of table goadmin_dashboard_widgets
+# ------------------------------------------------------------
+
+DROP TABLE IF EXISTS `goadmin_dashboard_widgets`;
+
+CREATE TABLE `goadmin_dashboard_widgets` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `dashboard_id` int(11) unsigned NOT NULL DEFAULT '0',
+  `title` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `type` varchar(20) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `source` varchar(20) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'sql',
+  `query` text COLLATE utf8mb4_unicode_ci NOT NULL,
+  `width` int(11) unsigned NOT NULL DEFAULT '6',
+  `order` int(11) unsigned NOT NULL DEFAULT '0',
+  `refresh` int(11) unsigned NOT NULL DEFAULT '0',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `admin_dashboard_widgets_dashboard_id_index` (`dashboard_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+
+# Dump of table goadmin_webhooks
+# ------------------------------------------------------------
+
+DROP TABLE IF EXISTS `goadmin_webhooks`;
+
+CREATE TABLE `goadmin_webhooks` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `prefix` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '*',
+  `event` varchar(50) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '*',
+  `url` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `secret` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `enabled` tinyint(4) unsigned NOT NULL DEFAULT '1',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+
+# Dump of table goadmin_webhook_deliveries
+# ------------------------------------------------------------
+
+DROP TABLE IF EXISTS `goadmin_webhook_deliveries`;
+
+CREATE TABLE `goadmin_webhook_deliveries` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `webhook_id` int(11) unsigned NOT NULL DEFAULT '0',
+  `prefix` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `event` varchar(50) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `payload` text COLLATE utf8mb4_unicode_ci NOT NULL,
+  `status` varchar(20) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'pending',
+  `attempts` int(11) unsigned NOT NULL DEFAULT '0',
+  `next_attempt_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `response_code` int(11) unsigned NOT NULL DEFAULT '0',
+  `error` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `admin_webhook_deliveries_status_index` (`status`,`next_attempt_at`),
+  KEY `admin_webhook_deliveries_webhook_id_index` (`webhook_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+
+# Dump of table goadmin_user_identities
+# ------------------------------------------------------------
+
+DROP TABLE IF EXISTS `goadmin_user_identities`;
+
+CREATE TABLE `goadmin_user_identities` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) unsigned NOT NULL,
+  `provider` varchar(50) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `subject` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `admin_user_identities_provider_subject_unique` (`provider`,`subject`(191)),
+  KEY `admin_user_identities_user_id_index` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+
 # Dump of table goadmin_site
 # ------------------------------------------------------------
 
@@ -94,6 +253,41 @@ CREATE TABLE `goadmin_site` (
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 
+# Dump of table goadmin_migrations
+# ------------------------------------------------------------
+
+DROP TABLE IF EXISTS `goadmin_migrations`;
+
+CREATE TABLE `goadmin_migrations` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `version` varchar(50) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `admin_migrations_version_unique` (`version`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+LOCK TABLES `goadmin_migrations` WRITE;
+/*!40000 ALTER TABLE `goadmin_migrations` DISABLE KEYS */;
+
+INSERT INTO `goadmin_migrations` (`id`, `version`, `created_at`)
+VALUES
+	(1,'2020_04_14_100427','2026-10-18 00:00:00'),
+	(2,'2020_08_04_092427','2026-10-18 00:00:00'),
+	(3,'2026_10_18_101500','2026-10-18 00:00:00'),
+	(4,'2026_10_18_120000','2026-10-18 00:00:00'),
+	(5,'2026_10_18_140000','2026-10-18 00:00:00'),
+	(6,'2026_10_18_160000','2026-10-18 00:00:00'),
+	(7,'2026_10_18_170000','2026-10-18 00:00:00'),
+	(8,'2026_10_18_180000','2026-10-18 00:00:00'),
+	(9,'2026_10_18_190000','2026-10-18 00:00:00'),
+	(10,'2026_10_18_200000','2026-10-18 00:00:00'),
+	(11,'2026_10_18_210000','2026-10-18 00:00:00'),
+	(12,'2026_10_18_220000','2026-10-18 00:00:00');
+
+/*!40000 ALTER TABLE `goadmin_migrations` ENABLE KEYS */;
+UNLOCK TABLES;
+
+
 # Dump of table goadmin_permissions
 # ------------------------------------------------------------
 
@@ -238,9 +432,11 @@ CREATE TABLE `goadmin_session` (
   `id` int(11) unsigned NOT NULL AUTO_INCREMENT,
   `sid` varchar(50) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
   `values` varchar(3000) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `user_id` int(11) unsigned NOT NULL DEFAULT '0',
   `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
   `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
-  PRIMARY KEY (`id`)
+  PRIMARY KEY (`id`),
+  KEY `admin_session_user_id_index` (`user_id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
 
 
@@ -282,6 +478,12 @@ CREATE TABLE `goadmin_users` (
   `name` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
   `avatar` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
   `remember_token` varchar(100) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `totp_secret` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `totp_enabled` tinyint(1) NOT NULL DEFAULT '0',
+  `totp_recovery_codes` varchar(1000) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `totp_last_step` bigint(20) NOT NULL DEFAULT '0',
+  `totp_tries` int(11) unsigned NOT NULL DEFAULT '0',
+  `totp_locked_until` bigint(20) NOT NULL DEFAULT '0',
   `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
   `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
   PRIMARY KEY (`id`),
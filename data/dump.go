@@ -0,0 +1,11 @@
+package data
+
+import "embed"
+
+// Dumps are the sql dumps of the goadmin system tables with the initial
+// data, which are admin.sql of mysql, admin.pgsql of postgresql, admin.mssql
+// of mssql and admin.sqlite of sqlite, the dump of the database file
+// admin.db.
+//
+//go:embed admin.sql admin.pgsql admin.mssql admin.sqlite
+var Dumps embed.FS
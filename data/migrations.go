@@ -0,0 +1,12 @@
+// Package data holds the sql of the goadmin system tables, of which the
+// migrations are embedded for modules/migration.
+package data
+
+import "embed"
+
+// Migrations are the sql files of the goadmin system tables named as
+// admin_<version>_<dialect>.sql, and admin_<version>_<dialect>.down.sql
+// which reverts the version.
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
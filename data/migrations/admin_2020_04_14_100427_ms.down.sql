@@ -0,0 +1 @@
+IF OBJECT_ID(N'goadmin_site', N'U') IS NOT NULL DROP TABLE [goadmin_site]
\ No newline at end of file
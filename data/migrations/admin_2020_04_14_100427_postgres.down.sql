@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.goadmin_site;
+DROP SEQUENCE IF EXISTS public.goadmin_site_myid_seq;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "goadmin_site";
\ No newline at end of file
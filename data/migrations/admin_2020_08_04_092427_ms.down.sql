@@ -0,0 +1,8 @@
+DECLARE @sql nvarchar(max) = N''
+SELECT @sql += N'ALTER TABLE [goadmin_menu] DROP CONSTRAINT ' + QUOTENAME(d.name) + N';'
+FROM sys.default_constraints d
+JOIN sys.columns c ON d.parent_object_id = c.object_id AND d.parent_column_id = c.column_id
+WHERE d.parent_object_id = OBJECT_ID(N'goadmin_menu') AND c.name IN (N'plugin_name', N'uuid')
+EXEC sp_executesql @sql
+
+ALTER TABLE [goadmin_menu] DROP COLUMN [plugin_name], [uuid]
\ No newline at end of file
@@ -1,3 +1,3 @@
 ALTER TABLE goadmin_menu
 ADD plugin_name varchar(150) NOT NULL DEFAULT '',
-ADD uuid varchar(150) NOT NULL DEFAULT '';
\ No newline at end of file
+    uuid varchar(150) NOT NULL DEFAULT '';
\ No newline at end of file
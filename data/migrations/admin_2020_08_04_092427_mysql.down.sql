@@ -0,0 +1,3 @@
+ALTER TABLE goadmin_menu
+DROP COLUMN `uuid`,
+DROP COLUMN `plugin_name`;
\ No newline at end of file
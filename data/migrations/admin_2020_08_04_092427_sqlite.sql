@@ -1,3 +1,2 @@
-ALTER TABLE goadmin_menu
-ADD COLUMN `uuid` varchar(150) NOT NULL DEFAULT '',
-ADD COLUMN `plugin_name` varchar(150) NOT NULL DEFAULT '';
\ No newline at end of file
+ALTER TABLE goadmin_menu ADD COLUMN `uuid` varchar(150) NOT NULL DEFAULT '';
+ALTER TABLE goadmin_menu ADD COLUMN `plugin_name` varchar(150) NOT NULL DEFAULT '';
\ No newline at end of file
@@ -0,0 +1,8 @@
+DECLARE @sql nvarchar(max) = N''
+SELECT @sql += N'ALTER TABLE [goadmin_users] DROP CONSTRAINT ' + QUOTENAME(d.name) + N';'
+FROM sys.default_constraints d
+JOIN sys.columns c ON d.parent_object_id = c.object_id AND d.parent_column_id = c.column_id
+WHERE d.parent_object_id = OBJECT_ID(N'goadmin_users') AND c.name IN (N'totp_secret', N'totp_enabled', N'totp_recovery_codes')
+EXEC sp_executesql @sql
+
+ALTER TABLE [goadmin_users] DROP COLUMN [totp_secret], [totp_enabled], [totp_recovery_codes]
\ No newline at end of file
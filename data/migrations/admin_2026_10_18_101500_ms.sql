@@ -0,0 +1,4 @@
+ALTER TABLE goadmin_users
+ADD totp_secret varchar(100) NOT NULL DEFAULT '',
+    totp_enabled tinyint NOT NULL DEFAULT 0,
+    totp_recovery_codes varchar(1000) NOT NULL DEFAULT '';
\ No newline at end of file
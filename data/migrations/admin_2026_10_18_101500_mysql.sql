@@ -0,0 +1,4 @@
+ALTER TABLE goadmin_users
+ADD COLUMN `totp_secret` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+ADD COLUMN `totp_enabled` tinyint(1) NOT NULL DEFAULT '0',
+ADD COLUMN `totp_recovery_codes` varchar(1000) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '';
\ No newline at end of file
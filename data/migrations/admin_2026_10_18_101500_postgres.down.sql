@@ -0,0 +1,4 @@
+ALTER TABLE goadmin_users
+DROP COLUMN totp_secret,
+DROP COLUMN totp_enabled,
+DROP COLUMN totp_recovery_codes;
\ No newline at end of file
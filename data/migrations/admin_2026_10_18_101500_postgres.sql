@@ -0,0 +1,4 @@
+ALTER TABLE goadmin_users
+ADD COLUMN totp_secret character varying(100) NOT NULL DEFAULT '',
+ADD COLUMN totp_enabled smallint NOT NULL DEFAULT 0,
+ADD COLUMN totp_recovery_codes character varying(1000) NOT NULL DEFAULT '';
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE goadmin_users ADD COLUMN `totp_secret` varchar(100) NOT NULL DEFAULT '';
+ALTER TABLE goadmin_users ADD COLUMN `totp_enabled` INT NOT NULL DEFAULT '0';
+ALTER TABLE goadmin_users ADD COLUMN `totp_recovery_codes` varchar(1000) NOT NULL DEFAULT '';
\ No newline at end of file
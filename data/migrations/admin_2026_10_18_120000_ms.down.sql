@@ -0,0 +1 @@
+IF OBJECT_ID(N'goadmin_audit_log', N'U') IS NOT NULL DROP TABLE [goadmin_audit_log]
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE[goadmin_audit_log] (
+ [id] int   identity(1,1) ,
+ [user_id] int   NOT NULL DEFAULT 0,
+ [user_name] varchar(100)   NOT NULL DEFAULT '',
+ [table_name] varchar(255)   NOT NULL,
+ [record_id] varchar(255)   NOT NULL,
+ [action] varchar(10)   NOT NULL,
+ [changes] text   NOT NULL,
+ [created_at] datetime NULL DEFAULT GETDATE(),
+ [updated_at] datetime NULL DEFAULT GETDATE(),
+  PRIMARY KEY ([id]),
+) 
+
+CREATE INDEX [admin_audit_log_record_index] ON [goadmin_audit_log] ([table_name], [record_id])
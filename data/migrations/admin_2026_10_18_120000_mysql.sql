@@ -0,0 +1,13 @@
+CREATE TABLE `goadmin_audit_log` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) unsigned NOT NULL DEFAULT '0',
+  `user_name` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `table_name` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `record_id` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `action` varchar(10) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `changes` longtext COLLATE utf8mb4_unicode_ci NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `admin_audit_log_record_index` (`table_name`(100),`record_id`(100))
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
\ No newline at end of file
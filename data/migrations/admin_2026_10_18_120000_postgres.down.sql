@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.goadmin_audit_log;
+DROP SEQUENCE IF EXISTS public.goadmin_audit_log_myid_seq;
\ No newline at end of file
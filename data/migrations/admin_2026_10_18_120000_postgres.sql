@@ -0,0 +1,73 @@
+--
+-- PostgreSQL database dump
+--
+
+-- Dumped from database version 9.5.14
+-- Dumped by pg_dump version 10.5
+
+SET statement_timeout = 0;
+SET lock_timeout = 0;
+SET idle_in_transaction_session_timeout = 0;
+SET client_encoding = 'EUC_CN';
+SET standard_conforming_strings = on;
+SELECT pg_catalog.set_config('search_path', '', false);
+SET check_function_bodies = false;
+SET client_min_messages = warning;
+SET row_security = off;
+
+SET default_tablespace = '';
+
+SET default_with_oids = false;
+
+--
+-- Name: goadmin_audit_log_myid_seq; Type: SEQUENCE; Schema: public; Owner: postgres
+--
+
+CREATE SEQUENCE public.goadmin_audit_log_myid_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    MAXVALUE 99999999
+    CACHE 1;
+
+
+ALTER TABLE public.goadmin_audit_log_myid_seq OWNER TO postgres;
+
+--
+-- Name: goadmin_audit_log; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.goadmin_audit_log (
+    id integer DEFAULT nextval('public.goadmin_audit_log_myid_seq'::regclass) NOT NULL,
+    user_id integer DEFAULT 0 NOT NULL,
+    user_name character varying(100) DEFAULT ''::character varying NOT NULL,
+    table_name character varying(255) NOT NULL,
+    record_id character varying(255) NOT NULL,
+    action character varying(10) NOT NULL,
+    changes text NOT NULL,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone DEFAULT now()
+);
+
+
+ALTER TABLE public.goadmin_audit_log OWNER TO postgres;
+
+--
+-- Name: goadmin_audit_log goadmin_audit_log_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.goadmin_audit_log
+    ADD CONSTRAINT goadmin_audit_log_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: admin_audit_log_record_index; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX admin_audit_log_record_index ON public.goadmin_audit_log USING btree (table_name, record_id);
+
+
+--
+-- PostgreSQL database dump complete
+--
+
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "goadmin_audit_log" (
+  `id` integer PRIMARY KEY autoincrement,
+  `user_id` INT NOT NULL DEFAULT '0',
+  `user_name` CHAR(100) COLLATE NOCASE NOT NULL DEFAULT '',
+  `table_name` CHAR(255) COLLATE NOCASE NOT NULL,
+  `record_id` CHAR(255) COLLATE NOCASE NOT NULL,
+  `action` CHAR(10) COLLATE NOCASE NOT NULL,
+  `changes` text COLLATE NOCASE NOT NULL,
+`created_at` TIMESTAMP default CURRENT_TIMESTAMP,
+`updated_at` TIMESTAMP default CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "admin_audit_log_record_index" ON "goadmin_audit_log" (`table_name`, `record_id`);
\ No newline at end of file
@@ -0,0 +1 @@
+IF OBJECT_ID(N'goadmin_api_tokens', N'U') IS NOT NULL DROP TABLE [goadmin_api_tokens]
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE[goadmin_api_tokens] (
+ [id] int   identity(1,1) ,
+ [user_id] int   NOT NULL DEFAULT 0,
+ [name] varchar(100)   NOT NULL,
+ [token_hash] varchar(64)   NOT NULL,
+ [scopes] varchar(1000)   NOT NULL DEFAULT '',
+ [service_account] tinyint   NOT NULL DEFAULT 0,
+ [last_used_at] datetime NULL,
+ [expires_at] datetime NULL,
+ [created_at] datetime NULL DEFAULT GETDATE(),
+ [updated_at] datetime NULL DEFAULT GETDATE(),
+  PRIMARY KEY ([id]),
+) 
+
+CREATE UNIQUE INDEX [admin_api_tokens_token_hash_unique] ON [goadmin_api_tokens] ([token_hash])
+CREATE INDEX [admin_api_tokens_user_id_index] ON [goadmin_api_tokens] ([user_id])
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.goadmin_api_tokens;
+DROP SEQUENCE IF EXISTS public.goadmin_api_tokens_myid_seq;
\ No newline at end of file
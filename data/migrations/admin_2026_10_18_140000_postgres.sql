@@ -0,0 +1,81 @@
+--
+-- PostgreSQL database dump
+--
+
+-- Dumped from database version 9.5.14
+-- Dumped by pg_dump version 10.5
+
+SET statement_timeout = 0;
+SET lock_timeout = 0;
+SET idle_in_transaction_session_timeout = 0;
+SET client_encoding = 'EUC_CN';
+SET standard_conforming_strings = on;
+SELECT pg_catalog.set_config('search_path', '', false);
+SET check_function_bodies = false;
+SET client_min_messages = warning;
+SET row_security = off;
+
+SET default_tablespace = '';
+
+SET default_with_oids = false;
+
+--
+-- Name: goadmin_api_tokens_myid_seq; Type: SEQUENCE; Schema: public; Owner: postgres
+--
+
+CREATE SEQUENCE public.goadmin_api_tokens_myid_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    MAXVALUE 99999999
+    CACHE 1;
+
+
+ALTER TABLE public.goadmin_api_tokens_myid_seq OWNER TO postgres;
+
+--
+-- Name: goadmin_api_tokens; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.goadmin_api_tokens (
+    id integer DEFAULT nextval('public.goadmin_api_tokens_myid_seq'::regclass) NOT NULL,
+    user_id integer DEFAULT 0 NOT NULL,
+    name character varying(100) NOT NULL,
+    token_hash character varying(64) NOT NULL,
+    scopes character varying(1000) DEFAULT ''::character varying NOT NULL,
+    service_account smallint DEFAULT 0 NOT NULL,
+    last_used_at timestamp without time zone,
+    expires_at timestamp without time zone,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone DEFAULT now()
+);
+
+
+ALTER TABLE public.goadmin_api_tokens OWNER TO postgres;
+
+--
+-- Name: goadmin_api_tokens goadmin_api_tokens_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.goadmin_api_tokens
+    ADD CONSTRAINT goadmin_api_tokens_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: admin_api_tokens_token_hash_unique; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE UNIQUE INDEX admin_api_tokens_token_hash_unique ON public.goadmin_api_tokens USING btree (token_hash);
+
+
+--
+-- Name: admin_api_tokens_user_id_index; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX admin_api_tokens_user_id_index ON public.goadmin_api_tokens USING btree (user_id);
+
+
+--
+-- PostgreSQL database dump complete
+--
+
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "goadmin_api_tokens";
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS "goadmin_api_tokens" (
+  `id` integer PRIMARY KEY autoincrement,
+  `user_id` INT NOT NULL DEFAULT '0',
+  `name` CHAR(100) COLLATE NOCASE NOT NULL,
+  `token_hash` CHAR(64) COLLATE NOCASE NOT NULL,
+  `scopes` CHAR(1000) COLLATE NOCASE NOT NULL DEFAULT '',
+  `service_account` INT NOT NULL DEFAULT '0',
+  `last_used_at` TIMESTAMP NULL,
+  `expires_at` TIMESTAMP NULL,
+`created_at` TIMESTAMP default CURRENT_TIMESTAMP,
+`updated_at` TIMESTAMP default CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "admin_api_tokens_token_hash_unique" ON "goadmin_api_tokens" (`token_hash`);
+CREATE INDEX IF NOT EXISTS "admin_api_tokens_user_id_index" ON "goadmin_api_tokens" (`user_id`);
\ No newline at end of file
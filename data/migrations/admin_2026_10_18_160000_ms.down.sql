@@ -0,0 +1 @@
+IF OBJECT_ID(N'goadmin_saved_views', N'U') IS NOT NULL DROP TABLE [goadmin_saved_views]
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE[goadmin_saved_views] (
+ [id] int   identity(1,1) ,
+ [user_id] int   NOT NULL DEFAULT 0,
+ [prefix] varchar(100)   NOT NULL,
+ [name] varchar(100)   NOT NULL,
+ [params] varchar(2000)   NOT NULL DEFAULT '',
+ [role_id] int   NULL,
+ [created_at] datetime NULL DEFAULT GETDATE(),
+ [updated_at] datetime NULL DEFAULT GETDATE(),
+  PRIMARY KEY ([id]),
+) 
+
+CREATE INDEX [admin_saved_views_user_id_prefix_index] ON [goadmin_saved_views] ([user_id], [prefix])
+CREATE INDEX [admin_saved_views_role_id_prefix_index] ON [goadmin_saved_views] ([role_id], [prefix])
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `goadmin_saved_views`;
\ No newline at end of file
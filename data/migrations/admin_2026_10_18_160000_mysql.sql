@@ -0,0 +1,13 @@
+CREATE TABLE `goadmin_saved_views` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) unsigned NOT NULL DEFAULT '0',
+  `prefix` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `name` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `params` varchar(2000) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `role_id` int(11) DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `admin_saved_views_user_id_prefix_index` (`user_id`,`prefix`),
+  KEY `admin_saved_views_role_id_prefix_index` (`role_id`,`prefix`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.goadmin_saved_views;
+DROP SEQUENCE IF EXISTS public.goadmin_saved_views_myid_seq;
\ No newline at end of file
@@ -0,0 +1,79 @@
+--
+-- PostgreSQL database dump
+--
+
+-- Dumped from database version 9.5.14
+-- Dumped by pg_dump version 10.5
+
+SET statement_timeout = 0;
+SET lock_timeout = 0;
+SET idle_in_transaction_session_timeout = 0;
+SET client_encoding = 'EUC_CN';
+SET standard_conforming_strings = on;
+SELECT pg_catalog.set_config('search_path', '', false);
+SET check_function_bodies = false;
+SET client_min_messages = warning;
+SET row_security = off;
+
+SET default_tablespace = '';
+
+SET default_with_oids = false;
+
+--
+-- Name: goadmin_saved_views_myid_seq; Type: SEQUENCE; Schema: public; Owner: postgres
+--
+
+CREATE SEQUENCE public.goadmin_saved_views_myid_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    MAXVALUE 99999999
+    CACHE 1;
+
+
+ALTER TABLE public.goadmin_saved_views_myid_seq OWNER TO postgres;
+
+--
+-- Name: goadmin_saved_views; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.goadmin_saved_views (
+    id integer DEFAULT nextval('public.goadmin_saved_views_myid_seq'::regclass) NOT NULL,
+    user_id integer DEFAULT 0 NOT NULL,
+    prefix character varying(100) NOT NULL,
+    name character varying(100) NOT NULL,
+    params character varying(2000) DEFAULT ''::character varying NOT NULL,
+    role_id integer,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone DEFAULT now()
+);
+
+
+ALTER TABLE public.goadmin_saved_views OWNER TO postgres;
+
+--
+-- Name: goadmin_saved_views goadmin_saved_views_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.goadmin_saved_views
+    ADD CONSTRAINT goadmin_saved_views_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: admin_saved_views_user_id_prefix_index; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX admin_saved_views_user_id_prefix_index ON public.goadmin_saved_views USING btree (user_id, prefix);
+
+
+--
+-- Name: admin_saved_views_role_id_prefix_index; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX admin_saved_views_role_id_prefix_index ON public.goadmin_saved_views USING btree (role_id, prefix);
+
+
+--
+-- PostgreSQL database dump complete
+--
+
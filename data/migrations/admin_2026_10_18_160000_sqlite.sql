@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "goadmin_saved_views" (
+  `id` integer PRIMARY KEY autoincrement,
+  `user_id` INT NOT NULL DEFAULT '0',
+  `prefix` CHAR(100) COLLATE NOCASE NOT NULL,
+  `name` CHAR(100) COLLATE NOCASE NOT NULL,
+  `params` CHAR(2000) COLLATE NOCASE NOT NULL DEFAULT '',
+  `role_id` INT NULL,
+`created_at` TIMESTAMP default CURRENT_TIMESTAMP,
+`updated_at` TIMESTAMP default CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "admin_saved_views_user_id_prefix_index" ON "goadmin_saved_views" (`user_id`, `prefix`);
+CREATE INDEX IF NOT EXISTS "admin_saved_views_role_id_prefix_index" ON "goadmin_saved_views" (`role_id`, `prefix`);
\ No newline at end of file
@@ -0,0 +1,2 @@
+IF OBJECT_ID(N'goadmin_dashboard_widgets', N'U') IS NOT NULL DROP TABLE [goadmin_dashboard_widgets]
+IF OBJECT_ID(N'goadmin_dashboards', N'U') IS NOT NULL DROP TABLE [goadmin_dashboards]
\ No newline at end of file
@@ -0,0 +1,24 @@
+CREATE TABLE[goadmin_dashboards] (
+ [id] int   identity(1,1) ,
+ [title] varchar(100)   NOT NULL,
+ [description] varchar(255)   NOT NULL DEFAULT '',
+ [created_at] datetime NULL DEFAULT GETDATE(),
+ [updated_at] datetime NULL DEFAULT GETDATE(),
+  PRIMARY KEY ([id]),
+) 
+
+CREATE TABLE[goadmin_dashboard_widgets] (
+ [id] int   identity(1,1) ,
+ [dashboard_id] int   NOT NULL DEFAULT 0,
+ [title] varchar(100)   NOT NULL DEFAULT '',
+ [type] varchar(20)   NOT NULL,
+ [source] varchar(20)   NOT NULL DEFAULT 'sql',
+ [query] text   NOT NULL,
+ [width] int   NOT NULL DEFAULT 6,
+ [order] int   NOT NULL DEFAULT 0,
+ [created_at] datetime NULL DEFAULT GETDATE(),
+ [updated_at] datetime NULL DEFAULT GETDATE(),
+  PRIMARY KEY ([id]),
+) 
+
+CREATE INDEX [admin_dashboard_widgets_dashboard_id_index] ON [goadmin_dashboard_widgets] ([dashboard_id])
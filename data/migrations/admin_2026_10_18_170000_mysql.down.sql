@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `goadmin_dashboard_widgets`;
+DROP TABLE IF EXISTS `goadmin_dashboards`;
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS public.goadmin_dashboard_widgets;
+DROP SEQUENCE IF EXISTS public.goadmin_dashboard_widgets_myid_seq;
+DROP TABLE IF EXISTS public.goadmin_dashboards;
+DROP SEQUENCE IF EXISTS public.goadmin_dashboards_myid_seq;
\ No newline at end of file
@@ -0,0 +1,111 @@
+--
+-- PostgreSQL database dump
+--
+
+-- Dumped from database version 9.5.14
+-- Dumped by pg_dump version 10.5
+
+SET statement_timeout = 0;
+SET lock_timeout = 0;
+SET idle_in_transaction_session_timeout = 0;
+SET client_encoding = 'EUC_CN';
+SET standard_conforming_strings = on;
+SELECT pg_catalog.set_config('search_path', '', false);
+SET check_function_bodies = false;
+SET client_min_messages = warning;
+SET row_security = off;
+
+SET default_tablespace = '';
+
+SET default_with_oids = false;
+
+--
+-- Name: goadmin_dashboards_myid_seq; Type: SEQUENCE; Schema: public; Owner: postgres
+--
+
+CREATE SEQUENCE public.goadmin_dashboards_myid_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    MAXVALUE 99999999
+    CACHE 1;
+
+
+ALTER TABLE public.goadmin_dashboards_myid_seq OWNER TO postgres;
+
+--
+-- Name: goadmin_dashboards; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.goadmin_dashboards (
+    id integer DEFAULT nextval('public.goadmin_dashboards_myid_seq'::regclass) NOT NULL,
+    title character varying(100) NOT NULL,
+    description character varying(255) DEFAULT ''::character varying NOT NULL,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone DEFAULT now()
+);
+
+
+ALTER TABLE public.goadmin_dashboards OWNER TO postgres;
+
+--
+-- Name: goadmin_dashboard_widgets_myid_seq; Type: SEQUENCE; Schema: public; Owner: postgres
+--
+
+CREATE SEQUENCE public.goadmin_dashboard_widgets_myid_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    MAXVALUE 99999999
+    CACHE 1;
+
+
+ALTER TABLE public.goadmin_dashboard_widgets_myid_seq OWNER TO postgres;
+
+--
+-- Name: goadmin_dashboard_widgets; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.goadmin_dashboard_widgets (
+    id integer DEFAULT nextval('public.goadmin_dashboard_widgets_myid_seq'::regclass) NOT NULL,
+    dashboard_id integer DEFAULT 0 NOT NULL,
+    title character varying(100) DEFAULT ''::character varying NOT NULL,
+    type character varying(20) NOT NULL,
+    source character varying(20) DEFAULT 'sql'::character varying NOT NULL,
+    query text NOT NULL,
+    width integer DEFAULT 6 NOT NULL,
+    "order" integer DEFAULT 0 NOT NULL,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone DEFAULT now()
+);
+
+
+ALTER TABLE public.goadmin_dashboard_widgets OWNER TO postgres;
+
+--
+-- Name: goadmin_dashboards goadmin_dashboards_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.goadmin_dashboards
+    ADD CONSTRAINT goadmin_dashboards_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: goadmin_dashboard_widgets goadmin_dashboard_widgets_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.goadmin_dashboard_widgets
+    ADD CONSTRAINT goadmin_dashboard_widgets_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: admin_dashboard_widgets_dashboard_id_index; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX admin_dashboard_widgets_dashboard_id_index ON public.goadmin_dashboard_widgets USING btree (dashboard_id);
+
+
+--
+-- PostgreSQL database dump complete
+--
+
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS "goadmin_dashboards" (
+  `id` integer PRIMARY KEY autoincrement,
+  `title` CHAR(100) COLLATE NOCASE NOT NULL,
+  `description` CHAR(255) COLLATE NOCASE NOT NULL DEFAULT '',
+`created_at` TIMESTAMP default CURRENT_TIMESTAMP,
+`updated_at` TIMESTAMP default CURRENT_TIMESTAMP
+);
+CREATE TABLE IF NOT EXISTS "goadmin_dashboard_widgets" (
+  `id` integer PRIMARY KEY autoincrement,
+  `dashboard_id` INT NOT NULL DEFAULT '0',
+  `title` CHAR(100) COLLATE NOCASE NOT NULL DEFAULT '',
+  `type` CHAR(20) COLLATE NOCASE NOT NULL,
+  `source` CHAR(20) COLLATE NOCASE NOT NULL DEFAULT 'sql',
+  `query` text COLLATE NOCASE NOT NULL,
+  `width` INT NOT NULL DEFAULT '6',
+  `order` INT NOT NULL DEFAULT '0',
+`created_at` TIMESTAMP default CURRENT_TIMESTAMP,
+`updated_at` TIMESTAMP default CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "admin_dashboard_widgets_dashboard_id_index" ON "goadmin_dashboard_widgets" (`dashboard_id`);
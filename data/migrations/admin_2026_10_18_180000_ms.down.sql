@@ -0,0 +1,8 @@
+DECLARE @sql nvarchar(max) = N''
+SELECT @sql += N'ALTER TABLE [goadmin_dashboard_widgets] DROP CONSTRAINT ' + QUOTENAME(d.name) + N';'
+FROM sys.default_constraints d
+JOIN sys.columns c ON d.parent_object_id = c.object_id AND d.parent_column_id = c.column_id
+WHERE d.parent_object_id = OBJECT_ID(N'goadmin_dashboard_widgets') AND c.name IN (N'refresh')
+EXEC sp_executesql @sql
+
+ALTER TABLE [goadmin_dashboard_widgets] DROP COLUMN [refresh]
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE goadmin_dashboard_widgets
+ADD refresh int NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE goadmin_dashboard_widgets
+DROP COLUMN refresh;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE goadmin_dashboard_widgets ADD COLUMN `refresh` INT NOT NULL DEFAULT '0';
\ No newline at end of file
@@ -0,0 +1,2 @@
+IF OBJECT_ID(N'goadmin_webhook_deliveries', N'U') IS NOT NULL DROP TABLE [goadmin_webhook_deliveries]
+IF OBJECT_ID(N'goadmin_webhooks', N'U') IS NOT NULL DROP TABLE [goadmin_webhooks]
\ No newline at end of file
@@ -0,0 +1,31 @@
+CREATE TABLE[goadmin_webhooks] (
+ [id] int   identity(1,1) ,
+ [prefix] varchar(100)   NOT NULL DEFAULT '*',
+ [event] varchar(50)   NOT NULL DEFAULT '*',
+ [url] varchar(255)   NOT NULL,
+ [secret] varchar(100)   NOT NULL DEFAULT '',
+ [enabled] tinyint   NOT NULL DEFAULT 1,
+ [created_at] datetime NULL DEFAULT GETDATE(),
+ [updated_at] datetime NULL DEFAULT GETDATE(),
+  PRIMARY KEY ([id]),
+) 
+
+CREATE TABLE[goadmin_webhook_deliveries] (
+ [id] int   identity(1,1) ,
+ [webhook_id] int   NOT NULL DEFAULT 0,
+ [prefix] varchar(100)   NOT NULL DEFAULT '',
+ [event] varchar(50)   NOT NULL DEFAULT '',
+ [payload] text   NOT NULL,
+ [status] varchar(20)   NOT NULL DEFAULT 'pending',
+ [attempts] int   NOT NULL DEFAULT 0,
+ [next_attempt_at] datetime NULL DEFAULT GETDATE(),
+ [response_code] int   NOT NULL DEFAULT 0,
+ [error] varchar(255)   NOT NULL DEFAULT '',
+ [created_at] datetime NULL DEFAULT GETDATE(),
+ [updated_at] datetime NULL DEFAULT GETDATE(),
+  PRIMARY KEY ([id]),
+) 
+
+CREATE INDEX [admin_webhook_deliveries_status_index] ON [goadmin_webhook_deliveries] ([status], [next_attempt_at])
+
+CREATE INDEX [admin_webhook_deliveries_webhook_id_index] ON [goadmin_webhook_deliveries] ([webhook_id])
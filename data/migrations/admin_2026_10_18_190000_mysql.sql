@@ -0,0 +1,29 @@
+CREATE TABLE `goadmin_webhooks` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `prefix` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '*',
+  `event` varchar(50) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '*',
+  `url` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `secret` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `enabled` tinyint(4) unsigned NOT NULL DEFAULT '1',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `goadmin_webhook_deliveries` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `webhook_id` int(11) unsigned NOT NULL DEFAULT '0',
+  `prefix` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `event` varchar(50) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `payload` text COLLATE utf8mb4_unicode_ci NOT NULL,
+  `status` varchar(20) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'pending',
+  `attempts` int(11) unsigned NOT NULL DEFAULT '0',
+  `next_attempt_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `response_code` int(11) unsigned NOT NULL DEFAULT '0',
+  `error` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `admin_webhook_deliveries_status_index` (`status`,`next_attempt_at`),
+  KEY `admin_webhook_deliveries_webhook_id_index` (`webhook_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS public.goadmin_webhook_deliveries;
+DROP SEQUENCE IF EXISTS public.goadmin_webhook_deliveries_myid_seq;
+DROP TABLE IF EXISTS public.goadmin_webhooks;
+DROP SEQUENCE IF EXISTS public.goadmin_webhooks_myid_seq;
\ No newline at end of file
@@ -0,0 +1,123 @@
+--
+-- PostgreSQL database dump
+--
+
+-- Dumped from database version 9.5.14
+-- Dumped by pg_dump version 10.5
+
+SET statement_timeout = 0;
+SET lock_timeout = 0;
+SET idle_in_transaction_session_timeout = 0;
+SET client_encoding = 'EUC_CN';
+SET standard_conforming_strings = on;
+SELECT pg_catalog.set_config('search_path', '', false);
+SET check_function_bodies = false;
+SET client_min_messages = warning;
+SET row_security = off;
+
+SET default_tablespace = '';
+
+SET default_with_oids = false;
+
+--
+-- Name: goadmin_webhooks_myid_seq; Type: SEQUENCE; Schema: public; Owner: postgres
+--
+
+CREATE SEQUENCE public.goadmin_webhooks_myid_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    MAXVALUE 99999999
+    CACHE 1;
+
+
+ALTER TABLE public.goadmin_webhooks_myid_seq OWNER TO postgres;
+
+--
+-- Name: goadmin_webhooks; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.goadmin_webhooks (
+    id integer DEFAULT nextval('public.goadmin_webhooks_myid_seq'::regclass) NOT NULL,
+    prefix character varying(100) DEFAULT '*'::character varying NOT NULL,
+    event character varying(50) DEFAULT '*'::character varying NOT NULL,
+    url character varying(255) NOT NULL,
+    secret character varying(100) DEFAULT ''::character varying NOT NULL,
+    enabled smallint DEFAULT 1 NOT NULL,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone DEFAULT now()
+);
+
+
+ALTER TABLE public.goadmin_webhooks OWNER TO postgres;
+
+--
+-- Name: goadmin_webhook_deliveries_myid_seq; Type: SEQUENCE; Schema: public; Owner: postgres
+--
+
+CREATE SEQUENCE public.goadmin_webhook_deliveries_myid_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    MAXVALUE 99999999
+    CACHE 1;
+
+
+ALTER TABLE public.goadmin_webhook_deliveries_myid_seq OWNER TO postgres;
+
+--
+-- Name: goadmin_webhook_deliveries; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.goadmin_webhook_deliveries (
+    id integer DEFAULT nextval('public.goadmin_webhook_deliveries_myid_seq'::regclass) NOT NULL,
+    webhook_id integer DEFAULT 0 NOT NULL,
+    prefix character varying(100) DEFAULT ''::character varying NOT NULL,
+    event character varying(50) DEFAULT ''::character varying NOT NULL,
+    payload text NOT NULL,
+    status character varying(20) DEFAULT 'pending'::character varying NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    next_attempt_at timestamp without time zone DEFAULT now(),
+    response_code integer DEFAULT 0 NOT NULL,
+    error character varying(255) DEFAULT ''::character varying NOT NULL,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone DEFAULT now()
+);
+
+
+ALTER TABLE public.goadmin_webhook_deliveries OWNER TO postgres;
+
+--
+-- Name: goadmin_webhooks goadmin_webhooks_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.goadmin_webhooks
+    ADD CONSTRAINT goadmin_webhooks_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: goadmin_webhook_deliveries goadmin_webhook_deliveries_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.goadmin_webhook_deliveries
+    ADD CONSTRAINT goadmin_webhook_deliveries_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: admin_webhook_deliveries_status_index; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX admin_webhook_deliveries_status_index ON public.goadmin_webhook_deliveries USING btree (status, next_attempt_at);
+
+
+--
+-- Name: admin_webhook_deliveries_webhook_id_index; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX admin_webhook_deliveries_webhook_id_index ON public.goadmin_webhook_deliveries USING btree (webhook_id);
+
+
+--
+-- PostgreSQL database dump complete
+--
+
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "goadmin_webhook_deliveries";
+DROP TABLE IF EXISTS "goadmin_webhooks";
\ No newline at end of file
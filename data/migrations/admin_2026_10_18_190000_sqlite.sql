@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS "goadmin_webhooks" (
+  `id` integer PRIMARY KEY autoincrement,
+  `prefix` CHAR(100) COLLATE NOCASE NOT NULL DEFAULT '*',
+  `event` CHAR(50) COLLATE NOCASE NOT NULL DEFAULT '*',
+  `url` CHAR(255) COLLATE NOCASE NOT NULL,
+  `secret` CHAR(100) COLLATE NOCASE NOT NULL DEFAULT '',
+  `enabled` INT NOT NULL DEFAULT '1',
+`created_at` TIMESTAMP default CURRENT_TIMESTAMP,
+`updated_at` TIMESTAMP default CURRENT_TIMESTAMP
+);
+CREATE TABLE IF NOT EXISTS "goadmin_webhook_deliveries" (
+  `id` integer PRIMARY KEY autoincrement,
+  `webhook_id` INT NOT NULL DEFAULT '0',
+  `prefix` CHAR(100) COLLATE NOCASE NOT NULL DEFAULT '',
+  `event` CHAR(50) COLLATE NOCASE NOT NULL DEFAULT '',
+  `payload` text COLLATE NOCASE NOT NULL,
+  `status` CHAR(20) COLLATE NOCASE NOT NULL DEFAULT 'pending',
+  `attempts` INT NOT NULL DEFAULT '0',
+  `next_attempt_at` TIMESTAMP default CURRENT_TIMESTAMP,
+  `response_code` INT NOT NULL DEFAULT '0',
+  `error` CHAR(255) COLLATE NOCASE NOT NULL DEFAULT '',
+`created_at` TIMESTAMP default CURRENT_TIMESTAMP,
+`updated_at` TIMESTAMP default CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "admin_webhook_deliveries_status_index" ON "goadmin_webhook_deliveries" (`status`, `next_attempt_at`);
+CREATE INDEX IF NOT EXISTS "admin_webhook_deliveries_webhook_id_index" ON "goadmin_webhook_deliveries" (`webhook_id`);
@@ -0,0 +1 @@
+IF OBJECT_ID(N'goadmin_user_identities', N'U') IS NOT NULL DROP TABLE [goadmin_user_identities]
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE[goadmin_user_identities] (
+ [id] int   identity(1,1) ,
+ [user_id] int   NOT NULL,
+ [provider] varchar(50)   NOT NULL,
+ [subject] varchar(255)   NOT NULL,
+ [created_at] datetime NULL DEFAULT GETDATE(),
+ [updated_at] datetime NULL DEFAULT GETDATE(),
+  PRIMARY KEY ([id]),
+) 
+
+CREATE UNIQUE INDEX [admin_user_identities_provider_subject_unique] ON [goadmin_user_identities] ([provider], [subject])
+
+CREATE INDEX [admin_user_identities_user_id_index] ON [goadmin_user_identities] ([user_id])
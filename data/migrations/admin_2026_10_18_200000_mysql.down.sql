@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `goadmin_user_identities`;
\ No newline at end of file
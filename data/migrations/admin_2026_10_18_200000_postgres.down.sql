@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.goadmin_user_identities;
+DROP SEQUENCE IF EXISTS public.goadmin_user_identities_myid_seq;
\ No newline at end of file
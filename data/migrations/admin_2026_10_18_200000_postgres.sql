@@ -0,0 +1,77 @@
+--
+-- PostgreSQL database dump
+--
+
+-- Dumped from database version 9.5.14
+-- Dumped by pg_dump version 10.5
+
+SET statement_timeout = 0;
+SET lock_timeout = 0;
+SET idle_in_transaction_session_timeout = 0;
+SET client_encoding = 'EUC_CN';
+SET standard_conforming_strings = on;
+SELECT pg_catalog.set_config('search_path', '', false);
+SET check_function_bodies = false;
+SET client_min_messages = warning;
+SET row_security = off;
+
+SET default_tablespace = '';
+
+SET default_with_oids = false;
+
+--
+-- Name: goadmin_user_identities_myid_seq; Type: SEQUENCE; Schema: public; Owner: postgres
+--
+
+CREATE SEQUENCE public.goadmin_user_identities_myid_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    MAXVALUE 99999999
+    CACHE 1;
+
+
+ALTER TABLE public.goadmin_user_identities_myid_seq OWNER TO postgres;
+
+--
+-- Name: goadmin_user_identities; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.goadmin_user_identities (
+    id integer DEFAULT nextval('public.goadmin_user_identities_myid_seq'::regclass) NOT NULL,
+    user_id integer NOT NULL,
+    provider character varying(50) NOT NULL,
+    subject character varying(255) NOT NULL,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone DEFAULT now()
+);
+
+
+ALTER TABLE public.goadmin_user_identities OWNER TO postgres;
+
+--
+-- Name: goadmin_user_identities goadmin_user_identities_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.goadmin_user_identities
+    ADD CONSTRAINT goadmin_user_identities_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: admin_user_identities_provider_subject_unique; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE UNIQUE INDEX admin_user_identities_provider_subject_unique ON public.goadmin_user_identities USING btree (provider, subject);
+
+
+--
+-- Name: admin_user_identities_user_id_index; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX admin_user_identities_user_id_index ON public.goadmin_user_identities USING btree (user_id);
+
+
+--
+-- PostgreSQL database dump complete
+--
+
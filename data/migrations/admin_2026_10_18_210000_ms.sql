@@ -0,0 +1,4 @@
+ALTER TABLE goadmin_users
+ADD totp_last_step bigint NOT NULL DEFAULT 0,
+    totp_tries int NOT NULL DEFAULT 0,
+    totp_locked_until bigint NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE goadmin_users
+ADD COLUMN `totp_last_step` bigint(20) NOT NULL DEFAULT '0',
+ADD COLUMN `totp_tries` int(11) unsigned NOT NULL DEFAULT '0',
+ADD COLUMN `totp_locked_until` bigint(20) NOT NULL DEFAULT '0';
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE goadmin_users
+DROP COLUMN totp_last_step,
+DROP COLUMN totp_tries,
+DROP COLUMN totp_locked_until;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE goadmin_users ADD COLUMN `totp_last_step` INT NOT NULL DEFAULT '0';
+ALTER TABLE goadmin_users ADD COLUMN `totp_tries` INT NOT NULL DEFAULT '0';
+ALTER TABLE goadmin_users ADD COLUMN `totp_locked_until` INT NOT NULL DEFAULT '0';
\ No newline at end of file
@@ -0,0 +1,10 @@
+DROP INDEX [admin_session_user_id_index] ON [goadmin_session]
+
+DECLARE @sql nvarchar(max) = N''
+SELECT @sql += N'ALTER TABLE [goadmin_session] DROP CONSTRAINT ' + QUOTENAME(d.name) + N';'
+FROM sys.default_constraints d
+JOIN sys.columns c ON d.parent_object_id = c.object_id AND d.parent_column_id = c.column_id
+WHERE d.parent_object_id = OBJECT_ID(N'goadmin_session') AND c.name IN (N'user_id')
+EXEC sp_executesql @sql
+
+ALTER TABLE [goadmin_session] DROP COLUMN [user_id]
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE goadmin_session
+ADD user_id int NOT NULL DEFAULT 0;
+GO
+
+CREATE INDEX [admin_session_user_id_index] ON [goadmin_session] ([user_id])
\ No newline at end of file
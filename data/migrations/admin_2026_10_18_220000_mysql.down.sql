@@ -0,0 +1,3 @@
+ALTER TABLE goadmin_session
+DROP KEY `admin_session_user_id_index`,
+DROP COLUMN `user_id`;
\ No newline at end of file
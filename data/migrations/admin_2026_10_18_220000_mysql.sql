@@ -0,0 +1,3 @@
+ALTER TABLE goadmin_session
+ADD COLUMN `user_id` int(11) unsigned NOT NULL DEFAULT '0',
+ADD KEY `admin_session_user_id_index` (`user_id`);
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS public.admin_session_user_id_index;
+
+ALTER TABLE goadmin_session
+DROP COLUMN user_id;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE goadmin_session
+ADD COLUMN user_id integer NOT NULL DEFAULT 0;
+
+CREATE INDEX admin_session_user_id_index ON public.goadmin_session USING btree (user_id);
\ No newline at end of file
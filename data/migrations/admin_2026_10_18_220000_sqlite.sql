@@ -0,0 +1,2 @@
+ALTER TABLE goadmin_session ADD COLUMN `user_id` INT NOT NULL DEFAULT '0';
+CREATE INDEX IF NOT EXISTS "admin_session_user_id_index" ON "goadmin_session" (`user_id`);
\ No newline at end of file
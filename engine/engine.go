@@ -27,6 +27,7 @@ import (
 	"github.com/GoAdminGroup/go-admin/modules/errors"
 	"github.com/GoAdminGroup/go-admin/modules/logger"
 	"github.com/GoAdminGroup/go-admin/modules/menu"
+	"github.com/GoAdminGroup/go-admin/modules/migration"
 	"github.com/GoAdminGroup/go-admin/modules/service"
 	"github.com/GoAdminGroup/go-admin/modules/system"
 	"github.com/GoAdminGroup/go-admin/modules/ui"
@@ -186,9 +187,29 @@ func (eng *Engine) initDatabase() *Engine {
 	defaultConnection := db.GetConnection(eng.Services)
 	defaultAdapter.SetConnection(defaultConnection)
 	eng.Adapter.SetConnection(defaultConnection)
+	if eng.config.AutoMigrate {
+		eng.migrate(defaultConnection)
+	}
 	return eng
 }
 
+// migrate apply the pending migrations of the system tables, and panic if
+// any of them fails.
+func (eng *Engine) migrate(conn db.Connection) {
+	printInitMsg(language.Get("migrate database"))
+	migrator, err := migration.New(conn)
+	if err != nil {
+		logger.Panic(err)
+	}
+	versions, err := migrator.Up()
+	for _, version := range versions {
+		logger.Info("migrated: " + version)
+	}
+	if err != nil {
+		logger.Panic(err)
+	}
+}
+
 // AddAdapter add the adapter of engine.
 func (eng *Engine) AddAdapter(ada adapter.WebFrameWork) *Engine {
 	eng.Adapter = ada
@@ -409,6 +430,10 @@ func printInitMsg(msg string) {
 
 func (eng *Engine) initJumpNavButtons() {
 	printInitMsg(language.Get("initialize navigation buttons"))
+	if !(*eng.NavButtons).CheckExist(types.NavBtnSearchName) {
+		*eng.NavButtons = append(*eng.NavButtons, types.GetNavSearchButton(config.Url("/search"),
+			language.Get("global search"), types.NavBtnSearchName))
+	}
 	for _, param := range eng.initNavJumpButtonParams() {
 		eng.addJumpNavButton(param)
 	}
@@ -469,6 +494,12 @@ func (eng *Engine) initNavJumpButtonParams() []navJumpButtonParam {
 			URL:        "/plugins",
 			Title:      "plugin",
 			TitleScore: "plugin",
+		}, {
+			Exist:   eng.config.Auth.Provider != auth.ProviderPortal,
+			Icon:    icon.Shield,
+			BtnName: types.NavBtnTOTPName,
+			URL:     "/totp",
+			Title:   "two-factor authentication",
 		},
 	}
 }
@@ -720,6 +751,14 @@ func (eng *Engine) AdminPlugin() *admin.Admin {
 	return adm
 }
 
+// Close stop the background jobs of the admin plugin such as the dispatcher
+// of the webhooks, which should be called when the application shuts down.
+func (eng *Engine) Close() {
+	if plug, exist := eng.FindPluginByName("admin"); exist {
+		plug.(*admin.Admin).Close()
+	}
+}
+
 // SetCaptcha set the captcha config.
 func (eng *Engine) SetCaptcha(captcha map[string]string) *Engine {
 	eng.AdminPlugin().SetCaptcha(captcha)
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/constant"
+)
+
+// ApiTokenPrefix is the prefix of the api tokens, which makes them easy to
+// be found by the secret scanners.
+const ApiTokenPrefix = "gat_"
+
+// GenerateApiToken return a new api token.
+func GenerateApiToken() string {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return ApiTokenPrefix + hex.EncodeToString(b)
+}
+
+// HashApiToken return the hash of the api token which is stored.
+func HashApiToken(token string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(token)))
+	return hex.EncodeToString(sum[:])
+}
+
+// BearerToken return the token of the Authorization header of the bearer
+// scheme.
+func BearerToken(ctx *context.Context) string {
+	header := ctx.Headers("Authorization")
+	if len(header) < 7 || !strings.EqualFold(header[:7], "bearer ") {
+		return ""
+	}
+	return strings.TrimSpace(header[7:])
+}
+
+// filterByApiToken return the user of the api token. The tokens are only
+// accepted by the api routes, and the request has to be allowed by the scopes
+// of the token and the permissions of the roles of its user. A service
+// account has the roles of the super admin who created it and the name of
+// the token.
+func filterByApiToken(ctx *context.Context, token string, conn db.Connection) (models.UserModel, bool, bool) {
+
+	user := models.User().SetConn(conn)
+
+	if !strings.HasPrefix(ctx.Path(), config.Url("/api/")) {
+		return user, false, true
+	}
+
+	apiToken := models.ApiToken().SetConn(conn).FindByHash(HashApiToken(token))
+	if apiToken.IsEmpty() {
+		return user, false, true
+	}
+
+	user = user.Find(apiToken.UserId)
+	if user.IsEmpty() {
+		return user, false, true
+	}
+	user = user.WithRole().WithMenus().WithPermissions()
+	if apiToken.ServiceAccount {
+		user.Name = apiToken.Name
+		user.LevelName = "service account"
+	}
+
+	apiToken.Touch()
+
+	ok := apiToken.Allow(ctx.Query(constant.PrefixKey), ctx.Method()) &&
+		CheckPermissions(user, ctx.Request.URL.RequestURI(), ctx.Method(), ctx.PostForm())
+	return user, true, ok
+}
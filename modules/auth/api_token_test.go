@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateApiToken(t *testing.T) {
+	token := GenerateApiToken()
+	assert.True(t, strings.HasPrefix(token, ApiTokenPrefix))
+	assert.NotEqual(t, token, GenerateApiToken())
+	assert.Equal(t, HashApiToken(token), HashApiToken(" "+token+" "))
+	assert.Len(t, HashApiToken(token), 64)
+}
+
+func TestBearerToken(t *testing.T) {
+	req := httptest.NewRequest("GET", "/admin/api/v1/users", nil)
+	assert.Equal(t, BearerToken(context.NewContext(req)), "")
+
+	req.Header.Set("Authorization", "Basic YWRtaW46YWRtaW4=")
+	assert.Equal(t, BearerToken(context.NewContext(req)), "")
+
+	req.Header.Set("Authorization", "bearer gat_123")
+	assert.Equal(t, BearerToken(context.NewContext(req)), "gat_123")
+}
+
+func TestFilterByApiToken(t *testing.T) {
+	conn := testAdminConn(t)
+
+	newToken := func(userId int64) string {
+		token := GenerateApiToken()
+		_, err := models.ApiToken().SetConn(conn).New(userId, "robot", HashApiToken(token),
+			[]string{"*:read"}, true, "")
+		assert.Nil(t, err)
+		return token
+	}
+	request := func(method, token string) (models.UserModel, bool, bool) {
+		req := httptest.NewRequest(method, config.Url("/api/v1/users"), nil)
+		return filterByApiToken(context.NewContext(req), token, conn)
+	}
+
+	// a service account has the roles of the user who created it.
+	token := newToken(1)
+	user, found, ok := request("GET", token)
+	assert.True(t, found)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), user.Id)
+	assert.Equal(t, "robot", user.Name)
+	assert.Equal(t, models.User().SetConn(conn).Find(int64(1)).WithRole().Role, user.Role)
+
+	// the scopes still limit it.
+	_, found, ok = request("POST", token)
+	assert.True(t, found)
+	assert.False(t, ok)
+
+	user, found, _ = request("GET", newToken(2))
+	assert.True(t, found)
+	assert.Equal(t, models.User().SetConn(conn).Find(int64(2)).WithRole().Role, user.Role)
+
+	// the token of a deleted user is not accepted.
+	_, found, _ = request("GET", newToken(100))
+	assert.False(t, found)
+}
@@ -33,7 +33,7 @@ func Check(password string, username string, conn db.Connection) (user models.Us
 	} else {
 		if comparePassword(password, user.Password) {
 			ok = true
-			user = user.WithMenus()
+			user = user.WithRole().WithMenus().WithPermissions()
 			user.UpdatePwd(EncodePassword([]byte(password)))
 		} else {
 			ok = false
@@ -64,7 +64,14 @@ func SetCookie(ctx *context.Context, user models.UserModel, conn db.Connection)
 		return err
 	}
 
-	return ses.Add("user_id", user.Id)
+	delete(ses.Values, totpUserKey)
+	setSessionInfo(ctx, ses)
+
+	if err := ses.Add(sessionUserKey, user.Id); err != nil {
+		return err
+	}
+
+	return limitSessions(user.Id, ses.Sid, conn)
 }
 
 // DelCookie delete the cookie from Context.
@@ -78,10 +85,14 @@ func DelCookie(ctx *context.Context, conn db.Connection) error {
 	return ses.Clear()
 }
 
+// TokenService keeps the csrf tokens. The tokens are kept by the session
+// store when it is a TokenStore, otherwise in the memory and the
+// goadmin_session table.
 type TokenService struct {
 	tokens CSRFToken
 	lock   sync.Mutex
 	conn   db.Connection
+	store  TokenStore
 }
 
 func (s *TokenService) Name() string {
@@ -89,8 +100,14 @@ func (s *TokenService) Name() string {
 }
 
 func InitCSRFTokenSrv(conn db.Connection) (string, service.Service) {
+	if store, ok := NewDriver(conn).(TokenStore); ok {
+		return TokenServiceKey, &TokenService{
+			conn:  conn,
+			store: store,
+		}
+	}
 	list, err := db.WithDriver(conn).Table("goadmin_session").
-		Where("values", "=", "__csrf_token__").
+		Where("values", "=", csrfTokenValue).
 		All()
 	if db.CheckError(err, db.QUERY) {
 		logger.Error("csrf token query from database error: ", err)
@@ -108,6 +125,9 @@ func InitCSRFTokenSrv(conn db.Connection) (string, service.Service) {
 const (
 	TokenServiceKey = "token_csrf_helper"
 	ServiceKey      = "auth"
+
+	// csrfTokenValue is the value of the csrf tokens in the session table.
+	csrfTokenValue = "__csrf_token__"
 )
 
 func GetTokenService(s interface{}) *TokenService {
@@ -119,13 +139,19 @@ func GetTokenService(s interface{}) *TokenService {
 
 // AddToken add the token to the CSRFToken.
 func (s *TokenService) AddToken() string {
+	tokenStr := modules.Uuid()
+	if s.store != nil {
+		if err := s.store.AddToken(tokenStr); err != nil {
+			logger.Error("csrf token add error: ", err)
+		}
+		return tokenStr
+	}
 	s.lock.Lock()
 	defer s.lock.Unlock()
-	tokenStr := modules.Uuid()
 	s.tokens = append(s.tokens, tokenStr)
 	_, err := db.WithDriver(s.conn).Table("goadmin_session").Insert(dialect.H{
 		"sid":    tokenStr,
-		"values": "__csrf_token__",
+		"values": csrfTokenValue,
 	})
 	if db.CheckError(err, db.INSERT) {
 		logger.Error("csrf token insert into database error: ", err)
@@ -136,12 +162,21 @@ func (s *TokenService) AddToken() string {
 // CheckToken check the given token with tokens in the CSRFToken, if exist
 // return true.
 func (s *TokenService) CheckToken(toCheckToken string) bool {
+	if s.store != nil {
+		ok, err := s.store.UseToken(toCheckToken)
+		if err != nil {
+			logger.Error("csrf token check error: ", err)
+		}
+		return ok
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
 	for i := 0; i < len(s.tokens); i++ {
 		if (s.tokens)[i] == toCheckToken {
 			s.tokens = append((s.tokens)[:i], (s.tokens)[i+1:]...)
 			err := db.WithDriver(s.conn).Table("goadmin_session").
 				Where("sid", "=", toCheckToken).
-				Where("values", "=", "__csrf_token__").
+				Where("values", "=", csrfTokenValue).
 				Delete()
 			if db.CheckError(err, db.DELETE) {
 				logger.Error("csrf token delete from database error: ", err)
@@ -40,6 +40,13 @@ func DefaultInvoker(conn db.Connection) *Invoker {
 	return &Invoker{
 		prefix: config.Prefix(),
 		authFailCallback: func(ctx *context.Context) {
+			if BearerToken(ctx) != "" {
+				ctx.JSON(http.StatusUnauthorized, map[string]interface{}{
+					"code": http.StatusUnauthorized,
+					"msg":  language.Get("unauthorized"),
+				})
+				return
+			}
 			if ctx.Request.URL.Path == config.Url(config.GetLoginUrl()) {
 				return
 			}
@@ -85,7 +92,7 @@ func DefaultInvoker(conn db.Connection) *Invoker {
 			}
 		},
 		permissionDenyCallback: func(ctx *context.Context) {
-			if ctx.Headers(constant.PjaxHeader) == "" && ctx.Method() != "GET" {
+			if (ctx.Headers(constant.PjaxHeader) == "" && ctx.Method() != "GET") || BearerToken(ctx) != "" {
 				ctx.JSON(http.StatusForbidden, map[string]interface{}{
 					"code": http.StatusForbidden,
 					"msg":  language.Get(errors.PermissionDenied),
@@ -147,11 +154,24 @@ func (invoker *Invoker) Middleware() context.Handler {
 }
 
 // Filter retrieve the user model from Context and check the permission
-// at the same time.
+// at the same time. The user of the api token of the Authorization header
+// is used if there is one.
 func Filter(ctx *context.Context, conn db.Connection) (models.UserModel, bool, bool) {
 
-	token := ctx.Cookie(v1.DefaultPortalCookie)
-	user, ok := GetCurUser(token, conn)
+	var (
+		user models.UserModel
+		ok   bool
+	)
+
+	if token := BearerToken(ctx); token != "" {
+		return filterByApiToken(ctx, token, conn)
+	}
+
+	if config.GetAuth().Provider == ProviderPortal {
+		user, ok = GetCurUser(ctx.Cookie(v1.DefaultPortalCookie), conn)
+	} else {
+		user, ok = GetCurUserBySession(ctx.Cookie(DefaultCookieKey), conn)
+	}
 	if !ok {
 		return user, false, true
 	}
@@ -178,7 +198,42 @@ func GetCurUser(sesKey string, conn db.Connection) (models.UserModel, bool) {
 	}
 	user.Role, _ = userMap["role"].(int64)
 	user.LevelName = "Super"
-	user = user.WithMenus()
+	user = user.WithMenus().WithPermissions()
+	return user, user.HasMenu()
+}
+
+// GetCurUserBySession return the user model of the session, which is set
+// by the signin of the local, ldap, oidc and custom providers.
+func GetCurUserBySession(sesKey string, conn db.Connection) (models.UserModel, bool) {
+
+	user := models.User().SetConn(conn)
+
+	if sesKey == "" {
+		return user, false
+	}
+
+	driver := NewDriver(conn)
+	values, err := driver.Load(sesKey)
+	if err != nil {
+		return user, false
+	}
+
+	// the session values are decoded from json.
+	userId, ok := values[sessionUserKey].(float64)
+	if !ok {
+		return user, false
+	}
+
+	user = user.Find(int64(userId))
+	if user.IsEmpty() {
+		return user, false
+	}
+
+	if !touchSession(driver, sesKey, values) {
+		return user, false
+	}
+
+	user = user.WithRole().WithMenus().WithPermissions()
 	return user, user.HasMenu()
 }
 
@@ -0,0 +1,217 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/modules/db/dialect"
+	"github.com/GoAdminGroup/go-admin/modules/logger"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules"
+)
+
+const (
+	ProviderPortal = "portal"
+	ProviderLocal  = "local"
+	ProviderLDAP   = "ldap"
+	ProviderOIDC   = "oidc"
+
+	stateCookieKey = "go_admin_auth_state"
+
+	identityTable = "goadmin_user_identities"
+)
+
+var (
+	// ErrWrongPasswordOrUserName is returned by the providers which check a
+	// password when the credentials are wrong.
+	ErrWrongPasswordOrUserName = errors.New("wrong password or username")
+
+	// ErrUserNameTaken is returned by Provision when the username of a new
+	// identity belongs to a local user, who is never linked automatically.
+	ErrUserNameTaken = errors.New("the username is taken by a user who is not linked to the identity")
+
+	// ErrNoDefaultRole is returned by Provision when a user should be
+	// provisioned without the default role of the auth config.
+	ErrNoDefaultRole = errors.New("no default role of the auth config")
+)
+
+// Identity is the user authenticated by a Provider.
+type Identity struct {
+	// Subject is the stable id of the user at the provider, such as the dn
+	// of LDAP or the issuer and the subject of OIDC, which is linked to the
+	// user of GoAdmin. It is empty for the users of the user table.
+	Subject  string
+	UserName string
+	Name     string
+	Avatar   string
+}
+
+// Provider authenticates the user of a signin request.
+type Provider interface {
+	Authenticate(ctx *context.Context, conn db.Connection) (Identity, error)
+}
+
+// RedirectProvider is a Provider which signs in on an external page. The
+// login page redirects to LoginURL and the provider redirects back to the
+// signin callback route, where Authenticate is called.
+type RedirectProvider interface {
+	Provider
+	LoginURL(state string) (string, error)
+}
+
+// ProviderList is the registry of the authentication providers. The portal
+// provider signs in through chime-portal and is not in the list.
+var ProviderList = map[string]Provider{
+	ProviderLocal: new(localProvider),
+	ProviderLDAP:  new(ldapProvider),
+	ProviderOIDC:  new(oidcProvider),
+}
+
+// AddProvider registers a custom provider, which can then be selected
+// with the provider of the auth config.
+func AddProvider(key string, provider Provider) {
+	if _, exist := ProviderList[key]; exist {
+		panic("provider exist")
+	}
+	ProviderList[key] = provider
+}
+
+func GetProvider(key string) (Provider, bool) {
+	provider, ok := ProviderList[key]
+	return provider, ok
+}
+
+// ProviderProcessor return a Processor which authenticates with the given
+// provider of the key and provisions the user at the first login.
+func ProviderProcessor(key string, provider Provider, conn db.Connection) Processor {
+	return func(ctx *context.Context) (models.UserModel, bool, string) {
+		identity, err := provider.Authenticate(ctx, conn)
+		if err != nil {
+			if err != ErrWrongPasswordOrUserName {
+				logger.Error("authenticate error: ", err)
+				return models.UserModel{}, false, "fail"
+			}
+			return models.UserModel{}, false, err.Error()
+		}
+		user, err := Provision(key, identity, conn)
+		if err != nil {
+			logger.Error("provision user error: ", err)
+			return user, false, "fail"
+		}
+		return user, true, ""
+	}
+}
+
+// Provision return the user of the identity. An identity without a subject
+// is a user of the user table. An identity with a subject is the user linked
+// to the provider and the subject, and a new one is inserted into the user
+// table with the default role of the auth config and a random password, so
+// that the user can only sign in through the provider. The new identity is
+// never linked to an existing user with the same username.
+func Provision(provider string, identity Identity, conn db.Connection) (models.UserModel, error) {
+
+	if identity.Subject == "" {
+		user := models.User().SetConn(conn).FindByUserName(identity.UserName)
+		if user.IsEmpty() {
+			return user, ErrWrongPasswordOrUserName
+		}
+		return user.WithRole().WithMenus().WithPermissions(), nil
+	}
+
+	link, err := db.WithDriver(conn).Table(identityTable).
+		Where("provider", "=", provider).
+		Where("subject", "=", identity.Subject).
+		First()
+	if db.CheckError(err, db.QUERY) {
+		return models.UserModel{}, err
+	}
+	if link != nil {
+		user := models.User().SetConn(conn).Find(link["user_id"])
+		if user.IsEmpty() {
+			return user, errors.New("the linked user is not found")
+		}
+		return user.WithRole().WithMenus().WithPermissions(), nil
+	}
+
+	if !models.User().SetConn(conn).FindByUserName(identity.UserName).IsEmpty() {
+		return models.UserModel{}, ErrUserNameTaken
+	}
+
+	role := config.GetAuth().DefaultRole
+	if role == nil {
+		return models.UserModel{}, ErrNoDefaultRole
+	}
+
+	name := identity.Name
+	if name == "" {
+		name = identity.UserName
+	}
+	password := EncodePassword([]byte(modules.Uuid()))
+
+	// the user model does not insert with the transaction.
+	var id int64
+	_, txErr := db.WithDriver(conn).WithTransaction(func(tx *sql.Tx) (error, map[string]interface{}) {
+		var err error
+		id, err = db.WithDriver(conn).WithTx(tx).Table(config.GetAuthUserTable()).Insert(dialect.H{
+			"username": identity.UserName,
+			"password": password,
+			"name":     name,
+			"avatar":   identity.Avatar,
+		})
+		if db.CheckError(err, db.INSERT) {
+			return err, nil
+		}
+		_, err = db.WithDriver(conn).WithTx(tx).Table(identityTable).Insert(dialect.H{
+			"user_id":  id,
+			"provider": provider,
+			"subject":  identity.Subject,
+		})
+		if db.CheckError(err, db.INSERT) {
+			return err, nil
+		}
+		_, err = db.WithDriver(conn).WithTx(tx).Table("goadmin_role_users").Insert(dialect.H{
+			"role_id": *role,
+			"user_id": id,
+		})
+		if db.CheckError(err, db.INSERT) {
+			return err, nil
+		}
+		return nil, nil
+	})
+	if txErr != nil {
+		return models.UserModel{}, txErr
+	}
+
+	return models.User().SetConn(conn).Find(id).WithRole().WithMenus().WithPermissions(), nil
+}
+
+// SetState set a random state cookie for the redirect of a RedirectProvider
+// and return the state.
+func SetState(ctx *context.Context) string {
+	state := modules.Uuid()
+	ctx.SetCookie(&http.Cookie{
+		Name:     stateCookieKey,
+		Value:    state,
+		MaxAge:   600,
+		Expires:  time.Now().Add(10 * time.Minute),
+		HttpOnly: true,
+		Path:     "/",
+	})
+	return state
+}
+
+// CheckState check the state of the callback request of a RedirectProvider
+// with the state cookie.
+func CheckState(ctx *context.Context) bool {
+	state := ctx.Query("state")
+	return state != "" && ctx.Cookie(stateCookieKey) == state
+}
@@ -0,0 +1,93 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// ldapProvider searches the user with the bind dn and checks the password
+// by binding as the found entry.
+type ldapProvider struct {
+	cfg *config.LDAP
+}
+
+// NewLDAPProvider return a LDAP provider of the given config. The
+// registered LDAP provider uses the LDAP config of the auth config.
+func NewLDAPProvider(cfg config.LDAP) Provider {
+	return &ldapProvider{cfg: &cfg}
+}
+
+func (p *ldapProvider) config() config.LDAP {
+	if p.cfg != nil {
+		return *p.cfg
+	}
+	return config.GetAuth().LDAP
+}
+
+func (p *ldapProvider) Authenticate(ctx *context.Context, _ db.Connection) (Identity, error) {
+	var (
+		username = ctx.FormValue("username")
+		password = ctx.FormValue("password")
+		cfg      = p.config()
+	)
+	if username == "" || password == "" {
+		return Identity{}, ErrWrongPasswordOrUserName
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify}
+
+	conn, err := ldap.DialURL(cfg.URL, ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return Identity{}, err
+	}
+	defer conn.Close()
+
+	if cfg.StartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			return Identity{}, err
+		}
+	}
+
+	if cfg.BindDN != "" {
+		err = conn.Bind(cfg.BindDN, cfg.BindPassword)
+	} else {
+		err = conn.UnauthenticatedBind("")
+	}
+	if err != nil {
+		return Identity{}, err
+	}
+
+	res, err := conn.Search(ldap.NewSearchRequest(cfg.BaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf(cfg.UserFilter, ldap.EscapeFilter(username)),
+		[]string{"dn", cfg.NameAttribute}, nil))
+	if err != nil {
+		return Identity{}, err
+	}
+	if len(res.Entries) != 1 {
+		return Identity{}, ErrWrongPasswordOrUserName
+	}
+
+	entry := res.Entries[0]
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return Identity{}, ErrWrongPasswordOrUserName
+		}
+		return Identity{}, err
+	}
+
+	return Identity{
+		Subject:  entry.DN,
+		UserName: username,
+		Name:     entry.GetAttributeValue(cfg.NameAttribute),
+	}, nil
+}
@@ -0,0 +1,32 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+)
+
+// localProvider checks the password against the user table.
+type localProvider struct{}
+
+func (*localProvider) Authenticate(ctx *context.Context, conn db.Connection) (Identity, error) {
+	var (
+		username = ctx.FormValue("username")
+		password = ctx.FormValue("password")
+	)
+	if username == "" || password == "" {
+		return Identity{}, ErrWrongPasswordOrUserName
+	}
+	user, ok := Check(password, username, conn)
+	if !ok {
+		return Identity{}, ErrWrongPasswordOrUserName
+	}
+	return Identity{
+		UserName: user.UserName,
+		Name:     user.Name,
+		Avatar:   user.Avatar,
+	}, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	stdctx "context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"sync"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// oidcProvider signs in with the authorization code flow of an OpenID
+// Connect issuer. The issuer is discovered at the first use.
+type oidcProvider struct {
+	cfg *config.OIDC
+
+	lock     sync.Mutex
+	issuer   string
+	provider *oidc.Provider
+}
+
+// NewOIDCProvider return an OpenID Connect provider of the given config.
+// The registered OIDC provider uses the OIDC config of the auth config.
+func NewOIDCProvider(cfg config.OIDC) RedirectProvider {
+	return &oidcProvider{cfg: &cfg}
+}
+
+func (p *oidcProvider) config() config.OIDC {
+	if p.cfg != nil {
+		return *p.cfg
+	}
+	return config.GetAuth().OIDC
+}
+
+func (p *oidcProvider) oauth2Config() (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	cfg := p.config()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.provider == nil || p.issuer != cfg.Issuer {
+		// the key set of the provider keeps the context of the discovery.
+		provider, err := oidc.NewProvider(stdctx.Background(), cfg.Issuer)
+		if err != nil {
+			return nil, nil, err
+		}
+		p.provider = provider
+		p.issuer = cfg.Issuer
+	}
+
+	return &oauth2.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		RedirectURL:  cfg.RedirectURL,
+		Endpoint:     p.provider.Endpoint(),
+		Scopes:       cfg.Scopes,
+	}, p.provider.Verifier(&oidc.Config{ClientID: cfg.ClientID}), nil
+}
+
+func (p *oidcProvider) LoginURL(state string) (string, error) {
+	c, _, err := p.oauth2Config()
+	if err != nil {
+		return "", err
+	}
+	return c.AuthCodeURL(state, oidc.Nonce(oidcNonce(state))), nil
+}
+
+// oidcNonce return the nonce of the authentication request of the state, so
+// that the id token is bound to the state cookie of the browser.
+func oidcNonce(state string) string {
+	sum := sha256.Sum256([]byte("nonce:" + state))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (p *oidcProvider) Authenticate(ctx *context.Context, _ db.Connection) (Identity, error) {
+	c, verifier, err := p.oauth2Config()
+	if err != nil {
+		return Identity{}, err
+	}
+
+	if e := ctx.Query("error"); e != "" {
+		return Identity{}, errors.New(e + ": " + ctx.Query("error_description"))
+	}
+
+	token, err := c.Exchange(ctx.Request.Context(), ctx.Query("code"))
+	if err != nil {
+		return Identity{}, err
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return Identity{}, errors.New("no id_token in the token response")
+	}
+
+	idToken, err := verifier.Verify(ctx.Request.Context(), rawIDToken)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(oidcNonce(ctx.Query("state")))) != 1 {
+		return Identity{}, errors.New("wrong nonce of the id_token")
+	}
+
+	var claims map[string]interface{}
+	if err := idToken.Claims(&claims); err != nil {
+		return Identity{}, err
+	}
+
+	userNameClaim := p.config().UserNameClaim
+	username, _ := claims[userNameClaim].(string)
+	if username == "" {
+		return Identity{}, errors.New("no " + userNameClaim + " claim in the id_token")
+	}
+
+	identity := Identity{
+		Subject:  idToken.Issuer + "#" + idToken.Subject,
+		UserName: username,
+	}
+	identity.Name, _ = claims["name"].(string)
+	identity.Avatar, _ = claims["picture"].(string)
+	return identity, nil
+}
@@ -0,0 +1,330 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	_ "github.com/GoAdminGroup/go-admin/modules/db/drivers/sqlite"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddProvider(t *testing.T) {
+	AddProvider("test", new(localProvider))
+	defer delete(ProviderList, "test")
+
+	p, ok := GetProvider("test")
+	assert.Equal(t, ok, true)
+	assert.NotNil(t, p)
+
+	assert.Panics(t, func() { AddProvider(ProviderLocal, new(localProvider)) })
+
+	_, ok = GetProvider(ProviderPortal)
+	assert.Equal(t, ok, false)
+}
+
+func signinContext(form url.Values) *context.Context {
+	req := httptest.NewRequest(http.MethodPost, "/admin/signin", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return context.NewContext(req)
+}
+
+type ldapUser struct {
+	uid, cn, password string
+}
+
+var ldapUsers = map[string]ldapUser{
+	"cn=admin,dc=example,dc=org":            {password: "admin"},
+	"uid=alice,ou=people,dc=example,dc=org": {uid: "alice", cn: "Alice", password: "secret"},
+}
+
+// serveLDAP is a stub LDAP server which answers the simple binds and the
+// equality searches of ldapUsers.
+func serveLDAP(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go func(conn net.Conn) {
+			defer conn.Close()
+			for {
+				packet, err := ber.ReadPacket(conn)
+				if err != nil || len(packet.Children) < 2 {
+					return
+				}
+				id, _ := packet.Children[0].Value.(int64)
+				op := packet.Children[1]
+				switch op.Tag {
+				case ldap.ApplicationBindRequest:
+					code := uint16(ldap.LDAPResultInvalidCredentials)
+					user, ok := ldapUsers[op.Children[1].Data.String()]
+					if ok && user.password == op.Children[2].Data.String() {
+						code = ldap.LDAPResultSuccess
+					}
+					_, _ = conn.Write(ldapMessage(id, ldap.ApplicationBindResponse, ldapResult(code)...))
+				case ldap.ApplicationSearchRequest:
+					uid := op.Children[6].Children[1].Data.String()
+					for dn, user := range ldapUsers {
+						if user.uid == "" || user.uid != uid {
+							continue
+						}
+						attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+						attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "cn", ""))
+						values := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+						values.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, user.cn, ""))
+						attr.AppendChild(values)
+						attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+						attrs.AppendChild(attr)
+						_, _ = conn.Write(ldapMessage(id, ldap.ApplicationSearchResultEntry,
+							ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, dn, ""), attrs))
+					}
+					_, _ = conn.Write(ldapMessage(id, ldap.ApplicationSearchResultDone, ldapResult(ldap.LDAPResultSuccess)...))
+				default:
+					return
+				}
+			}
+		}(conn)
+	}
+}
+
+func ldapMessage(id int64, tag ber.Tag, children ...*ber.Packet) []byte {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""))
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+	for _, child := range children {
+		op.AppendChild(child)
+	}
+	packet.AppendChild(op)
+	return packet.Bytes()
+}
+
+func ldapResult(code uint16) []*ber.Packet {
+	return []*ber.Packet{
+		ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, uint64(code), ""),
+		ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""),
+		ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""),
+	}
+}
+
+func TestLDAPProvider(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer l.Close()
+	go serveLDAP(l)
+
+	p := NewLDAPProvider(config.LDAP{
+		URL:           "ldap://" + l.Addr().String(),
+		BindDN:        "cn=admin,dc=example,dc=org",
+		BindPassword:  "admin",
+		BaseDN:        "dc=example,dc=org",
+		UserFilter:    "(uid=%s)",
+		NameAttribute: "cn",
+	})
+
+	identity, err := p.Authenticate(signinContext(url.Values{
+		"username": {"alice"},
+		"password": {"secret"},
+	}), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, identity, Identity{
+		Subject:  "uid=alice,ou=people,dc=example,dc=org",
+		UserName: "alice",
+		Name:     "Alice",
+	})
+
+	_, err = p.Authenticate(signinContext(url.Values{
+		"username": {"alice"},
+		"password": {"wrong"},
+	}), nil)
+	assert.Equal(t, err, ErrWrongPasswordOrUserName)
+
+	_, err = p.Authenticate(signinContext(url.Values{
+		"username": {"bob"},
+		"password": {"secret"},
+	}), nil)
+	assert.Equal(t, err, ErrWrongPasswordOrUserName)
+}
+
+// fakeIssuer is a fake OpenID Connect issuer which exchanges the code
+// "good" for an id token of alice.
+func fakeIssuer(t *testing.T, clientID string) *httptest.Server {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", "test"))
+	assert.Nil(t, err)
+
+	var srv *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                srv.URL,
+			"authorization_endpoint":                srv.URL + "/auth",
+			"token_endpoint":                        srv.URL + "/token",
+			"jwks_uri":                              srv.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+			Key: &key.PublicKey, KeyID: "test", Algorithm: "RS256", Use: "sig",
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != "good" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		idToken, err := jwt.Signed(signer).Claims(map[string]interface{}{
+			"iss":                srv.URL,
+			"aud":                clientID,
+			"sub":                "1",
+			"iat":                time.Now().Unix(),
+			"exp":                time.Now().Add(time.Hour).Unix(),
+			"nonce":              oidcNonce("state"),
+			"preferred_username": "alice",
+			"name":               "Alice",
+		}).CompactSerialize()
+		assert.Nil(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     idToken,
+		})
+	})
+	srv = httptest.NewServer(mux)
+	return srv
+}
+
+func TestOIDCProvider(t *testing.T) {
+	srv := fakeIssuer(t, "goadmin")
+	defer srv.Close()
+
+	p := NewOIDCProvider(config.OIDC{
+		Issuer:        srv.URL,
+		ClientID:      "goadmin",
+		ClientSecret:  "secret",
+		RedirectURL:   "http://127.0.0.1/admin/signin/callback",
+		Scopes:        []string{"openid", "profile"},
+		UserNameClaim: "preferred_username",
+	})
+
+	loginURL, err := p.LoginURL("state")
+	assert.Nil(t, err)
+	u, err := url.Parse(loginURL)
+	assert.Nil(t, err)
+	assert.Equal(t, u.Path, "/auth")
+	assert.Equal(t, u.Query().Get("state"), "state")
+	assert.Equal(t, u.Query().Get("client_id"), "goadmin")
+	assert.Equal(t, u.Query().Get("nonce"), oidcNonce("state"))
+
+	callback := func(state, code string) *context.Context {
+		return context.NewContext(httptest.NewRequest(http.MethodGet,
+			"/admin/signin/callback?state="+state+"&code="+code, nil))
+	}
+
+	identity, err := p.Authenticate(callback("state", "good"), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, identity, Identity{Subject: srv.URL + "#1", UserName: "alice", Name: "Alice"})
+
+	// the id token is issued for the authentication request of another state.
+	_, err = p.Authenticate(callback("other", "good"), nil)
+	assert.NotNil(t, err)
+
+	_, err = p.Authenticate(callback("state", "bad"), nil)
+	assert.NotNil(t, err)
+}
+
+// testAdminConn return a connection of a copy of the sqlite database of
+// the admin tables.
+func testAdminConn(t *testing.T) db.Connection {
+	content, err := os.ReadFile("../../data/admin.db")
+	assert.Nil(t, err)
+	file := filepath.Join(t.TempDir(), "admin.db")
+	assert.Nil(t, os.WriteFile(file, content, 0600))
+
+	return db.GetConnectionByDriver(db.DriverSqlite).InitDB(map[string]config.Database{
+		"default": {Driver: db.DriverSqlite, File: file},
+	})
+}
+
+func TestProvision(t *testing.T) {
+	conn := testAdminConn(t)
+
+	_, err := models.User().SetConn(conn).New("bob", EncodePassword([]byte("secret")), "Bob", "")
+	assert.Nil(t, err)
+
+	role := int64(3)
+	defer func() { testConfig.Auth.DefaultRole = nil }()
+
+	// the users of the user table have no subject.
+	user, err := Provision(ProviderLocal, Identity{UserName: "bob"}, conn)
+	assert.Nil(t, err)
+	assert.Equal(t, user.UserName, "bob")
+
+	_, err = Provision(ProviderLocal, Identity{UserName: "carol"}, conn)
+	assert.Equal(t, err, ErrWrongPasswordOrUserName)
+
+	// the default role must be set to provision a user.
+	_, err = Provision(ProviderOIDC, Identity{Subject: "iss#1", UserName: "alice"}, conn)
+	assert.Equal(t, err, ErrNoDefaultRole)
+
+	testConfig.Auth.DefaultRole = &role
+
+	alice, err := Provision(ProviderOIDC, Identity{Subject: "iss#1", UserName: "alice", Name: "Alice"}, conn)
+	assert.Nil(t, err)
+	assert.Equal(t, alice.Name, "Alice")
+
+	// the linked user is found by the subject even if the username changes.
+	user, err = Provision(ProviderOIDC, Identity{Subject: "iss#1", UserName: "alice2"}, conn)
+	assert.Nil(t, err)
+	assert.Equal(t, user.Id, alice.Id)
+
+	roles, err := db.WithDriver(conn).Table("goadmin_role_users").Where("user_id", "=", alice.Id).All()
+	assert.Nil(t, err)
+	assert.Equal(t, len(roles), 1)
+	assert.Equal(t, roles[0]["role_id"], role)
+
+	// an identity is never linked to an existing user by the username.
+	_, err = Provision(ProviderOIDC, Identity{Subject: "iss#2", UserName: "bob"}, conn)
+	assert.Equal(t, err, ErrUserNameTaken)
+	_, err = Provision(ProviderLDAP, Identity{Subject: "uid=alice", UserName: "alice"}, conn)
+	assert.Equal(t, err, ErrUserNameTaken)
+
+	count, err := db.WithDriver(conn).Table("goadmin_users").Count()
+	assert.Nil(t, err)
+	assert.Equal(t, count, int64(4))
+}
+
+func TestCheckState(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/admin/signin/callback?state=abc", nil)
+	req.AddCookie(&http.Cookie{Name: stateCookieKey, Value: "abc"})
+	assert.Equal(t, CheckState(context.NewContext(req)), true)
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/signin/callback?state=abc", nil)
+	req.AddCookie(&http.Cookie{Name: stateCookieKey, Value: "xyz"})
+	assert.Equal(t, CheckState(context.NewContext(req)), false)
+}
@@ -20,6 +20,12 @@ import (
 
 const DefaultCookieKey = "go_admin_session"
 
+const (
+	DriverDB     = "db"
+	DriverMemory = "memory"
+	DriverRedis  = "redis"
+)
+
 // NewDBDriver return the default PersistenceDriver.
 func newDBDriver(conn db.Connection) *DBDriver {
 	return &DBDriver{
@@ -34,9 +40,80 @@ type PersistenceDriver interface {
 	Update(sid string, values map[string]interface{}) error
 }
 
+// TokenStore is implemented by the PersistenceDriver which also stores the
+// csrf tokens of the TokenService. The tokens of the other drivers are kept
+// in the goadmin_session table.
+type TokenStore interface {
+	AddToken(token string) error
+	// UseToken delete the token and report whether it existed.
+	UseToken(token string) (bool, error)
+}
+
+// SessionManager is implemented by the PersistenceDriver which can list and
+// delete the sessions, it is used by the sessions page and the limit of the
+// concurrent sessions of a user.
+type SessionManager interface {
+	// List return the values of the sessions by the sid.
+	List() (map[string]map[string]interface{}, error)
+	// ListByUser return the values of the sessions of the user by the sid.
+	ListByUser(userId int64) (map[string]map[string]interface{}, error)
+	Delete(sids ...string) error
+}
+
+// SessionToucher is implemented by the PersistenceDriver which can update
+// the values of an existing session only, so that a revoked session is not
+// recreated by the update of its last seen time.
+type SessionToucher interface {
+	// Touch update the values of the session and report whether it existed.
+	Touch(sid string, values map[string]interface{}) (bool, error)
+}
+
+// DriverGenerator return the PersistenceDriver of the session store config.
+type DriverGenerator func(conn db.Connection, cfg config.SessionStore) PersistenceDriver
+
+// DriverList is the registry of the session stores.
+var DriverList = map[string]DriverGenerator{
+	DriverDB: func(conn db.Connection, _ config.SessionStore) PersistenceDriver {
+		return newDBDriver(conn)
+	},
+	DriverMemory: func(db.Connection, config.SessionStore) PersistenceDriver {
+		return defaultMemoryDriver
+	},
+	DriverRedis: func(_ db.Connection, cfg config.SessionStore) PersistenceDriver {
+		return getRedisDriver(cfg.Redis)
+	},
+}
+
+// AddDriver registers a custom session store, which can then be selected
+// with the driver of the session store config.
+func AddDriver(key string, gen DriverGenerator) {
+	if _, exist := DriverList[key]; exist {
+		panic("session driver exist")
+	}
+	DriverList[key] = gen
+}
+
+func GetDriver(key string) (DriverGenerator, bool) {
+	gen, ok := DriverList[key]
+	return gen, ok
+}
+
+// NewDriver return the PersistenceDriver of the session store config. An
+// unknown driver falls back to the database.
+func NewDriver(conn db.Connection) PersistenceDriver {
+	cfg := config.GetSessionStore()
+	if gen, ok := GetDriver(cfg.Driver); ok {
+		return gen(conn, cfg)
+	}
+	if cfg.Driver != "" {
+		logger.Error("unknown session driver: ", cfg.Driver)
+	}
+	return newDBDriver(conn)
+}
+
 // GetSessionByKey get the session value by key.
 func GetSessionByKey(sesKey, key string, conn db.Connection) (interface{}, error) {
-	m, err := newDBDriver(conn).Load(sesKey)
+	m, err := NewDriver(conn).Load(sesKey)
 	return m[key], err
 }
 
@@ -126,7 +203,7 @@ func InitSession(ctx *context.Context, conn db.Connection) (*Session, error) {
 		Cookie:  DefaultCookieKey,
 	})
 
-	sessions.UseDriver(newDBDriver(conn))
+	sessions.UseDriver(NewDriver(conn))
 	sessions.Values = make(map[string]interface{})
 
 	return sessions.StartCtx(ctx)
@@ -206,15 +283,10 @@ func (driver *DBDriver) Update(sid string, values map[string]interface{}) error
 		sesValue := string(valuesByte)
 		sesModel, _ := driver.table().Where("sid", "=", sid).First()
 		if sesModel == nil {
-			if !config.GetNoLimitLoginIP() {
-				err = driver.table().Where("values", "=", sesValue).Delete()
-				if db.CheckError(err, db.DELETE) {
-					return err
-				}
-			}
 			_, err := driver.table().Insert(dialect.H{
-				"values": sesValue,
-				"sid":    sid,
+				"values":  sesValue,
+				"sid":     sid,
+				"user_id": sessionUserId(values),
 			})
 			if db.CheckError(err, db.INSERT) {
 				return err
@@ -223,7 +295,8 @@ func (driver *DBDriver) Update(sid string, values map[string]interface{}) error
 			_, err := driver.table().
 				Where("sid", "=", sid).
 				Update(dialect.H{
-					"values": sesValue,
+					"values":  sesValue,
+					"user_id": sessionUserId(values),
 				})
 			if db.CheckError(err, db.UPDATE) {
 				return err
@@ -233,6 +306,66 @@ func (driver *DBDriver) Update(sid string, values map[string]interface{}) error
 	return nil
 }
 
+// Touch implements the SessionToucher.Touch.
+func (driver *DBDriver) Touch(sid string, values map[string]interface{}) (bool, error) {
+	valuesByte, err := json.Marshal(values)
+	if err != nil {
+		return false, err
+	}
+	_, err = driver.table().
+		Where("sid", "=", sid).
+		Where("values", "!=", csrfTokenValue).
+		Update(dialect.H{"values": string(valuesByte)})
+	if err == db.ErrNoAffectRow {
+		return false, nil
+	}
+	if db.CheckError(err, db.UPDATE) {
+		return false, err
+	}
+	return true, nil
+}
+
+// List implements the SessionManager.List.
+func (driver *DBDriver) List() (map[string]map[string]interface{}, error) {
+	return driver.list(driver.table().Where("values", "!=", csrfTokenValue))
+}
+
+// ListByUser implements the SessionManager.ListByUser.
+func (driver *DBDriver) ListByUser(userId int64) (map[string]map[string]interface{}, error) {
+	return driver.list(driver.table().Where("user_id", "=", userId).Where("values", "!=", csrfTokenValue))
+}
+
+func (driver *DBDriver) list(sql *db.SQL) (map[string]map[string]interface{}, error) {
+	list, err := sql.All()
+	if db.CheckError(err, db.QUERY) {
+		return nil, err
+	}
+	sessions := make(map[string]map[string]interface{}, len(list))
+	for _, item := range list {
+		var values map[string]interface{}
+		if json.Unmarshal([]byte(item["values"].(string)), &values) == nil && len(values) > 0 {
+			sessions[item["sid"].(string)] = values
+		}
+	}
+	return sessions, nil
+}
+
+// Delete implements the SessionManager.Delete.
+func (driver *DBDriver) Delete(sids ...string) error {
+	if len(sids) == 0 {
+		return nil
+	}
+	ids := make([]interface{}, len(sids))
+	for i, sid := range sids {
+		ids[i] = sid
+	}
+	err := driver.table().WhereIn("sid", ids).Where("values", "!=", csrfTokenValue).Delete()
+	if db.CheckError(err, db.DELETE) {
+		return err
+	}
+	return nil
+}
+
 func (driver *DBDriver) table() *db.SQL {
 	return db.Table(driver.tableName).WithDriver(driver.conn)
 }
@@ -0,0 +1,223 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+)
+
+const (
+	sessionUserKey      = "user_id"
+	sessionIPKey        = "ip"
+	sessionUserAgentKey = "user_agent"
+	sessionCreatedKey   = "created_at"
+	sessionLastSeenKey  = "last_seen"
+
+	// lastSeenInterval is the least interval between the updates of the
+	// last seen time of a session.
+	lastSeenInterval = time.Minute
+)
+
+// ErrSessionNotManaged is returned when the session driver is not a
+// SessionManager.
+var ErrSessionNotManaged = errors.New("the session driver can not list the sessions")
+
+// SessionInfo is a login session of a user.
+type SessionInfo struct {
+	Sid       string
+	UserId    int64
+	IP        string
+	UserAgent string
+	CreatedAt time.Time
+	LastSeen  time.Time
+}
+
+func newSessionInfo(sid string, values map[string]interface{}) (SessionInfo, bool) {
+	id := sessionUserId(values)
+	if id == 0 {
+		return SessionInfo{}, false
+	}
+	info := SessionInfo{
+		Sid:       sid,
+		UserId:    id,
+		CreatedAt: unixValue(values[sessionCreatedKey]),
+		LastSeen:  unixValue(values[sessionLastSeenKey]),
+	}
+	info.IP, _ = values[sessionIPKey].(string)
+	info.UserAgent, _ = values[sessionUserAgentKey].(string)
+	return info, true
+}
+
+// sessionUserId return the user id of the session values, which are decoded
+// from json when loaded, or 0 when the session is not logged in.
+func sessionUserId(values map[string]interface{}) int64 {
+	switch id := values[sessionUserKey].(type) {
+	case float64:
+		return int64(id)
+	case int64:
+		return id
+	case int:
+		return int64(id)
+	}
+	return 0
+}
+
+func unixValue(v interface{}) time.Time {
+	if sec, ok := v.(float64); ok {
+		return time.Unix(int64(sec), 0)
+	}
+	return time.Time{}
+}
+
+func sessionManager(conn db.Connection) (SessionManager, error) {
+	if m, ok := NewDriver(conn).(SessionManager); ok {
+		return m, nil
+	}
+	return nil, ErrSessionNotManaged
+}
+
+// ListSessions return the login sessions, the latest seen first.
+func ListSessions(conn db.Connection) ([]SessionInfo, error) {
+	m, err := sessionManager(conn)
+	if err != nil {
+		return nil, err
+	}
+	list, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+	return sortSessions(list, 0), nil
+}
+
+// sortSessions return the login sessions of the list, of the user if userId
+// is not 0, the latest seen first.
+func sortSessions(list map[string]map[string]interface{}, userId int64) []SessionInfo {
+	sessions := make([]SessionInfo, 0, len(list))
+	for sid, values := range list {
+		if info, ok := newSessionInfo(sid, values); ok && (userId == 0 || info.UserId == userId) {
+			sessions = append(sessions, info)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		if sessions[i].LastSeen.Equal(sessions[j].LastSeen) {
+			return sessions[i].Sid < sessions[j].Sid
+		}
+		return sessions[i].LastSeen.After(sessions[j].LastSeen)
+	})
+	return sessions
+}
+
+// UserSessions return the login sessions of the user, the latest seen
+// first.
+func UserSessions(userId int64, conn db.Connection) ([]SessionInfo, error) {
+	m, err := sessionManager(conn)
+	if err != nil {
+		return nil, err
+	}
+	list, err := m.ListByUser(userId)
+	if err != nil {
+		return nil, err
+	}
+	return sortSessions(list, userId), nil
+}
+
+// RevokeSessions logout the sessions.
+func RevokeSessions(conn db.Connection, sids ...string) error {
+	m, err := sessionManager(conn)
+	if err != nil {
+		return err
+	}
+	return m.Delete(sids...)
+}
+
+// RevokeUserSessions logout all the sessions of the user.
+func RevokeUserSessions(userId int64, conn db.Connection) error {
+	sessions, err := UserSessions(userId, conn)
+	if err != nil {
+		return err
+	}
+	sids := make([]string, len(sessions))
+	for i, info := range sessions {
+		sids[i] = info.Sid
+	}
+	return RevokeSessions(conn, sids...)
+}
+
+// MaxSessions return the max number of the concurrent sessions of a user,
+// 0 means no limit.
+func MaxSessions() int {
+	if max := config.GetMaxSessionsPerUser(); max > 0 {
+		return max
+	}
+	if !config.GetNoLimitLoginIP() {
+		return 1
+	}
+	return 0
+}
+
+// limitSessions logout the earliest created sessions of the user over
+// MaxSessions except the given one. It does nothing when the session driver
+// is not a SessionManager.
+func limitSessions(userId int64, sid string, conn db.Connection) error {
+	max := MaxSessions()
+	if max == 0 {
+		return nil
+	}
+	sessions, err := UserSessions(userId, conn)
+	if err == ErrSessionNotManaged {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(sessions) <= max {
+		return nil
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		if sessions[i].Sid == sid || sessions[j].Sid == sid {
+			return sessions[i].Sid == sid
+		}
+		return sessions[i].CreatedAt.After(sessions[j].CreatedAt)
+	})
+	sids := make([]string, 0, len(sessions)-max)
+	for _, info := range sessions[max:] {
+		sids = append(sids, info.Sid)
+	}
+	return RevokeSessions(conn, sids...)
+}
+
+// setSessionInfo set the client and the times of a login session.
+func setSessionInfo(ctx *context.Context, ses *Session) {
+	now := time.Now().Unix()
+	ses.Values[sessionIPKey] = ctx.LocalIP()
+	ses.Values[sessionUserAgentKey] = ctx.Request.UserAgent()
+	ses.Values[sessionCreatedKey] = now
+	ses.Values[sessionLastSeenKey] = now
+}
+
+// touchSession update the last seen time of the session, at most once in
+// lastSeenInterval. It return false if the session does not exist any more,
+// such as it is revoked after it is loaded. The time is not updated with the
+// drivers which do not implement the SessionToucher, since their update would
+// recreate a revoked session.
+func touchSession(driver PersistenceDriver, sid string, values map[string]interface{}) bool {
+	now := time.Now()
+	if now.Sub(unixValue(values[sessionLastSeenKey])) < lastSeenInterval {
+		return true
+	}
+	toucher, ok := driver.(SessionToucher)
+	if !ok {
+		return true
+	}
+	values[sessionLastSeenKey] = now.Unix()
+	exist, err := toucher.Touch(sid, values)
+	return exist || err != nil
+}
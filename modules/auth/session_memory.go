@@ -0,0 +1,197 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/modules/config"
+)
+
+// memorySweepInterval is the least interval between the sweeps of the
+// expired entries.
+const memorySweepInterval = time.Minute
+
+var defaultMemoryDriver = NewMemoryDriver()
+
+type memoryEntry struct {
+	values  []byte
+	expires time.Time
+}
+
+// MemoryDriver is a driver which stores the sessions and the csrf tokens in
+// the memory of the process. The entries expire after the session life time.
+type MemoryDriver struct {
+	lock      sync.Mutex
+	sessions  map[string]memoryEntry
+	tokens    map[string]time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryDriver return a new MemoryDriver.
+func NewMemoryDriver() *MemoryDriver {
+	return &MemoryDriver{
+		sessions:  make(map[string]memoryEntry),
+		tokens:    make(map[string]time.Time),
+		lastSweep: time.Now(),
+	}
+}
+
+func lifeTime() time.Duration {
+	return time.Second * time.Duration(config.GetSessionLifeTime())
+}
+
+// Load implements the PersistenceDriver.Load.
+func (driver *MemoryDriver) Load(sid string) (map[string]interface{}, error) {
+	driver.lock.Lock()
+	entry, ok := driver.sessions[sid]
+	if ok && time.Now().After(entry.expires) {
+		delete(driver.sessions, sid)
+		ok = false
+	}
+	driver.lock.Unlock()
+
+	if !ok {
+		return map[string]interface{}{}, nil
+	}
+
+	// the values are kept encoded, so that they are decoded with the same
+	// types as the other drivers.
+	var values map[string]interface{}
+	err := json.Unmarshal(entry.values, &values)
+	return values, err
+}
+
+// Update implements the PersistenceDriver.Update.
+func (driver *MemoryDriver) Update(sid string, values map[string]interface{}) error {
+	if sid == "" {
+		return nil
+	}
+
+	driver.lock.Lock()
+	defer driver.lock.Unlock()
+
+	driver.sweep()
+
+	if len(values) == 0 {
+		delete(driver.sessions, sid)
+		return nil
+	}
+
+	valuesByte, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	driver.sessions[sid] = memoryEntry{
+		values:  valuesByte,
+		expires: time.Now().Add(lifeTime()),
+	}
+	return nil
+}
+
+// Touch implements the SessionToucher.Touch.
+func (driver *MemoryDriver) Touch(sid string, values map[string]interface{}) (bool, error) {
+	valuesByte, err := json.Marshal(values)
+	if err != nil {
+		return false, err
+	}
+
+	driver.lock.Lock()
+	defer driver.lock.Unlock()
+
+	entry, ok := driver.sessions[sid]
+	if !ok || time.Now().After(entry.expires) {
+		return false, nil
+	}
+	driver.sessions[sid] = memoryEntry{
+		values:  valuesByte,
+		expires: time.Now().Add(lifeTime()),
+	}
+	return true, nil
+}
+
+// List implements the SessionManager.List.
+func (driver *MemoryDriver) List() (map[string]map[string]interface{}, error) {
+	return driver.list(0)
+}
+
+// ListByUser implements the SessionManager.ListByUser.
+func (driver *MemoryDriver) ListByUser(userId int64) (map[string]map[string]interface{}, error) {
+	return driver.list(userId)
+}
+
+// list return the unexpired sessions, of the user if userId is not 0.
+func (driver *MemoryDriver) list(userId int64) (map[string]map[string]interface{}, error) {
+	driver.lock.Lock()
+	defer driver.lock.Unlock()
+	now := time.Now()
+	sessions := make(map[string]map[string]interface{}, len(driver.sessions))
+	for sid, entry := range driver.sessions {
+		if now.After(entry.expires) {
+			continue
+		}
+		var values map[string]interface{}
+		if err := json.Unmarshal(entry.values, &values); err != nil {
+			return nil, err
+		}
+		if userId == 0 || sessionUserId(values) == userId {
+			sessions[sid] = values
+		}
+	}
+	return sessions, nil
+}
+
+// Delete implements the SessionManager.Delete.
+func (driver *MemoryDriver) Delete(sids ...string) error {
+	driver.lock.Lock()
+	defer driver.lock.Unlock()
+	for _, sid := range sids {
+		delete(driver.sessions, sid)
+	}
+	return nil
+}
+
+// AddToken implements the TokenStore.AddToken.
+func (driver *MemoryDriver) AddToken(token string) error {
+	driver.lock.Lock()
+	defer driver.lock.Unlock()
+	driver.sweep()
+	driver.tokens[token] = time.Now().Add(lifeTime())
+	return nil
+}
+
+// UseToken implements the TokenStore.UseToken.
+func (driver *MemoryDriver) UseToken(token string) (bool, error) {
+	driver.lock.Lock()
+	defer driver.lock.Unlock()
+	expires, ok := driver.tokens[token]
+	if !ok {
+		return false, nil
+	}
+	delete(driver.tokens, token)
+	return time.Now().Before(expires), nil
+}
+
+// sweep delete the expired entries, the lock is held by the caller.
+func (driver *MemoryDriver) sweep() {
+	now := time.Now()
+	if now.Sub(driver.lastSweep) < memorySweepInterval {
+		return
+	}
+	driver.lastSweep = now
+	for sid, entry := range driver.sessions {
+		if now.After(entry.expires) {
+			delete(driver.sessions, sid)
+		}
+	}
+	for token, expires := range driver.tokens {
+		if now.After(expires) {
+			delete(driver.tokens, token)
+		}
+	}
+}
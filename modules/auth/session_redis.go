@@ -0,0 +1,227 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/gomodule/redigo/redis"
+)
+
+var (
+	redisDrivers     = make(map[config.Redis]*RedisDriver)
+	redisDriversLock sync.Mutex
+)
+
+// getRedisDriver return the shared RedisDriver of the config, so that the
+// connection pool is created once.
+func getRedisDriver(cfg config.Redis) *RedisDriver {
+	redisDriversLock.Lock()
+	defer redisDriversLock.Unlock()
+	if driver, ok := redisDrivers[cfg]; ok {
+		return driver
+	}
+	driver := NewRedisDriver(cfg)
+	redisDrivers[cfg] = driver
+	return driver
+}
+
+// RedisDriver is a driver which stores the sessions and the csrf tokens in
+// redis. The keys expire after the session life time.
+type RedisDriver struct {
+	pool   *redis.Pool
+	prefix string
+}
+
+// NewRedisDriver return a new RedisDriver of the config.
+func NewRedisDriver(cfg config.Redis) *RedisDriver {
+	return &RedisDriver{
+		pool: &redis.Pool{
+			MaxIdle:     10,
+			IdleTimeout: 5 * time.Minute,
+			Dial: func() (redis.Conn, error) {
+				return redis.Dial("tcp", cfg.Addr,
+					redis.DialPassword(cfg.Password),
+					redis.DialDatabase(cfg.DB),
+					redis.DialConnectTimeout(5*time.Second))
+			},
+		},
+		prefix: cfg.Prefix,
+	}
+}
+
+func (driver *RedisDriver) sessionKey(sid string) string {
+	return driver.prefix + sid
+}
+
+func (driver *RedisDriver) tokenKey(token string) string {
+	return driver.prefix + "csrf:" + token
+}
+
+// sidsKey is the key of the set of the session ids, which is used to list
+// the sessions.
+func (driver *RedisDriver) sidsKey() string {
+	return driver.prefix + "sids"
+}
+
+// userSidsKey is the key of the set of the session ids of the user.
+func (driver *RedisDriver) userSidsKey(userId int64) string {
+	return driver.prefix + "user:" + strconv.FormatInt(userId, 10)
+}
+
+// Load implements the PersistenceDriver.Load.
+func (driver *RedisDriver) Load(sid string) (map[string]interface{}, error) {
+	conn := driver.pool.Get()
+	defer conn.Close()
+
+	valuesByte, err := redis.Bytes(conn.Do("GET", driver.sessionKey(sid)))
+	if err == redis.ErrNil {
+		return map[string]interface{}{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]interface{}
+	err = json.Unmarshal(valuesByte, &values)
+	return values, err
+}
+
+// Update implements the PersistenceDriver.Update.
+func (driver *RedisDriver) Update(sid string, values map[string]interface{}) error {
+	if sid == "" {
+		return nil
+	}
+
+	conn := driver.pool.Get()
+	defer conn.Close()
+
+	if len(values) == 0 {
+		return driver.del(conn, sid)
+	}
+
+	valuesByte, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	if _, err := conn.Do("SADD", driver.sidsKey(), sid); err != nil {
+		return err
+	}
+	if userId := sessionUserId(values); userId != 0 {
+		if _, err := conn.Do("SADD", driver.userSidsKey(userId), sid); err != nil {
+			return err
+		}
+	}
+	_, err = conn.Do("SET", driver.sessionKey(sid), valuesByte, "EX", config.GetSessionLifeTime())
+	return err
+}
+
+// Touch implements the SessionToucher.Touch, the key is set only if it
+// exists.
+func (driver *RedisDriver) Touch(sid string, values map[string]interface{}) (bool, error) {
+	valuesByte, err := json.Marshal(values)
+	if err != nil {
+		return false, err
+	}
+
+	conn := driver.pool.Get()
+	defer conn.Close()
+
+	reply, err := conn.Do("SET", driver.sessionKey(sid), valuesByte, "EX", config.GetSessionLifeTime(), "XX")
+	if err != nil {
+		return false, err
+	}
+	return reply != nil, nil
+}
+
+// List implements the SessionManager.List. The ids of the expired sessions
+// are removed from the set.
+func (driver *RedisDriver) List() (map[string]map[string]interface{}, error) {
+	return driver.list(driver.sidsKey(), 0)
+}
+
+// ListByUser implements the SessionManager.ListByUser. The ids of the
+// expired sessions and the sessions of the other users are removed from the
+// set of the user.
+func (driver *RedisDriver) ListByUser(userId int64) (map[string]map[string]interface{}, error) {
+	return driver.list(driver.userSidsKey(userId), userId)
+}
+
+// list return the sessions of the set key, of the user if userId is not 0.
+func (driver *RedisDriver) list(key string, userId int64) (map[string]map[string]interface{}, error) {
+	conn := driver.pool.Get()
+	defer conn.Close()
+
+	sids, err := redis.Strings(conn.Do("SMEMBERS", key))
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make(map[string]map[string]interface{}, len(sids))
+	for _, sid := range sids {
+		valuesByte, err := redis.Bytes(conn.Do("GET", driver.sessionKey(sid)))
+		if err == redis.ErrNil {
+			if _, err := conn.Do("SREM", key, sid); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var values map[string]interface{}
+		if err := json.Unmarshal(valuesByte, &values); err != nil {
+			return nil, err
+		}
+		if userId != 0 && sessionUserId(values) != userId {
+			if _, err := conn.Do("SREM", key, sid); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		sessions[sid] = values
+	}
+	return sessions, nil
+}
+
+// Delete implements the SessionManager.Delete.
+func (driver *RedisDriver) Delete(sids ...string) error {
+	conn := driver.pool.Get()
+	defer conn.Close()
+	return driver.del(conn, sids...)
+}
+
+func (driver *RedisDriver) del(conn redis.Conn, sids ...string) error {
+	for _, sid := range sids {
+		if _, err := conn.Do("DEL", driver.sessionKey(sid)); err != nil {
+			return err
+		}
+		if _, err := conn.Do("SREM", driver.sidsKey(), sid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddToken implements the TokenStore.AddToken.
+func (driver *RedisDriver) AddToken(token string) error {
+	conn := driver.pool.Get()
+	defer conn.Close()
+	_, err := conn.Do("SET", driver.tokenKey(token), 1, "EX", config.GetSessionLifeTime())
+	return err
+}
+
+// UseToken implements the TokenStore.UseToken.
+func (driver *RedisDriver) UseToken(token string) (bool, error) {
+	conn := driver.pool.Get()
+	defer conn.Close()
+	n, err := redis.Int(conn.Do("DEL", driver.tokenKey(token)))
+	return n == 1, err
+}
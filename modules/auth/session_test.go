@@ -0,0 +1,348 @@
+package auth
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/stretchr/testify/assert"
+)
+
+var testConfig *config.Config
+
+func TestMain(m *testing.M) {
+	testConfig = config.Initialize(&config.Config{
+		SessionLifeTime: 60,
+		SessionStore:    config.SessionStore{Driver: DriverMemory},
+	})
+	os.Exit(m.Run())
+}
+
+// redisStub is a redis stand-in which answers the commands used by the
+// RedisDriver.
+type redisStub struct {
+	lock    sync.Mutex
+	values  map[string]string
+	sets    map[string]map[string]bool
+	expires map[string]time.Time
+}
+
+func serveRedis(t *testing.T) (*redisStub, string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	stub := &redisStub{
+		values:  map[string]string{},
+		sets:    map[string]map[string]bool{},
+		expires: map[string]time.Time{},
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go stub.serve(conn)
+		}
+	}()
+	return stub, l.Addr().String()
+}
+
+func (stub *redisStub) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil || !strings.HasPrefix(line, "*") {
+			return
+		}
+		n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		args := make([]string, n)
+		for i := range args {
+			if _, err := r.ReadString('\n'); err != nil {
+				return
+			}
+			arg, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			args[i] = strings.TrimSuffix(arg, "\r\n")
+		}
+		_, _ = conn.Write([]byte(stub.do(args)))
+	}
+}
+
+func (stub *redisStub) get(key string) (string, bool) {
+	if expires, ok := stub.expires[key]; ok && time.Now().After(expires) {
+		delete(stub.values, key)
+		delete(stub.expires, key)
+	}
+	value, ok := stub.values[key]
+	return value, ok
+}
+
+func bulk(value string, ok bool) string {
+	if !ok {
+		return "$-1\r\n"
+	}
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+}
+
+func (stub *redisStub) do(args []string) string {
+	stub.lock.Lock()
+	defer stub.lock.Unlock()
+	switch strings.ToUpper(args[0]) {
+	case "GET":
+		return bulk(stub.get(args[1]))
+	case "SET":
+		if _, ok := stub.get(args[1]); !ok && len(args) == 6 && strings.ToUpper(args[5]) == "XX" {
+			return "$-1\r\n"
+		}
+		stub.values[args[1]] = args[2]
+		delete(stub.expires, args[1])
+		if len(args) >= 5 && strings.ToUpper(args[3]) == "EX" {
+			sec, _ := strconv.Atoi(args[4])
+			stub.expires[args[1]] = time.Now().Add(time.Duration(sec) * time.Second)
+		}
+		return "+OK\r\n"
+	case "SADD":
+		if stub.sets[args[1]] == nil {
+			stub.sets[args[1]] = map[string]bool{}
+		}
+		stub.sets[args[1]][args[2]] = true
+		return ":1\r\n"
+	case "SREM":
+		delete(stub.sets[args[1]], args[2])
+		return ":1\r\n"
+	case "SMEMBERS":
+		res := fmt.Sprintf("*%d\r\n", len(stub.sets[args[1]]))
+		for member := range stub.sets[args[1]] {
+			res += bulk(member, true)
+		}
+		return res
+	case "DEL":
+		_, ok := stub.get(args[1])
+		delete(stub.values, args[1])
+		delete(stub.expires, args[1])
+		if ok {
+			return ":1\r\n"
+		}
+		return ":0\r\n"
+	}
+	return "-ERR unknown command\r\n"
+}
+
+func testDriver(t *testing.T, driver PersistenceDriver) {
+	values, err := driver.Load("sid1")
+	assert.Nil(t, err)
+	assert.Equal(t, len(values), 0)
+
+	assert.Nil(t, driver.Update("sid1", map[string]interface{}{"user_id": 1}))
+	values, err = driver.Load("sid1")
+	assert.Nil(t, err)
+	assert.Equal(t, values["user_id"], float64(1))
+
+	assert.Nil(t, driver.Update("sid2", map[string]interface{}{"user_id": 2}))
+	assert.Nil(t, driver.Update("sid3", map[string]interface{}{"user_id": 3}))
+
+	m, ok := driver.(SessionManager)
+	assert.Equal(t, ok, true)
+	list, err := m.List()
+	assert.Nil(t, err)
+	assert.Equal(t, len(list), 3)
+	assert.Equal(t, list["sid2"]["user_id"], float64(2))
+	list, err = m.ListByUser(2)
+	assert.Nil(t, err)
+	assert.Equal(t, len(list), 1)
+	assert.Equal(t, list["sid2"]["user_id"], float64(2))
+
+	assert.Nil(t, m.Delete("sid1", "sid2"))
+	values, err = driver.Load("sid1")
+	assert.Nil(t, err)
+	assert.Equal(t, len(values), 0)
+	list, err = m.List()
+	assert.Nil(t, err)
+	assert.Equal(t, len(list), 1)
+	list, err = m.ListByUser(2)
+	assert.Nil(t, err)
+	assert.Equal(t, len(list), 0)
+
+	toucher, ok := driver.(SessionToucher)
+	assert.Equal(t, ok, true)
+	ok, err = toucher.Touch("sid3", map[string]interface{}{"user_id": 3, "last_seen": 1})
+	assert.Nil(t, err)
+	assert.Equal(t, ok, true)
+	values, err = driver.Load("sid3")
+	assert.Nil(t, err)
+	assert.Equal(t, values["last_seen"], float64(1))
+	ok, err = toucher.Touch("sid1", map[string]interface{}{"user_id": 1})
+	assert.Nil(t, err)
+	assert.Equal(t, ok, false)
+	values, err = driver.Load("sid1")
+	assert.Nil(t, err)
+	assert.Equal(t, len(values), 0)
+
+	assert.Nil(t, driver.Update("sid3", map[string]interface{}{}))
+	values, err = driver.Load("sid3")
+	assert.Nil(t, err)
+	assert.Equal(t, len(values), 0)
+	list, err = m.List()
+	assert.Nil(t, err)
+	assert.Equal(t, len(list), 0)
+
+	store, ok := driver.(TokenStore)
+	assert.Equal(t, ok, true)
+	assert.Nil(t, store.AddToken("token"))
+	ok, err = store.UseToken("token")
+	assert.Nil(t, err)
+	assert.Equal(t, ok, true)
+	ok, err = store.UseToken("token")
+	assert.Nil(t, err)
+	assert.Equal(t, ok, false)
+}
+
+func TestMemoryDriver(t *testing.T) {
+	testDriver(t, NewMemoryDriver())
+}
+
+func TestDBDriverListByUser(t *testing.T) {
+	driver := newDBDriver(testAdminConn(t))
+	assert.Nil(t, driver.Update("sid1", map[string]interface{}{"user_id": 1}))
+	assert.Nil(t, driver.Update("sid2", map[string]interface{}{"user_id": 2}))
+	assert.Nil(t, driver.Update("sid3", map[string]interface{}{"user_id": int64(2)}))
+
+	list, err := driver.ListByUser(2)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(list))
+	assert.Equal(t, float64(2), list["sid3"]["user_id"])
+
+	assert.Nil(t, driver.Delete("sid2"))
+	list, err = driver.ListByUser(2)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(list))
+
+	// the revoked session is not recreated by the touch.
+	ok, err := driver.Touch("sid2", map[string]interface{}{"user_id": 2})
+	assert.Nil(t, err)
+	assert.Equal(t, false, ok)
+	ok, err = driver.Touch("sid3", map[string]interface{}{"user_id": 2, "last_seen": 1})
+	assert.Nil(t, err)
+	assert.Equal(t, true, ok)
+	values, err := driver.Load("sid3")
+	assert.Nil(t, err)
+	assert.Equal(t, float64(1), values["last_seen"])
+	list, err = driver.ListByUser(2)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(list))
+}
+
+func TestMemoryDriverExpires(t *testing.T) {
+	driver := NewMemoryDriver()
+	assert.Nil(t, driver.Update("sid", map[string]interface{}{"user_id": 1}))
+	assert.Nil(t, driver.AddToken("token"))
+
+	entry := driver.sessions["sid"]
+	entry.expires = time.Now().Add(-time.Second)
+	driver.sessions["sid"] = entry
+	driver.tokens["token"] = time.Now().Add(-time.Second)
+
+	values, err := driver.Load("sid")
+	assert.Nil(t, err)
+	assert.Equal(t, len(values), 0)
+	ok, _ := driver.UseToken("token")
+	assert.Equal(t, ok, false)
+}
+
+func TestRedisDriver(t *testing.T) {
+	stub, addr := serveRedis(t)
+	testDriver(t, NewRedisDriver(config.Redis{Addr: addr, Prefix: "test:"}))
+
+	stub.lock.Lock()
+	defer stub.lock.Unlock()
+	for key := range stub.values {
+		assert.Equal(t, strings.HasPrefix(key, "test:"), true)
+		_, ok := stub.expires[key]
+		assert.Equal(t, ok, true)
+	}
+}
+
+func TestTokenServiceStore(t *testing.T) {
+	s := &TokenService{store: NewMemoryDriver()}
+	token := s.AddToken()
+	assert.Equal(t, s.CheckToken(token), true)
+	assert.Equal(t, s.CheckToken(token), false)
+	assert.Equal(t, s.CheckToken("unknown"), false)
+}
+
+func TestAddDriver(t *testing.T) {
+	assert.Panics(t, func() {
+		AddDriver(DriverMemory, func(_ db.Connection, _ config.SessionStore) PersistenceDriver { return nil })
+	})
+	_, ok := GetDriver(DriverRedis)
+	assert.Equal(t, ok, true)
+}
+
+func TestLimitSessions(t *testing.T) {
+	defer func() { defaultMemoryDriver = NewMemoryDriver() }()
+
+	now := float64(time.Now().Unix())
+	for i, sid := range []string{"a", "b", "c"} {
+		assert.Nil(t, defaultMemoryDriver.Update(sid, map[string]interface{}{
+			sessionUserKey:     1,
+			sessionIPKey:       "127.0.0.1",
+			sessionCreatedKey:  now + float64(i),
+			sessionLastSeenKey: now + float64(i),
+		}))
+	}
+	assert.Nil(t, defaultMemoryDriver.Update("d", map[string]interface{}{sessionUserKey: 2}))
+	assert.Nil(t, defaultMemoryDriver.Update("pending", map[string]interface{}{totpUserKey: 1}))
+
+	sessions, err := ListSessions(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, len(sessions), 4)
+	assert.Equal(t, sessions[0].Sid, "c")
+	assert.Equal(t, sessions[0].IP, "127.0.0.1")
+
+	// the earliest session "a" is kept as the current one.
+	assert.Equal(t, MaxSessions(), 1)
+	assert.Nil(t, limitSessions(1, "a", nil))
+	sessions, err = UserSessions(1, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, len(sessions), 1)
+	assert.Equal(t, sessions[0].Sid, "a")
+
+	assert.Nil(t, RevokeUserSessions(1, nil))
+	sessions, err = ListSessions(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, len(sessions), 1)
+	assert.Equal(t, sessions[0].UserId, int64(2))
+}
+
+func TestTouchSession(t *testing.T) {
+	driver := NewMemoryDriver()
+	old := float64(time.Now().Add(-2 * lastSeenInterval).Unix())
+	values := map[string]interface{}{sessionUserKey: float64(1), sessionLastSeenKey: old}
+	assert.Nil(t, driver.Update("sid", values))
+
+	assert.Equal(t, touchSession(driver, "sid", values), true)
+	values, err := driver.Load("sid")
+	assert.Nil(t, err)
+	assert.Equal(t, values[sessionLastSeenKey].(float64) > old, true)
+
+	// a revoked session is not recreated.
+	assert.Nil(t, driver.Delete("sid"))
+	values[sessionLastSeenKey] = old
+	assert.Equal(t, touchSession(driver, "sid", values), false)
+	values, err = driver.Load("sid")
+	assert.Nil(t, err)
+	assert.Equal(t, len(values), 0)
+}
@@ -0,0 +1,231 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/modules/logger"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
+)
+
+const (
+	// TOTPPeriod is the time step of the codes in seconds.
+	TOTPPeriod = 30
+	// TOTPDigits is the length of the codes.
+	TOTPDigits = 6
+	// TOTPSkew is the number of the time steps before and after the current
+	// one in which a code is still accepted.
+	TOTPSkew = 1
+
+	// RecoveryCodeCount is the number of the generated recovery codes.
+	RecoveryCodeCount = 10
+
+	// MaxTOTPTries is the number of the wrong codes after which the user is
+	// locked for TOTPLockout and the pending signin is cleared.
+	MaxTOTPTries = 5
+	// TOTPLockout is the time for which the user is locked.
+	TOTPLockout = 15 * time.Minute
+
+	totpUserKey = "totp_user_id"
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret return a random base32 encoded secret of 160 bits.
+func GenerateTOTPSecret() string {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return totpEncoding.EncodeToString(b)
+}
+
+// TOTPCode return the code of the secret at the given time, see RFC 6238.
+func TOTPCode(secret string, t time.Time) (string, error) {
+	return totpCode(secret, uint64(t.Unix()/TOTPPeriod))
+}
+
+func totpCode(secret string, counter uint64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", err
+	}
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < TOTPDigits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", TOTPDigits, value%mod), nil
+}
+
+// ValidateTOTP check the code with the codes of the secret around the given
+// time.
+func ValidateTOTP(secret, code string, t time.Time) bool {
+	_, ok := validateTOTP(secret, code, t)
+	return ok
+}
+
+// validateTOTP check the code like ValidateTOTP and return the time step of
+// the matched code.
+func validateTOTP(secret, code string, t time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if secret == "" || len(code) != TOTPDigits {
+		return 0, false
+	}
+	counter := t.Unix() / TOTPPeriod
+	for i := -TOTPSkew; i <= TOTPSkew; i++ {
+		expected, err := totpCode(secret, uint64(counter+int64(i)))
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return counter + int64(i), true
+		}
+	}
+	return 0, false
+}
+
+// VerifyTOTP check the TOTP code of the user and record its time step, so
+// that a code which has been used, or is older than a used one, is refused.
+func VerifyTOTP(user models.UserModel, code string) bool {
+	step, ok := validateTOTP(user.TOTPSecret, code, time.Now())
+	return ok && step > user.TOTPLastStep && user.UseTOTPStep(step)
+}
+
+// TOTPURL return the otpauth url of the secret which is scanned by the
+// authenticator apps.
+func TOTPURL(issuer, account, secret string) string {
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprintf("%d", TOTPDigits))
+	v.Set("period", fmt.Sprintf("%d", TOTPPeriod))
+	return "otpauth://totp/" + url.PathEscape(issuer+":"+account) + "?" + v.Encode()
+}
+
+// GenerateRecoveryCodes return the recovery codes and their hashes, the
+// hashes are stored and the codes are shown to the user once.
+func GenerateRecoveryCodes() (codes []string, hashes []string) {
+	codes = make([]string, RecoveryCodeCount)
+	hashes = make([]string, RecoveryCodeCount)
+	for i := 0; i < RecoveryCodeCount; i++ {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			panic(err)
+		}
+		s := hex.EncodeToString(b)
+		codes[i] = s[:5] + "-" + s[5:]
+		hashes[i] = HashRecoveryCode(codes[i])
+	}
+	return
+}
+
+// HashRecoveryCode return the hash of the recovery code.
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// CheckTOTP check the code of the user which is an unused TOTP code or an
+// unused recovery code. A matched recovery code is used up.
+func CheckTOTP(user models.UserModel, code string) bool {
+	if VerifyTOTP(user, code) {
+		return true
+	}
+	return user.UseRecoveryCode(HashRecoveryCode(code))
+}
+
+// FailTOTP count a wrong code of the user. The count is kept by the user
+// instead of the session, so that it is not reset by a new signin. It
+// returns true when the user is locked after MaxTOTPTries wrong codes.
+func FailTOTP(user models.UserModel) bool {
+	locked, err := user.FailTOTP(MaxTOTPTries, time.Now().Add(TOTPLockout))
+	if err != nil {
+		logger.Error("count wrong totp code error: ", err)
+	}
+	return locked
+}
+
+// NeedTOTP check the user has to pass the TOTP verification at signin,
+// which is when the user has enabled it, or the user is a super admin
+// and the auth config requires it.
+func NeedTOTP(user models.UserModel) bool {
+	return user.TOTPEnabled || (user.IsSuperAdmin() && config.GetAuth().TOTP.RequireSuperAdmin)
+}
+
+// SetTOTPPending set the user of the session which has passed the first
+// factor and waits for the TOTP verification.
+func SetTOTPPending(ctx *context.Context, user models.UserModel, conn db.Connection) error {
+	ses, err := InitSession(ctx, conn)
+
+	if err != nil {
+		return err
+	}
+
+	delete(ses.Values, "user_id")
+
+	return ses.Add(totpUserKey, user.Id)
+}
+
+// GetTOTPPending return the user of the session which waits for the TOTP
+// verification.
+func GetTOTPPending(ctx *context.Context, conn db.Connection) (models.UserModel, bool) {
+	user := models.User().SetConn(conn)
+
+	ses, err := InitSession(ctx, conn)
+	if err != nil {
+		return user, false
+	}
+
+	// the session values are decoded from json.
+	id, ok := ses.Get(totpUserKey).(float64)
+	if !ok {
+		return user, false
+	}
+
+	user = user.Find(int64(id))
+	return user, !user.IsEmpty()
+}
+
+// FailTOTPPending count a wrong code of the user of the pending signin and
+// clear the session when the user is locked. It returns true when the
+// session is cleared.
+func FailTOTPPending(ctx *context.Context, user models.UserModel, conn db.Connection) bool {
+	if !FailTOTP(user) {
+		return false
+	}
+	if ses, err := InitSession(ctx, conn); err == nil {
+		_ = ses.Clear()
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTOTPCode(t *testing.T) {
+	// the SHA1 test vectors of RFC 6238 appendix B, truncated to 6 digits.
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+	for unix, code := range map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	} {
+		got, err := TOTPCode(secret, time.Unix(unix, 0))
+		assert.Nil(t, err)
+		assert.Equal(t, got, code)
+	}
+
+	_, err := TOTPCode("not base32!", time.Now())
+	assert.NotNil(t, err)
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret := GenerateTOTPSecret()
+	now := time.Unix(1700000000, 0)
+
+	code, err := TOTPCode(secret, now)
+	assert.Nil(t, err)
+
+	assert.Equal(t, ValidateTOTP(secret, code, now), true)
+	assert.Equal(t, ValidateTOTP(secret, " "+code+" ", now), true)
+	assert.Equal(t, ValidateTOTP(secret, code, now.Add(TOTPPeriod*time.Second)), true)
+	assert.Equal(t, ValidateTOTP(secret, code, now.Add(-TOTPPeriod*time.Second)), true)
+	assert.Equal(t, ValidateTOTP(secret, code, now.Add(3*TOTPPeriod*time.Second)), false)
+	assert.Equal(t, ValidateTOTP(secret, "12345", now), false)
+	assert.Equal(t, ValidateTOTP("", code, now), false)
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, hashes := GenerateRecoveryCodes()
+	assert.Equal(t, len(codes), RecoveryCodeCount)
+	assert.Equal(t, len(hashes), RecoveryCodeCount)
+
+	for i, code := range codes {
+		assert.Equal(t, len(code), 11)
+		assert.Equal(t, HashRecoveryCode(code), hashes[i])
+		assert.Equal(t, HashRecoveryCode(" "+strings.ToUpper(strings.ReplaceAll(code, "-", ""))), hashes[i])
+	}
+	assert.NotEqual(t, codes[0], codes[1])
+}
+
+func TestTOTPURL(t *testing.T) {
+	u, err := url.Parse(TOTPURL("GoAdmin", "admin", "JBSWY3DPEHPK3PXP"))
+	assert.Nil(t, err)
+	assert.Equal(t, u.Scheme, "otpauth")
+	assert.Equal(t, u.Host, "totp")
+	assert.Equal(t, u.Path, "/GoAdmin:admin")
+	assert.Equal(t, u.Query().Get("secret"), "JBSWY3DPEHPK3PXP")
+	assert.Equal(t, u.Query().Get("issuer"), "GoAdmin")
+	assert.Equal(t, u.Query().Get("digits"), "6")
+}
+
+func TestVerifyTOTP(t *testing.T) {
+	conn := testAdminConn(t)
+
+	testConfig.Auth.TOTP.SecretKey = "key"
+	defer func() { testConfig.Auth.TOTP.SecretKey = "" }()
+
+	user, err := models.User().SetConn(conn).Find(1).SetTOTPSecret(GenerateTOTPSecret())
+	assert.Nil(t, err)
+
+	// the secret is encrypted in the user table.
+	row, err := db.WithDriver(conn).Table("goadmin_users").Where("id", "=", 1).First()
+	assert.Nil(t, err)
+	assert.NotEqual(t, row["totp_secret"], user.TOTPSecret)
+	assert.Equal(t, models.User().SetConn(conn).Find(1).TOTPSecret, user.TOTPSecret)
+
+	code, err := TOTPCode(user.TOTPSecret, time.Now())
+	assert.Nil(t, err)
+
+	assert.Equal(t, VerifyTOTP(user, code), true)
+	// a used code is refused.
+	assert.Equal(t, VerifyTOTP(user, code), false)
+	assert.Equal(t, VerifyTOTP(models.User().SetConn(conn).Find(1), code), false)
+
+	for i := 1; i < MaxTOTPTries; i++ {
+		assert.Equal(t, FailTOTP(user), false)
+	}
+	assert.Equal(t, models.User().SetConn(conn).Find(1).TOTPLocked(), false)
+	assert.Equal(t, FailTOTP(user), true)
+	assert.Equal(t, models.User().SetConn(conn).Find(1).TOTPLocked(), true)
+
+	codes, hashes := GenerateRecoveryCodes()
+	user, err = user.EnableTOTP(hashes)
+	assert.Nil(t, err)
+	assert.Equal(t, CheckTOTP(user, codes[0]), true)
+	assert.Equal(t, CheckTOTP(models.User().SetConn(conn).Find(1), codes[0]), false)
+
+	// the secret can not be set without the key.
+	testConfig.Auth.TOTP.SecretKey = ""
+	_, err = user.SetTOTPSecret(GenerateTOTPSecret())
+	assert.NotNil(t, err)
+}
@@ -326,6 +326,9 @@ type Config struct {
 	// Session valid time duration,units are seconds. Default 7200.
 	SessionLifeTime int `json:"session_life_time,omitempty" yaml:"session_life_time,omitempty" ini:"session_life_time,omitempty"`
 
+	// Store of the sessions and the csrf tokens. See modules/auth/session.go.
+	SessionStore SessionStore `json:"session_store,omitempty" yaml:"session_store,omitempty" ini:"session_store,omitempty"`
+
 	// Assets visit link.
 	AssetUrl string `json:"asset_url,omitempty" yaml:"asset_url,omitempty" ini:"asset_url,omitempty"`
 
@@ -350,6 +353,9 @@ type Config struct {
 	// Auth user table
 	AuthUserTable string `json:"auth_user_table,omitempty" yaml:"auth_user_table,omitempty" ini:"auth_user_table,omitempty"`
 
+	// Authentication provider of the login. See modules/auth/provider.go.
+	Auth Auth `json:"auth,omitempty" yaml:"auth,omitempty" ini:"auth,omitempty"`
+
 	// Extra config info
 	Extra ExtraInfo `json:"extra,omitempty" yaml:"extra,omitempty" ini:"extra,omitempty"`
 
@@ -359,6 +365,11 @@ type Config struct {
 	// Limit login with different IPs
 	NoLimitLoginIP bool `json:"no_limit_login_ip,omitempty" yaml:"no_limit_login_ip,omitempty" ini:"no_limit_login_ip,omitempty"`
 
+	// Max concurrent sessions of a user, the earliest sessions are logged
+	// out at the signin over it. Default 0, which is one session unless
+	// NoLimitLoginIP is true, and then no limit.
+	MaxSessionsPerUser int `json:"max_sessions_per_user,omitempty" yaml:"max_sessions_per_user,omitempty" ini:"max_sessions_per_user,omitempty"`
+
 	// When site off is true, website will be closed
 	SiteOff bool `json:"site_off,omitempty" yaml:"site_off,omitempty" ini:"site_off,omitempty"`
 
@@ -406,6 +417,18 @@ type Config struct {
 
 	URLFormat URLFormat `json:"url_format,omitempty" yaml:"url_format,omitempty" ini:"url_format,omitempty"`
 
+	// Apply the pending migrations of the goadmin system tables to the
+	// default connection at the startup. The versions already in a database
+	// installed from an earlier sql dump are recorded without being run.
+	// See modules/migration.
+	AutoMigrate bool `json:"auto_migrate,omitempty" yaml:"auto_migrate,omitempty" ini:"auto_migrate,omitempty"`
+
+	// Deny the paths of the non super admin users whose roles have no
+	// permissions. The paths not matched by the permissions of the roles are
+	// always denied, without it the users without permissions are restricted
+	// by the menus of their roles only, as before the permissions were checked.
+	PermissionDefaultDeny bool `json:"permission_default_deny,omitempty" yaml:"permission_default_deny,omitempty" ini:"permission_default_deny,omitempty"`
+
 	prefix string       `json:"-" yaml:"-" ini:"-"`
 	lock   sync.RWMutex `json:"-" yaml:"-" ini:"-"`
 }
@@ -437,12 +460,118 @@ type RotateCfg struct {
 	Compress   bool `json:"compress,omitempty" yaml:"compress,omitempty" ini:"compress,omitempty"`
 }
 
+// Auth is the config of the authentication provider.
+type Auth struct {
+	// Provider is one of "portal", "local", "ldap", "oidc" or the name of a
+	// custom provider. Default "portal", which signs in through chime-portal.
+	Provider string `json:"provider,omitempty" yaml:"provider,omitempty" ini:"provider,omitempty"`
+
+	// DefaultRole is the role of the users provisioned at their first login
+	// with an external provider. There is no default, the users are not
+	// provisioned until it is set.
+	DefaultRole *int64 `json:"default_role,omitempty" yaml:"default_role,omitempty" ini:"default_role,omitempty"`
+
+	LDAP LDAP `json:"ldap,omitempty" yaml:"ldap,omitempty" ini:"ldap,omitempty"`
+	OIDC OIDC `json:"oidc,omitempty" yaml:"oidc,omitempty" ini:"oidc,omitempty"`
+	TOTP TOTP `json:"totp,omitempty" yaml:"totp,omitempty" ini:"totp,omitempty"`
+}
+
+func (a Auth) SetDefault() Auth {
+	a.Provider = utils.SetDefault(a.Provider, "", "portal")
+	a.LDAP.UserFilter = utils.SetDefault(a.LDAP.UserFilter, "", "(uid=%s)")
+	a.LDAP.NameAttribute = utils.SetDefault(a.LDAP.NameAttribute, "", "cn")
+	a.OIDC.UserNameClaim = utils.SetDefault(a.OIDC.UserNameClaim, "", "preferred_username")
+	if len(a.OIDC.Scopes) == 0 {
+		a.OIDC.Scopes = []string{"openid", "profile", "email"}
+	}
+	a.TOTP.Issuer = utils.SetDefault(a.TOTP.Issuer, "", "GoAdmin")
+	return a
+}
+
+// LDAP is the config of the LDAP authentication provider. The user is
+// searched with the bind dn and authenticated by binding as the found entry.
+type LDAP struct {
+	// URL of the server, like ldap://127.0.0.1:389 or ldaps://127.0.0.1:636.
+	URL                string `json:"url,omitempty" yaml:"url,omitempty" ini:"url,omitempty"`
+	StartTLS           bool   `json:"start_tls,omitempty" yaml:"start_tls,omitempty" ini:"start_tls,omitempty"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty" yaml:"insecure_skip_verify,omitempty" ini:"insecure_skip_verify,omitempty"`
+	BindDN             string `json:"bind_dn,omitempty" yaml:"bind_dn,omitempty" ini:"bind_dn,omitempty"`
+	BindPassword       string `json:"bind_password,omitempty" yaml:"bind_password,omitempty" ini:"bind_password,omitempty"`
+	BaseDN             string `json:"base_dn,omitempty" yaml:"base_dn,omitempty" ini:"base_dn,omitempty"`
+	// UserFilter is the search filter of the user, %s is replaced by the
+	// escaped username. Default "(uid=%s)".
+	UserFilter string `json:"user_filter,omitempty" yaml:"user_filter,omitempty" ini:"user_filter,omitempty"`
+	// NameAttribute is the attribute used as the display name. Default "cn".
+	NameAttribute string `json:"name_attribute,omitempty" yaml:"name_attribute,omitempty" ini:"name_attribute,omitempty"`
+}
+
+// OIDC is the config of the OpenID Connect authentication provider.
+type OIDC struct {
+	Issuer       string `json:"issuer,omitempty" yaml:"issuer,omitempty" ini:"issuer,omitempty"`
+	ClientID     string `json:"client_id,omitempty" yaml:"client_id,omitempty" ini:"client_id,omitempty"`
+	ClientSecret string `json:"client_secret,omitempty" yaml:"client_secret,omitempty" ini:"client_secret,omitempty"`
+	// RedirectURL is the absolute url of the signin callback route, like
+	// https://example.com/admin/signin/callback.
+	RedirectURL string   `json:"redirect_url,omitempty" yaml:"redirect_url,omitempty" ini:"redirect_url,omitempty"`
+	Scopes      []string `json:"scopes,omitempty" yaml:"scopes,omitempty" ini:"scopes,omitempty"`
+	// UserNameClaim is the claim of the id token used as the username.
+	// Default "preferred_username".
+	UserNameClaim string `json:"user_name_claim,omitempty" yaml:"user_name_claim,omitempty" ini:"user_name_claim,omitempty"`
+}
+
+// TOTP is the config of the two-factor authentication with the time-based
+// one-time passwords. It works with the providers which sign in with the
+// session of GoAdmin, not with the portal.
+type TOTP struct {
+	// Issuer is shown by the authenticator apps. Default "GoAdmin".
+	Issuer string `json:"issuer,omitempty" yaml:"issuer,omitempty" ini:"issuer,omitempty"`
+	// RequireSuperAdmin makes the super admins enroll at their next signin.
+	// It can not be used with the portal provider.
+	RequireSuperAdmin bool `json:"require_super_admin,omitempty" yaml:"require_super_admin,omitempty" ini:"require_super_admin,omitempty"`
+	// SecretKey encrypts the secrets stored in the user table. The users can
+	// not enroll without it, and the enrolled users can not sign in after it
+	// is changed.
+	SecretKey string `json:"secret_key,omitempty" yaml:"secret_key,omitempty" ini:"secret_key,omitempty"`
+}
+
+// SessionStore is the config of the store of the sessions and the csrf
+// tokens.
+type SessionStore struct {
+	// Driver is one of "db", "memory", "redis" or the name of a custom
+	// driver. Default "db", which stores into the goadmin_session table.
+	// The memory store is lost at restart and is not shared between the
+	// instances.
+	Driver string `json:"driver,omitempty" yaml:"driver,omitempty" ini:"driver,omitempty"`
+
+	Redis Redis `json:"redis,omitempty" yaml:"redis,omitempty" ini:"redis,omitempty"`
+}
+
+func (s SessionStore) SetDefault() SessionStore {
+	s.Driver = utils.SetDefault(s.Driver, "", "db")
+	s.Redis.Addr = utils.SetDefault(s.Redis.Addr, "", "127.0.0.1:6379")
+	s.Redis.Prefix = utils.SetDefault(s.Redis.Prefix, "", "goadmin_session:")
+	return s
+}
+
+// Redis is the config of the redis session store.
+type Redis struct {
+	// Addr of the server. Default "127.0.0.1:6379".
+	Addr     string `json:"addr,omitempty" yaml:"addr,omitempty" ini:"addr,omitempty"`
+	Password string `json:"password,omitempty" yaml:"password,omitempty" ini:"password,omitempty"`
+	DB       int    `json:"db,omitempty" yaml:"db,omitempty" ini:"db,omitempty"`
+	// Prefix of the keys. Default "goadmin_session:".
+	Prefix string `json:"prefix,omitempty" yaml:"prefix,omitempty" ini:"prefix,omitempty"`
+}
+
 type URLFormat struct {
 	Info       string `json:"info,omitempty" yaml:"info,omitempty" ini:"info,omitempty"`
 	Detail     string `json:"detail,omitempty" yaml:"detail,omitempty" ini:"detail,omitempty"`
 	Create     string `json:"create,omitempty" yaml:"create,omitempty" ini:"create,omitempty"`
 	Delete     string `json:"delete,omitempty" yaml:"delete,omitempty" ini:"delete,omitempty"`
 	Export     string `json:"export,omitempty" yaml:"export,omitempty" ini:"export,omitempty"`
+	Import     string `json:"import,omitempty" yaml:"import,omitempty" ini:"import,omitempty"`
+	Restore    string `json:"restore,omitempty" yaml:"restore,omitempty" ini:"restore,omitempty"`
+	Purge      string `json:"purge,omitempty" yaml:"purge,omitempty" ini:"purge,omitempty"`
 	Edit       string `json:"edit,omitempty" yaml:"edit,omitempty" ini:"edit,omitempty"`
 	ShowEdit   string `json:"show_edit,omitempty" yaml:"show_edit,omitempty" ini:"show_edit,omitempty"`
 	ShowCreate string `json:"show_create,omitempty" yaml:"show_create,omitempty" ini:"show_create,omitempty"`
@@ -457,6 +586,9 @@ func (f URLFormat) SetDefault() URLFormat {
 	f.Create = utils.SetDefault(f.Create, "", "/new/:__prefix")
 	f.Delete = utils.SetDefault(f.Delete, "", "/delete/:__prefix")
 	f.Export = utils.SetDefault(f.Export, "", "/export/:__prefix")
+	f.Import = utils.SetDefault(f.Import, "", "/import/:__prefix")
+	f.Restore = utils.SetDefault(f.Restore, "", "/restore/:__prefix")
+	f.Purge = utils.SetDefault(f.Purge, "", "/purge/:__prefix")
 	f.Info = utils.SetDefault(f.Info, "", "/info/:__prefix")
 	f.Update = utils.SetDefault(f.Update, "", "/update/:__prefix")
 	return f
@@ -922,6 +1054,8 @@ func SetDefault(cfg *Config) *Config {
 		cfg.prefix = cfg.UrlPrefix
 	}
 	cfg.URLFormat = cfg.URLFormat.SetDefault()
+	cfg.Auth = cfg.Auth.SetDefault()
+	cfg.SessionStore = cfg.SessionStore.SetDefault()
 	return cfg
 }
 
@@ -938,6 +1072,10 @@ func Initialize(cfg *Config) *Config {
 
 	initLogger(SetDefault(cfg))
 
+	if cfg.Auth.Provider == "portal" && cfg.Auth.TOTP.RequireSuperAdmin {
+		panic("the two-factor authentication can not be required with the portal auth provider")
+	}
+
 	_global = cfg
 
 	return _global
@@ -1072,6 +1210,12 @@ func GetOpenAdminApi() bool {
 	return _global.OpenAdminApi
 }
 
+func GetPermissionDefaultDeny() bool {
+	_global.lock.RLock()
+	defer _global.lock.RUnlock()
+	return _global.PermissionDefaultDeny
+}
+
 func GetAllowDelOperationLog() bool {
 	_global.lock.RLock()
 	defer _global.lock.RUnlock()
@@ -1268,6 +1412,18 @@ func GetAuthUserTable() string {
 	return _global.AuthUserTable
 }
 
+func GetAuth() Auth {
+	_global.lock.RLock()
+	defer _global.lock.RUnlock()
+	return _global.Auth
+}
+
+func GetSessionStore() SessionStore {
+	_global.lock.RLock()
+	defer _global.lock.RUnlock()
+	return _global.SessionStore
+}
+
 func GetExtra() map[string]interface{} {
 	_global.lock.RLock()
 	defer _global.lock.RUnlock()
@@ -1286,6 +1442,12 @@ func GetNoLimitLoginIP() bool {
 	return _global.NoLimitLoginIP
 }
 
+func GetMaxSessionsPerUser() int {
+	_global.lock.RLock()
+	defer _global.lock.RUnlock()
+	return _global.MaxSessionsPerUser
+}
+
 func GetHideVisitorUserCenterEntrance() bool {
 	_global.lock.RLock()
 	defer _global.lock.RUnlock()
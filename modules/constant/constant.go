@@ -12,6 +12,10 @@ const (
 
 	ContextNodeNeedAuth = "need_auth"
 
+	// ContextNodeAction marks the callbacks of the custom actions, whose
+	// events are notified to the webhooks.
+	ContextNodeAction = "action"
+
 	IframeKey   = "__goadmin_iframe"
 	IframeIDKey = "__goadmin_iframe_id"
 )
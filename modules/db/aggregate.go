@@ -0,0 +1,183 @@
+package db
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/modules/db/dialect"
+)
+
+// The aggregate functions of the time buckets.
+const (
+	AggregateCount = "count"
+	AggregateSum   = "sum"
+	AggregateAvg   = "avg"
+)
+
+// The units of the time buckets.
+const (
+	BucketHour  = dialect.BucketHour
+	BucketDay   = dialect.BucketDay
+	BucketMonth = dialect.BucketMonth
+)
+
+// Bucket is the aggregated value of the rows of which the time field is in
+// the same hour, day or month. The label is formatted as "2006-01-02 15:00",
+// "2006-01-02" or "2006-01" by the unit.
+type Bucket struct {
+	Label string
+	Value float64
+}
+
+// Buckets is the time buckets ordered by the label.
+type Buckets []Bucket
+
+// Aggregate group the rows by the time buckets of the time field, and return
+// the count of the rows, or the sum or the average of the field of each
+// bucket. The wheres of the SQL are kept, so the rows can be limited to a
+// range of time before aggregating.
+func (sql *SQL) Aggregate(fn, field, timeField, unit string) (Buckets, error) {
+
+	var value string
+
+	switch fn {
+	case AggregateCount:
+		value = "count(*)"
+	case AggregateSum, AggregateAvg:
+		if field == "" {
+			RecycleSQL(sql)
+			return nil, errors.New("the field of " + fn + " can not be empty")
+		}
+		value = fn + "(" + sql.wrapField(field) + ")"
+	default:
+		RecycleSQL(sql)
+		return nil, errors.New("wrong aggregate function: " + fn)
+	}
+
+	if unit != BucketHour && unit != BucketDay && unit != BucketMonth {
+		RecycleSQL(sql)
+		return nil, errors.New("wrong bucket unit: " + unit)
+	}
+
+	bucket := sql.dialect.TimeBucket(timeField, unit)
+
+	items, err := sql.SelectRaw(bucket+" as bucket_label", value+" as bucket_value").
+		GroupByRaw(bucket).
+		OrderByRaw(bucket + " asc").
+		All()
+
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make(Buckets, 0, len(items))
+	for _, item := range items {
+		label := bucketString(item["bucket_label"])
+		if label == "" {
+			continue
+		}
+		buckets = append(buckets, Bucket{Label: label, Value: bucketFloat(item["bucket_value"])})
+	}
+
+	return buckets, nil
+}
+
+// wrapField wrap each part of the field which may be qualified by the table.
+func (sql *SQL) wrapField(field string) string {
+	parts := strings.Split(field, ".")
+	for i, part := range parts {
+		parts[i] = sql.wrap(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// Fill return the buckets of each hour, day or month from the start to the
+// end time, of which the missing ones are zero. The times should be in the
+// location of the stored time field.
+func (b Buckets) Fill(from, to time.Time, unit string) Buckets {
+
+	values := make(map[string]float64, len(b))
+	for _, bucket := range b {
+		values[bucket.Label] = bucket.Value
+	}
+
+	var (
+		format string
+		next   func(t time.Time) time.Time
+		start  time.Time
+	)
+
+	switch unit {
+	case BucketHour:
+		format = "2006-01-02 15:00"
+		start = time.Date(from.Year(), from.Month(), from.Day(), from.Hour(), 0, 0, 0, from.Location())
+		next = func(t time.Time) time.Time { return t.Add(time.Hour) }
+	case BucketMonth:
+		format = "2006-01"
+		start = time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, from.Location())
+		next = func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }
+	default:
+		format = "2006-01-02"
+		start = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	}
+
+	filled := make(Buckets, 0)
+	for t := start; !t.After(to); t = next(t) {
+		label := t.Format(format)
+		filled = append(filled, Bucket{Label: label, Value: values[label]})
+	}
+	return filled
+}
+
+// Labels return the labels of the buckets.
+func (b Buckets) Labels() []string {
+	labels := make([]string, len(b))
+	for i, bucket := range b {
+		labels[i] = bucket.Label
+	}
+	return labels
+}
+
+// Values return the values of the buckets.
+func (b Buckets) Values() []float64 {
+	values := make([]float64, len(b))
+	for i, bucket := range b {
+		values[i] = bucket.Value
+	}
+	return values
+}
+
+func bucketString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []uint8:
+		return string(v)
+	default:
+		return ""
+	}
+}
+
+func bucketFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case int:
+		return float64(v)
+	case []uint8:
+		f, _ := strconv.ParseFloat(string(v), 64)
+		return f
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/modules/db/dialect"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestBucketsFill(t *testing.T) {
+	buckets := Buckets{{Label: "2026-10-02", Value: 3}, {Label: "2026-10-04", Value: 1.5}}
+
+	filled := buckets.Fill(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2026, 10, 4, 8, 0, 0, 0, time.UTC), BucketDay)
+
+	assert.Equal(t, filled.Labels(), []string{"2026-10-01", "2026-10-02", "2026-10-03", "2026-10-04"})
+	assert.Equal(t, filled.Values(), []float64{0, 3, 0, 1.5})
+
+	filled = Buckets{}.Fill(time.Date(2026, 10, 1, 22, 30, 0, 0, time.UTC),
+		time.Date(2026, 10, 2, 0, 10, 0, 0, time.UTC), BucketHour)
+
+	assert.Equal(t, filled.Labels(), []string{"2026-10-01 22:00", "2026-10-01 23:00", "2026-10-02 00:00"})
+
+	filled = Buckets{{Label: "2026-12", Value: 2}}.Fill(time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), BucketMonth)
+
+	assert.Equal(t, filled.Labels(), []string{"2026-11", "2026-12", "2027-01"})
+	assert.Equal(t, filled.Values(), []float64{0, 2, 0})
+}
+
+func TestTimeBucketSelect(t *testing.T) {
+	for driver, statement := range map[string]string{
+		DriverMysql: "select `type`,count(*) as n,date_format(`created_at`, '%Y-%m') as bucket_label " +
+			"from `goadmin_logs` group by date_format(`created_at`, '%Y-%m') ",
+		DriverSqlite: "select `type`,count(*) as n,strftime('%Y-%m', `created_at`) as bucket_label " +
+			"from `goadmin_logs` group by strftime('%Y-%m', `created_at`) ",
+		DriverPostgresql: `select "type",count(*) as n,to_char("created_at", 'YYYY-MM') as bucket_label ` +
+			`from "goadmin_logs" group by to_char("created_at", 'YYYY-MM') `,
+		DriverMssql: "select [type],count(*) as n,convert(varchar(7), [created_at], 120) as bucket_label " +
+			"from [goadmin_logs] group by convert(varchar(7), [created_at], 120) ",
+	} {
+		d := dialect.GetDialectByDriver(driver)
+		bucket := d.TimeBucket("created_at", BucketMonth)
+		comp := dialect.SQLComponent{
+			Fields:    []string{"type"},
+			Functions: []string{""},
+			RawFields: []string{"count(*) as n", bucket + " as bucket_label"},
+			TableName: "goadmin_logs",
+			Group:     bucket,
+		}
+		assert.Equal(t, d.Select(&comp), statement, driver)
+	}
+}
+
+func TestTimeBucketQualified(t *testing.T) {
+	for driver, bucket := range map[string]string{
+		DriverMysql:      "date_format(`goadmin_logs`.`created_at`, '%Y-%m-%d')",
+		DriverSqlite:     "strftime('%Y-%m-%d', `goadmin_logs`.`created_at`)",
+		DriverPostgresql: `to_char("goadmin_logs"."created_at", 'YYYY-MM-DD')`,
+		DriverMssql:      "convert(varchar(10), [goadmin_logs].[created_at], 120)",
+	} {
+		assert.Equal(t, dialect.GetDialectByDriver(driver).TimeBucket("goadmin_logs.created_at", BucketDay), bucket, driver)
+	}
+}
@@ -5,6 +5,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
@@ -44,6 +45,34 @@ type Connection interface {
 	ExecWithTx(tx *sql.Tx, query string, args ...interface{}) (sql.Result, error)
 	ExecWith(tx *sql.Tx, conn, query string, args ...interface{}) (sql.Result, error)
 
+	// QueryContext is the query method of sql with the given context.
+	QueryContext(ctx context.Context, query string, args ...interface{}) ([]map[string]interface{}, error)
+
+	// ExecContext is the exec method of sql with the given context.
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+
+	// QueryWithConnectionContext is the query method with given connection and context of sql.
+	QueryWithConnectionContext(ctx context.Context, conn, query string, args ...interface{}) ([]map[string]interface{}, error)
+
+	// QueryWithTxContext is the query method of sql with the given context
+	// within the transaction.
+	QueryWithTxContext(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]map[string]interface{}, error)
+
+	// QueryWithContext is the query method of sql with the given context,
+	// within the transaction if tx is not nil.
+	QueryWithContext(ctx context.Context, tx *sql.Tx, conn, query string, args ...interface{}) ([]map[string]interface{}, error)
+
+	// ExecWithConnectionContext is the exec method with given connection and context of sql.
+	ExecWithConnectionContext(ctx context.Context, conn, query string, args ...interface{}) (sql.Result, error)
+
+	// ExecWithTxContext is the exec method of sql with the given context
+	// within the transaction.
+	ExecWithTxContext(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) (sql.Result, error)
+
+	// ExecWithContext is the exec method of sql with the given context,
+	// within the transaction if tx is not nil.
+	ExecWithContext(ctx context.Context, tx *sql.Tx, conn, query string, args ...interface{}) (sql.Result, error)
+
 	BeginTxWithReadUncommitted() *sql.Tx
 	BeginTxWithReadCommitted() *sql.Tx
 	BeginTxWithRepeatableRead() *sql.Tx
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	_ "github.com/GoAdminGroup/go-admin/modules/db/drivers/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSqliteWithContext(t *testing.T) {
+
+	conn := testConn(DriverSqlite, config.Database{File: filepath.Join(t.TempDir(), "admin.db")})
+
+	_, err := conn.ExecWithContext(context.Background(), nil, "default", "CREATE TABLE posts (`id` integer PRIMARY KEY autoincrement, `title` varchar(50))")
+	assert.Nil(t, err)
+
+	_, err = conn.ExecWithContext(context.Background(), nil, "default", "INSERT INTO posts (`title`) VALUES (?)", "a")
+	assert.Nil(t, err)
+
+	rows, err := conn.QueryWithContext(context.Background(), nil, "default", "SELECT title FROM posts")
+	assert.Nil(t, err)
+	assert.Equal(t, []map[string]interface{}{{"title": "a"}}, rows)
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = conn.QueryWithContext(canceled, nil, "default", "SELECT title FROM posts")
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	_, err = conn.ExecWithContext(canceled, nil, "default", "INSERT INTO posts (`title`) VALUES (?)", "b")
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	tx := conn.BeginTx()
+	_, err = conn.ExecWithContext(canceled, tx, "default", "INSERT INTO posts (`title`) VALUES (?)", "b")
+	assert.NotNil(t, err)
+	_ = tx.Rollback()
+
+	count, err := WithDriver(conn).Table("posts").Count()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// a running query is interrupted at the deadline.
+	timeout, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	begin := time.Now()
+	_, err = conn.QueryWithContext(timeout, nil, "default",
+		"WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM c) SELECT count(*) FROM c")
+	assert.NotNil(t, err)
+	assert.True(t, time.Since(begin) < 5*time.Second)
+
+	_, err = WithDriver(conn).WithContext(canceled).Table("posts").All()
+	assert.True(t, errors.Is(err, context.Canceled))
+}
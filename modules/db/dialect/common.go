@@ -49,6 +49,21 @@ func (c commonDialect) ShowTables() string {
 	return "show tables"
 }
 
+func (c commonDialect) ApproximateCount(table string) (string, []interface{}) {
+	return "", nil
+}
+
+func (c commonDialect) TimeBucket(field, unit string) string {
+	format := "%Y-%m-%d"
+	switch unit {
+	case BucketHour:
+		format = "%Y-%m-%d %H:00"
+	case BucketMonth:
+		format = "%Y-%m"
+	}
+	return "date_format(" + wrapField(c.delimiter, c.delimiter2, field) + ", '" + format + "')"
+}
+
 func (c commonDialect) GetDelimiter() string {
 	return c.delimiter
 }
@@ -21,6 +21,16 @@ type Dialect interface {
 	// ShowTables show tables of database
 	ShowTables() string
 
+	// ApproximateCount estimate the rows of specified table from the
+	// statistics of database with the arguments of the statement, it is
+	// empty if the database has none.
+	ApproximateCount(table string) (string, []interface{})
+
+	// TimeBucket return the expression which formats the time field, which
+	// may be qualified by the table, to the label of the bucket of given unit,
+	// which is hour, day or month.
+	TimeBucket(field, unit string) string
+
 	// Insert
 	Insert(comp *SQLComponent) string
 
@@ -37,6 +47,13 @@ type Dialect interface {
 	GetDelimiter() string
 }
 
+// The units of the time buckets.
+const (
+	BucketHour  = "hour"
+	BucketDay   = "day"
+	BucketMonth = "month"
+)
+
 // GetDialect return the default Dialect.
 func GetDialect() Dialect {
 	return GetDialectByDriver(config.GetDatabases().GetDefault().Driver)
@@ -77,6 +94,7 @@ type H map[string]interface{}
 type SQLComponent struct {
 	Fields     []string
 	Functions  []string
+	RawFields  []string
 	TableName  string
 	Wheres     []Where
 	Leftjoins  []Join
@@ -166,7 +184,7 @@ func (sql *SQLComponent) processLeftJoinField(field, delimiter, delimiter2 strin
 }
 
 func (sql *SQLComponent) getFields(delimiter, delimiter2 string) string {
-	if len(sql.Fields) == 0 {
+	if len(sql.Fields) == 0 && len(sql.RawFields) == 0 {
 		return "*"
 	}
 	fields := ""
@@ -188,6 +206,9 @@ func (sql *SQLComponent) getFields(delimiter, delimiter2 string) string {
 			}
 		}
 	}
+	for _, raw := range sql.RawFields {
+		fields += raw + ","
+	}
 	return fields[:len(fields)-1]
 }
 
@@ -198,6 +219,15 @@ func wrap(delimiter, delimiter2, field string) string {
 	return delimiter + field + delimiter2
 }
 
+// wrapField wrap each part of the field which may be qualified by the table.
+func wrapField(delimiter, delimiter2, field string) string {
+	parts := strings.Split(field, ".")
+	for i, part := range parts {
+		parts[i] = wrap(delimiter, delimiter2, part)
+	}
+	return strings.Join(parts, ".")
+}
+
 func (sql *SQLComponent) getWheres(delimiter, delimiter2 string) string {
 	if len(sql.Wheres) == 0 {
 		if sql.WhereRaws != "" {
@@ -21,3 +21,20 @@ func (mssql) ShowColumns(table string) string {
 func (mssql) ShowTables() string {
 	return "select * from information_schema.TABLES"
 }
+
+func (mssql) ApproximateCount(table string) (string, []interface{}) {
+	return "select sum(p.rows) as size from sys.partitions p join sys.tables t on t.object_id = p.object_id " +
+		"where t.name = ? and p.index_id in (0, 1)", []interface{}{table}
+}
+
+func (m mssql) TimeBucket(field, unit string) string {
+	field = wrapField(m.delimiter, m.delimiter2, field)
+	switch unit {
+	case BucketHour:
+		return "convert(varchar(13), " + field + ", 120) + ':00'"
+	case BucketMonth:
+		return "convert(varchar(7), " + field + ", 120)"
+	default:
+		return "convert(varchar(10), " + field + ", 120)"
+	}
+}
@@ -19,3 +19,8 @@ func (mysql) ShowColumns(table string) string {
 func (mysql) ShowTables() string {
 	return "show tables"
 }
+
+func (mysql) ApproximateCount(table string) (string, []interface{}) {
+	return "select table_rows as size from information_schema.tables where table_schema = database() and table_name = ?",
+		[]interface{}{table}
+}
@@ -15,3 +15,8 @@ func (oceanbase) ShowColumns(table string) string {
 func (oceanbase) ShowTables() string {
 	return "show tables"
 }
+
+func (oceanbase) ApproximateCount(table string) (string, []interface{}) {
+	return "select table_rows as size from information_schema.tables where table_schema = database() and table_name = ?",
+		[]interface{}{table}
+}
@@ -21,6 +21,16 @@ func (postgresql) ShowTables() string {
 	return "SELECT tablename FROM pg_catalog.pg_tables WHERE schemaname != 'pg_catalog' AND schemaname != 'information_schema';"
 }
 
+func (postgresql) ApproximateCount(table string) (string, []interface{}) {
+	tableArr := strings.Split(table, "\".\"")
+	if len(tableArr) > 1 {
+		return "select c.reltuples::bigint as size from pg_class c join pg_namespace n on n.oid = c.relnamespace " +
+			"where c.relname = ? and n.nspname = ?", []interface{}{tableArr[1], tableArr[0]}
+	}
+	return "select c.reltuples::bigint as size from pg_class c join pg_namespace n on n.oid = c.relnamespace " +
+		"where c.relname = ? and n.nspname = current_schema()", []interface{}{table}
+}
+
 func (postgresql) ShowColumns(table string) string {
 	tableArr := strings.Split(table, "\".\"")
 	if len(tableArr) > 1 {
@@ -29,3 +39,14 @@ func (postgresql) ShowColumns(table string) string {
 		return fmt.Sprintf("select * from information_schema.columns where table_name = '%s'", table)
 	}
 }
+
+func (p postgresql) TimeBucket(field, unit string) string {
+	format := "YYYY-MM-DD"
+	switch unit {
+	case BucketHour:
+		format = "YYYY-MM-DD HH24:00"
+	case BucketMonth:
+		format = "YYYY-MM"
+	}
+	return "to_char(" + wrapField(p.delimiter, p.delimiter2, field) + ", '" + format + "')"
+}
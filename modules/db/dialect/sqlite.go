@@ -19,3 +19,14 @@ func (sqlite) ShowColumns(table string) string {
 func (sqlite) ShowTables() string {
 	return "SELECT name as tablename FROM sqlite_master WHERE type ='table'"
 }
+
+func (s sqlite) TimeBucket(field, unit string) string {
+	format := "%Y-%m-%d"
+	switch unit {
+	case BucketHour:
+		format = "%Y-%m-%d %H:00"
+	case BucketMonth:
+		format = "%Y-%m"
+	}
+	return "strftime('" + format + "', " + wrapField(s.delimiter, s.delimiter2, field) + ")"
+}
@@ -5,6 +5,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"regexp"
@@ -341,3 +342,59 @@ func (db *Mssql) ExecWithTx(tx *sql.Tx, query string, args ...interface{}) (sql.
 	query = db.handleSqlBeforeExec(query)
 	return CommonExecWithTx(tx, query, args...)
 }
+
+// QueryContext implements the method Connection.QueryContext.
+func (db *Mssql) QueryContext(ctx context.Context, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	query = db.handleSqlBeforeExec(query)
+	return CommonQueryContext(ctx, db.DbList["default"], query, args...)
+}
+
+// ExecContext implements the method Connection.ExecContext.
+func (db *Mssql) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	query = db.handleSqlBeforeExec(query)
+	return CommonExecContext(ctx, db.DbList["default"], query, args...)
+}
+
+// QueryWithConnectionContext implements the method Connection.QueryWithConnectionContext.
+func (db *Mssql) QueryWithConnectionContext(ctx context.Context, con string, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	query = db.handleSqlBeforeExec(query)
+	return CommonQueryContext(ctx, db.DbList[con], query, args...)
+}
+
+// ExecWithConnectionContext implements the method Connection.ExecWithConnectionContext.
+func (db *Mssql) ExecWithConnectionContext(ctx context.Context, con string, query string, args ...interface{}) (sql.Result, error) {
+	query = db.handleSqlBeforeExec(query)
+	return CommonExecContext(ctx, db.DbList[con], query, args...)
+}
+
+// QueryWithTxContext is query method within the transaction with the given context.
+func (db *Mssql) QueryWithTxContext(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	query = db.handleSqlBeforeExec(query)
+	return CommonQueryWithTxContext(ctx, tx, query, args...)
+}
+
+// ExecWithTxContext is exec method within the transaction with the given context.
+func (db *Mssql) ExecWithTxContext(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) (sql.Result, error) {
+	query = db.handleSqlBeforeExec(query)
+	return CommonExecWithTxContext(ctx, tx, query, args...)
+}
+
+// QueryWithContext implements the method Connection.QueryWithContext, which
+// queries within the transaction if tx is not nil, otherwise with the
+// connection of the given name.
+func (db *Mssql) QueryWithContext(ctx context.Context, tx *sql.Tx, conn, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	if tx != nil {
+		return db.QueryWithTxContext(ctx, tx, query, args...)
+	}
+	return db.QueryWithConnectionContext(ctx, conn, query, args...)
+}
+
+// ExecWithContext implements the method Connection.ExecWithContext, which
+// execs within the transaction if tx is not nil, otherwise with the
+// connection of the given name.
+func (db *Mssql) ExecWithContext(ctx context.Context, tx *sql.Tx, conn, query string, args ...interface{}) (sql.Result, error) {
+	if tx != nil {
+		return db.ExecWithTxContext(ctx, tx, query, args...)
+	}
+	return db.ExecWithConnectionContext(ctx, conn, query, args...)
+}
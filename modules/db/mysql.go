@@ -5,6 +5,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/GoAdminGroup/go-admin/modules/config"
@@ -173,3 +174,53 @@ func (db *Mysql) BeginTxAndConnection(conn string) *sql.Tx {
 func (db *Mysql) BeginTxWithLevelAndConnection(conn string, level sql.IsolationLevel) *sql.Tx {
 	return CommonBeginTxWithLevel(db.DbList[conn], level)
 }
+
+// QueryContext implements the method Connection.QueryContext.
+func (db *Mysql) QueryContext(ctx context.Context, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	return CommonQueryContext(ctx, db.DbList["default"], query, args...)
+}
+
+// ExecContext implements the method Connection.ExecContext.
+func (db *Mysql) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return CommonExecContext(ctx, db.DbList["default"], query, args...)
+}
+
+// QueryWithConnectionContext implements the method Connection.QueryWithConnectionContext.
+func (db *Mysql) QueryWithConnectionContext(ctx context.Context, con string, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	return CommonQueryContext(ctx, db.DbList[con], query, args...)
+}
+
+// ExecWithConnectionContext implements the method Connection.ExecWithConnectionContext.
+func (db *Mysql) ExecWithConnectionContext(ctx context.Context, con string, query string, args ...interface{}) (sql.Result, error) {
+	return CommonExecContext(ctx, db.DbList[con], query, args...)
+}
+
+// QueryWithTxContext is query method within the transaction with the given context.
+func (db *Mysql) QueryWithTxContext(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	return CommonQueryWithTxContext(ctx, tx, query, args...)
+}
+
+// ExecWithTxContext is exec method within the transaction with the given context.
+func (db *Mysql) ExecWithTxContext(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) (sql.Result, error) {
+	return CommonExecWithTxContext(ctx, tx, query, args...)
+}
+
+// QueryWithContext implements the method Connection.QueryWithContext, which
+// queries within the transaction if tx is not nil, otherwise with the
+// connection of the given name.
+func (db *Mysql) QueryWithContext(ctx context.Context, tx *sql.Tx, conn, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	if tx != nil {
+		return db.QueryWithTxContext(ctx, tx, query, args...)
+	}
+	return db.QueryWithConnectionContext(ctx, conn, query, args...)
+}
+
+// ExecWithContext implements the method Connection.ExecWithContext, which
+// execs within the transaction if tx is not nil, otherwise with the
+// connection of the given name.
+func (db *Mysql) ExecWithContext(ctx context.Context, tx *sql.Tx, conn, query string, args ...interface{}) (sql.Result, error) {
+	if tx != nil {
+		return db.ExecWithTxContext(ctx, tx, query, args...)
+	}
+	return db.ExecWithConnectionContext(ctx, conn, query, args...)
+}
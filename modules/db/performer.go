@@ -20,48 +20,19 @@ func CommonQuery(db *sql.DB, query string, args ...interface{}) ([]map[string]in
 		panic(err)
 	}
 
-	defer func() {
-		if rs != nil {
-			_ = rs.Close()
-		}
-	}()
+	return scanRows(rs)
+}
 
-	col, colErr := rs.Columns()
+// CommonQueryContext is a common method of query with the given context.
+func CommonQueryContext(ctx context.Context, db *sql.DB, query string, args ...interface{}) ([]map[string]interface{}, error) {
 
-	if colErr != nil {
-		return nil, colErr
-	}
+	rs, err := db.QueryContext(ctx, query, args...)
 
-	typeVal, err := rs.ColumnTypes()
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: regular expressions for sqlite, use the dialect module
-	// tell the drive to reduce the performance loss
-	results := make([]map[string]interface{}, 0)
-
-	r, _ := regexp.Compile(`\\((.*)\\)`)
-	for rs.Next() {
-		var colVar = make([]interface{}, len(col))
-		for i := 0; i < len(col); i++ {
-			typeName := strings.ToUpper(r.ReplaceAllString(typeVal[i].DatabaseTypeName(), ""))
-			SetColVarType(&colVar, i, typeName)
-		}
-		result := make(map[string]interface{})
-		if scanErr := rs.Scan(colVar...); scanErr != nil {
-			return nil, scanErr
-		}
-		for j := 0; j < len(col); j++ {
-			typeName := strings.ToUpper(r.ReplaceAllString(typeVal[j].DatabaseTypeName(), ""))
-			SetResultValue(&result, col[j], colVar[j], typeName)
-		}
-		results = append(results, result)
-	}
-	if err := rs.Err(); err != nil {
-		return nil, err
-	}
-	return results, nil
+	return scanRows(rs)
 }
 
 // CommonExec is a common method of exec.
@@ -74,6 +45,16 @@ func CommonExec(db *sql.DB, query string, args ...interface{}) (sql.Result, erro
 	return rs, nil
 }
 
+// CommonExecContext is a common method of exec with the given context.
+func CommonExecContext(ctx context.Context, db *sql.DB, query string, args ...interface{}) (sql.Result, error) {
+
+	rs, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
 // CommonQueryWithTx is a common method of query.
 func CommonQueryWithTx(tx *sql.Tx, query string, args ...interface{}) ([]map[string]interface{}, error) {
 
@@ -83,6 +64,51 @@ func CommonQueryWithTx(tx *sql.Tx, query string, args ...interface{}) ([]map[str
 		panic(err)
 	}
 
+	return scanRows(rs)
+}
+
+// CommonQueryWithTxContext is a common method of query within the transaction with the given context.
+func CommonQueryWithTxContext(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]map[string]interface{}, error) {
+
+	rs, err := tx.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRows(rs)
+}
+
+// CommonExecWithTx is a common method of exec.
+func CommonExecWithTx(tx *sql.Tx, query string, args ...interface{}) (sql.Result, error) {
+	rs, err := tx.Exec(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+// CommonExecWithTxContext is a common method of exec within the transaction with the given context.
+func CommonExecWithTxContext(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) (sql.Result, error) {
+	rs, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+// CommonBeginTxWithLevel starts a transaction with given transaction isolation level and db connection.
+func CommonBeginTxWithLevel(db *sql.DB, level sql.IsolationLevel) *sql.Tx {
+	tx, err := db.BeginTx(context.Background(), &sql.TxOptions{Isolation: level})
+	if err != nil {
+		panic(err)
+	}
+	return tx
+}
+
+// scanRows reads all the rows into maps keyed by column name and closes rs.
+func scanRows(rs *sql.Rows) ([]map[string]interface{}, error) {
+
 	defer func() {
 		if rs != nil {
 			_ = rs.Close()
@@ -126,21 +152,3 @@ func CommonQueryWithTx(tx *sql.Tx, query string, args ...interface{}) ([]map[str
 	}
 	return results, nil
 }
-
-// CommonExecWithTx is a common method of exec.
-func CommonExecWithTx(tx *sql.Tx, query string, args ...interface{}) (sql.Result, error) {
-	rs, err := tx.Exec(query, args...)
-	if err != nil {
-		return nil, err
-	}
-	return rs, nil
-}
-
-// CommonBeginTxWithLevel starts a transaction with given transaction isolation level and db connection.
-func CommonBeginTxWithLevel(db *sql.DB, level sql.IsolationLevel) *sql.Tx {
-	tx, err := db.BeginTx(context.Background(), &sql.TxOptions{Isolation: level})
-	if err != nil {
-		panic(err)
-	}
-	return tx
-}
@@ -5,6 +5,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strconv"
@@ -181,3 +182,53 @@ func (db *Postgresql) QueryWithTx(tx *sql.Tx, query string, args ...interface{})
 func (db *Postgresql) ExecWithTx(tx *sql.Tx, query string, args ...interface{}) (sql.Result, error) {
 	return CommonExecWithTx(tx, filterQuery(query), args...)
 }
+
+// QueryContext implements the method Connection.QueryContext.
+func (db *Postgresql) QueryContext(ctx context.Context, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	return CommonQueryContext(ctx, db.DbList["default"], filterQuery(query), args...)
+}
+
+// ExecContext implements the method Connection.ExecContext.
+func (db *Postgresql) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return CommonExecContext(ctx, db.DbList["default"], filterQuery(query), args...)
+}
+
+// QueryWithConnectionContext implements the method Connection.QueryWithConnectionContext.
+func (db *Postgresql) QueryWithConnectionContext(ctx context.Context, con string, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	return CommonQueryContext(ctx, db.DbList[con], filterQuery(query), args...)
+}
+
+// ExecWithConnectionContext implements the method Connection.ExecWithConnectionContext.
+func (db *Postgresql) ExecWithConnectionContext(ctx context.Context, con string, query string, args ...interface{}) (sql.Result, error) {
+	return CommonExecContext(ctx, db.DbList[con], filterQuery(query), args...)
+}
+
+// QueryWithTxContext is query method within the transaction with the given context.
+func (db *Postgresql) QueryWithTxContext(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	return CommonQueryWithTxContext(ctx, tx, filterQuery(query), args...)
+}
+
+// ExecWithTxContext is exec method within the transaction with the given context.
+func (db *Postgresql) ExecWithTxContext(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) (sql.Result, error) {
+	return CommonExecWithTxContext(ctx, tx, filterQuery(query), args...)
+}
+
+// QueryWithContext implements the method Connection.QueryWithContext, which
+// queries within the transaction if tx is not nil, otherwise with the
+// connection of the given name.
+func (db *Postgresql) QueryWithContext(ctx context.Context, tx *sql.Tx, conn, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	if tx != nil {
+		return db.QueryWithTxContext(ctx, tx, query, args...)
+	}
+	return db.QueryWithConnectionContext(ctx, conn, query, args...)
+}
+
+// ExecWithContext implements the method Connection.ExecWithContext, which
+// execs within the transaction if tx is not nil, otherwise with the
+// connection of the given name.
+func (db *Postgresql) ExecWithContext(ctx context.Context, tx *sql.Tx, conn, query string, args ...interface{}) (sql.Result, error) {
+	if tx != nil {
+		return db.ExecWithTxContext(ctx, tx, query, args...)
+	}
+	return db.ExecWithConnectionContext(ctx, conn, query, args...)
+}
@@ -5,6 +5,7 @@
 package db
 
 import (
+	"context"
 	dbsql "database/sql"
 	"errors"
 	"regexp"
@@ -23,14 +24,19 @@ type SQL struct {
 	dialect dialect.Dialect
 	conn    string
 	tx      *dbsql.Tx
+	ctx     context.Context
 }
 
+// ErrNoAffectRow is returned by the statements which affect no row.
+var ErrNoAffectRow = errors.New("no affect row")
+
 // SQLPool is a object pool of SQL.
 var SQLPool = sync.Pool{
 	New: func() interface{} {
 		return &SQL{
 			SQLComponent: dialect.SQLComponent{
 				Fields:     make([]string, 0),
+				RawFields:  make([]string, 0),
 				TableName:  "",
 				Args:       make([]interface{}, 0),
 				Wheres:     make([]dialect.Where, 0),
@@ -104,6 +110,13 @@ func (sql *SQL) WithTx(tx *dbsql.Tx) *SQL {
 	return sql
 }
 
+// WithContext set the context of SQL, which is passed through to the driver
+// so that the statement can be cancelled or given a deadline.
+func (sql *SQL) WithContext(ctx context.Context) *SQL {
+	sql.ctx = ctx
+	return sql
+}
+
 // TableName set table of SQL.
 func (sql *SQL) Table(table string) *SQL {
 	sql.clean()
@@ -126,6 +139,13 @@ func (sql *SQL) Select(fields ...string) *SQL {
 	return sql
 }
 
+// SelectRaw add the select expressions which are not wrapped, such as the
+// aggregate functions with an alias.
+func (sql *SQL) SelectRaw(raws ...string) *SQL {
+	sql.RawFields = append(sql.RawFields, raws...)
+	return sql
+}
+
 // OrderBy set order fields.
 func (sql *SQL) OrderBy(fields ...string) *SQL {
 	if len(fields) == 0 {
@@ -421,7 +441,7 @@ func (sql *SQL) First() (map[string]interface{}, error) {
 
 	sql.dialect.Select(&sql.SQLComponent)
 
-	res, err := sql.diver.QueryWith(sql.tx, sql.conn, sql.Statement, sql.Args...)
+	res, err := sql.diver.QueryWithContext(sql.context(), sql.tx, sql.conn, sql.Statement, sql.Args...)
 
 	if err != nil {
 		return nil, err
@@ -439,21 +459,21 @@ func (sql *SQL) All() ([]map[string]interface{}, error) {
 
 	sql.dialect.Select(&sql.SQLComponent)
 
-	return sql.diver.QueryWith(sql.tx, sql.conn, sql.Statement, sql.Args...)
+	return sql.diver.QueryWithContext(sql.context(), sql.tx, sql.conn, sql.Statement, sql.Args...)
 }
 
 // ShowColumns show columns info.
 func (sql *SQL) ShowColumns() ([]map[string]interface{}, error) {
 	defer RecycleSQL(sql)
 
-	return sql.diver.QueryWithConnection(sql.conn, sql.dialect.ShowColumns(sql.TableName))
+	return sql.diver.QueryWithConnectionContext(sql.context(), sql.conn, sql.dialect.ShowColumns(sql.TableName))
 }
 
 // ShowTables show table info.
 func (sql *SQL) ShowTables() ([]string, error) {
 	defer RecycleSQL(sql)
 
-	models, err := sql.diver.QueryWithConnection(sql.conn, sql.dialect.ShowTables())
+	models, err := sql.diver.QueryWithConnectionContext(sql.context(), sql.conn, sql.dialect.ShowTables())
 
 	if err != nil {
 		return []string{}, err
@@ -493,14 +513,14 @@ func (sql *SQL) Update(values dialect.H) (int64, error) {
 
 	sql.dialect.Update(&sql.SQLComponent)
 
-	res, err := sql.diver.ExecWith(sql.tx, sql.conn, sql.Statement, sql.Args...)
+	res, err := sql.diver.ExecWithContext(sql.context(), sql.tx, sql.conn, sql.Statement, sql.Args...)
 
 	if err != nil {
 		return 0, err
 	}
 
 	if affectRow, _ := res.RowsAffected(); affectRow < 1 {
-		return 0, errors.New("no affect row")
+		return 0, ErrNoAffectRow
 	}
 
 	return res.LastInsertId()
@@ -512,14 +532,14 @@ func (sql *SQL) Delete() error {
 
 	sql.dialect.Delete(&sql.SQLComponent)
 
-	res, err := sql.diver.ExecWith(sql.tx, sql.conn, sql.Statement, sql.Args...)
+	res, err := sql.diver.ExecWithContext(sql.context(), sql.tx, sql.conn, sql.Statement, sql.Args...)
 
 	if err != nil {
 		return err
 	}
 
 	if affectRow, _ := res.RowsAffected(); affectRow < 1 {
-		return errors.New("no affect row")
+		return ErrNoAffectRow
 	}
 
 	return nil
@@ -531,20 +551,20 @@ func (sql *SQL) Exec() (int64, error) {
 
 	sql.dialect.Update(&sql.SQLComponent)
 
-	res, err := sql.diver.ExecWith(sql.tx, sql.conn, sql.Statement, sql.Args...)
+	res, err := sql.diver.ExecWithContext(sql.context(), sql.tx, sql.conn, sql.Statement, sql.Args...)
 
 	if err != nil {
 		return 0, err
 	}
 
 	if affectRow, _ := res.RowsAffected(); affectRow < 1 {
-		return 0, errors.New("no affect row")
+		return 0, ErrNoAffectRow
 	}
 
 	return res.LastInsertId()
 }
 
-const postgresInsertCheckTableName = "goadmin_menu|goadmin_permissions|goadmin_roles|goadmin_users"
+const postgresInsertCheckTableName = "goadmin_menu|goadmin_permissions|goadmin_roles|goadmin_users|goadmin_migrations"
 
 // Insert exec the insert method of given key/value pairs.
 func (sql *SQL) Insert(values dialect.H) (int64, error) {
@@ -556,18 +576,18 @@ func (sql *SQL) Insert(values dialect.H) (int64, error) {
 
 	if sql.diver.Name() == DriverPostgresql && (strings.Contains(postgresInsertCheckTableName, sql.TableName)) {
 
-		resMap, err := sql.diver.QueryWith(sql.tx, sql.conn, sql.Statement+" RETURNING id", sql.Args...)
+		resMap, err := sql.diver.QueryWithContext(sql.context(), sql.tx, sql.conn, sql.Statement+" RETURNING id", sql.Args...)
 
 		if err != nil {
 
 			// Fixed java h2 database postgresql mode
-			_, err := sql.diver.QueryWith(sql.tx, sql.conn, sql.Statement, sql.Args...)
+			_, err := sql.diver.QueryWithContext(sql.context(), sql.tx, sql.conn, sql.Statement, sql.Args...)
 
 			if err != nil {
 				return 0, err
 			}
 
-			res, err := sql.diver.QueryWithConnection(sql.conn, `SELECT max("id") as "id" FROM "`+sql.TableName+`"`)
+			res, err := sql.diver.QueryWithConnectionContext(sql.context(), sql.conn, `SELECT max("id") as "id" FROM "`+sql.TableName+`"`)
 
 			if err != nil {
 				return 0, err
@@ -581,25 +601,32 @@ func (sql *SQL) Insert(values dialect.H) (int64, error) {
 		}
 
 		if len(resMap) == 0 {
-			return 0, errors.New("no affect row")
+			return 0, ErrNoAffectRow
 		}
 
 		return resMap[0]["id"].(int64), nil
 	}
 
-	res, err := sql.diver.ExecWith(sql.tx, sql.conn, sql.Statement, sql.Args...)
+	res, err := sql.diver.ExecWithContext(sql.context(), sql.tx, sql.conn, sql.Statement, sql.Args...)
 
 	if err != nil {
 		return 0, err
 	}
 
 	if affectRow, _ := res.RowsAffected(); affectRow < 1 {
-		return 0, errors.New("no affect row")
+		return 0, ErrNoAffectRow
 	}
 
 	return res.LastInsertId()
 }
 
+func (sql *SQL) context() context.Context {
+	if sql.ctx == nil {
+		return context.Background()
+	}
+	return sql.ctx
+}
+
 func (sql *SQL) wrap(field string) string {
 	return sql.diver.GetDelimiter() + field + sql.diver.GetDelimiter2()
 }
@@ -609,6 +636,7 @@ func (sql *SQL) clean() {
 	sql.Group = ""
 	sql.Values = make(map[string]interface{})
 	sql.Fields = make([]string, 0)
+	sql.RawFields = make([]string, 0)
 	sql.TableName = ""
 	sql.Wheres = make([]dialect.Where, 0)
 	sql.Leftjoins = make([]dialect.Join, 0)
@@ -631,6 +659,7 @@ func RecycleSQL(sql *SQL) {
 	sql.conn = ""
 	sql.diver = nil
 	sql.tx = nil
+	sql.ctx = nil
 	sql.dialect = nil
 
 	SQLPool.Put(sql)
@@ -18,10 +18,14 @@ const (
 	PermissionDenied     = "permission denied"
 	WrongID              = "wrong id"
 	OperationNotAllow    = "operation not allow"
+	WrongFile            = "wrong file"
 	EditFailWrongToken   = "edit fail, wrong token"
 	CreateFailWrongToken = "create fail, wrong token"
 	NoPermission         = "no permission"
 	SiteOff              = "site is off"
+	WrongContentType     = "wrong content type"
+	WrongJSONBody        = "wrong json body"
+	FileTooLarge         = "file too large"
 )
 
 func WrongPK(pk string) string {
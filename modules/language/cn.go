@@ -19,6 +19,7 @@ var cn = LangSet{
 
 	"new":             "新建",
 	"export":          "导出",
+	"import":          "导入",
 	"action":          "操作",
 	"toggle dropdown": "下拉",
 	"delete":          "删除",
@@ -102,6 +103,118 @@ var cn = LangSet{
 	"continue editing":  "继续编辑",
 	"continue creating": "继续新增",
 
+	"wrong file":        "错误的文件",
+	"dry run":           "试运行",
+	"total":             "总数",
+	"inserted":          "已插入",
+	"unmatched columns": "未匹配的列",
+	"import fail":       "导入失败",
+
+	"the role value of the portal user": "portal 用户的角色值",
+
+	"two-factor authentication":                                       "两步验证",
+	"two-factor authentication is not supported by the portal signin": "portal 登录不支持两步验证",
+	"two-factor authentication is required":                           "必须开启两步验证",
+	"scan the qrcode with the authenticator app":                      "请使用身份验证器应用扫描二维码",
+	"verification code":                                               "验证码",
+	"verify":                                                          "验证",
+	"wrong verification code":                                         "验证码错误",
+	"too many wrong verification codes, please try again later":       "验证码错误次数过多，请稍后再试",
+	"recovery codes":                                                  "恢复码",
+	"regenerate recovery codes":                                       "重新生成恢复码",
+	"save the recovery codes":                                         "请妥善保存以下恢复码，每个只能使用一次",
+	"status":                                                          "状态",
+	"enabled":                                                         "已开启",
+	"disabled":                                                        "未开启",
+	"enable":                                                          "开启",
+	"disable":                                                         "关闭",
+	"secret":                                                          "密钥",
+
+	"sessions":                   "登录会话",
+	"user agent":                 "客户端",
+	"last seen":                  "最后活动",
+	"revoke":                     "注销",
+	"log out everywhere":         "注销所有登录",
+	"log out all your sessions?": "确定注销所有登录会话？",
+
+	"history":   "修改记录",
+	"field":     "字段",
+	"old value": "原值",
+	"new value": "新值",
+	"operator":  "操作人",
+	"create":    "新建",
+	"update":    "更新",
+
+	"trash":                   "回收站",
+	"restore":                 "恢复",
+	"are you sure to restore": "确定要恢复吗",
+	"restore fail":            "恢复失败",
+	"purge fail":              "彻底删除失败",
+
+	"wrong content type": "错误的内容类型",
+	"wrong json body":    "错误的JSON请求体",
+	"file too large":     "文件过大",
+
+	"api tokens":                   "API令牌",
+	"token name":                   "令牌名称",
+	"token type":                   "令牌类型",
+	"personal token":               "个人令牌",
+	"service account":              "服务账号",
+	"scopes":                       "权限范围",
+	"last used":                    "最近使用",
+	"expires at":                   "过期时间",
+	"api tokens need a local user": "API令牌需要本地用户",
+	"scopes are separated by commas, such as users:read,posts:write or *:read":            "权限范围以逗号分隔，如 users:read,posts:write 或 *:read",
+	"leave it empty for a token which never expires":                                      "留空则令牌永不过期",
+	"a service account has the permissions of its creator within the scopes of the token": "服务账号拥有其创建者在令牌权限范围内的权限",
+	"copy the token now, it will not be shown again":                                      "请立即复制令牌，之后将不再显示",
+
+	"showing <b>%d</b> entries":                    "显示 <b>%d</b> 条记录",
+	"showing <b>%d</b> of about <b>%d</b> entries": "显示 <b>%d</b> 条记录，总共约 <b>%d</b> 条记录",
+
+	"shift-click to sort by more fields": "按住 Shift 点击以按多个字段排序",
+
+	"saved views":                 "保存的视图",
+	"save the current view":       "保存当前视图",
+	"save and share with my role": "保存并共享给我的角色",
+	"view name":                   "视图名称",
+	"view name can not be empty":  "视图名称不能为空",
+
+	"global search":  "全局搜索",
+	"search results": "搜索结果",
+	"no results":     "没有找到结果",
+
+	"dashboards":        "仪表盘列表",
+	"dashboard title":   "仪表盘标题",
+	"description":       "描述",
+	"dashboard widgets": "仪表盘组件",
+	"widget title":      "组件标题",
+	"widget type":       "组件类型",
+	"widget line":       "折线图",
+	"widget bar":        "柱状图",
+	"widget pie":        "饼图",
+	"widget radar":      "雷达图",
+	"widget stat":       "统计值",
+	"widget table":      "表格",
+	"data source":       "数据来源",
+	"sql query":         "SQL查询",
+	"data function":     "数据函数",
+	"query or function": "查询或函数",
+	"width":             "宽度",
+	"order":             "排序",
+	"the first column of a chart is the labels and the others are the data sets, a stat shows the first column of the first row": "图表的第一列为标签，其余列为数据集，统计值显示第一行的第一列",
+	"the width of the twelve columns of a row":                    "一行共十二列中所占的宽度",
+	"the width should be from 1 to 12":                            "宽度应为1到12",
+	"data function not found":                                     "数据函数不存在",
+	"no dashboards":                                               "没有仪表盘",
+	"dashboard not found":                                         "仪表盘不存在",
+	"failed to load the data of the widget":                       "加载组件数据失败",
+	"only a super admin can edit the widgets":                     "只有超级管理员可以编辑组件",
+	"refresh interval":                                            "刷新间隔",
+	"the seconds to reload the data of a chart, zero means never": "图表重新加载数据的秒数，0表示不刷新",
+	"the refresh interval should not be negative":                 "刷新间隔不能为负数",
+	"dashboard widget not found":                                  "仪表盘组件不存在",
+
 	"username and password can not be empty":        "用户名密码不能为空",
 	"operation not allow":                           "不允许的操作",
 	"password does not match":                       "密码不一致",
@@ -132,6 +245,7 @@ var cn = LangSet{
 	"initialize navigation buttons":   "初始化导航栏按钮",
 	"initialize plugins":              "初始化插件",
 	"initialize database connections": "初始化数据库连接",
+	"migrate database":                "迁移数据库",
 	"initialize success":              "初始化成功🍺🍺",
 
 	"plugins":          "插件",
@@ -140,6 +254,59 @@ var cn = LangSet{
 	"uninstalled":      "未安装",
 	"plugin setting":   "插件设置",
 
+	"the installation is locked": "安装已锁定",
+	"database":                   "数据库",
+	"host":                       "主机",
+	"port":                       "端口",
+	"database name":              "数据库名",
+	"file":                       "文件",
+	"test connection":            "测试连接",
+	"super administrator":        "超级管理员",
+	"config file":                "配置文件",
+	"previous":                   "上一步",
+	"install":                    "安装",
+	"install success, the config file is written to": "安装成功，配置文件已写入",
+	"install token": "安装令牌",
+	"the install token is printed to the log of the server at the startup": "安装令牌在启动时打印在服务器日志中",
+	"wrong install token": "安装令牌错误",
+
+	"please check the fields of the form": "请检查表单字段",
+	"is required":                         "必填",
+	"should be at least %d characters":    "至少 %d 个字符",
+	"should be at most %d characters":     "最多 %d 个字符",
+	"should be a number":                  "必须是数字",
+	"should not be less than %s":          "不能小于 %s",
+	"should not be greater than %s":       "不能大于 %s",
+	"is in a wrong format":                "格式错误",
+	"already exists":                      "已存在",
+	"should be %s %s":                     "必须 %s %s",
+	"should be an email":                  "必须是邮箱",
+	"should be an url":                    "必须是网址",
+	"should be an ip":                     "必须是IP地址",
+	"the record has been changed by others, please merge the changes and save again": "记录已被他人修改，请合并修改后重新保存",
+	"changed by others, the latest value is":                                         "已被他人修改，最新值为",
+	"the version of the record is required":                                          "记录的版本不能为空",
+	"webhooks":                                                                       "网络钩子",
+	"webhook":                                                                        "网络钩子",
+	"webhook deliveries":                                                             "网络钩子投递",
+	"deliveries":                                                                     "投递记录",
+	"table prefix":                                                                   "表前缀",
+	"event":                                                                          "事件",
+	"url":                                                                            "地址",
+	"pending":                                                                        "待投递",
+	"failed":                                                                         "失败",
+	"attempts":                                                                       "尝试次数",
+	"response code":                                                                  "响应码",
+	"next attempt at":                                                                "下次尝试时间",
+	"retry":                                                                          "重试",
+	"payload":                                                                        "载荷",
+	"the prefix of the table such as manager, * means all the tables":                             "表的前缀，如manager，*表示所有表",
+	"created, updated, deleted, restored or the event of a custom action, * means all the events": "created、updated、deleted、restored或自定义操作的事件，*表示所有事件",
+	"the payloads are signed with the secret in the header X-GoAdmin-Signature":                   "载荷使用密钥签名，签名在请求头X-GoAdmin-Signature中",
+	"the url should be a http or https one":                                                       "地址必须是http或https地址",
+	"the url should not be a local or private address":                                            "地址不能是本地或内网地址",
+	"the table prefix and the event can not be empty":                                             "表前缀和事件不能为空",
+
 	"showing <b>%s</b> to <b>%s</b> of <b>%s</b> entries": "显示第 <b>%s</b> 到第 <b>%s</b> 条记录，总共 <b>%s</b> 条记录",
 
 	"second":  "秒",
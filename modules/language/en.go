@@ -98,6 +98,7 @@ var en = LangSet{
 	"initialize navigation buttons":   "Initialize navigation buttons",
 	"initialize plugins":              "Initialize plugins",
 	"initialize database connections": "Initialize database connections",
+	"migrate database":                "Migrate database",
 	"initialize success":              "Initialize success🍺🍺",
 
 	"not found":      "Not found",
@@ -19,6 +19,7 @@ var jp = LangSet{
 
 	"new":             "新規",
 	"export":          "出力",
+	"import":          "インポート",
 	"action":          "操作",
 	"toggle dropdown": "プルダウン",
 	"delete":          "削除",
@@ -91,6 +92,118 @@ var jp = LangSet{
 
 	"adapter is nil, import the default adapter or use addadapter method add the adapter": "adapter is nil, import the default adapter or use AddAdapter method add the adapter",
 
+	"wrong file":        "不正なファイル",
+	"dry run":           "ドライラン",
+	"total":             "合計",
+	"inserted":          "挿入済み",
+	"unmatched columns": "一致しない列",
+	"import fail":       "インポートに失敗しました",
+
+	"the role value of the portal user": "portal ユーザーのロール値",
+
+	"two-factor authentication":                                       "二要素認証",
+	"two-factor authentication is not supported by the portal signin": "portal のサインインは二要素認証に対応していません",
+	"two-factor authentication is required":                           "二要素認証は必須です",
+	"scan the qrcode with the authenticator app":                      "認証アプリで QR コードをスキャンしてください",
+	"verification code":                                               "確認コード",
+	"verify":                                                          "確認",
+	"wrong verification code":                                         "確認コードが正しくありません",
+	"too many wrong verification codes, please try again later":       "確認コードの誤りが多すぎます。しばらくしてから再試行してください",
+	"recovery codes":                                                  "リカバリーコード",
+	"regenerate recovery codes":                                       "リカバリーコードを再生成",
+	"save the recovery codes":                                         "以下のリカバリーコードを保管してください。各コードは一度だけ使えます",
+	"status":                                                          "状態",
+	"enabled":                                                         "有効",
+	"disabled":                                                        "無効",
+	"enable":                                                          "有効にする",
+	"disable":                                                         "無効にする",
+	"secret":                                                          "シークレット",
+
+	"sessions":                   "セッション",
+	"user agent":                 "ユーザーエージェント",
+	"last seen":                  "最終アクセス",
+	"revoke":                     "失効",
+	"log out everywhere":         "すべてのセッションからログアウト",
+	"log out all your sessions?": "すべてのセッションからログアウトしますか？",
+
+	"history":   "変更履歴",
+	"field":     "フィールド",
+	"old value": "変更前",
+	"new value": "変更後",
+	"operator":  "操作者",
+	"create":    "作成",
+	"update":    "更新",
+
+	"trash":                   "ゴミ箱",
+	"restore":                 "復元",
+	"are you sure to restore": "復元してもよろしいですか",
+	"restore fail":            "復元に失敗しました",
+	"purge fail":              "完全削除に失敗しました",
+
+	"wrong content type": "コンテンツタイプが正しくありません",
+	"wrong json body":    "JSONリクエストボディが正しくありません",
+	"file too large":     "ファイルが大きすぎます",
+
+	"api tokens":                   "APIトークン",
+	"token name":                   "トークン名",
+	"token type":                   "トークンの種類",
+	"personal token":               "個人トークン",
+	"service account":              "サービスアカウント",
+	"scopes":                       "スコープ",
+	"last used":                    "最終使用",
+	"expires at":                   "有効期限",
+	"api tokens need a local user": "APIトークンにはローカルユーザーが必要です",
+	"scopes are separated by commas, such as users:read,posts:write or *:read":            "スコープはカンマ区切りです（例：users:read,posts:write または *:read）",
+	"leave it empty for a token which never expires":                                      "空欄の場合、トークンは無期限です",
+	"a service account has the permissions of its creator within the scopes of the token": "サービスアカウントはトークンのスコープ内で作成者の権限を持ちます",
+	"copy the token now, it will not be shown again":                                      "トークンを今すぐコピーしてください。再表示されません",
+
+	"showing <b>%d</b> entries":                    "<b>%d</b> 件を表示",
+	"showing <b>%d</b> of about <b>%d</b> entries": "<b>%d</b> 件を表示（全約 <b>%d</b> 件）",
+
+	"shift-click to sort by more fields": "Shift キーを押しながらクリックすると複数のフィールドで並べ替えます",
+
+	"saved views":                 "保存済みビュー",
+	"save the current view":       "現在のビューを保存",
+	"save and share with my role": "保存して自分のロールと共有",
+	"view name":                   "ビュー名",
+	"view name can not be empty":  "ビュー名を入力してください",
+
+	"global search":  "全体検索",
+	"search results": "検索結果",
+	"no results":     "結果が見つかりません",
+
+	"dashboards":        "ダッシュボード一覧",
+	"dashboard title":   "ダッシュボードのタイトル",
+	"description":       "説明",
+	"dashboard widgets": "ダッシュボードのウィジェット",
+	"widget title":      "ウィジェットのタイトル",
+	"widget type":       "ウィジェットの種類",
+	"widget line":       "折れ線グラフ",
+	"widget bar":        "棒グラフ",
+	"widget pie":        "円グラフ",
+	"widget radar":      "レーダーチャート",
+	"widget stat":       "統計値",
+	"widget table":      "テーブル",
+	"data source":       "データソース",
+	"sql query":         "SQLクエリ",
+	"data function":     "データ関数",
+	"query or function": "クエリまたは関数",
+	"width":             "幅",
+	"order":             "並び順",
+	"the first column of a chart is the labels and the others are the data sets, a stat shows the first column of the first row": "グラフの最初の列はラベル、他の列はデータセットです。統計値は最初の行の最初の列を表示します",
+	"the width of the twelve columns of a row":                    "一行の十二列のうちの幅",
+	"the width should be from 1 to 12":                            "幅は1から12の間で入力してください",
+	"data function not found":                                     "データ関数が見つかりません",
+	"no dashboards":                                               "ダッシュボードがありません",
+	"dashboard not found":                                         "ダッシュボードが見つかりません",
+	"failed to load the data of the widget":                       "ウィジェットのデータの読み込みに失敗しました",
+	"only a super admin can edit the widgets":                     "ウィジェットを編集できるのはスーパー管理者のみです",
+	"refresh interval":                                            "更新間隔",
+	"the seconds to reload the data of a chart, zero means never": "グラフのデータを再読み込みする秒数、0は更新しません",
+	"the refresh interval should not be negative":                 "更新間隔は負の数にできません",
+	"dashboard widget not found":                                  "ダッシュボードのウィジェットが見つかりません",
+
 	"username and password can not be empty":        "アカウントまたパスワードが正しく入力されていることを確認してください",
 	"operation not allow":                           "この操作を実行するアクセス許可が必要です",
 	"password does not match":                       "パスワードが正しくありません",
@@ -108,6 +221,7 @@ var jp = LangSet{
 	"initialize navigation buttons":   "Initialize navigation buttons",
 	"initialize plugins":              "Initialize plugins",
 	"initialize database connections": "Initialize database connections",
+	"migrate database":                "Migrate database",
 	"initialize success":              "Initialize success🍺🍺",
 
 	"not found":      "Not found",
@@ -120,6 +234,60 @@ var jp = LangSet{
 	"uninstalled":      "Uninstalled",
 	"plugin setting":   "Plugin Setting",
 
+	"the installation is locked": "インストールはロックされています",
+	"database":                   "データベース",
+	"host":                       "ホスト",
+	"port":                       "ポート",
+	"database name":              "データベース名",
+	"file":                       "ファイル",
+	"test connection":            "接続テスト",
+	"super administrator":        "スーパー管理者",
+	"config file":                "設定ファイル",
+	"previous":                   "前へ",
+	"install":                    "インストール",
+	"install success, the config file is written to": "インストールに成功しました。設定ファイルの書き込み先",
+	"install token": "インストールトークン",
+	"the install token is printed to the log of the server at the startup": "インストールトークンは起動時にサーバーのログに出力されます",
+	"wrong install token": "インストールトークンが間違っています",
+
+	"please check the fields of the form": "フォームの項目を確認してください",
+	"is required":                         "必須です",
+	"should be at least %d characters":    "%d 文字以上にしてください",
+	"should be at most %d characters":     "%d 文字以下にしてください",
+	"should be a number":                  "数値にしてください",
+	"should not be less than %s":          "%s 以上にしてください",
+	"should not be greater than %s":       "%s 以下にしてください",
+	"is in a wrong format":                "形式が正しくありません",
+	"already exists":                      "既に存在します",
+	"should be %s %s":                     "%s %s にしてください",
+	"should be an email":                  "メールアドレスにしてください",
+	"should be an url":                    "URLにしてください",
+	"should be an ip":                     "IPアドレスにしてください",
+	"the record has been changed by others, please merge the changes and save again": "レコードは他のユーザーによって変更されました。変更をマージして再度保存してください",
+	"changed by others, the latest value is":                                         "他のユーザーによって変更されました。最新の値",
+	"the version of the record is required":                                          "レコードのバージョンは必須です",
+	"webhooks":                                                                       "Webhook",
+	"webhook":                                                                        "Webhook",
+	"webhook deliveries":                                                             "Webhook配信",
+	"deliveries":                                                                     "配信履歴",
+	"table prefix":                                                                   "テーブルプレフィックス",
+	"event":                                                                          "イベント",
+	"url":                                                                            "URL",
+	"pending":                                                                        "保留中",
+	"failed":                                                                         "失敗",
+	"attempts":                                                                       "試行回数",
+	"response code":                                                                  "レスポンスコード",
+	"next attempt at":                                                                "次回試行日時",
+	"retry":                                                                          "再試行",
+	"payload":                                                                        "ペイロード",
+	"the prefix of the table such as manager, * means all the tables":                             "managerなどのテーブルのプレフィックス、*はすべてのテーブル",
+	"created, updated, deleted, restored or the event of a custom action, * means all the events": "created、updated、deleted、restored またはカスタムアクションのイベント、*はすべてのイベント",
+	"the payloads are signed with the secret in the header X-GoAdmin-Signature":                   "ペイロードはシークレットで署名され、署名はヘッダーX-GoAdmin-Signatureにあります",
+	"the url should be a http or https one":                                                       "URLはhttpまたはhttpsである必要があります",
+	"the url should not be a local or private address":                                            "URLはローカルまたはプライベートアドレスにできません",
+	"the table prefix and the event can not be empty":                                             "テーブルプレフィックスとイベントは空にできません",
+	"success": "成功",
+
 	"second":  "second",
 	"seconds": "seconds",
 	"minute":  "minute",
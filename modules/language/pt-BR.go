@@ -98,6 +98,7 @@ var ptbr = LangSet{
 	"initialize navigation buttons":   "Inicializando botões de navegação",
 	"initialize plugins":              "Inicializando plugins",
 	"initialize database connections": "Inicializando conexões de banco de dados",
+	"migrate database":                "Migrando banco de dados",
 	"initialize success":              "Inicialização feita com sucesso🍺🍺",
 
 	"not found":      "Não encontrado",
@@ -56,6 +56,7 @@ var tc = LangSet{
 	"uri":        "路徑",
 
 	"export":    "導出",
+	"import":    "導入",
 	"home":      "首頁",
 	"all":       "全部",
 	"more":      "更多",
@@ -96,6 +97,118 @@ var tc = LangSet{
 
 	"adapter is nil, import the default adapter or use addadapter method add the adapter": "適配器為空，請先 import 對應的適配器或使用 AddAdapter 方法引入",
 
+	"wrong file":        "錯誤的文件",
+	"dry run":           "試運行",
+	"total":             "總數",
+	"inserted":          "已插入",
+	"unmatched columns": "未匹配的列",
+	"import fail":       "導入失敗",
+
+	"the role value of the portal user": "portal 用戶的角色值",
+
+	"two-factor authentication":                                       "兩步驗證",
+	"two-factor authentication is not supported by the portal signin": "portal 登錄不支持兩步驗證",
+	"two-factor authentication is required":                           "必須開啟兩步驗證",
+	"scan the qrcode with the authenticator app":                      "請使用身份驗證器應用掃描二維碼",
+	"verification code":                                               "驗證碼",
+	"verify":                                                          "驗證",
+	"wrong verification code":                                         "驗證碼錯誤",
+	"too many wrong verification codes, please try again later":       "驗證碼錯誤次數過多，請稍後再試",
+	"recovery codes":                                                  "恢復碼",
+	"regenerate recovery codes":                                       "重新生成恢復碼",
+	"save the recovery codes":                                         "請妥善保存以下恢復碼，每個只能使用一次",
+	"status":                                                          "狀態",
+	"enabled":                                                         "已開啟",
+	"disabled":                                                        "未開啟",
+	"enable":                                                          "開啟",
+	"disable":                                                         "關閉",
+	"secret":                                                          "密鑰",
+
+	"sessions":                   "登入會話",
+	"user agent":                 "用戶端",
+	"last seen":                  "最後活動",
+	"revoke":                     "登出",
+	"log out everywhere":         "登出所有裝置",
+	"log out all your sessions?": "確定登出所有登入會話？",
+
+	"history":   "修改記錄",
+	"field":     "欄位",
+	"old value": "原值",
+	"new value": "新值",
+	"operator":  "操作人",
+	"create":    "新建",
+	"update":    "更新",
+
+	"trash":                   "回收站",
+	"restore":                 "恢復",
+	"are you sure to restore": "確定要恢復嗎",
+	"restore fail":            "恢復失敗",
+	"purge fail":              "徹底刪除失敗",
+
+	"wrong content type": "錯誤的內容類型",
+	"wrong json body":    "錯誤的JSON請求體",
+	"file too large":     "文件過大",
+
+	"api tokens":                   "API令牌",
+	"token name":                   "令牌名稱",
+	"token type":                   "令牌類型",
+	"personal token":               "個人令牌",
+	"service account":              "服務賬號",
+	"scopes":                       "權限範圍",
+	"last used":                    "最近使用",
+	"expires at":                   "過期時間",
+	"api tokens need a local user": "API令牌需要本地用戶",
+	"scopes are separated by commas, such as users:read,posts:write or *:read":            "權限範圍以逗號分隔，如 users:read,posts:write 或 *:read",
+	"leave it empty for a token which never expires":                                      "留空則令牌永不過期",
+	"a service account has the permissions of its creator within the scopes of the token": "服務賬號擁有其創建者在令牌權限範圍內的權限",
+	"copy the token now, it will not be shown again":                                      "請立即複製令牌，之後將不再顯示",
+
+	"showing <b>%d</b> entries":                    "顯示 <b>%d</b> 筆記錄",
+	"showing <b>%d</b> of about <b>%d</b> entries": "顯示 <b>%d</b> 筆記錄，總共約 <b>%d</b> 筆記錄",
+
+	"shift-click to sort by more fields": "按住 Shift 點擊以按多個欄位排序",
+
+	"saved views":                 "儲存的檢視",
+	"save the current view":       "儲存目前檢視",
+	"save and share with my role": "儲存並共享給我的角色",
+	"view name":                   "檢視名稱",
+	"view name can not be empty":  "檢視名稱不能為空",
+
+	"global search":  "全域搜尋",
+	"search results": "搜尋結果",
+	"no results":     "沒有找到結果",
+
+	"dashboards":        "儀表盤列表",
+	"dashboard title":   "儀表盤標題",
+	"description":       "描述",
+	"dashboard widgets": "儀表盤組件",
+	"widget title":      "組件標題",
+	"widget type":       "組件類型",
+	"widget line":       "折線圖",
+	"widget bar":        "柱狀圖",
+	"widget pie":        "餅圖",
+	"widget radar":      "雷達圖",
+	"widget stat":       "統計值",
+	"widget table":      "表格",
+	"data source":       "數據來源",
+	"sql query":         "SQL查詢",
+	"data function":     "數據函數",
+	"query or function": "查詢或函數",
+	"width":             "寬度",
+	"order":             "排序",
+	"the first column of a chart is the labels and the others are the data sets, a stat shows the first column of the first row": "圖表的第一列為標籤，其餘列為數據集，統計值顯示第一行的第一列",
+	"the width of the twelve columns of a row":                    "一行共十二列中所佔的寬度",
+	"the width should be from 1 to 12":                            "寬度應為1到12",
+	"data function not found":                                     "數據函數不存在",
+	"no dashboards":                                               "沒有儀表盤",
+	"dashboard not found":                                         "儀表盤不存在",
+	"failed to load the data of the widget":                       "加載組件數據失敗",
+	"only a super admin can edit the widgets":                     "只有超級管理員可以編輯組件",
+	"refresh interval":                                            "刷新間隔",
+	"the seconds to reload the data of a chart, zero means never": "圖表重新加載數據的秒數，0表示不刷新",
+	"the refresh interval should not be negative":                 "刷新間隔不能為負數",
+	"dashboard widget not found":                                  "儀表盤組件不存在",
+
 	"username and password can not be empty":        "用戶名密碼不能為空",
 	"operation not allow":                           "不允許的操作",
 	"password does not match":                       "密碼不壹致",
@@ -126,6 +239,7 @@ var tc = LangSet{
 	"initialize navigation buttons":   "初始化導航欄按鈕",
 	"initialize plugins":              "初始化插件",
 	"initialize database connections": "初始化數據庫連接",
+	"migrate database":                "遷移數據庫",
 	"initialize success":              "初始化成功🍺🍺",
 
 	"plugins":          "插件",
@@ -134,6 +248,59 @@ var tc = LangSet{
 	"uninstalled":      "未安裝",
 	"plugin setting":   "插件設置",
 
+	"the installation is locked": "安裝已鎖定",
+	"database":                   "數據庫",
+	"host":                       "主機",
+	"port":                       "端口",
+	"database name":              "數據庫名",
+	"file":                       "文件",
+	"test connection":            "測試連接",
+	"super administrator":        "超級管理員",
+	"config file":                "配置文件",
+	"previous":                   "上一步",
+	"install":                    "安裝",
+	"install success, the config file is written to": "安裝成功，配置文件已寫入",
+	"install token": "安裝令牌",
+	"the install token is printed to the log of the server at the startup": "安裝令牌在啟動時打印在服務器日誌中",
+	"wrong install token": "安裝令牌錯誤",
+
+	"please check the fields of the form": "請檢查表單字段",
+	"is required":                         "必填",
+	"should be at least %d characters":    "至少 %d 個字符",
+	"should be at most %d characters":     "最多 %d 個字符",
+	"should be a number":                  "必須是數字",
+	"should not be less than %s":          "不能小於 %s",
+	"should not be greater than %s":       "不能大於 %s",
+	"is in a wrong format":                "格式錯誤",
+	"already exists":                      "已存在",
+	"should be %s %s":                     "必須 %s %s",
+	"should be an email":                  "必須是郵箱",
+	"should be an url":                    "必須是網址",
+	"should be an ip":                     "必須是IP地址",
+	"the record has been changed by others, please merge the changes and save again": "記錄已被他人修改，請合併修改後重新保存",
+	"changed by others, the latest value is":                                         "已被他人修改，最新值為",
+	"the version of the record is required":                                          "記錄的版本不能為空",
+	"webhooks":                                                                       "網路鉤子",
+	"webhook":                                                                        "網路鉤子",
+	"webhook deliveries":                                                             "網路鉤子投遞",
+	"deliveries":                                                                     "投遞記錄",
+	"table prefix":                                                                   "表前綴",
+	"event":                                                                          "事件",
+	"url":                                                                            "地址",
+	"pending":                                                                        "待投遞",
+	"failed":                                                                         "失敗",
+	"attempts":                                                                       "嘗試次數",
+	"response code":                                                                  "回應碼",
+	"next attempt at":                                                                "下次嘗試時間",
+	"retry":                                                                          "重試",
+	"payload":                                                                        "載荷",
+	"the prefix of the table such as manager, * means all the tables":                             "表的前綴，如manager，*表示所有表",
+	"created, updated, deleted, restored or the event of a custom action, * means all the events": "created、updated、deleted、restored或自定義操作的事件，*表示所有事件",
+	"the payloads are signed with the secret in the header X-GoAdmin-Signature":                   "載荷使用密鑰簽名，簽名在請求頭X-GoAdmin-Signature中",
+	"the url should be a http or https one":                                                       "地址必須是http或https地址",
+	"the url should not be a local or private address":                                            "地址不能是本地或內網地址",
+	"the table prefix and the event can not be empty":                                             "表前綴和事件不能為空",
+
 	"second":  "秒",
 	"seconds": "秒",
 	"minute":  "分",
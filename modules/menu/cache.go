@@ -0,0 +1,171 @@
+package menu
+
+import (
+	"sync"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
+)
+
+func init() {
+	models.MenusLoader = func(user models.UserModel) ([]int64, map[string]any) {
+		return roleCache.roleMenus(user.Role, func() ([]int64, map[string]any) {
+			user.AllMenuMap = nil
+			user = user.QueryMenus()
+			return user.MenuIds, user.BlackMenuMap
+		})
+	}
+	models.OnMenuChanged(Invalidate)
+}
+
+// roleMenus is the cached menu ids and black menu map of a role.
+type roleMenus struct {
+	ids   []int64
+	black map[string]any
+}
+
+// rowsKey is the key of the cached menus of the role in the plugin.
+type rowsKey struct {
+	role   int64
+	plugin string
+}
+
+// cache is the cache of the menus by role, which is shared by the users of
+// the role, so the cached values must not be changed. The generation is
+// increased when it is invalidated, so that a value queried before the
+// invalidation is not cached.
+type cache struct {
+	lock       sync.RWMutex
+	generation uint64
+	roles      map[int64]roleMenus
+	rows       map[rowsKey][]map[string]interface{}
+	hooks      []func()
+}
+
+func newCache() *cache {
+	return &cache{
+		roles: make(map[int64]roleMenus),
+		rows:  make(map[rowsKey][]map[string]interface{}),
+	}
+}
+
+var roleCache = newCache()
+
+// roleMenus return the cached menu ids and black menu map of the role, they
+// are loaded by the load function and cached if they are not.
+func (c *cache) roleMenus(role int64, load func() ([]int64, map[string]any)) ([]int64, map[string]any) {
+	c.lock.RLock()
+	item, ok := c.roles[role]
+	generation := c.generation
+	c.lock.RUnlock()
+
+	if ok {
+		return item.ids, item.black
+	}
+
+	ids, black := load()
+
+	c.lock.Lock()
+	if c.generation == generation {
+		c.roles[role] = roleMenus{ids: ids, black: black}
+	}
+	c.lock.Unlock()
+
+	return ids, black
+}
+
+// menuRows return the cached menus of the role in the plugin, they are loaded
+// by the load function and cached if they are not.
+func (c *cache) menuRows(role int64, plugin string, load func() []map[string]interface{}) []map[string]interface{} {
+	key := rowsKey{role: role, plugin: plugin}
+
+	c.lock.RLock()
+	rows, ok := c.rows[key]
+	generation := c.generation
+	c.lock.RUnlock()
+
+	if ok {
+		return rows
+	}
+
+	rows = load()
+
+	c.lock.Lock()
+	if c.generation == generation {
+		c.rows[key] = rows
+	}
+	c.lock.Unlock()
+
+	return rows
+}
+
+func (c *cache) clear() {
+	c.lock.Lock()
+	c.generation++
+	c.roles = make(map[int64]roleMenus)
+	c.rows = make(map[rowsKey][]map[string]interface{})
+	c.lock.Unlock()
+}
+
+func (c *cache) addHook(hook func()) {
+	c.lock.Lock()
+	c.hooks = append(c.hooks, hook)
+	c.lock.Unlock()
+}
+
+func (c *cache) invalidate() {
+	c.clear()
+
+	c.lock.RLock()
+	hooks := c.hooks
+	c.lock.RUnlock()
+
+	for _, hook := range hooks {
+		hook()
+	}
+}
+
+// Invalidate clear the cached menus of all the roles, and call the hooks of
+// AddInvalidationHook. It is called after the menus or the roles of the menus
+// are changed.
+func Invalidate() {
+	roleCache.invalidate()
+}
+
+// InvalidateLocal clear the cached menus of all the roles without calling the
+// hooks, which is called when the invalidation of another instance is received.
+func InvalidateLocal() {
+	roleCache.clear()
+}
+
+// AddInvalidationHook add the hook which is called after the cached menus are
+// invalidated by this instance. In a deployment of multiple instances, the
+// hook publishes the invalidation, such as by redis, and the other instances
+// call InvalidateLocal when they receive it.
+func AddInvalidationHook(hook func()) {
+	roleCache.addHook(hook)
+}
+
+// queryMenuRows query the menus of the user in the plugin.
+func queryMenuRows(user models.UserModel, conn db.Connection, plugName string) []map[string]interface{} {
+	var menus []map[string]interface{}
+	if user.IsSuperAdmin() {
+		menus, _ = db.WithDriver(conn).Table("goadmin_menu").
+			Where("id", ">", 0).
+			Where("plugin_name", "=", plugName).
+			OrderBy("order", "asc").
+			All()
+	} else {
+
+		var ids []interface{}
+		for i := 0; i < len(user.MenuIds); i++ {
+			ids = append(ids, user.MenuIds[i])
+		}
+		menus, _ = db.WithDriver(conn).Table("goadmin_menu").
+			WhereIn("id", ids).
+			Where("plugin_name", "=", plugName).
+			OrderBy("order", "asc").
+			All()
+	}
+	return menus
+}
@@ -0,0 +1,55 @@
+package menu
+
+import (
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestCache(t *testing.T) {
+	c := newCache()
+
+	loads := 0
+	load := func() ([]int64, map[string]any) {
+		loads++
+		return []int64{1, 2}, map[string]any{"/info/users": struct{}{}}
+	}
+
+	ids, black := c.roleMenus(1, load)
+	assert.Equal(t, ids, []int64{1, 2})
+	assert.Equal(t, len(black), 1)
+	c.roleMenus(1, load)
+	assert.Equal(t, loads, 1)
+	c.roleMenus(2, load)
+	assert.Equal(t, loads, 2)
+
+	rows := 0
+	c.menuRows(1, "", func() []map[string]interface{} {
+		rows++
+		return []map[string]interface{}{{"id": int64(1)}}
+	})
+	c.menuRows(1, "", func() []map[string]interface{} {
+		rows++
+		return nil
+	})
+	assert.Equal(t, rows, 1)
+
+	hooks := 0
+	c.addHook(func() { hooks++ })
+
+	c.invalidate()
+	assert.Equal(t, hooks, 1)
+	c.roleMenus(1, load)
+	assert.Equal(t, loads, 3)
+
+	c.clear()
+	assert.Equal(t, hooks, 1)
+
+	// a value loaded before the invalidation is not cached
+	c.roleMenus(1, func() ([]int64, map[string]any) {
+		c.clear()
+		return load()
+	})
+	c.roleMenus(1, load)
+	assert.Equal(t, loads, 5)
+}
@@ -160,13 +160,14 @@ func NewMenu(conn db.Connection, data NewMenuData) (int64, error) {
 			"header":      data.Header,
 		})
 	if !db.CheckError(err, db.INSERT) {
+		Invalidate()
 		return id, nil
 	}
 	return id, err
 }
 
-// GetGlobalMenu return Menu of given user model.
-// GetGlobalMenu
+// GetGlobalMenu return Menu of given user model. The menus are cached by the
+// role of the user until they are changed.
 func GetGlobalMenu(user models.UserModel, conn db.Connection, lang string, pluginNames ...string) *Menu {
 
 	var (
@@ -179,26 +180,10 @@ func GetGlobalMenu(user models.UserModel, conn db.Connection, lang string, plugi
 		plugName = pluginNames[0]
 	}
 
-	//user.WithRoles().WithMenus()
-	user.WithMenus()
-	if user.IsSuperAdmin() {
-		menus, _ = db.WithDriver(conn).Table("goadmin_menu").
-			Where("id", ">", 0).
-			Where("plugin_name", "=", plugName).
-			OrderBy("order", "asc").
-			All()
-	} else {
-
-		var ids []interface{}
-		for i := 0; i < len(user.MenuIds); i++ {
-			ids = append(ids, user.MenuIds[i])
-		}
-		menus, _ = db.WithDriver(conn).Table("goadmin_menu").
-			WhereIn("id", ids).
-			Where("plugin_name", "=", plugName).
-			OrderBy("order", "asc").
-			All()
-	}
+	user = user.WithMenus()
+	menus = roleCache.menuRows(user.Role, plugName, func() []map[string]interface{} {
+		return queryMenuRows(user, conn, plugName)
+	})
 
 	var title string
 	for i := 0; i < len(menus); i++ {
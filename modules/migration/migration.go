@@ -0,0 +1,368 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+// Package migration applies the sql of the goadmin system tables under
+// data/migrations, and records the applied versions in the table
+// goadmin_migrations.
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/data"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/modules/db/dialect"
+)
+
+// Table is the table of the applied versions.
+const Table = "goadmin_migrations"
+
+// Migration is a version of the system tables, of which the down sql is
+// empty if the version can not be reverted in the dialect.
+type Migration struct {
+	Version string
+	Up      string
+	Down    string
+}
+
+// Migrations are the migrations ordered by the version.
+type Migrations []Migration
+
+// Status is the state of a version in the database.
+type Status struct {
+	Version   string
+	Applied   bool
+	AppliedAt string
+}
+
+const mysqlCreateTable = "CREATE TABLE IF NOT EXISTS `goadmin_migrations` (" +
+	"`id` int(10) unsigned NOT NULL AUTO_INCREMENT, " +
+	"`version` varchar(50) COLLATE utf8mb4_unicode_ci NOT NULL, " +
+	"`created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP, " +
+	"PRIMARY KEY (`id`), " +
+	"UNIQUE KEY `admin_migrations_version_unique` (`version`)" +
+	") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci"
+
+// createTable are the sql creating the table of the applied versions by driver.
+var createTable = map[string]string{
+	db.DriverMysql:     mysqlCreateTable,
+	db.DriverOceanBase: mysqlCreateTable,
+	db.DriverSqlite: `CREATE TABLE IF NOT EXISTS "goadmin_migrations" (` +
+		"`id` integer PRIMARY KEY autoincrement, " +
+		"`version` CHAR(50) COLLATE NOCASE NOT NULL UNIQUE, " +
+		"`created_at` TIMESTAMP default CURRENT_TIMESTAMP)",
+	db.DriverPostgresql: "CREATE SEQUENCE IF NOT EXISTS public.goadmin_migrations_myid_seq " +
+		"START WITH 1 INCREMENT BY 1 NO MINVALUE MAXVALUE 99999999 CACHE 1;\n" +
+		"CREATE TABLE IF NOT EXISTS public.goadmin_migrations (" +
+		"id integer DEFAULT nextval('public.goadmin_migrations_myid_seq'::regclass) NOT NULL PRIMARY KEY, " +
+		"version character varying(50) NOT NULL UNIQUE, " +
+		"created_at timestamp without time zone DEFAULT now())",
+	db.DriverMssql: "IF OBJECT_ID(N'goadmin_migrations', N'U') IS NULL CREATE TABLE [goadmin_migrations] (" +
+		"[id] int identity(1,1), " +
+		"[version] varchar(50) NOT NULL, " +
+		"[created_at] datetime NULL DEFAULT GETDATE(), " +
+		"PRIMARY KEY ([id]), " +
+		"UNIQUE ([version]))",
+}
+
+// legacyVersions are the versions in the sql dumps from before the applied
+// versions were recorded, with a column added by each of them, by which they
+// are found applied in the databases installed from those dumps.
+var legacyVersions = []struct {
+	version string
+	table   string
+	column  string
+}{
+	{"2020_04_14_100427", "goadmin_site", "key"},
+	{"2020_08_04_092427", "goadmin_menu", "uuid"},
+}
+
+// Dialect return the dialect in the names of the migration files of the driver.
+func Dialect(driver string) string {
+	switch driver {
+	case db.DriverMysql, db.DriverOceanBase:
+		return "mysql"
+	case db.DriverPostgresql:
+		return "postgres"
+	case db.DriverMssql:
+		return "ms"
+	default:
+		return driver
+	}
+}
+
+// Load return the migrations of the driver in the file system, of which the
+// files are named as admin_<version>_<dialect>.sql and the reverting ones as
+// admin_<version>_<dialect>.down.sql.
+func Load(fsys fs.FS, driver string) (Migrations, error) {
+
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		suffix   = "_" + Dialect(driver)
+		versions = make(map[string]*Migration)
+	)
+
+	for _, file := range files {
+		name := strings.TrimSuffix(path.Base(file), ".sql")
+		down := strings.HasSuffix(name, ".down")
+		name = strings.TrimSuffix(name, ".down")
+
+		if !strings.HasPrefix(name, "admin_") || !strings.HasSuffix(name, suffix) {
+			continue
+		}
+
+		version := strings.TrimSuffix(strings.TrimPrefix(name, "admin_"), suffix)
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		if versions[version] == nil {
+			versions[version] = &Migration{Version: version}
+		}
+		if down {
+			versions[version].Down = string(content)
+		} else {
+			versions[version].Up = string(content)
+		}
+	}
+
+	migrations := make(Migrations, 0, len(versions))
+	for _, m := range versions {
+		if m.Up == "" {
+			return nil, errors.New("migration " + m.Version + " has no up sql")
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Migrator runs the migrations on the default connection.
+type Migrator struct {
+	conn       db.Connection
+	migrations Migrations
+}
+
+// New return a migrator of the embedded migrations of the connection driver.
+func New(conn db.Connection) (*Migrator, error) {
+	sub, err := fs.Sub(data.Migrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := Load(sub, conn.Name())
+	if err != nil {
+		return nil, err
+	}
+	return NewWithMigrations(conn, migrations), nil
+}
+
+// NewWithMigrations return a migrator of the given migrations.
+func NewWithMigrations(conn db.Connection, migrations Migrations) *Migrator {
+	return &Migrator{conn: conn, migrations: migrations}
+}
+
+// Status return the state of each version.
+func (m *Migrator) Status() ([]Status, error) {
+
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]Status, len(m.migrations))
+	for i, migration := range m.migrations {
+		at, ok := applied[migration.Version]
+		status[i] = Status{Version: migration.Version, Applied: ok, AppliedAt: at}
+	}
+	return status, nil
+}
+
+// Up apply the pending versions in order, and return the applied ones. Each
+// version is applied in a transaction with its record, but mysql commits
+// every statement of ddl implicitly, so a failed version may be partly
+// applied there.
+func (m *Migrator) Up() ([]string, error) {
+
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]string, 0)
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		err := m.run(migration.Version, migration.Up, func(tx *db.SQL) error {
+			_, err := tx.Insert(dialect.H{"version": migration.Version})
+			return err
+		})
+		if err != nil {
+			return versions, err
+		}
+		versions = append(versions, migration.Version)
+	}
+	return versions, nil
+}
+
+// Down revert the latest steps of the applied versions, and return the
+// reverted ones.
+func (m *Migrator) Down(steps int) ([]string, error) {
+
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]string, 0)
+	for i := len(m.migrations) - 1; i >= 0 && len(versions) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if migration.Down == "" {
+			return versions, errors.New("migration " + migration.Version + " can not be reverted in " + m.conn.Name())
+		}
+		err := m.run(migration.Version, migration.Down, func(tx *db.SQL) error {
+			return tx.Where("version", "=", migration.Version).Delete()
+		})
+		if err != nil {
+			return versions, err
+		}
+		versions = append(versions, migration.Version)
+	}
+	return versions, nil
+}
+
+// Baseline record the versions until the given one as applied without running
+// them, which is for the databases installed from the sql dump. All the
+// versions are recorded if the given version is empty.
+func (m *Migrator) Baseline(version string) ([]string, error) {
+
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]string, 0)
+	for _, migration := range m.migrations {
+		if version != "" && migration.Version > version {
+			break
+		}
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		_, err := db.WithDriver(m.conn).Table(Table).Insert(dialect.H{"version": migration.Version})
+		if err != nil {
+			return versions, err
+		}
+		versions = append(versions, migration.Version)
+	}
+	return versions, nil
+}
+
+// applied create the table of the versions if not exists, and return the
+// applied versions with the time of them. When the table is created in a
+// database installed before the versions were recorded, the legacy versions
+// found in it are recorded as applied.
+func (m *Migrator) applied() (map[string]string, error) {
+
+	create, ok := createTable[m.conn.Name()]
+	if !ok {
+		return nil, errors.New("migration is not supported in " + m.conn.Name())
+	}
+
+	recorded := m.exists(Table, "version")
+
+	for _, statement := range Statements(m.conn.Name(), create) {
+		if _, err := m.conn.ExecContext(context.Background(), statement); err != nil {
+			return nil, err
+		}
+	}
+
+	if !recorded {
+		for _, legacy := range legacyVersions {
+			if !m.exists(legacy.table, legacy.column) {
+				continue
+			}
+			_, err := db.WithDriver(m.conn).Table(Table).Insert(dialect.H{"version": legacy.version})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	items, err := db.WithDriver(m.conn).Table(Table).All()
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[string]string, len(items))
+	for _, item := range items {
+		applied[toString(item["version"])] = toString(item["created_at"])
+	}
+	return applied, nil
+}
+
+// exists check if the column of the table exists.
+func (m *Migrator) exists(table, column string) bool {
+	_, err := db.WithDriver(m.conn).Table(table).Select(column).WhereRaw("1 = 0").All()
+	return err == nil
+}
+
+// run exec the statements of the content and the record function in a
+// transaction of the default connection.
+func (m *Migrator) run(version, content string, record func(tx *db.SQL) error) (err error) {
+
+	tx := m.conn.BeginTx()
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	for _, statement := range Statements(m.conn.Name(), content) {
+		if _, err = m.conn.ExecWithTxContext(context.Background(), tx, statement); err != nil {
+			return errors.New("migration " + version + ": " + err.Error())
+		}
+	}
+
+	return record(db.WithDriver(m.conn).WithTx(tx).Table(Table))
+}
+
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []uint8:
+		return string(v)
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05")
+	case nil:
+		return ""
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
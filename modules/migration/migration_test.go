@@ -0,0 +1,155 @@
+package migration
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	_ "github.com/GoAdminGroup/go-admin/modules/db/drivers/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatements(t *testing.T) {
+	content := `--
+-- PostgreSQL database dump
+--
+
+SET statement_timeout = 0;
+SELECT pg_catalog.set_config('search_path', '', false);
+
+CREATE SEQUENCE public.goadmin_site_myid_seq
+    START WITH 1
+    CACHE 1;
+
+ALTER TABLE public.goadmin_site_myid_seq OWNER TO postgres;
+
+COPY public.goadmin_site (id, key, value) FROM stdin;
+1	it's	\N
+\.
+
+ALTER TABLE ONLY public.goadmin_site
+    ADD CONSTRAINT goadmin_site_pkey PRIMARY KEY (id);`
+
+	assert.Equal(t, []string{
+		"CREATE SEQUENCE public.goadmin_site_myid_seq\n    START WITH 1\n    CACHE 1",
+		"INSERT INTO public.goadmin_site (id, key, value) VALUES ('1', 'it''s', NULL)",
+		"ALTER TABLE ONLY public.goadmin_site\n    ADD CONSTRAINT goadmin_site_pkey PRIMARY KEY (id)",
+	}, Statements(db.DriverPostgresql, content))
+
+	assert.Equal(t, []string{"CREATE TABLE [a] ([id] int)\nCREATE INDEX [b] ON [a] ([id])", "DROP TABLE [c]"},
+		Statements(db.DriverMssql, "CREATE TABLE [a] ([id] int)\nCREATE INDEX [b] ON [a] ([id])\ngo\nDROP TABLE [c]\n"))
+
+	assert.Equal(t, []string{
+		"CREATE FUNCTION f() RETURNS trigger AS $body$\nBEGIN\n  NEW.a := 'x;';\n  RETURN NEW;\nEND;\n$body$ LANGUAGE plpgsql",
+		"INSERT INTO a VALUES ('a;\nb;', E'it\\'s;', \"c;\")",
+		"SELECT $$;$$, $1",
+	}, Statements(db.DriverPostgresql, "CREATE FUNCTION f() RETURNS trigger AS $body$\nBEGIN\n  NEW.a := 'x;';\n"+
+		"  RETURN NEW;\nEND;\n$body$ LANGUAGE plpgsql;\n"+
+		"INSERT INTO a VALUES ('a;\nb;', E'it\\'s;', \"c;\"); -- a comment;\nSELECT $$;$$, $1;"))
+
+	assert.Equal(t, []string{
+		"INSERT INTO `a` VALUES ('it\\'s;', 'b'';')",
+		"CREATE TRIGGER t BEFORE INSERT ON a FOR EACH ROW\nBEGIN\n  SET NEW.b = 1;\nEND",
+		"/*!40101 SET NAMES utf8mb4 */",
+	}, Statements(db.DriverMysql, "# a comment;\nINSERT INTO `a` VALUES ('it\\'s;', 'b'';');\nDELIMITER //\n"+
+		"CREATE TRIGGER t BEFORE INSERT ON a FOR EACH ROW\nBEGIN\n  SET NEW.b = 1;\nEND //\nDELIMITER ;\n"+
+		"/*!40101 SET NAMES utf8mb4 */;"))
+
+	assert.Equal(t, []string{
+		"CREATE TRIGGER t AFTER UPDATE ON a\nBEGIN\n  UPDATE b SET c = CASE WHEN 1 THEN ';' END;\n  DELETE FROM d;\nEND",
+		"DROP TABLE e",
+	}, Statements(db.DriverSqlite, "CREATE TRIGGER t AFTER UPDATE ON a\nBEGIN\n  UPDATE b SET c = CASE WHEN 1 THEN ';' END;\n"+
+		"  DELETE FROM d;\nEND;\nDROP TABLE e;"))
+}
+
+func TestLoad(t *testing.T) {
+	for _, driver := range []string{db.DriverMysql, db.DriverPostgresql, db.DriverMssql, db.DriverSqlite} {
+		m, err := New(db.GetConnectionByDriver(driver))
+		assert.Nil(t, err)
+		assert.Equal(t, "2020_04_14_100427", m.migrations[0].Version)
+		assert.Equal(t, 12, len(m.migrations))
+		for _, migration := range m.migrations {
+			assert.NotEmpty(t, Statements(driver, migration.Up), migration.Version)
+		}
+	}
+}
+
+func TestMigrator(t *testing.T) {
+
+	conn := db.GetConnectionByDriver(db.DriverSqlite).InitDB(map[string]config.Database{
+		"default": {Driver: db.DriverSqlite, File: filepath.Join(t.TempDir(), "admin.db")},
+	})
+
+	// the database is installed from a dump with the legacy versions.
+	_, err := conn.Exec("CREATE TABLE goadmin_menu (`id` integer PRIMARY KEY autoincrement, " +
+		"`uuid` varchar(150) NOT NULL DEFAULT '', `plugin_name` varchar(150) NOT NULL DEFAULT '')")
+	assert.Nil(t, err)
+	_, err = conn.Exec("CREATE TABLE goadmin_site (`id` integer PRIMARY KEY autoincrement, `key` CHAR(100))")
+	assert.Nil(t, err)
+	_, err = conn.Exec("CREATE TABLE goadmin_users (`id` integer PRIMARY KEY autoincrement)")
+	assert.Nil(t, err)
+	_, err = conn.Exec("CREATE TABLE goadmin_session (`id` integer PRIMARY KEY autoincrement)")
+	assert.Nil(t, err)
+
+	m, err := New(conn)
+	assert.Nil(t, err)
+
+	versions, err := m.Up()
+	assert.Nil(t, err)
+	assert.Equal(t, 10, len(versions))
+	assert.Equal(t, "2026_10_18_101500", versions[0])
+
+	versions, err = m.Up()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(versions))
+
+	_, err = db.WithDriver(conn).Table("goadmin_dashboard_widgets").Insert(map[string]interface{}{
+		"type": "line", "query": "select 1", "refresh": 10,
+	})
+	assert.Nil(t, err)
+
+	// the columns added in 210000 and 220000 can not be dropped in sqlite.
+	_, err = conn.Exec("DELETE FROM goadmin_migrations WHERE version in ('2026_10_18_210000', '2026_10_18_220000')")
+	assert.Nil(t, err)
+
+	versions, err = m.Down(1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2026_10_18_200000"}, versions)
+
+	_, err = db.WithDriver(conn).Table("goadmin_user_identities").All()
+	assert.NotNil(t, err)
+
+	versions, err = m.Down(1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2026_10_18_190000"}, versions)
+
+	_, err = db.WithDriver(conn).Table("goadmin_webhooks").All()
+	assert.NotNil(t, err)
+
+	// the column added in 180000 can not be dropped in sqlite.
+	versions, err = m.Down(1)
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, len(versions))
+
+	_, err = conn.Exec("DELETE FROM goadmin_migrations WHERE version = '2026_10_18_180000'")
+	assert.Nil(t, err)
+
+	versions, err = m.Down(1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2026_10_18_170000"}, versions)
+
+	status, err := m.Status()
+	assert.Nil(t, err)
+	assert.True(t, status[5].Applied)
+	assert.False(t, status[6].Applied)
+	assert.NotEmpty(t, status[5].AppliedAt)
+
+	_, err = db.WithDriver(conn).Table("goadmin_dashboards").All()
+	assert.NotNil(t, err)
+
+	versions, err = m.Baseline("")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2026_10_18_170000", "2026_10_18_180000", "2026_10_18_190000",
+		"2026_10_18_200000", "2026_10_18_210000", "2026_10_18_220000"}, versions)
+}
@@ -0,0 +1,257 @@
+package migration
+
+import (
+	"strings"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+)
+
+// Statements split the sql of the driver into the statements to exec one by
+// one. The statements end at the semicolons out of the string literals, the
+// quoted identifiers, the comments, the dollar quoted bodies of postgres and
+// the BEGIN ... END blocks of the sqlite triggers, and the DELIMITER lines of
+// mysql change the end of them. A mssql file is a batch unless it is
+// separated by the GO lines. The rows of the COPY blocks of pg_dump are turned
+// into inserts, and the comments, the session settings and the ownerships of
+// pg_dump are skipped, of which the search_path would leak to the pooled
+// connections.
+func Statements(driver, content string) []string {
+
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+
+	if driver == db.DriverMssql {
+		return batches(content)
+	}
+
+	var (
+		sp      = &splitter{driver: driver, delimiter: ";", statements: make([]string, 0)}
+		copying = ""
+	)
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if copying != "" {
+			if trimmed == `\.` {
+				copying = ""
+			} else {
+				sp.statements = append(sp.statements, copying+copyValues(line)+")")
+			}
+			continue
+		}
+		if sp.empty() {
+			if strings.HasPrefix(trimmed, "COPY ") && strings.HasSuffix(trimmed, " FROM stdin;") {
+				copying = "INSERT INTO " + strings.TrimSuffix(strings.TrimPrefix(trimmed, "COPY "), " FROM stdin;") + " VALUES ("
+				continue
+			}
+			if sp.mysql() && len(trimmed) > 10 && strings.EqualFold(trimmed[:10], "DELIMITER ") {
+				sp.delimiter = strings.TrimSpace(trimmed[10:])
+				continue
+			}
+		}
+
+		sp.scan(line)
+	}
+	sp.add()
+
+	return sp.statements
+}
+
+// splitter split the statements line by line, and keeps the state of the
+// statement between the lines.
+type splitter struct {
+	driver     string
+	delimiter  string
+	statements []string
+
+	buf strings.Builder
+	// quote is the end of the literal, the identifier, the comment or the
+	// dollar quoted body in which the scan is.
+	quote string
+	// backslash is true if the backslashes escape in the literal.
+	backslash bool
+	// word is the last word out of the quotes, and depth is the depth of the
+	// BEGIN ... END blocks of a sqlite trigger.
+	word  strings.Builder
+	depth int
+}
+
+func (sp *splitter) mysql() bool {
+	return sp.driver == db.DriverMysql || sp.driver == db.DriverOceanBase
+}
+
+// empty check if no statement is being split.
+func (sp *splitter) empty() bool {
+	return sp.quote == "" && strings.TrimSpace(sp.buf.String()) == ""
+}
+
+// add end the statement.
+func (sp *splitter) add() {
+	sp.endWord()
+	statement := strings.TrimSpace(sp.buf.String())
+	sp.buf.Reset()
+	sp.depth = 0
+	if statement != "" && !skipped(statement) {
+		sp.statements = append(sp.statements, statement)
+	}
+}
+
+// scan add the line to the statement, and end the statements in it.
+func (sp *splitter) scan(line string) {
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+
+		if sp.quote != "" {
+			switch {
+			case sp.backslash && c == '\\' && i+1 < len(line):
+				sp.buf.WriteString(line[i : i+2])
+				i++
+				continue
+			case strings.HasPrefix(line[i:], sp.quote):
+				sp.buf.WriteString(sp.quote)
+				i += len(sp.quote) - 1
+				sp.quote = ""
+				continue
+			}
+			sp.buf.WriteByte(c)
+			continue
+		}
+
+		if isWordByte(c) {
+			sp.word.WriteByte(c)
+			sp.buf.WriteByte(c)
+			continue
+		}
+		sp.endWord()
+
+		switch {
+		case strings.HasPrefix(line[i:], "--") && (!sp.mysql() || i+2 == len(line) || line[i+2] == ' ' || line[i+2] == '\t'),
+			c == '#' && sp.mysql():
+			sp.buf.WriteByte('\n')
+			return
+		case strings.HasPrefix(line[i:], sp.delimiter) && sp.depth == 0:
+			i += len(sp.delimiter) - 1
+			sp.add()
+			continue
+		case strings.HasPrefix(line[i:], "/*"):
+			sp.quote = "*/"
+			sp.backslash = false
+			sp.buf.WriteString("/*")
+			i++
+			continue
+		case c == '\'' || c == '"' || c == '`':
+			sp.quote = string(c)
+			sp.backslash = c != '`' && (sp.mysql() || (sp.driver == db.DriverPostgresql && c == '\'' && i > 0 &&
+				(line[i-1] == 'E' || line[i-1] == 'e') && (i == 1 || !isWordByte(line[i-2]))))
+		case c == '$' && sp.driver == db.DriverPostgresql && (i == 0 || !isWordByte(line[i-1])):
+			if tag := dollarTag(line[i:]); tag != "" {
+				sp.quote = tag
+				sp.backslash = false
+				sp.buf.WriteString(tag)
+				i += len(tag) - 1
+				continue
+			}
+		}
+		sp.buf.WriteByte(c)
+	}
+	sp.endWord()
+	sp.buf.WriteByte('\n')
+}
+
+// endWord count the BEGIN ... END blocks of a sqlite trigger by the word.
+func (sp *splitter) endWord() {
+	word := strings.ToUpper(sp.word.String())
+	sp.word.Reset()
+	if (word != "BEGIN" && word != "CASE" && word != "END") || sp.driver != db.DriverSqlite ||
+		!createsTrigger(sp.buf.String()) {
+		return
+	}
+	if word != "END" {
+		sp.depth++
+	} else if sp.depth > 0 {
+		sp.depth--
+	}
+}
+
+// createsTrigger check if the statement creates a trigger.
+func createsTrigger(statement string) bool {
+	words := strings.Fields(strings.ToUpper(statement))
+	if len(words) < 2 || words[0] != "CREATE" {
+		return false
+	}
+	if words[1] == "TEMP" || words[1] == "TEMPORARY" {
+		return len(words) > 2 && words[2] == "TRIGGER"
+	}
+	return words[1] == "TRIGGER"
+}
+
+// dollarTag return the tag of the dollar quote at the start of s, such as $$
+// or $body$, or empty if there is not.
+func dollarTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		if s[i] == '$' {
+			return s[:i+1]
+		}
+		if !isWordByte(s[i]) || (i == 1 && s[i] >= '0' && s[i] <= '9') {
+			return ""
+		}
+	}
+	return ""
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func batches(content string) []string {
+
+	var (
+		statements = make([]string, 0)
+		lines      = make([]string, 0)
+	)
+
+	add := func() {
+		statement := strings.TrimSpace(strings.Join(lines, "\n"))
+		lines = lines[:0]
+		if statement != "" {
+			statements = append(statements, statement)
+		}
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		if strings.EqualFold(strings.TrimSpace(line), "GO") {
+			add()
+			continue
+		}
+		lines = append(lines, line)
+	}
+	add()
+
+	return statements
+}
+
+// copyValues return the values of the row of a COPY block in the text format,
+// of which the columns are separated by tabs and \N is null.
+func copyValues(row string) string {
+	values := strings.Split(row, "\t")
+	for i, value := range values {
+		if value == `\N` {
+			values[i] = "NULL"
+			continue
+		}
+		value = strings.NewReplacer(`\\`, `\`, `\t`, "\t", `\n`, "\n", `\r`, "\r").Replace(value)
+		values[i] = "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	}
+	return strings.Join(values, ", ")
+}
+
+func skipped(statement string) bool {
+	return strings.HasPrefix(statement, "SET ") ||
+		strings.HasPrefix(statement, "SELECT pg_catalog.set_config(") ||
+		strings.HasPrefix(statement, "CREATE EXTENSION ") ||
+		strings.HasPrefix(statement, "COMMENT ON EXTENSION ") ||
+		strings.HasPrefix(statement, "REVOKE ") ||
+		strings.HasPrefix(statement, "GRANT ") ||
+		(strings.HasPrefix(statement, "ALTER ") && strings.Contains(statement, " OWNER TO "))
+}
@@ -3,13 +3,17 @@ package admin
 import (
 	"github.com/GoAdminGroup/go-admin/context"
 	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/logger"
 	"github.com/GoAdminGroup/go-admin/modules/service"
 	"github.com/GoAdminGroup/go-admin/modules/system"
 	"github.com/GoAdminGroup/go-admin/modules/utils"
 	"github.com/GoAdminGroup/go-admin/plugins"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/controller"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/guard"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/install"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/table"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/webhook"
 	"github.com/GoAdminGroup/go-admin/template/types"
 	"github.com/GoAdminGroup/go-admin/template/types/action"
 	_ "github.com/GoAdminGroup/go-admin/template/types/display"
@@ -38,11 +42,24 @@ func (admin *Admin) InitPlugin(services service.List) {
 		"normal_manager": st.GetPersonalTable,
 		"op":             st.GetOpTable,
 		"menu":           st.GetMenuTable,
+		"roles":          st.GetRolesTable,
+		"permission":     st.GetPermissionTable,
+		"sessions":       st.GetSessionsTable,
+		"api_tokens":     st.GetApiTokensTable,
+
+		"dashboards":        st.GetDashboardsTable,
+		"dashboard_widgets": st.GetDashboardWidgetsTable,
+
+		"webhooks":           st.GetWebhooksTable,
+		"webhook_deliveries": st.GetWebhookDeliveriesTable,
 	}
 	if c.IsAllowConfigModification() {
 		genList.Add("site", st.GetSiteTable)
 	}
 	admin.tableList.Combine(genList)
+	// the pages of the api tokens only list and revoke the tokens of the
+	// login user.
+	models.AllowPaths("/info/api_tokens", "/info/api_tokens/new", "/new/api_tokens", "/delete/api_tokens")
 	admin.guardian = guard.New(admin.Services, admin.Conn, admin.tableList, admin.UI.NavButtons)
 	handlerCfg := controller.Config{
 		Config:     c,
@@ -57,9 +74,21 @@ func (admin *Admin) InitPlugin(services service.List) {
 
 	table.SetServices(services)
 
+	if !install.Locked(admin.Conn) {
+		logger.Info("the install token is " + install.NewToken() + ", install at " + config.Url("/install"))
+	}
+
+	webhook.Start(admin.Conn)
+
 	action.InitOperationHandlerSetter(admin.GetAddOperationFn())
 }
 
+// Close stop the dispatcher of the webhooks, which should be called when the
+// application shuts down.
+func (admin *Admin) Close() {
+	webhook.Stop()
+}
+
 func (admin *Admin) GetIndexURL() string {
 	return config.GetIndexURL()
 }
@@ -14,7 +14,7 @@ func (h *Handler) Update(ctx *context.Context) {
 	err := param.Panel.UpdateData(param.Value)
 
 	if err != nil {
-		response.Error(ctx, err.Error())
+		formErrorResponse(ctx, err, nil)
 		return
 	}
 
@@ -21,7 +21,7 @@ func (h *Handler) ApiCreate(ctx *context.Context) {
 
 	err := param.Panel.InsertData(param.Value())
 	if err != nil {
-		response.Error(ctx, err.Error())
+		formErrorResponse(ctx, err, nil)
 		return
 	}
 
@@ -110,7 +110,7 @@ $('.delete-btn').on('click', function (event) {
 		desc = panel.GetInfo().Description + language.Get("Detail")
 	}
 
-	formInfo, err := newPanel.GetDataWithId(param.WithPKs(id))
+	formInfo, err := newPanel.GetDataWithId(param.WithContext(ctx.Request.Context()).WithPKs(id))
 
 	if err != nil {
 		response.Error(ctx, err.Error())
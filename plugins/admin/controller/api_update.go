@@ -34,7 +34,7 @@ func (h *Handler) ApiUpdate(ctx *context.Context) {
 
 	err := param.Panel.UpdateData(param.Value())
 	if err != nil {
-		response.Error(ctx, err.Error())
+		formErrorResponse(ctx, err, nil)
 		return
 	}
 
@@ -58,7 +58,7 @@ func (h *Handler) ApiUpdateForm(ctx *context.Context) {
 		footerKind = "edit_only"
 	}
 
-	formInfo, err := panel.GetDataWithId(param)
+	formInfo, err := panel.GetDataWithId(param.WithContext(ctx.Request.Context()))
 
 	if err != nil {
 		response.Error(ctx, err.Error())
@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/auth"
+	"github.com/GoAdminGroup/go-admin/modules/logger"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/guard"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/parameter"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/resource"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/response"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/table"
+)
+
+// RestList return the records of a page of the table.
+func (h *Handler) RestList(ctx *context.Context) {
+	var (
+		param  = guard.GetRestParam(ctx)
+		info   = param.Panel.GetInfo()
+		params = parameter.GetParam(ctx.Request.URL, info.DefaultPageSize, info.SortField,
+			info.GetSort()).WithContext(ctx.Request.Context())
+	)
+
+	panelInfo, err := param.Panel.GetData(params)
+
+	// the page of the cursor pagination without a cursor is reached by
+	// passing the cursors of the pages before it forward.
+	if info.CursorPagination && params.Cursor == "" {
+		for page := 1; err == nil && page < params.PageInt; page++ {
+			if panelInfo.NextCursor == "" {
+				panelInfo.InfoList, panelInfo.PreviousCursor = nil, ""
+				break
+			}
+			params.Cursor = panelInfo.NextCursor
+			panelInfo, err = param.Panel.GetData(params)
+		}
+	}
+
+	if err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+
+	data := map[string]interface{}{
+		"items":     resource.Records(resource.Fields(param.Panel), panelInfo.InfoList),
+		"total":     panelInfo.Total,
+		"page":      params.PageInt,
+		"page_size": params.PageSizeInt,
+	}
+	if info.CursorPagination {
+		data["previous_cursor"] = panelInfo.PreviousCursor
+		data["next_cursor"] = panelInfo.NextCursor
+	}
+
+	response.OkWithData(ctx, data)
+}
+
+// RestDetail return the record of the id.
+func (h *Handler) RestDetail(ctx *context.Context) {
+	param := guard.GetRestParam(ctx)
+
+	record, ok := h.restRecord(ctx, param.Panel, param.Id)
+	if !ok {
+		return
+	}
+
+	response.OkWithData(ctx, record)
+}
+
+// RestCreate create a record from the JSON body.
+func (h *Handler) RestCreate(ctx *context.Context) {
+	param := guard.GetRestParam(ctx)
+
+	if err := param.Panel.InsertData(param.Values); err != nil {
+		formErrorResponse(ctx, err, nil)
+		return
+	}
+
+	response.Ok(ctx)
+}
+
+// RestUpdate update the fields in the JSON body of the record of the id.
+func (h *Handler) RestUpdate(ctx *context.Context) {
+	param := guard.GetRestParam(ctx)
+
+	if _, ok := h.restRecord(ctx, param.Panel, param.Id); !ok {
+		return
+	}
+
+	if err := param.Panel.UpdateData(param.Values); err != nil {
+		formErrorResponse(ctx, err, nil)
+		return
+	}
+
+	record, ok := h.restRecord(ctx, param.Panel, param.Id)
+	if !ok {
+		return
+	}
+
+	response.OkWithData(ctx, record)
+}
+
+// RestDelete delete the record of the id.
+func (h *Handler) RestDelete(ctx *context.Context) {
+	param := guard.GetRestParam(ctx)
+
+	if _, ok := h.restRecord(ctx, param.Panel, param.Id); !ok {
+		return
+	}
+
+	if err := param.Panel.DeleteData(param.Id); err != nil {
+		logger.Error(err)
+		response.Error(ctx, "delete fail")
+		return
+	}
+
+	response.Ok(ctx)
+}
+
+// OpenAPI return the OpenAPI document of the JSON api of the tables, with
+// the operations which the login user is permitted to request.
+func (h *Handler) OpenAPI(ctx *context.Context) {
+	var (
+		user   = auth.Auth(ctx)
+		tables = make(map[string]table.Table, len(h.generators))
+	)
+	for prefix, gen := range h.generators {
+		tables[prefix] = gen(ctx)
+	}
+	ctx.JSON(http.StatusOK, resource.Document(h.config.Title, "1", h.config.Url("/api/v1"), tables,
+		func(path, method string) bool {
+			return auth.CheckPermissions(user, path, method, nil)
+		}))
+}
+
+// restRecord return the record of the id, or write a not found response.
+func (h *Handler) restRecord(ctx *context.Context, panel table.Table, id string) (map[string]interface{}, bool) {
+	if id == "" {
+		response.NotFound(ctx, "not found")
+		return nil, false
+	}
+
+	info, err := panel.GetDataWithIds(parameter.BaseParam().WithContext(ctx.Request.Context()).WithPKs(id))
+	if err != nil {
+		response.Error(ctx, err.Error())
+		return nil, false
+	}
+
+	if len(info.InfoList) == 0 {
+		response.NotFound(ctx, "not found")
+		return nil, false
+	}
+
+	return resource.Record(resource.Fields(panel), info.InfoList[0]), true
+}
@@ -4,17 +4,251 @@ import (
 	"bytes"
 	template2 "html/template"
 	"net/http"
+	"net/url"
 
 	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/auth"
 	"github.com/GoAdminGroup/go-admin/modules/logger"
 	"github.com/GoAdminGroup/go-admin/modules/system"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/response"
 	"github.com/GoAdminGroup/go-admin/template"
 	"github.com/GoAdminGroup/go-admin/template/types"
+	"github.com/GoAdminGroup/go-admin/template/types/display"
 )
 
+// Auth check the input password and username for authentication.
+func (h *Handler) Auth(ctx *context.Context) {
+
+	user, ok, errMsg := h.authProcessor()(ctx)
+
+	if !ok {
+		response.BadRequest(ctx, errMsg)
+		return
+	}
+
+	if auth.NeedTOTP(user) {
+		if err := auth.SetTOTPPending(ctx, user, h.conn); err != nil {
+			response.Error(ctx, err.Error())
+			return
+		}
+		response.OkWithData(ctx, map[string]interface{}{
+			"url": h.totpLoginURL(ctx),
+		})
+		return
+	}
+
+	if err := auth.SetCookie(ctx, user, h.conn); err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+
+	response.OkWithData(ctx, map[string]interface{}{
+		"url": h.refererURL(ctx),
+	})
+}
+
+// AuthTOTP check the code of the user who has passed the first factor, and
+// enables the secret of a user who enrolls at the signin.
+func (h *Handler) AuthTOTP(ctx *context.Context) {
+
+	user, ok := auth.GetTOTPPending(ctx, h.conn)
+
+	if !ok {
+		response.BadRequest(ctx, "login overdue, please login again")
+		return
+	}
+
+	if user.TOTPLocked() {
+		response.BadRequest(ctx, "too many wrong verification codes, please try again later")
+		return
+	}
+
+	var (
+		code          = ctx.FormValue("code")
+		recoveryCodes []string
+		err           error
+	)
+
+	if user.TOTPEnabled {
+		ok = auth.CheckTOTP(user, code)
+	} else {
+		ok = auth.VerifyTOTP(user, code)
+	}
+
+	if !ok {
+		if auth.FailTOTPPending(ctx, user, h.conn) {
+			response.BadRequest(ctx, "login overdue, please login again")
+			return
+		}
+		response.BadRequest(ctx, "wrong verification code")
+		return
+	}
+
+	if !user.TOTPEnabled {
+		var hashes []string
+		recoveryCodes, hashes = auth.GenerateRecoveryCodes()
+		if user, err = user.EnableTOTP(hashes); err != nil {
+			response.Error(ctx, err.Error())
+			return
+		}
+	}
+
+	if err = auth.SetCookie(ctx, user, h.conn); err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+
+	data := map[string]interface{}{
+		"url": h.refererURL(ctx),
+	}
+	if len(recoveryCodes) > 0 {
+		data["recovery_codes"] = recoveryCodes
+	}
+	response.OkWithData(ctx, data)
+}
+
+// AuthCallback signs in the user redirected back by a redirect provider.
+func (h *Handler) AuthCallback(ctx *context.Context) {
+
+	if !auth.CheckState(ctx) {
+		response.BadRequest(ctx, "wrong state")
+		return
+	}
+
+	user, ok, errMsg := h.authProcessor()(ctx)
+
+	if !ok {
+		response.BadRequest(ctx, errMsg)
+		return
+	}
+
+	location := h.config.GetIndexURL()
+
+	if auth.NeedTOTP(user) {
+		if err := auth.SetTOTPPending(ctx, user, h.conn); err != nil {
+			response.Error(ctx, err.Error())
+			return
+		}
+		location = h.config.Url(h.config.LoginUrl)
+	} else if err := auth.SetCookie(ctx, user, h.conn); err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+
+	ctx.AddHeader("Location", location)
+	ctx.SetStatusCode(http.StatusFound)
+}
+
+// refererURL return the url to jump to after the signin, which is the ref
+// parameter of the login page, or the index page.
+func (h *Handler) refererURL(ctx *context.Context) string {
+	if ref := ctx.Referer(); ref != "" {
+		if u, err := url.Parse(ref); err == nil {
+			if r := u.Query().Get("ref"); r != "" {
+				rr, _ := url.QueryUnescape(r)
+				return rr
+			}
+		}
+	}
+	return h.config.GetIndexURL()
+}
+
+// totpLoginURL return the url of the login page which shows the TOTP step,
+// with the ref parameter of the current login page.
+func (h *Handler) totpLoginURL(ctx *context.Context) string {
+	u := h.config.Url(h.config.LoginUrl)
+	if ref := ctx.Referer(); ref != "" {
+		if r, err := url.Parse(ref); err == nil && r.Query().Get("ref") != "" {
+			u += "?ref=" + url.QueryEscape(r.Query().Get("ref"))
+		}
+	}
+	return u
+}
+
+// authProcessor return the processor of the auth service, or the one of the
+// provider of the auth config.
+func (h *Handler) authProcessor() auth.Processor {
+	if s, exist := h.services.GetOrNot(auth.ServiceKey); exist {
+		return auth.GetService(s).P
+	}
+	if provider, ok := auth.GetProvider(h.config.Auth.Provider); ok {
+		return auth.ProviderProcessor(h.config.Auth.Provider, provider, h.conn)
+	}
+	return func(ctx *context.Context) (models.UserModel, bool, string) {
+		logger.Error("unknown auth provider: ", h.config.Auth.Provider)
+		return models.UserModel{}, false, "fail"
+	}
+}
+
+// Logout delete the cookie.
+func (h *Handler) Logout(ctx *context.Context) {
+	if err := auth.DelCookie(ctx, h.conn); err != nil {
+		logger.Error("logout error", err)
+	}
+	ctx.AddHeader("Location", h.config.Url(h.config.LoginUrl))
+	ctx.SetStatusCode(http.StatusFound)
+}
+
+// LogoutAll logout all the sessions of the login user.
+func (h *Handler) LogoutAll(ctx *context.Context) {
+	if !h.authSrv().CheckToken(ctx.FormValue(form.TokenKey)) {
+		response.BadRequest(ctx, "wrong token")
+		return
+	}
+	if err := auth.RevokeUserSessions(auth.Auth(ctx).Id, h.conn); err != nil {
+		logger.Error("logout all error", err)
+		response.Error(ctx, err.Error())
+		return
+	}
+	response.OkWithData(ctx, map[string]interface{}{
+		"url": h.config.Url(h.config.LoginUrl),
+	})
+}
+
 // ShowLogin show the login page.
 func (h *Handler) ShowLogin(ctx *context.Context) {
 
+	var (
+		totpStep   bool
+		totpQrcode template2.URL
+		totpSecret string
+	)
+
+	if h.config.Auth.Provider != auth.ProviderPortal {
+		if user, ok := auth.GetTOTPPending(ctx, h.conn); ok {
+			totpStep = true
+			if !user.TOTPEnabled {
+				if user.TOTPSecret == "" {
+					var err error
+					if user, err = user.SetTOTPSecret(auth.GenerateTOTPSecret()); err != nil {
+						logger.Error("set totp secret error: ", err)
+					}
+				}
+				if user.TOTPSecret != "" {
+					totpSecret = user.TOTPSecret
+					totpQrcode = template2.URL(display.QrcodeSrc(auth.TOTPURL(h.config.Auth.TOTP.Issuer,
+						user.UserName, user.TOTPSecret)))
+				}
+			}
+		}
+	}
+
+	if provider, ok := auth.GetProvider(h.config.Auth.Provider); ok && !totpStep {
+		if p, ok := provider.(auth.RedirectProvider); ok {
+			u, err := p.LoginURL(auth.SetState(ctx))
+			if err != nil {
+				logger.Error("auth provider login url error: ", err)
+				ctx.HTML(http.StatusOK, "auth provider error (；′⌒`)")
+				return
+			}
+			ctx.AddHeader("Location", u)
+			ctx.SetStatusCode(http.StatusFound)
+			return
+		}
+	}
+
 	tmpl, name := template.GetComp("login").GetTemplate()
 	buf := new(bytes.Buffer)
 	if err := tmpl.ExecuteTemplate(buf, name, struct {
@@ -23,6 +257,10 @@ func (h *Handler) ShowLogin(ctx *context.Context) {
 		Logo      template2.HTML
 		CdnUrl    string
 		System    types.SystemInfo
+
+		TOTP       bool
+		TOTPQrcode template2.URL
+		TOTPSecret string
 	}{
 		UrlPrefix: h.config.AssertPrefix(),
 		Title:     h.config.LoginTitle,
@@ -31,7 +269,10 @@ func (h *Handler) ShowLogin(ctx *context.Context) {
 			Version:    system.Version(),
 			AppVersion: system.AppVersion(),
 		},
-		CdnUrl: h.config.AssetUrl,
+		CdnUrl:     h.config.AssetUrl,
+		TOTP:       totpStep,
+		TOTPQrcode: totpQrcode,
+		TOTPSecret: totpSecret,
 	}); err == nil {
 		ctx.HTML(http.StatusOK, buf.String())
 	} else {
@@ -20,6 +20,7 @@ import (
 	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/constant"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/response"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/table"
 	"github.com/GoAdminGroup/go-admin/template"
 	"github.com/GoAdminGroup/go-admin/template/icon"
@@ -79,28 +80,21 @@ func (h *Handler) SetRoutes(r context.RouterMap) {
 
 func (h *Handler) table(prefix string, ctx *context.Context) table.Table {
 	t := h.generators[prefix](ctx)
+	if user, ok := ctx.User().(models.UserModel); ok {
+		table.SetAuditUser(t, user)
+	}
+	table.SetWebhookPrefix(t, prefix)
 	authHandler := auth.Middleware(db.GetConnection(h.services))
-	for _, cb := range t.GetInfo().Callbacks {
-		if cb.Value[constant.ContextNodeNeedAuth] == 1 {
-			h.AddOperation(context.Node{
-				Path:     cb.Path,
-				Method:   cb.Method,
-				Handlers: append([]context.Handler{authHandler}, cb.Handlers...),
-			})
-		} else {
-			h.AddOperation(context.Node{Path: cb.Path, Method: cb.Method, Handlers: cb.Handlers})
+	callbacks := append(append([]context.Node{}, t.GetInfo().Callbacks...), t.GetForm().Callbacks...)
+	for _, cb := range callbacks {
+		handlers := cb.Handlers
+		if cb.Value[constant.ContextNodeAction] == 1 {
+			handlers = append(append([]context.Handler{}, handlers...), table.ActionNotifier(t, prefix, cb.Path))
 		}
-	}
-	for _, cb := range t.GetForm().Callbacks {
 		if cb.Value[constant.ContextNodeNeedAuth] == 1 {
-			h.AddOperation(context.Node{
-				Path:     cb.Path,
-				Method:   cb.Method,
-				Handlers: append([]context.Handler{authHandler}, cb.Handlers...),
-			})
-		} else {
-			h.AddOperation(context.Node{Path: cb.Path, Method: cb.Method, Handlers: cb.Handlers})
+			handlers = append([]context.Handler{authHandler}, handlers...)
 		}
+		h.AddOperation(context.Node{Path: cb.Path, Method: cb.Method, Handlers: handlers})
 	}
 	return t
 }
@@ -443,11 +437,18 @@ func formContent(form types.FormAttribute, isTab, iframe, isHideBack bool, heade
 		GetContent()
 }
 
-func detailContent(form types.FormAttribute, editUrl, deleteUrl string, iframe bool) template2.HTML {
+func detailContent(form types.FormAttribute, editUrl, deleteUrl string, iframe bool, history template2.HTML) template2.HTML {
+	body := form.GetContent()
+	if history != "" {
+		body = aTab().SetData([]map[string]template2.HTML{
+			{"title": language.GetFromHtml("detail"), "content": body},
+			{"title": language.GetFromHtml("history"), "content": history},
+		}).GetContent()
+	}
 	return aBox().
 		SetHeader(form.GetDetailBoxHeader(editUrl, deleteUrl)).
 		WithHeadBorder().
-		SetBody(form.GetContent()).
+		SetBody(body).
 		SetIframeStyle(iframe).
 		GetContent()
 }
@@ -460,3 +461,60 @@ func menuFormContent(form types.FormAttribute) template2.HTML {
 		SetBody(form.GetContent()).
 		GetContent()
 }
+
+const fieldErrorsKey = "field_errors"
+
+type fieldErrors struct {
+	errs   types.FieldErrors
+	values form.Values
+}
+
+// formErrorAlert return the alert of the error of the posted form. The field
+// errors are kept in the context with the posted values, which are rendered
+// inline by the form shown again.
+func formErrorAlert(ctx *context.Context, err error, values form.Values) template2.HTML {
+	msg := err.Error()
+	if errs, ok := types.GetFieldErrors(err); ok {
+		ctx.SetUserValue(fieldErrorsKey, fieldErrors{errs: errs, values: values})
+		if _, conflict := err.(*types.ConflictError); !conflict {
+			msg = language.Get("please check the fields of the form")
+		}
+	}
+	return aAlert().Warning(msg)
+}
+
+// withFieldErrors render the field errors kept in the context inline.
+func withFieldErrors(ctx *context.Context, info table.FormInfo) table.FormInfo {
+	fe, ok := ctx.UserValue[fieldErrorsKey].(fieldErrors)
+	if !ok {
+		return info
+	}
+	info.FieldList = info.FieldList.WithErrors(fe.errs, fe.values)
+	for i := range info.GroupFieldList {
+		info.GroupFieldList[i] = info.GroupFieldList[i].WithErrors(fe.errs, fe.values)
+	}
+	return info
+}
+
+// formErrorResponse respond the error of the posted form, of which the field
+// errors are in the data by field name, and the conflict of the optimistic
+// lock with the latest version and values.
+func formErrorResponse(ctx *context.Context, err error, data map[string]interface{}) {
+	if errs, ok := types.GetFieldErrors(err); ok {
+		if data == nil {
+			data = make(map[string]interface{})
+		}
+		data["errors"] = errs
+	}
+	if conflict, ok := err.(*types.ConflictError); ok {
+		if data == nil {
+			data = make(map[string]interface{})
+		}
+		data["conflict"] = conflict
+	}
+	if data == nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+	response.Error(ctx, err.Error(), data)
+}
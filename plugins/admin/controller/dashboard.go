@@ -0,0 +1,247 @@
+package controller
+
+import (
+	"html/template"
+	"strconv"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/auth"
+	"github.com/GoAdminGroup/go-admin/modules/language"
+	"github.com/GoAdminGroup/go-admin/modules/logger"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/dashboard"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/response"
+	"github.com/GoAdminGroup/go-admin/template/chartjs"
+	"github.com/GoAdminGroup/go-admin/template/types"
+)
+
+// dashboardColors are the colors of the data sets of the charts in order.
+var dashboardColors = []chartjs.Color{
+	"rgba(60,141,188,0.9)", "rgba(0,166,90,0.9)", "rgba(243,156,18,0.9)", "rgba(221,75,57,0.9)",
+	"rgba(96,92,168,0.9)", "rgba(0,192,239,0.9)", "rgba(210,214,222,0.9)", "rgba(57,204,204,0.9)",
+}
+
+func dashboardColor(i int) chartjs.Color {
+	return dashboardColors[i%len(dashboardColors)]
+}
+
+// ShowDashboards list the dashboards which the login user has the permission
+// to see, which is checked with the menus of the role of the user.
+func (h *Handler) ShowDashboards(ctx *context.Context) {
+
+	user := auth.Auth(ctx)
+
+	items := ""
+	for _, item := range models.Dashboard().SetConn(h.conn).All() {
+		url := user.GetCheckPermissionByUrlMethod(h.routePath("dashboard", "id", strconv.FormatInt(item.Id, 10)),
+			h.route("dashboard").Method())
+		if url == "" {
+			continue
+		}
+		items += `<li class="list-group-item"><a href="` + url + `">` + template.HTMLEscapeString(item.Title) +
+			`</a> <small class="text-muted">` + template.HTMLEscapeString(item.Description) + `</small></li>`
+	}
+
+	body := template.HTML(`<p>` + language.GetFromHtml("no dashboards") + `</p>`)
+	if items != "" {
+		body = template.HTML(`<ul class="list-group">` + items + `</ul>`)
+	}
+
+	h.HTML(ctx, user, types.Panel{
+		Content: aRow().SetContent(aCol().SetSize(types.Size(12, 12, 12)).SetContent(aBox().
+			WithHeadBorder().
+			SetHeader("<b>" + language.GetFromHtml("dashboards") + "</b>").
+			SetBody(body).
+			GetContent()).GetContent()).GetContent(),
+		Title:       language.GetFromHtml("dashboards"),
+		Description: language.GetFromHtml("dashboards"),
+	})
+}
+
+// ShowDashboard show the widgets of the dashboard in the order of the layout.
+func (h *Handler) ShowDashboard(ctx *context.Context) {
+
+	user := auth.Auth(ctx)
+
+	item := models.Dashboard().SetConn(h.conn).Find(ctx.Query("id"))
+	if item.IsEmpty() {
+		h.HTML(ctx, user, types.Panel{
+			Content:     aAlert().Warning(language.Get("dashboard not found")),
+			Title:       language.GetFromHtml("dashboards"),
+			Description: language.GetFromHtml("dashboards"),
+		})
+		return
+	}
+
+	var (
+		cols  = template.HTML("")
+		width = int64(0)
+		rows  = template.HTML("")
+	)
+
+	for _, widget := range item.Widgets() {
+		if widget.Width < 1 || widget.Width > 12 {
+			widget.Width = 6
+		}
+		if width+widget.Width > 12 {
+			rows += aRow().SetContent(cols).GetContent()
+			cols, width = "", 0
+		}
+		cols += aCol().SetSize(types.Size(12, int(widget.Width), int(widget.Width))).
+			SetContent(h.dashboardWidget(ctx, widget)).GetContent()
+		width += widget.Width
+	}
+	if cols != "" {
+		rows += aRow().SetContent(cols).GetContent()
+	}
+
+	h.HTML(ctx, user, types.Panel{
+		Content:     rows,
+		Title:       template.HTML(template.HTMLEscapeString(item.Title)),
+		Description: template.HTML(template.HTMLEscapeString(item.Description)),
+	})
+}
+
+// dashboardWidget return the box of the widget, the error of the data is
+// logged and a failure is shown in the box instead of the content.
+func (h *Handler) dashboardWidget(ctx *context.Context, widget models.DashboardWidgetModel) template.HTML {
+
+	var (
+		title   = template.HTML(template.HTMLEscapeString(widget.Title))
+		content template.HTML
+	)
+
+	data, err := h.dashboardWidgetData(ctx, widget)
+
+	if err != nil {
+		content = template.HTML(`<p class="text-danger">` + language.GetFromHtml("failed to load the data of the widget") + `</p>`)
+	} else {
+		url := ""
+		if widget.Refresh > 0 {
+			url = h.routePath("dashboard_widget_data", "id", strconv.FormatInt(widget.Id, 10))
+		}
+		content = dashboardContent(widget, url, data)
+	}
+
+	return aBox().WithHeadBorder().SetHeader(title).SetBody(content).GetContent()
+}
+
+// DashboardWidgetData return the data of the chart of the widget in the
+// shape of chart.js, which the dashboard reloads every refresh seconds. It
+// is allowed by the permission of the dashboard of the widget.
+func (h *Handler) DashboardWidgetData(ctx *context.Context) {
+
+	widget := models.Dashboard().SetConn(h.conn).FindWidget(ctx.Query("id"))
+	if widget.Id == 0 {
+		response.NotFound(ctx, "dashboard widget not found")
+		return
+	}
+
+	if auth.Auth(ctx).GetCheckPermissionByUrlMethod(h.routePath("dashboard", "id", strconv.FormatInt(widget.DashboardId, 10)),
+		h.route("dashboard").Method()) == "" {
+		response.Forbidden(ctx, "permission denied")
+		return
+	}
+
+	data, err := h.dashboardWidgetData(ctx, widget)
+	if err != nil {
+		response.Error(ctx, "failed to load the data of the widget")
+		return
+	}
+
+	chartData := dashboardChartData(widget.Type, data)
+
+	response.OkWithData(ctx, map[string]interface{}{
+		"labels":   chartData.Labels,
+		"datasets": chartData.DataSets,
+	})
+}
+
+func (h *Handler) dashboardWidgetData(ctx *context.Context, widget models.DashboardWidgetModel) (dashboard.Data, error) {
+	data, err := dashboard.Widget{
+		Title:  widget.Title,
+		Type:   widget.Type,
+		Source: widget.Source,
+		Query:  widget.Query,
+		Width:  int(widget.Width),
+	}.GetData(ctx.Request.Context(), h.conn)
+	if err != nil {
+		logger.Error("dashboard widget error", widget.Id, err)
+	}
+	return data, err
+}
+
+// dashboardChartData return the chart data of the widget, a pie has only the
+// first data set.
+func dashboardChartData(typ string, data dashboard.Data) *chartjs.Data {
+	names, values := data.DataSets()
+	chartData := chartjs.NewData(data.Labels())
+	for i, name := range names {
+		chartData.AddDataSet(name, values[i])
+		if typ == dashboard.Pie {
+			break
+		}
+	}
+	return chartData
+}
+
+// dashboardContent return the content of the data by the type of the widget,
+// the charts are bound to the data url if it is not empty.
+func dashboardContent(widget models.DashboardWidgetModel, url string, data dashboard.Data) template.HTML {
+
+	var (
+		id            = "dashboard-widget-" + strconv.FormatInt(widget.Id, 10)
+		labels        = data.Labels()
+		names, values = data.DataSets()
+		refresh       = int(widget.Refresh)
+	)
+
+	switch widget.Type {
+	case dashboard.Line:
+		chart := chartjs.Line().SetID(id).SetHeight(180).SetLabels(labels).SetDataURL(url).SetRefreshInterval(refresh)
+		for i, name := range names {
+			chart.AddDataSet(name).DSData(values[i]).DSBorderColor(dashboardColor(i)).DSFill(false)
+		}
+		return chart.GetContent()
+	case dashboard.Bar:
+		chart := chartjs.Bar().SetID(id).SetHeight(180).SetLabels(labels).SetDataURL(url).SetRefreshInterval(refresh)
+		for i, name := range names {
+			chart.AddDataSet(name).DSData(values[i]).DSBackgroundColor(dashboardColor(i))
+		}
+		return chart.GetContent()
+	case dashboard.Pie:
+		chart := chartjs.Pie().SetID(id).SetHeight(180).SetLabels(labels).SetDataURL(url).SetRefreshInterval(refresh)
+		if len(names) > 0 {
+			colors := make([]chartjs.Color, len(labels))
+			for i := range colors {
+				colors[i] = dashboardColor(i)
+			}
+			chart.AddDataSet(names[0]).DSData(values[0]).DSBackgroundColor(colors)
+		}
+		return chart.GetContent()
+	case dashboard.Radar:
+		chart := chartjs.Radar().SetID(id).SetHeight(180).SetLabels(labels).SetDataURL(url).SetRefreshInterval(refresh)
+		for i, name := range names {
+			chart.AddDataSet(name).DSData(values[i]).DSBorderColor(dashboardColor(i)).DSFill(false)
+		}
+		return chart.GetContent()
+	case dashboard.Stat:
+		return template.HTML(`<h2 class="text-center">` + template.HTMLEscapeString(data.Value()) + `</h2>`)
+	default:
+		thead := make(types.Thead, len(data.Columns))
+		for i, column := range data.Columns {
+			thead[i] = types.TheadItem{Head: column, Field: column}
+		}
+		list := make([]map[string]types.InfoItem, len(data.Rows))
+		for i, row := range data.Rows {
+			list[i] = make(map[string]types.InfoItem, len(data.Columns))
+			for j, column := range data.Columns {
+				if j < len(row) {
+					value := dashboard.String(row[j])
+					list[i][column] = types.InfoItem{Content: template.HTML(template.HTMLEscapeString(value)), Value: value}
+				}
+			}
+		}
+		return aTable().SetThead(thead).SetInfoList(list).SetType("table").GetContent()
+	}
+}
@@ -0,0 +1,25 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/dashboard"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestDashboardChartData(t *testing.T) {
+	data := dashboard.Data{
+		Columns: []string{"day", "visits", "orders"},
+		Rows:    [][]interface{}{{"mon", int64(10), int64(3)}, {"tue", int64(12), int64(5)}},
+	}
+
+	chartData := dashboardChartData(dashboard.Line, data)
+	assert.Equal(t, chartData.Labels, []string{"mon", "tue"})
+	assert.Equal(t, len(chartData.DataSets), 2)
+	assert.Equal(t, chartData.DataSets[1].Label, "orders")
+	assert.Equal(t, chartData.DataSets[1].Data, []float64{3, 5})
+
+	chartData = dashboardChartData(dashboard.Pie, data)
+	assert.Equal(t, len(chartData.DataSets), 1)
+	assert.Equal(t, chartData.DataSets[0].Label, "visits")
+}
@@ -2,10 +2,12 @@ package controller
 
 import (
 	"fmt"
+	template2 "html/template"
 
 	"github.com/GoAdminGroup/go-admin/context"
 	"github.com/GoAdminGroup/go-admin/modules/auth"
 	"github.com/GoAdminGroup/go-admin/modules/language"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/constant"
 	form2 "github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
@@ -133,7 +135,7 @@ $('.delete-btn').on('click', function (event) {
 		}
 	}
 
-	formInfo, err := newPanel.GetDataWithId(param.WithPKs(id))
+	formInfo, err := newPanel.GetDataWithId(param.WithContext(ctx.Request.Context()).WithPKs(id))
 
 	if err != nil {
 		h.HTML(ctx, user, template.WarningPanelWithDescAndTitle(err.Error(), desc, title),
@@ -150,8 +152,48 @@ $('.delete-btn').on('click', function (event) {
 			SetHiddenFields(map[string]string{
 				form2.PreviousKey: infoUrl,
 			}).
-			SetPrefix(h.config.PrefixFixSlash()), editUrl, deleteUrl, !isNotIframe),
+			SetPrefix(h.config.PrefixFixSlash()), editUrl, deleteUrl, !isNotIframe,
+			h.auditHistory(formModel.Table, id)),
 		Description: template.HTML(desc),
 		Title:       template.HTML(title),
 	}, template.ExecuteOptions{Animation: param.Animation})
 }
+
+// auditHistory return the table of the audit log of the record, or empty if
+// the record has no audit log.
+func (h *Handler) auditHistory(table, id string) template2.HTML {
+	if table == "" {
+		return ""
+	}
+	logs, err := models.AuditLog().SetConn(h.conn).FindByRecord(table, id)
+	if err != nil || len(logs) == 0 {
+		return ""
+	}
+
+	list := make([]map[string]types.InfoItem, 0)
+	for _, log := range logs {
+		for _, change := range log.Changes {
+			list = append(list, map[string]types.InfoItem{
+				"created_at": {Content: template2.HTML(template2.HTMLEscapeString(log.CreatedAt))},
+				"user_name":  {Content: template2.HTML(template2.HTMLEscapeString(log.UserName))},
+				"action":     {Content: language.GetFromHtml(template2.HTML(log.Action))},
+				"field":      {Content: template2.HTML(template2.HTMLEscapeString(change.Field))},
+				"old":        {Content: template2.HTML(template2.HTMLEscapeString(change.Old))},
+				"new":        {Content: template2.HTML(template2.HTMLEscapeString(change.New))},
+			})
+		}
+	}
+
+	return aTable().
+		SetStyle("striped").
+		SetMinWidth("0.01%").
+		SetThead(types.Thead{
+			types.TheadItem{Head: language.Get("createdAt"), Field: "created_at"},
+			types.TheadItem{Head: language.Get("operator"), Field: "user_name"},
+			types.TheadItem{Head: language.Get("action"), Field: "action"},
+			types.TheadItem{Head: language.Get("field"), Field: "field"},
+			types.TheadItem{Head: language.Get("old value"), Field: "old"},
+			types.TheadItem{Head: language.Get("new value"), Field: "new"},
+		}).
+		SetInfoList(list).GetContent()
+}
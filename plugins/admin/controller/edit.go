@@ -58,7 +58,7 @@ func (h *Handler) showForm(ctx *context.Context, alert template2.HTML, prefix st
 		footerKind = "edit_only"
 	}
 
-	formInfo, err := panel.GetDataWithId(param)
+	formInfo, err := panel.GetDataWithId(param.WithContext(ctx.Request.Context()))
 
 	if err != nil {
 		logger.Error("receive data error: ", err)
@@ -73,6 +73,8 @@ func (h *Handler) showForm(ctx *context.Context, alert template2.HTML, prefix st
 		return
 	}
 
+	formInfo = withFieldErrors(ctx, formInfo)
+
 	showEditUrl := h.routePathWithPrefix("show_edit", prefix) + param.DeletePK().GetRouteParamStr()
 	infoUrl := h.routePathWithPrefix("info", prefix) + param.DeleteField(constant.EditPKKey).GetRouteParamStr()
 	editUrl := h.routePathWithPrefix("edit", prefix)
@@ -91,6 +93,10 @@ func (h *Handler) showForm(ctx *context.Context, alert template2.HTML, prefix st
 		form2.PreviousKey: infoUrl,
 	}
 
+	if formInfo.Version != "" {
+		hiddenFields[form2.VersionKey] = formInfo.Version
+	}
+
 	if ctx.Query(constant.IframeKey) != "" {
 		hiddenFields[constant.IframeKey] = ctx.Query(constant.IframeKey)
 	}
@@ -173,11 +179,11 @@ func (h *Handler) EditForm(ctx *context.Context) {
 	if err != nil {
 		logger.Error("update data error: ", err)
 		if ctx.WantJSON() {
-			response.Error(ctx, err.Error(), map[string]interface{}{
+			formErrorResponse(ctx, err, map[string]interface{}{
 				"token": h.authSrv().AddToken(),
 			})
 		} else {
-			h.showForm(ctx, aAlert().Warning(err.Error()), param.Prefix, param.Param, true)
+			h.showForm(ctx, formErrorAlert(ctx, err, param.Value()), param.Prefix, param.Param, true)
 		}
 		return
 	}
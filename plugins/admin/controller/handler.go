@@ -83,7 +83,7 @@ func (h *Handler) setFormWithReturnErrMessage(ctx *context.Context, errMsg strin
 		formInfo, _ = panel.GetDataWithId(parameter.GetParam(ctx.Request.URL,
 			panel.GetInfo().DefaultPageSize,
 			panel.GetInfo().SortField,
-			panel.GetInfo().GetSort()).WithPKs(id))
+			panel.GetInfo().GetSort()).WithContext(ctx.Request.Context()).WithPKs(id))
 		btnWord = f.FormEditBtnWord
 	} else {
 		f = panel.GetActualNewForm()
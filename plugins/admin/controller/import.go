@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"fmt"
+	template2 "html/template"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/errors"
+	"github.com/GoAdminGroup/go-admin/modules/language"
+	"github.com/GoAdminGroup/go-admin/modules/logger"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/guard"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/importer"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/response"
+)
+
+// Import inserts the rows of the uploaded CSV/XLSX file into the table and
+// responds with the report of every row. Nothing is inserted if any row
+// fails or when it is a dry run. The csrf token of the import is used, so
+// the response carries a new one for the next import.
+func (h *Handler) Import(ctx *context.Context) {
+
+	param := guard.GetImportParam(ctx)
+	defer func() {
+		_ = param.File.Close()
+	}()
+
+	token := map[string]interface{}{"token": h.authSrv().AddToken()}
+
+	records, err := param.Importer.Read(param.File)
+	if err != nil {
+		logger.Error("import error: ", err)
+		response.Error(ctx, errors.WrongFile, token)
+		return
+	}
+
+	panel := h.table(param.Prefix, ctx)
+	rows, unmatched := importer.Values(records, panel.GetActualNewForm().FieldList)
+
+	res, err := panel.ImportData(rows, param.DryRun)
+	if err != nil {
+		logger.Error("import error: ", err)
+		response.Error(ctx, "import fail", token)
+		return
+	}
+
+	data := map[string]interface{}{
+		"result":    res,
+		"unmatched": unmatched,
+		"token":     token["token"],
+	}
+
+	if len(res.Errors) > 0 && !param.DryRun {
+		response.Error(ctx, "import fail", data)
+		return
+	}
+
+	response.OkWithData(ctx, data)
+}
+
+// importJs adds the import button into the table header, which uploads the
+// chosen file with the csrf token and shows the report.
+func importJs(importUrl, token string) template2.JS {
+	return template2.JS(fmt.Sprintf(`
+$(function () {
+    let token = %q;
+    let input = $('<input type="file" accept=".csv,.xlsx" style="display:none">');
+    let dryRun = false;
+    let group = $('<div class="btn-group pull-right" style="margin-right: 10px">' +
+        '<a class="btn btn-sm btn-default import-btn" data-dry-run="false"></a>' +
+        '<button type="button" class="btn btn-sm btn-default dropdown-toggle" data-toggle="dropdown">' +
+        '<span class="caret"></span></button>' +
+        '<ul class="dropdown-menu" role="menu"><li><a href="#" class="import-btn" data-dry-run="true"></a></li></ul></div>');
+    group.find("a.import-btn").first().text(%q);
+    group.find("ul a.import-btn").text(%q);
+    group.append(input);
+    let anchor = $(".box-header .btn-group.pull-right").last();
+    if (anchor.length > 0) {
+        anchor.after(group);
+    } else {
+        $(".box-header").first().prepend(group);
+    }
+    group.find(".import-btn").click(function () {
+        dryRun = $(this).data("dry-run") === true;
+        input.val("").click();
+        return false;
+    });
+    input.on("change", function () {
+        let data = new FormData();
+        data.append("file", input.prop("files")[0]);
+        data.append("dry_run", dryRun ? "true" : "false");
+        data.append(%q, token);
+        NProgress.start();
+        $.ajax({
+            url: %q,
+            type: "post",
+            data: data,
+            cache: false,
+            processData: false,
+            contentType: false,
+            complete: function (xhr) {
+                NProgress.done();
+                let res = xhr.responseJSON || {};
+                if (res.data && res.data.token) {
+                    token = res.data.token;
+                }
+                let report = res.data ? res.data.result : null;
+                if (!report) {
+                    swal(res.msg || "error", "", "error");
+                    return;
+                }
+                let lines = [%q + ": " + report.total, %q + ": " + report.inserted];
+                report.errors.slice(0, 10).forEach(function (e) {
+                    lines.push("#" + e.row + ": " + e.msg);
+                });
+                if (res.data.unmatched.length > 0) {
+                    lines.push(%q + ": " + res.data.unmatched.join(", "));
+                }
+                swal(res.msg, lines.join("\n"), report.errors.length > 0 ? "error" : "success");
+                if (!report.dry_run && report.errors.length === 0) {
+                    $.pjax.reload("#pjax-container");
+                }
+            }
+        });
+    });
+});`, token, language.Get("import"), language.Get("dry run"), form.TokenKey, importUrl,
+		language.Get("total"), language.Get("inserted"), language.Get("unmatched columns")))
+}
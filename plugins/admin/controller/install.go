@@ -2,71 +2,145 @@ package controller
 
 import (
 	"bytes"
-	"database/sql"
 	"net/http"
 
 	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/logger"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/install"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/response"
+	installation "github.com/GoAdminGroup/go-admin/template/installation"
 )
 
-// ShowInstall show install page.
+// ShowInstall show install page, which is locked once the installation is done.
 func (h *Handler) ShowInstall(ctx *context.Context) {
 
-	buffer := new(bytes.Buffer)
-	//template.GetInstallPage(buffer)
+	locked := install.Locked(h.conn)
 
-	//rs, _ := mysql.Query("show tables;")
-	//fmt.Println(rs[0]["Tables_in_godmin"])
+	var (
+		token string
+		buf   = new(bytes.Buffer)
+	)
 
-	//rs2, _ := mysql.Query("show columns from users")
-	//fmt.Println(rs2[0]["Field"])
+	if !locked {
+		token = h.authSrv().AddToken()
+	}
 
-	ctx.HTML(http.StatusOK, buffer.String())
+	tmpl, name := installation.Get().GetTemplate()
+	if err := tmpl.ExecuteTemplate(buf, name, struct {
+		UrlPrefix string
+		Title     string
+		CdnUrl    string
+		LoginUrl  string
+		Locked    bool
+		Drivers   []string
+		Token     string
+	}{
+		UrlPrefix: h.config.AssertPrefix(),
+		Title:     h.config.Title,
+		CdnUrl:    h.config.AssetUrl,
+		LoginUrl:  h.config.Url(h.config.LoginUrl),
+		Locked:    locked,
+		Drivers:   install.Drivers(),
+		Token:     token,
+	}); err == nil {
+		ctx.HTML(http.StatusOK, buf.String())
+	} else {
+		logger.Error(err)
+		ctx.HTML(http.StatusOK, "parse template error (；′⌒`)")
+	}
 }
 
-// CheckDatabase check the database connection.
+// CheckDatabase check the database connection, and that the database has no
+// system tables.
 func (h *Handler) CheckDatabase(ctx *context.Context) {
 
-	ip := ctx.FormValue("h")
-	port := ctx.FormValue("po")
-	username := ctx.FormValue("u")
-	password := ctx.FormValue("pa")
-	databaseName := ctx.FormValue("db")
-
-	SqlDB, err := sql.Open("mysql", username+":"+password+"@tcp("+ip+":"+port+")/"+databaseName+"?charset=utf8mb4")
-	if SqlDB != nil {
-		if SqlDB.Ping() != nil {
-			response.Error(ctx, "请检查参数是否设置正确")
-			return
-		}
+	if !h.checkInstall(ctx) {
+		return
 	}
 
-	defer func() {
-		_ = SqlDB.Close()
-	}()
-
-	if err != nil {
-		response.Error(ctx, "请检查参数是否设置正确")
+	if err := install.Check(installDatabase(ctx)); err != nil {
+		h.installError(ctx, err.Error())
 		return
+	}
+
+	response.OkWithData(ctx, map[string]interface{}{
+		"token": h.authSrv().AddToken(),
+	})
+}
 
+// Install create the system tables, the super administrator and the config
+// file, and then lock the installation.
+func (h *Handler) Install(ctx *context.Context) {
+
+	if !h.checkInstall(ctx) {
+		return
 	}
 
-	//db.InitDB(username, password, port, ip, databaseName, 100, 100)
+	param := install.Param{
+		Database:   installDatabase(ctx),
+		UserName:   ctx.FormValue("username"),
+		Name:       ctx.FormValue("nickname"),
+		Password:   ctx.FormValue("password"),
+		ConfigPath: ctx.FormValue("config"),
+	}
 
-	tables := make([]map[string]interface{}, 0)
+	if param.UserName == "" || param.Password == "" {
+		h.installError(ctx, "username and password can not be empty")
+		return
+	}
 
-	list := "["
+	if param.Password != ctx.FormValue("password_again") {
+		h.installError(ctx, "password does not match")
+		return
+	}
 
-	for i := 0; i < len(tables); i++ {
-		if i != len(tables)-1 {
-			list += `"` + tables[i]["Tables_in_godmin"].(string) + `",`
-		} else {
-			list += `"` + tables[i]["Tables_in_godmin"].(string) + `"`
-		}
+	if err := install.Install(param); err != nil {
+		h.installError(ctx, err.Error())
+		return
 	}
-	list += "]"
 
 	response.OkWithData(ctx, map[string]interface{}{
-		"list": list,
+		"config": param.ConfigPath,
+		"url":    h.config.Url(h.config.LoginUrl),
+	})
+}
+
+// checkInstall check the installation is not locked, the one-time token of
+// the install pages printed to the log at the startup and the csrf token.
+func (h *Handler) checkInstall(ctx *context.Context) bool {
+	if install.Locked(h.conn) {
+		response.BadRequest(ctx, "the installation is locked")
+		return false
+	}
+	if !install.CheckToken(ctx.FormValue("install_token")) {
+		response.BadRequest(ctx, "wrong install token")
+		return false
+	}
+	if !h.authSrv().CheckToken(ctx.FormValue(form.TokenKey)) {
+		response.BadRequest(ctx, "wrong token")
+		return false
+	}
+	return true
+}
+
+// installError respond the error with a new token, as the token of the
+// request is used.
+func (h *Handler) installError(ctx *context.Context, msg string) {
+	response.Error(ctx, msg, map[string]interface{}{
+		"token": h.authSrv().AddToken(),
 	})
 }
+
+func installDatabase(ctx *context.Context) config.Database {
+	return config.Database{
+		Driver: ctx.FormValue("driver"),
+		Host:   ctx.FormValue("host"),
+		Port:   ctx.FormValue("port"),
+		User:   ctx.FormValue("user"),
+		Pwd:    ctx.FormValue("pwd"),
+		Name:   ctx.FormValue("name"),
+		File:   ctx.FormValue("file"),
+	}
+}
@@ -33,7 +33,7 @@ func (h *Handler) showNewForm(ctx *context.Context, alert template2.HTML, prefix
 	var (
 		user        = auth.Auth(ctx)
 		panel       = h.table(prefix, ctx)
-		formInfo    = panel.GetNewFormInfo()
+		formInfo    = withFieldErrors(ctx, panel.GetNewFormInfo())
 		infoUrl     = h.routePathWithPrefix("info", prefix) + paramStr
 		newUrl      = h.routePathWithPrefix("new", prefix)
 		showNewUrl  = h.routePathWithPrefix("show_new", prefix) + paramStr
@@ -117,11 +117,11 @@ func (h *Handler) NewForm(ctx *context.Context) {
 	if err != nil {
 		logger.Error("insert data error: ", err)
 		if ctx.WantJSON() {
-			response.Error(ctx, err.Error(), map[string]interface{}{
+			formErrorResponse(ctx, err, map[string]interface{}{
 				"token": h.authSrv().AddToken(),
 			})
 		} else {
-			h.showNewForm(ctx, aAlert().Warning(err.Error()), param.Prefix, param.Param.GetRouteParamStr(), true)
+			h.showNewForm(ctx, formErrorAlert(ctx, err, param.Value()), param.Prefix, param.Param.GetRouteParamStr(), true)
 		}
 		return
 	}
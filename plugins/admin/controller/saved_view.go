@@ -0,0 +1,243 @@
+package controller
+
+import (
+	"fmt"
+	template2 "html/template"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/auth"
+	"github.com/GoAdminGroup/go-admin/modules/language"
+	"github.com/GoAdminGroup/go-admin/modules/logger"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/constant"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/parameter"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/response"
+)
+
+// SaveView save the query parameters of the info page of the table as a view
+// of the login user, which is also shared with the role of the user if it is
+// asked.
+func (h *Handler) SaveView(ctx *context.Context) {
+
+	var (
+		user   = auth.Auth(ctx)
+		prefix = ctx.Query(constant.PrefixKey)
+		name   = strings.TrimSpace(ctx.FormValue("name"))
+	)
+
+	if !h.authSrv().CheckToken(ctx.FormValue(form.TokenKey)) {
+		response.BadRequest(ctx, "wrong token")
+		return
+	}
+
+	if user.IsEmpty() {
+		response.BadRequest(ctx, "saved views need a local user")
+		return
+	}
+
+	if user.GetCheckPermissionByUrlMethod(h.routePathWithPrefix("info", prefix), h.route("info").Method()) == "" {
+		response.Forbidden(ctx, "permission denied")
+		return
+	}
+
+	if name == "" || utf8.RuneCountInString(name) > 100 {
+		response.BadRequest(ctx, "wrong name")
+		return
+	}
+
+	values, err := url.ParseQuery(ctx.FormValue("params"))
+	if err != nil {
+		response.BadRequest(ctx, "wrong params")
+		return
+	}
+	values.Del(parameter.Page)
+	values.Del(parameter.CursorKey)
+
+	params := values.Encode()
+	if len(params) > 2000 {
+		response.BadRequest(ctx, "wrong params")
+		return
+	}
+
+	_, err = models.SavedView().SetConn(h.conn).New(user.Id, prefix, name, params,
+		ctx.FormValue("share") == "1", user.Role)
+	if err != nil {
+		logger.Error("save view error", err)
+		response.Error(ctx, err.Error())
+		return
+	}
+
+	response.Ok(ctx)
+}
+
+// DeleteSavedView delete the saved view of the login user.
+func (h *Handler) DeleteSavedView(ctx *context.Context) {
+
+	if !h.authSrv().CheckToken(ctx.FormValue(form.TokenKey)) {
+		response.BadRequest(ctx, "wrong token")
+		return
+	}
+
+	user := auth.Auth(ctx)
+
+	id, err := strconv.ParseInt(ctx.FormValue("id"), 10, 64)
+	if err != nil || user.IsEmpty() {
+		response.BadRequest(ctx, "wrong id")
+		return
+	}
+
+	if err := models.SavedView().SetConn(h.conn).Delete(id, user.Id); err != nil {
+		logger.Error("delete saved view error", err)
+		response.Error(ctx, "delete fail")
+		return
+	}
+
+	response.Ok(ctx)
+}
+
+// SavedViews return the saved views of the info page of the table for the
+// login user, with the csrf token to save or delete a view. They are loaded
+// when the dropdown of the saved views is opened.
+func (h *Handler) SavedViews(ctx *context.Context) {
+
+	var (
+		user    = auth.Auth(ctx)
+		prefix  = ctx.Query(constant.PrefixKey)
+		infoUrl = h.routePathWithPrefix("info", prefix)
+	)
+
+	if user.IsEmpty() {
+		response.BadRequest(ctx, "saved views need a local user")
+		return
+	}
+
+	if user.GetCheckPermissionByUrlMethod(infoUrl, h.route("info").Method()) == "" {
+		response.Forbidden(ctx, "permission denied")
+		return
+	}
+
+	views := models.SavedView().SetConn(h.conn).List(user.Id, user.Role, prefix)
+	items := make([]map[string]interface{}, len(views))
+	for i, view := range views {
+		items[i] = map[string]interface{}{
+			"id":     view.Id,
+			"name":   view.Name,
+			"url":    infoUrl + "?" + view.Params,
+			"shared": view.Shared,
+			"own":    view.UserId == user.Id,
+		}
+	}
+
+	response.OkWithData(ctx, map[string]interface{}{
+		"views": items,
+		"token": h.authSrv().AddToken(),
+	})
+}
+
+// savedViewsJs adds the dropdown of the saved views into the table header,
+// which also saves the current view of the info page. The views are loaded
+// when the dropdown is opened.
+func savedViewsJs(listUrl, saveUrl, deleteUrl, params string) template2.JS {
+	return template2.JS(fmt.Sprintf(`
+$(function () {
+    let token = "";
+    let post = function (url, data) {
+        data[%q] = token;
+        $.ajax({
+            method: "post",
+            url: url,
+            data: data,
+            success: function (data) {
+                if (typeof (data) === "string") {
+                    data = JSON.parse(data);
+                }
+                if (data.code === 200) {
+                    swal.close();
+                    $.pjax.reload("#pjax-container");
+                } else {
+                    swal(data.msg, "", "error");
+                }
+            },
+            error: function (data) {
+                swal(data.responseJSON ? data.responseJSON.msg : "error", "", "error");
+            }
+        });
+    };
+    let menu = $('<ul class="dropdown-menu dropdown-menu-right"></ul>');
+    let render = function (views) {
+        menu.empty();
+        views.forEach(function (view) {
+            let link = $('<a></a>').attr("href", view.url).text(view.name);
+            if (view.shared) {
+                link.append(' <i class="fa fa-users"></i>');
+            }
+            if (view.own) {
+                link.append($('<i class="fa fa-trash pull-right" style="margin-top: 3px"></i>').click(function () {
+                    post(%q, {id: view.id});
+                    return false;
+                }));
+            }
+            menu.append($("<li>").append(link));
+        });
+        if (views.length > 0) {
+            menu.append('<li class="divider"></li>');
+        }
+        [{share: "0", label: %q}, {share: "1", label: %q}].forEach(function (item) {
+            menu.append($("<li>").append($('<a href="#"></a>').text(item.label).click(function () {
+                swal({
+                    title: item.label,
+                    type: "input",
+                    showCancelButton: true,
+                    closeOnConfirm: false,
+                    confirmButtonText: %q,
+                    cancelButtonText: %q,
+                    inputPlaceholder: %q
+                }, function (name) {
+                    if (name === false) {
+                        return false;
+                    }
+                    if (name.trim() === "") {
+                        swal.showInputError(%q);
+                        return false;
+                    }
+                    post(%q, {name: name, params: %q, share: item.share});
+                });
+                return false;
+            })));
+        });
+    };
+    let btn = $('<div class="btn-group pull-right" style="margin-right: 10px">' +
+        '<a class="btn btn-sm btn-default dropdown-toggle" data-toggle="dropdown"><i class="fa fa-bookmark"></i> ' +
+        '<span class="hidden-xs"></span> <span class="caret"></span></a></div>');
+    btn.find(".hidden-xs").text(%q);
+    btn.append(menu);
+    btn.on("show.bs.dropdown", function () {
+        $.ajax({
+            method: "get",
+            url: %q,
+            success: function (data) {
+                if (typeof (data) === "string") {
+                    data = JSON.parse(data);
+                }
+                if (data.code === 200) {
+                    token = data.data.token;
+                    render(data.data.views);
+                }
+            }
+        });
+    });
+    let anchor = $(".box-header .btn-group.pull-right").last();
+    if (anchor.length > 0) {
+        anchor.after(btn);
+    } else {
+        $(".box-header").first().prepend(btn);
+    }
+});`, form.TokenKey, deleteUrl, language.Get("save the current view"), language.Get("save and share with my role"),
+		language.Get("save"), language.Get("cancel"), language.Get("view name"), language.Get("view name can not be empty"),
+		saveUrl, params, language.Get("saved views"), listUrl))
+}
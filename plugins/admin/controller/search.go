@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"fmt"
+	"html/template"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/auth"
+	"github.com/GoAdminGroup/go-admin/modules/language"
+	"github.com/GoAdminGroup/go-admin/modules/logger"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/constant"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/parameter"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/table"
+	"github.com/GoAdminGroup/go-admin/template/types"
+)
+
+// searchPageSize is the count of the rows of each table in the results of
+// the global search.
+const searchPageSize = 5
+
+// Search query the keyword in the searchable fields of the tables which the
+// login user has the permission to see, the results are grouped by table.
+func (h *Handler) Search(ctx *context.Context) {
+
+	var (
+		user    = auth.Auth(ctx)
+		keyword = strings.TrimSpace(ctx.Query("q"))
+		body    = template.HTML("")
+		found   = false
+	)
+
+	if utf8.RuneCountInString(keyword) > 100 {
+		keyword = string([]rune(keyword)[:100])
+	}
+
+	if keyword != "" {
+		prefixes := make([]string, 0, len(h.generators))
+		for prefix := range h.generators {
+			prefixes = append(prefixes, prefix)
+		}
+		sort.Strings(prefixes)
+
+		for _, prefix := range prefixes {
+			if user.GetCheckPermissionByUrlMethod(h.routePathWithPrefix("info", prefix), h.route("info").Method()) == "" {
+				continue
+			}
+
+			// the tables are generated for each search, since they can
+			// depend on the login user.
+			panel := h.generators[prefix](ctx)
+			if !table.Searchable(panel) {
+				continue
+			}
+
+			info := panel.GetInfo()
+			params := parameter.GetParam(&url.URL{}, searchPageSize, panel.GetPrimaryKey().Name, info.GetSort()).
+				WithContext(ctx.Request.Context()).
+				WithSearch(keyword)
+
+			panelInfo, err := panel.GetData(params)
+			if err != nil {
+				logger.Error("global search error", prefix, err)
+				continue
+			}
+			if len(panelInfo.InfoList) == 0 {
+				continue
+			}
+
+			found = true
+			body += searchResults(info, panelInfo, panel.GetPrimaryKey().Name,
+				user.GetCheckPermissionByUrlMethod(modules.AorEmpty(!info.IsHideDetailButton,
+					h.routePathWithPrefix("detail", prefix)+parameter.BaseParam().GetRouteParamStr()), h.route("detail").Method()),
+				h.routePathWithPrefix("info", prefix))
+		}
+	}
+
+	if keyword != "" && !found {
+		body = template.HTML(`<p>` + language.GetFromHtml("no results") + `</p>`)
+	}
+
+	form := template.HTML(`<form class="form-inline" action="` + h.config.Url("/search") + `" method="get">
+	<input type="text" class="form-control" name="q" value="` + template.HTMLEscapeString(keyword) + `" placeholder="` +
+		language.Get("global search") + `"/>
+	<button type="submit" class="btn btn-primary"><i class="fa fa-search"></i></button>
+</form><br>`)
+
+	h.HTML(ctx, user, types.Panel{
+		Content: aRow().SetContent(aCol().SetSize(types.Size(12, 12, 12)).SetContent(aBox().
+			WithHeadBorder().
+			SetHeader("<b>" + language.GetFromHtml("search results") + "</b>").
+			SetBody(form + body).
+			GetContent()).GetContent()).GetContent(),
+		Title:       language.GetFromHtml("global search"),
+		Description: language.GetFromHtml("search results"),
+	})
+}
+
+// searchResults return the results of the table, the rows are linked to the
+// detail page if the detail url is not empty.
+func searchResults(info *types.InfoPanel, panelInfo table.PanelInfo, pk, detailUrl, infoUrl string) template.HTML {
+
+	fields := make([]string, 0)
+	for _, field := range info.FieldList {
+		if field.Searchable {
+			fields = append(fields, field.Field)
+		}
+	}
+
+	items := ""
+	for _, row := range panelInfo.InfoList {
+		values := make([]string, 0, len(fields))
+		for _, field := range fields {
+			if value := row[field].Value; value != "" {
+				values = append(values, template.HTMLEscapeString(value))
+			}
+		}
+		id := row[pk].Value
+		label := "#" + template.HTMLEscapeString(id) + " " + strings.Join(values, " / ")
+		if detailUrl != "" {
+			label = `<a href="` + detailUrl + "&" + constant.DetailPKKey + "=" + url.QueryEscape(id) + `">` + label + `</a>`
+		}
+		items += `<li class="list-group-item">` + label + `</li>`
+	}
+
+	total := ""
+	if panelInfo.Total > 0 {
+		total = fmt.Sprintf(" <small>%d</small>", panelInfo.Total)
+	}
+
+	return template.HTML(fmt.Sprintf(`<h4><a href="%s">%s</a>%s</h4><ul class="list-group">%s</ul>`,
+		infoUrl, template.HTMLEscapeString(info.Title), total, items))
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/md5"
 	"fmt"
 	template2 "html/template"
+	"io"
 	"mime"
 	"net/http"
 	"path"
@@ -12,7 +13,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/360EntSecGroup-Skylar/excelize"
 	"github.com/GoAdminGroup/go-admin/context"
 	"github.com/GoAdminGroup/go-admin/modules/auth"
 	"github.com/GoAdminGroup/go-admin/modules/errors"
@@ -20,6 +20,7 @@ import (
 	"github.com/GoAdminGroup/go-admin/modules/logger"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/constant"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/exporter"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/guard"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/parameter"
@@ -67,7 +68,7 @@ func (h *Handler) showTableData(ctx *context.Context, prefix string, params para
 		panel = h.table(prefix, ctx)
 	}
 
-	panelInfo, err := panel.GetData(params.WithIsAll(false))
+	panelInfo, err := panel.GetData(params.WithContext(ctx.Request.Context()).WithIsAll(false))
 
 	if err != nil {
 		return panel, panelInfo, nil, err
@@ -88,9 +89,18 @@ func (h *Handler) showTableData(ctx *context.Context, prefix string, params para
 		user = auth.Auth(ctx)
 	)
 
+	// the rows in the trash can only be restored or purged, the delete
+	// button purges them.
+	if panel.GetSoftDelete() && params.IsTrash() {
+		editUrl, newUrl, exportUrl, detailUrl = "", "", "", ""
+		deleteUrl = modules.AorEmpty(!panel.GetInfo().IsHideDeleteButton, h.routePathWithPrefix(urlNamePrefix+"purge", prefix)+paramStr)
+		deleteUrl = user.GetCheckPermissionByUrlMethod(deleteUrl, h.route(urlNamePrefix+"purge").Method())
+	} else {
+		deleteUrl = user.GetCheckPermissionByUrlMethod(deleteUrl, h.route(urlNamePrefix+"delete").Method())
+	}
+
 	editUrl = user.GetCheckPermissionByUrlMethod(editUrl, h.route(urlNamePrefix+"show_edit").Method())
 	newUrl = user.GetCheckPermissionByUrlMethod(newUrl, h.route(urlNamePrefix+"show_new").Method())
-	deleteUrl = user.GetCheckPermissionByUrlMethod(deleteUrl, h.route(urlNamePrefix+"delete").Method())
 	exportUrl = user.GetCheckPermissionByUrlMethod(exportUrl, h.route(urlNamePrefix+"export").Method())
 	detailUrl = user.GetCheckPermissionByUrlMethod(detailUrl, h.route(urlNamePrefix+"detail").Method())
 
@@ -130,8 +140,23 @@ func (h *Handler) showTable(ctx *context.Context, prefix string, params paramete
 		info          = panel.GetInfo()
 		actionBtns    = info.Action
 		allActionBtns = info.ActionButtons.CheckPermissionWhenURLAndMethodNotEmpty(user)
+		trash         = panel.GetSoftDelete() && params.IsTrash()
+		hiddenFields  = map[string]string{form.NoAnimationKey: "true"}
+		resetUrl      = infoUrl
 	)
 
+	if trash {
+		actionBtns = ""
+		allActionBtns = make(types.Buttons, 0)
+		restoreUrl := user.GetCheckPermissionByUrlMethod(h.routePathWithPrefix("restore", prefix), h.route("restore").Method())
+		if restoreUrl != "" && panel.GetDeletable() {
+			allActionBtns = append(allActionBtns, types.GetActionButton(language.GetFromHtml("restore"),
+				types.NewDefaultAction("", "", "", restoreJs(restoreUrl)), "grid-row-restore"))
+		}
+		hiddenFields[parameter.Trash] = parameter.True
+		resetUrl = infoUrl + "?" + parameter.Trash + "=" + parameter.True
+	}
+
 	if actionBtns == template.HTML("") && len(allActionBtns) > 0 {
 		if info.ActionButtonFold {
 			ext := template2.HTML("")
@@ -179,6 +204,30 @@ func (h *Handler) showTable(ctx *context.Context, prefix string, params paramete
 
 	btns, btnsJs := info.Buttons.CheckPermissionWhenURLAndMethodNotEmpty(user).Content()
 
+	if trash {
+		btns, btnsJs = "", trashJs(infoUrl, language.Get("back"))
+	} else if panel.GetSoftDelete() && panel.GetDeletable() {
+		btnsJs += trashJs(infoUrl+"?"+parameter.Trash+"="+parameter.True, language.Get("trash"))
+	}
+
+	if exportUrl != "" && len(info.ExportFormats) > 1 {
+		actionJs += exportFormatsJs(exportUrl, info.ExportFormats[1:])
+	}
+
+	actionJs += sortJs(params.GetFixedParamStrWithoutSort())
+
+	if !user.IsEmpty() {
+		actionJs += savedViewsJs(h.routePathWithPrefix("saved_views", prefix), h.routePathWithPrefix("saved_view_new", prefix),
+			h.routePathWithPrefix("saved_view_delete", prefix), params.GetFixedParamStr().Encode())
+	}
+
+	if panel.GetImportable() && panel.GetCanAdd() {
+		importUrl := user.GetCheckPermissionByUrlMethod(h.routePathWithPrefix("import", prefix), h.route("import").Method())
+		if importUrl != "" {
+			actionJs += importJs(importUrl, h.authSrv().AddToken())
+		}
+	}
+
 	if info.TabGroups.Valid() {
 
 		dataTable = aDataTable().
@@ -268,10 +317,8 @@ func (h *Handler) showTable(ctx *context.Context, prefix string, params paramete
 				SetMethod("get").
 				SetLayout(info.FilterFormLayout).
 				SetUrl(infoUrl). //  + params.GetFixedParamStrWithoutColumnsAndPage()
-				SetHiddenFields(map[string]string{
-					form.NoAnimationKey: "true",
-				}).
-				SetOperationFooter(filterFormFooter(infoUrl)).
+				SetHiddenFields(hiddenFields).
+				SetOperationFooter(filterFormFooter(resetUrl)).
 				GetContent())
 	}
 
@@ -334,100 +381,250 @@ func (h *Handler) Assets(ctx *context.Context) {
 	}, data)
 }
 
-// Export export table rows as excel object.
+// Export export table rows in the format requested, which is one of the
+// formats the table offers.
 func (h *Handler) Export(ctx *context.Context) {
 	param := guard.GetExportParam(ctx)
 
-	tableName := "Sheet1"
-	prefix := ctx.Query(constant.PrefixKey)
-	panel := h.table(prefix, ctx)
+	var (
+		prefix    = ctx.Query(constant.PrefixKey)
+		panel     = h.table(prefix, ctx)
+		tableInfo = panel.GetInfo()
+		formats   = tableInfo.ExportFormats
+		format    = ctx.FormValue(constant.ExportFormatKey)
+		params    = parameter.GetParam(ctx.Request.URL, tableInfo.DefaultPageSize, tableInfo.SortField,
+			tableInfo.GetSort()).WithContext(ctx.Request.Context())
+	)
+
+	if len(formats) == 0 {
+		formats = []string{exporter.XLSX}
+	}
+
+	if format == "" {
+		format = formats[0]
+	}
 
-	f := excelize.NewFile()
-	index := f.NewSheet(tableName)
-	f.SetActiveSheet(index)
+	exp, ok := exporter.Get(format)
+
+	if !ok || !modules.InArray(formats, format) {
+		response.Error(ctx, "export error: wrong format")
+		return
+	}
 
 	var (
-		infoData  table.PanelInfo
-		fileName  string
-		err       error
-		tableInfo = panel.GetInfo()
-		params    parameter.Parameters
+		fileName string
+		fetch    exportFetcher
 	)
 
-	if fn := panel.GetInfo().ExportProcessFn; fn != nil {
-		params = parameter.GetParam(ctx.Request.URL, tableInfo.DefaultPageSize, tableInfo.SortField,
-			tableInfo.GetSort())
-		p, err := fn(params.WithIsAll(param.IsAll))
-		if err != nil {
-			response.Error(ctx, "export error")
-			return
+	if fn := tableInfo.ExportProcessFn; fn != nil {
+		fileName = fmt.Sprintf("%s-%d.%s", tableInfo.Title, time.Now().Unix(), exp.Extension())
+		fetch = func() (table.PanelInfo, bool, error) {
+			p, err := fn(params.WithIsAll(param.IsAll))
+			return table.PanelInfo{Thead: p.Thead, InfoList: p.InfoList}, false, err
+		}
+	} else if len(param.Id) > 0 {
+		fileName = fmt.Sprintf("%s-%d-id-%s.%s", tableInfo.Title, time.Now().Unix(), strings.Join(param.Id, "_"),
+			exp.Extension())
+		fetch = func() (table.PanelInfo, bool, error) {
+			info, err := panel.GetDataWithIds(params.WithPKs(param.Id...))
+			return info, false, err
+		}
+	} else if param.IsAll {
+		fileName = fmt.Sprintf("%s-%d-all.%s", tableInfo.Title, time.Now().Unix(), exp.Extension())
+		params.SetPageSize(strconv.Itoa(exporter.DefaultPageSize))
+		params = params.WithKeyset()
+		page := 0
+		fetch = func() (table.PanelInfo, bool, error) {
+			page++
+			params.SetPage(strconv.Itoa(page))
+			info, err := panel.GetData(params.WithIsAll(false))
+			// the rows of the database are paged by the keyset, and the ones of
+			// the custom data by the pages.
+			if info.CursorMode {
+				params.Cursor = info.NextCursor
+				return info, info.NextCursor != "", err
+			}
+			return info, len(info.InfoList) >= exporter.DefaultPageSize, err
 		}
-		infoData.Thead = p.Thead
-		infoData.InfoList = p.InfoList
 	} else {
-		if len(param.Id) == 0 {
-			params = parameter.GetParam(ctx.Request.URL, tableInfo.DefaultPageSize, tableInfo.SortField,
-				tableInfo.GetSort())
-			infoData, err = panel.GetData(params.WithIsAll(param.IsAll))
-			fileName = fmt.Sprintf("%s-%d-page-%s-pageSize-%s.xlsx", tableInfo.Title, time.Now().Unix(),
-				params.Page, params.PageSize)
-		} else {
-			infoData, err = panel.GetDataWithIds(parameter.GetParam(ctx.Request.URL,
-				tableInfo.DefaultPageSize, tableInfo.SortField, tableInfo.GetSort()).WithPKs(param.Id...))
-			fileName = fmt.Sprintf("%s-%d-id-%s.xlsx", tableInfo.Title, time.Now().Unix(), strings.Join(param.Id, "_"))
+		fileName = fmt.Sprintf("%s-%d-page-%s-pageSize-%s.%s", tableInfo.Title, time.Now().Unix(),
+			params.Page, params.PageSize, exp.Extension())
+		fetch = func() (table.PanelInfo, bool, error) {
+			info, err := panel.GetData(params.WithIsAll(false))
+			return info, false, err
 		}
+	}
+
+	// The first page is queried before responding so that an error can still be reported.
+	infoData, more, err := fetch()
+
+	if err != nil {
+		logger.Error("export error: ", err)
+		response.Error(ctx, "export error")
+		return
+	}
+
+	reader, writer := io.Pipe()
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("export error: ", r)
+				_ = writer.CloseWithError(fmt.Errorf("%v", r))
+			}
+		}()
+
+		err := writeExport(exp.NewWriter(writer, "Sheet1"), infoData, more, fetch, tableInfo.IsExportValue())
 		if err != nil {
-			response.Error(ctx, "export error")
-			return
+			logger.Error("export error: ", err)
 		}
-	}
+		_ = writer.CloseWithError(err)
+	}()
 
-	// TODO: support any numbers of fields.
-	orders := []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K",
-		"L", "M", "N", "O", "P", "Q", "R", "S", "T", "U", "V", "W", "X", "Y", "Z"}
+	ctx.AddHeader("content-disposition", `attachment; filename=`+fileName)
+	ctx.DataStream(http.StatusOK, exp.ContentType(), reader)
+}
 
-	if len(infoData.Thead) > 26 {
-		j := -1
-		for i := 0; i < len(infoData.Thead)-26; i++ {
-			if i%26 == 0 {
-				j++
+// exportFormatsJs adds the items of the other export formats into the export
+// dropdown menu of the table header.
+func exportFormatsJs(exportUrl string, formats []string) template2.JS {
+	items := ""
+	for _, format := range formats {
+		for _, isAll := range []string{"false", "true"} {
+			label := language.Get("current page")
+			if isAll == "true" {
+				label = language.Get("all")
 			}
-			letter := orders[j] + orders[i%26]
-			orders = append(orders, letter)
+			items += fmt.Sprintf(`{format: %q, isAll: %q, label: %q},`, format, isAll,
+				label+" ("+strings.ToUpper(format)+")")
 		}
 	}
+	return template2.JS(fmt.Sprintf(`
+$(function () {
+    let menu = $("#export-btn-0").closest("ul");
+    [%s].forEach(function (item) {
+        let link = $('<a href="#"></a>').text(item.label).click(function () {
+            let form = $("<form>").attr({style: "display:none", method: "post", action: %q});
+            form.append($("<input>").attr({type: "hidden", name: "is_all", value: item.isAll}));
+            form.append($("<input>").attr({type: "hidden", name: %q, value: item.format}));
+            $("body").append(form);
+            form.submit();
+            form.remove();
+            return false;
+        });
+        menu.append($("<li>").append(link));
+    });
+});`, items, exportUrl, constant.ExportFormatKey))
+}
+
+// sortJs marks the sort fields of the table header in order. A click sorts the
+// table by the field only, and a shift-click appends the field to the sort
+// fields or reverses its sort type.
+func sortJs(sortUrl string) template2.JS {
+	return template2.JS(fmt.Sprintf(`
+$(function () {
+    let query = new URLSearchParams(window.location.search);
+    let fields = query.getAll(%q);
+    let types = query.getAll(%q);
+    let sorts = fields.map(function (field, i) {
+        return {field: field, type: types[i] === "asc" ? "asc" : "desc"};
+    });
+    let toUrl = function (sorts) {
+        let p = new URLSearchParams();
+        sorts.forEach(function (sort) {
+            p.append(%q, sort.field);
+            p.append(%q, sort.type);
+        });
+        return "?" + p.toString() + %q;
+    };
+    let reverse = function (type) {
+        return type === "desc" ? "asc" : "desc";
+    };
+    $("th a[id^='sort-']").each(function () {
+        let a = $(this);
+        let field = a.attr("id").substring(5);
+        let index = sorts.findIndex(function (sort) {
+            return sort.field === field;
+        });
+        a.removeClass("fa-sort fa-sort-amount-asc fa-sort-amount-desc");
+        a.find("sup").remove();
+        if (index < 0) {
+            a.addClass("fa-sort");
+            a.attr("href", toUrl([{field: field, type: "desc"}]));
+        } else {
+            a.addClass("fa-sort-amount-" + sorts[index].type);
+            a.attr("href", toUrl([{field: field, type: reverse(sorts[index].type)}]));
+            if (sorts.length > 1) {
+                a.append($("<sup>").text(index + 1));
+            }
+        }
+        a.attr("title", %q);
+        a.off("click.sort").on("click.sort", function (e) {
+            if (!e.shiftKey) {
+                return;
+            }
+            let multi = sorts.slice();
+            if (index < 0) {
+                multi.push({field: field, type: "desc"});
+            } else {
+                multi[index] = {field: field, type: reverse(sorts[index].type)};
+            }
+            e.preventDefault();
+            e.stopPropagation();
+            if ($.pjax) {
+                $.pjax({url: toUrl(multi), container: "#pjax-container"});
+            } else {
+                window.location.href = toUrl(multi);
+            }
+        });
+    });
+});`, parameter.Sort, parameter.SortType, parameter.Sort, parameter.SortType, sortUrl,
+		language.Get("shift-click to sort by more fields")))
+}
 
-	columnIndex := 0
+// exportFetcher returns the next page of the exported data and whether there are more pages.
+type exportFetcher func() (table.PanelInfo, bool, error)
+
+func writeExport(w exporter.Writer, infoData table.PanelInfo, more bool, fetch exportFetcher, exportValue bool) error {
+
+	heads := make([]string, 0, len(infoData.Thead))
 	for _, head := range infoData.Thead {
 		if !head.Hide {
-			f.SetCellValue(tableName, orders[columnIndex]+"1", head.Head)
-			columnIndex++
+			heads = append(heads, head.Head)
 		}
 	}
 
-	count := 2
-	for _, info := range infoData.InfoList {
-		columnIndex = 0
-		for _, head := range infoData.Thead {
-			if !head.Hide {
-				if tableInfo.IsExportValue() {
-					f.SetCellValue(tableName, orders[columnIndex]+strconv.Itoa(count), info[head.Field].Value)
+	if err := w.WriteHeader(heads); err != nil {
+		return err
+	}
+
+	for {
+		for _, info := range infoData.InfoList {
+			values := make([]string, 0, len(heads))
+			for _, head := range infoData.Thead {
+				if head.Hide {
+					continue
+				}
+				if exportValue {
+					values = append(values, info[head.Field].Value)
 				} else {
-					f.SetCellValue(tableName, orders[columnIndex]+strconv.Itoa(count), info[head.Field].Content)
+					values = append(values, string(info[head.Field].Content))
 				}
-				columnIndex++
+			}
+			if err := w.WriteRow(values); err != nil {
+				return err
 			}
 		}
-		count++
-	}
 
-	buf, err := f.WriteToBuffer()
+		if !more {
+			break
+		}
 
-	if err != nil || buf == nil {
-		response.Error(ctx, "export error")
-		return
+		var err error
+		if infoData, more, err = fetch(); err != nil {
+			return err
+		}
 	}
 
-	ctx.AddHeader("content-disposition", `attachment; filename=`+fileName)
-	ctx.Data(200, "application/vnd.ms-excel", buf.Bytes())
+	return w.Close()
 }
@@ -0,0 +1,204 @@
+package controller
+
+import (
+	"fmt"
+	"html/template"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/auth"
+	"github.com/GoAdminGroup/go-admin/modules/language"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/response"
+	"github.com/GoAdminGroup/go-admin/template/types"
+	"github.com/GoAdminGroup/go-admin/template/types/display"
+)
+
+// ShowTOTP show the two-factor authentication settings of the login user.
+// A user who has not enabled it gets a new secret to scan.
+func (h *Handler) ShowTOTP(ctx *context.Context) {
+
+	user := auth.Auth(ctx)
+
+	if user.IsEmpty() {
+		h.HTML(ctx, user, types.Panel{
+			Content:     template.HTML(language.Get("two-factor authentication is not supported by the portal signin")),
+			Title:       language.GetFromHtml("two-factor authentication"),
+			Description: language.GetFromHtml("two-factor authentication"),
+		})
+		return
+	}
+
+	var (
+		body    template.HTML
+		buttons template.HTML
+		token   = auth.GetTokenService(h.services.Get(auth.TokenServiceKey)).AddToken()
+	)
+
+	if user.TOTPEnabled {
+		body = stripedTable([]map[string]types.InfoItem{
+			{
+				"key":   types.InfoItem{Content: language.GetFromHtml("status")},
+				"value": types.InfoItem{Content: language.GetFromHtml("enabled")},
+			}, {
+				"key":   types.InfoItem{Content: language.GetFromHtml("recovery codes")},
+				"value": types.InfoItem{Content: itos(len(user.RecoveryCodes))},
+			},
+		})
+		buttons = totpButton("recovery_codes", language.GetFromHtml("regenerate recovery codes"), "primary")
+		if !(user.IsSuperAdmin() && h.config.Auth.TOTP.RequireSuperAdmin) {
+			buttons += totpButton("disable", language.GetFromHtml("disable"), "danger")
+		}
+	} else {
+		if user.TOTPSecret == "" {
+			var err error
+			if user, err = user.SetTOTPSecret(auth.GenerateTOTPSecret()); err != nil {
+				response.Error(ctx, err.Error())
+				return
+			}
+		}
+		src := display.QrcodeSrc(auth.TOTPURL(h.config.Auth.TOTP.Issuer, user.UserName, user.TOTPSecret))
+		body = template.HTML(`<p>`+language.Get("scan the qrcode with the authenticator app")+`</p>`+
+			`<p><img src="`+template.HTMLEscapeString(src)+`" style="height:150px;width:150px;"/></p>`) +
+			stripedTable([]map[string]types.InfoItem{
+				{
+					"key":   types.InfoItem{Content: language.GetFromHtml("status")},
+					"value": types.InfoItem{Content: language.GetFromHtml("disabled")},
+				}, {
+					"key":   types.InfoItem{Content: language.GetFromHtml("secret")},
+					"value": types.InfoItem{Content: template.HTML(user.TOTPSecret)},
+				},
+			})
+		buttons = totpButton("enable", language.GetFromHtml("enable"), "primary")
+	}
+
+	body += template.HTML(`<div class="form-inline">
+	<input type="text" class="form-control" id="totp-code" autocomplete="one-time-code" placeholder="`+
+		language.Get("verification code")+`"/>
+	<input type="hidden" id="totp-token" value="`+token+`"/>
+	`) + buttons + `</div>` + totpJs(h.config.Url("/totp/"))
+
+	h.HTML(ctx, user, types.Panel{
+		Content: aRow().SetContent(aCol().SetSize(types.Size(12, 8, 6)).SetContent(aBox().
+			WithHeadBorder().
+			SetHeader("<b>" + language.GetFromHtml("two-factor authentication") + "</b>").
+			SetBody(body).
+			GetContent()).GetContent()).GetContent(),
+		Title:       language.GetFromHtml("two-factor authentication"),
+		Description: language.GetFromHtml("two-factor authentication"),
+	})
+}
+
+func totpButton(action string, text template.HTML, style string) template.HTML {
+	return template.HTML(`<button type="button" class="btn btn-` + style + ` totp-btn" data-action="` +
+		action + `">` + string(text) + `</button> `)
+}
+
+func totpJs(url string) template.HTML {
+	return template.HTML(fmt.Sprintf(`<script>
+$('.totp-btn').on('click', function () {
+	$.ajax({
+		method: 'post',
+		url: %q + $(this).data('action'),
+		data: {
+			code: $('#totp-code').val(),
+			%q: $('#totp-token').val()
+		},
+		complete: function (xhr) {
+			let res = xhr.responseJSON || {};
+			if (res.code !== 200) {
+				swal(res.msg || 'error', '', 'error');
+			} else if (res.data && res.data.recovery_codes) {
+				swal(%q, res.data.recovery_codes.join('\n'), 'success');
+			} else {
+				swal(res.msg, '', 'success');
+			}
+			$.pjax.reload('#pjax-container');
+		}
+	});
+});
+</script>`, url, form.TokenKey, language.Get("save the recovery codes")))
+}
+
+// totpUser check the token and the code of a TOTP setting request and
+// return the login user.
+func (h *Handler) totpUser(ctx *context.Context, enabled bool) (user models.UserModel, ok bool) {
+
+	user = auth.Auth(ctx)
+
+	if !auth.GetTokenService(h.services.Get(auth.TokenServiceKey)).CheckToken(ctx.FormValue(form.TokenKey)) {
+		response.BadRequest(ctx, "wrong token")
+		return user, false
+	}
+
+	if user.IsEmpty() || user.TOTPEnabled != enabled {
+		response.BadRequest(ctx, "wrong request")
+		return user, false
+	}
+
+	if user.TOTPLocked() {
+		response.BadRequest(ctx, "too many wrong verification codes, please try again later")
+		return user, false
+	}
+
+	code := ctx.FormValue("code")
+	if enabled {
+		ok = auth.CheckTOTP(user, code)
+	} else {
+		ok = auth.VerifyTOTP(user, code)
+	}
+	if !ok {
+		auth.FailTOTP(user)
+		response.BadRequest(ctx, "wrong verification code")
+	}
+	return
+}
+
+// EnableTOTP enable the secret of the login user with the first code.
+func (h *Handler) EnableTOTP(ctx *context.Context) {
+	user, ok := h.totpUser(ctx, false)
+	if !ok {
+		return
+	}
+	codes, hashes := auth.GenerateRecoveryCodes()
+	if _, err := user.EnableTOTP(hashes); err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+	response.OkWithData(ctx, map[string]interface{}{
+		"recovery_codes": codes,
+	})
+}
+
+// DisableTOTP disable the two-factor authentication of the login user.
+func (h *Handler) DisableTOTP(ctx *context.Context) {
+	user, ok := h.totpUser(ctx, true)
+	if !ok {
+		return
+	}
+	if user.IsSuperAdmin() && h.config.Auth.TOTP.RequireSuperAdmin {
+		response.BadRequest(ctx, "two-factor authentication is required")
+		return
+	}
+	if _, err := user.DisableTOTP(); err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+	response.Ok(ctx)
+}
+
+// RegenerateRecoveryCodes replace the recovery codes of the login user.
+func (h *Handler) RegenerateRecoveryCodes(ctx *context.Context) {
+	user, ok := h.totpUser(ctx, true)
+	if !ok {
+		return
+	}
+	codes, hashes := auth.GenerateRecoveryCodes()
+	if _, err := user.UpdateRecoveryCodes(hashes); err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+	response.OkWithData(ctx, map[string]interface{}{
+		"recovery_codes": codes,
+	})
+}
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"fmt"
+	template2 "html/template"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/language"
+	"github.com/GoAdminGroup/go-admin/modules/logger"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/guard"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/response"
+)
+
+// Restore move the rows out of the trash.
+func (h *Handler) Restore(ctx *context.Context) {
+
+	param := guard.GetDeleteParam(ctx)
+
+	if err := param.Panel.RestoreData(param.Id); err != nil {
+		logger.Error(err)
+		response.Error(ctx, "restore fail")
+		return
+	}
+
+	response.OkWithData(ctx, map[string]interface{}{
+		"token": h.authSrv().AddToken(),
+	})
+}
+
+// Purge delete the rows in the trash.
+func (h *Handler) Purge(ctx *context.Context) {
+
+	param := guard.GetDeleteParam(ctx)
+
+	if err := param.Panel.PurgeData(param.Id); err != nil {
+		logger.Error(err)
+		response.Error(ctx, "purge fail")
+		return
+	}
+
+	response.OkWithData(ctx, map[string]interface{}{
+		"token": h.authSrv().AddToken(),
+	})
+}
+
+// trashJs adds the button into the table header which switches between the
+// list and the trash.
+func trashJs(url, title string) template2.JS {
+	return template2.JS(fmt.Sprintf(`
+$(function () {
+    let btn = $('<div class="btn-group pull-right" style="margin-right: 10px">' +
+        '<a class="btn btn-sm btn-default"><i class="fa fa-trash"></i> <span class="hidden-xs"></span></a></div>');
+    btn.find("a").attr("href", %q);
+    btn.find("span").text(%q);
+    let anchor = $(".box-header .btn-group.pull-right").last();
+    if (anchor.length > 0) {
+        anchor.after(btn);
+    } else {
+        $(".box-header").first().prepend(btn);
+    }
+});`, url, title))
+}
+
+// restoreJs restores the row of the clicked restore button.
+func restoreJs(restoreUrl string) template2.JS {
+	return template2.JS(fmt.Sprintf(`
+$(function () {
+    $(".grid-row-restore").click(function () {
+        let id = $(this).data("id");
+        swal({
+            title: %q,
+            type: "warning",
+            showCancelButton: true,
+            confirmButtonColor: "#DD6B55",
+            confirmButtonText: %q,
+            closeOnConfirm: false,
+            cancelButtonText: %q
+        }, function () {
+            $.ajax({
+                method: "post",
+                url: %q,
+                data: {id: id},
+                success: function (data) {
+                    if (typeof (data) === "string") {
+                        data = JSON.parse(data);
+                    }
+                    if (data.code === 200) {
+                        swal.close();
+                        $.pjax.reload("#pjax-container");
+                    } else {
+                        swal(data.msg, "", "error");
+                    }
+                },
+                error: function (data) {
+                    swal(data.responseJSON ? data.responseJSON.msg : "error", "", "error");
+                }
+            });
+        });
+        return false;
+    });
+});`, language.Get("are you sure to restore"), language.Get("yes"), language.Get("cancel"), restoreUrl))
+}
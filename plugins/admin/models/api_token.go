@@ -0,0 +1,191 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/modules/db/dialect"
+)
+
+// The verbs of the scopes of the api tokens.
+const (
+	ApiTokenScopeRead  = "read"
+	ApiTokenScopeWrite = "write"
+
+	// ApiTokenScopeAll is the prefix of the scopes of all the tables.
+	ApiTokenScopeAll = "*"
+)
+
+// ApiTokenModel is api token model structure. The token itself is not
+// stored but its hash.
+type ApiTokenModel struct {
+	Base
+
+	Id             int64
+	UserId         int64
+	Name           string
+	TokenHash      string
+	Scopes         []string
+	ServiceAccount bool
+	LastUsedAt     string
+	ExpiresAt      string
+	CreatedAt      string
+	UpdatedAt      string
+}
+
+// ApiToken return a default api token model.
+func ApiToken() ApiTokenModel {
+	return ApiTokenModel{Base: Base{TableName: "goadmin_api_tokens"}}
+}
+
+func (t ApiTokenModel) SetConn(con db.Connection) ApiTokenModel {
+	t.Conn = con
+	return t
+}
+
+// New create a new api token model of the user. The token never expires if
+// expiresAt is empty.
+func (t ApiTokenModel) New(userId int64, name, tokenHash string, scopes []string, serviceAccount bool,
+	expiresAt string) (ApiTokenModel, error) {
+
+	var expires interface{}
+	if expiresAt != "" {
+		expires = expiresAt
+	}
+
+	id, err := t.Table(t.TableName).Insert(dialect.H{
+		"user_id":         userId,
+		"name":            name,
+		"token_hash":      tokenHash,
+		"scopes":          strings.Join(scopes, ","),
+		"service_account": boolToInt(serviceAccount),
+		"expires_at":      expires,
+	})
+
+	t.Id = id
+	t.UserId = userId
+	t.Name = name
+	t.TokenHash = tokenHash
+	t.Scopes = scopes
+	t.ServiceAccount = serviceAccount
+	t.ExpiresAt = expiresAt
+
+	return t, err
+}
+
+// FindByHash return the api token model of the hash which is not expired.
+func (t ApiTokenModel) FindByHash(tokenHash string) ApiTokenModel {
+	item, _ := t.Table(t.TableName).
+		Where("token_hash", "=", tokenHash).
+		WhereRaw("(expires_at is null or expires_at > ?)", time.Now().Format("2006-01-02 15:04:05")).
+		First()
+	return t.MapToModel(item)
+}
+
+// IsEmpty check the api token model is empty or not.
+func (t ApiTokenModel) IsEmpty() bool {
+	return t.Id == int64(0)
+}
+
+// apiTokenTouchInterval is the least interval between the records of the
+// last use of a token.
+const apiTokenTouchInterval = time.Minute
+
+// apiTokenTouches keeps the time of the last record of the use of the tokens
+// by the id.
+var apiTokenTouches sync.Map
+
+// Touch record the time of the last use of the token, at most once in
+// apiTokenTouchInterval.
+func (t ApiTokenModel) Touch() {
+	now := time.Now()
+	if last, ok := apiTokenTouches.Load(t.Id); ok && now.Sub(last.(time.Time)) < apiTokenTouchInterval {
+		return
+	}
+	apiTokenTouches.Store(t.Id, now)
+	_, _ = t.Table(t.TableName).
+		Where("id", "=", t.Id).
+		Update(dialect.H{
+			"last_used_at": now,
+		})
+}
+
+// Allow check the scopes of the token allow the method on the table of the
+// prefix. A write scope also allows reading, and the requests which are not
+// of a table need the scope of all the tables.
+func (t ApiTokenModel) Allow(prefix, method string) bool {
+	verb := ApiTokenScopeWrite
+	switch strings.ToUpper(method) {
+	case "GET", "HEAD", "OPTIONS":
+		verb = ApiTokenScopeRead
+	}
+	for _, scope := range t.Scopes {
+		p, v := splitScope(scope)
+		if p != ApiTokenScopeAll && (prefix == "" || p != prefix) {
+			continue
+		}
+		if v == verb || v == ApiTokenScopeWrite {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseApiTokenScopes parse the scopes separated by the commas, each of which
+// is a table prefix, or "*" for all the tables, and a verb joined by a colon,
+// such as "users:read".
+func ParseApiTokenScopes(s string) ([]string, error) {
+	scopes := make([]string, 0)
+	for _, scope := range strings.Split(s, ",") {
+		scope = strings.TrimSpace(scope)
+		if scope == "" {
+			continue
+		}
+		p, v := splitScope(scope)
+		if p == "" || (v != ApiTokenScopeRead && v != ApiTokenScopeWrite) {
+			return nil, errors.New("wrong scope: " + scope)
+		}
+		scopes = append(scopes, p+":"+v)
+	}
+	if len(scopes) == 0 {
+		return nil, errors.New("empty scopes")
+	}
+	return scopes, nil
+}
+
+func splitScope(scope string) (prefix, verb string) {
+	i := strings.LastIndex(scope, ":")
+	if i < 0 {
+		return "", ""
+	}
+	return strings.TrimSpace(scope[:i]), strings.TrimSpace(scope[i+1:])
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// MapToModel get the api token model from given map.
+func (t ApiTokenModel) MapToModel(m map[string]interface{}) ApiTokenModel {
+	t.Id, _ = m["id"].(int64)
+	t.UserId, _ = m["user_id"].(int64)
+	t.Name, _ = m["name"].(string)
+	t.TokenHash, _ = m["token_hash"].(string)
+	t.LastUsedAt, _ = m["last_used_at"].(string)
+	t.ExpiresAt, _ = m["expires_at"].(string)
+	t.CreatedAt, _ = m["created_at"].(string)
+	t.UpdatedAt, _ = m["updated_at"].(string)
+	serviceAccount, _ := m["service_account"].(int64)
+	t.ServiceAccount = serviceAccount == 1
+	t.Scopes = make([]string, 0)
+	if scopes, _ := m["scopes"].(string); scopes != "" {
+		t.Scopes = strings.Split(scopes, ",")
+	}
+	return t
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseApiTokenScopes(t *testing.T) {
+	scopes, err := ParseApiTokenScopes(" users:read, posts : write ,")
+	assert.Nil(t, err)
+	assert.Equal(t, scopes, []string{"users:read", "posts:write"})
+
+	_, err = ParseApiTokenScopes("users:delete")
+	assert.NotNil(t, err)
+	_, err = ParseApiTokenScopes("users")
+	assert.NotNil(t, err)
+	_, err = ParseApiTokenScopes(" , ")
+	assert.NotNil(t, err)
+}
+
+func TestApiTokenModel_Allow(t *testing.T) {
+	token := ApiToken().MapToModel(map[string]interface{}{
+		"id":     int64(1),
+		"scopes": "users:read,posts:write",
+	})
+	assert.True(t, token.Allow("users", "GET"))
+	assert.False(t, token.Allow("users", "POST"))
+	assert.True(t, token.Allow("posts", "GET"))
+	assert.True(t, token.Allow("posts", "DELETE"))
+	assert.False(t, token.Allow("roles", "GET"))
+	assert.False(t, token.Allow("", "GET"))
+
+	token.Scopes = []string{"*:read"}
+	assert.True(t, token.Allow("roles", "GET"))
+	assert.True(t, token.Allow("", "GET"))
+	assert.False(t, token.Allow("roles", "PUT"))
+}
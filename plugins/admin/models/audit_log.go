@@ -0,0 +1,117 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/modules/db/dialect"
+)
+
+// The actions of the audit log.
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+)
+
+// AuditChange is the change of a field of a record.
+type AuditChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// AuditLogModel is audit log model structure.
+type AuditLogModel struct {
+	Base
+
+	Id          int64
+	UserId      int64
+	UserName    string
+	RecordTable string
+	RecordId    string
+	Action      string
+	Changes     []AuditChange
+	CreatedAt   string
+	UpdatedAt   string
+}
+
+// AuditLog return a default audit log model.
+func AuditLog() AuditLogModel {
+	return AuditLogModel{Base: Base{TableName: "goadmin_audit_log"}}
+}
+
+func (t AuditLogModel) SetConn(con db.Connection) AuditLogModel {
+	t.Conn = con
+	return t
+}
+
+func (t AuditLogModel) WithTx(tx *sql.Tx) AuditLogModel {
+	t.Tx = tx
+	return t
+}
+
+// New create a new audit log model.
+func (t AuditLogModel) New(userId int64, userName, table, recordId, action string, changes []AuditChange) (AuditLogModel, error) {
+
+	changesByte, err := json.Marshal(changes)
+	if err != nil {
+		return t, err
+	}
+
+	id, err := t.Table(t.TableName).WithTx(t.Tx).Insert(dialect.H{
+		"user_id":    userId,
+		"user_name":  userName,
+		"table_name": table,
+		"record_id":  recordId,
+		"action":     action,
+		"changes":    string(changesByte),
+	})
+
+	t.Id = id
+	t.UserId = userId
+	t.UserName = userName
+	t.RecordTable = table
+	t.RecordId = recordId
+	t.Action = action
+	t.Changes = changes
+
+	return t, err
+}
+
+// FindByRecord return the audit logs of the record of the table, the latest
+// first.
+func (t AuditLogModel) FindByRecord(table, recordId string) ([]AuditLogModel, error) {
+	items, err := t.Table(t.TableName).
+		Where("table_name", "=", table).
+		Where("record_id", "=", recordId).
+		OrderBy("id", "desc").
+		All()
+	if err != nil {
+		return nil, err
+	}
+	logs := make([]AuditLogModel, len(items))
+	for i, item := range items {
+		logs[i] = t.MapToModel(item)
+	}
+	return logs, nil
+}
+
+// MapToModel get the audit log model from given map.
+func (t AuditLogModel) MapToModel(m map[string]interface{}) AuditLogModel {
+	t.Id, _ = m["id"].(int64)
+	t.UserId, _ = m["user_id"].(int64)
+	t.UserName, _ = m["user_name"].(string)
+	t.RecordTable, _ = m["table_name"].(string)
+	t.RecordId, _ = m["record_id"].(string)
+	t.Action, _ = m["action"].(string)
+	t.CreatedAt, _ = m["created_at"].(string)
+	t.UpdatedAt, _ = m["updated_at"].(string)
+	t.Changes = make([]AuditChange, 0)
+	if changes, ok := m["changes"].(string); ok {
+		_ = json.Unmarshal([]byte(changes), &t.Changes)
+	}
+	return t
+}
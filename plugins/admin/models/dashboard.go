@@ -0,0 +1,106 @@
+package models
+
+import (
+	"github.com/GoAdminGroup/go-admin/modules/db"
+)
+
+// DashboardModel is dashboard model structure.
+type DashboardModel struct {
+	Base
+
+	Id          int64
+	Title       string
+	Description string
+	CreatedAt   string
+	UpdatedAt   string
+}
+
+// DashboardWidgetModel is dashboard widget model structure. The widgets of a
+// dashboard are laid out in the order, each of which takes the width of the
+// twelve columns of a row. A chart reloads its data every refresh seconds.
+type DashboardWidgetModel struct {
+	Id          int64
+	DashboardId int64
+	Title       string
+	Type        string
+	Source      string
+	Query       string
+	Width       int64
+	Order       int64
+	Refresh     int64
+}
+
+// Dashboard return a default dashboard model.
+func Dashboard() DashboardModel {
+	return DashboardModel{Base: Base{TableName: "goadmin_dashboards"}}
+}
+
+func (t DashboardModel) SetConn(con db.Connection) DashboardModel {
+	t.Conn = con
+	return t
+}
+
+// Find return the dashboard model of given id.
+func (t DashboardModel) Find(id interface{}) DashboardModel {
+	item, _ := t.Table(t.TableName).Find(id)
+	return t.MapToModel(item)
+}
+
+// IsEmpty check the dashboard model is empty or not.
+func (t DashboardModel) IsEmpty() bool {
+	return t.Id == 0
+}
+
+// All return all the dashboards ordered by the id.
+func (t DashboardModel) All() []DashboardModel {
+	items, _ := t.Table(t.TableName).OrderBy("id", "asc").All()
+	dashboards := make([]DashboardModel, len(items))
+	for i, item := range items {
+		dashboards[i] = t.MapToModel(item)
+	}
+	return dashboards
+}
+
+// Widgets return the widgets of the dashboard in the order of the layout.
+func (t DashboardModel) Widgets() []DashboardWidgetModel {
+	items, _ := t.Table("goadmin_dashboard_widgets").
+		Where("dashboard_id", "=", t.Id).
+		OrderBy("order", "asc").
+		All()
+
+	widgets := make([]DashboardWidgetModel, len(items))
+	for i, item := range items {
+		widgets[i] = widgetFromMap(item)
+	}
+	return widgets
+}
+
+// FindWidget return the widget of given id.
+func (t DashboardModel) FindWidget(id interface{}) DashboardWidgetModel {
+	item, _ := t.Table("goadmin_dashboard_widgets").Find(id)
+	return widgetFromMap(item)
+}
+
+func widgetFromMap(m map[string]interface{}) DashboardWidgetModel {
+	var widget DashboardWidgetModel
+	widget.Id, _ = m["id"].(int64)
+	widget.DashboardId, _ = m["dashboard_id"].(int64)
+	widget.Title, _ = m["title"].(string)
+	widget.Type, _ = m["type"].(string)
+	widget.Source, _ = m["source"].(string)
+	widget.Query, _ = m["query"].(string)
+	widget.Width, _ = m["width"].(int64)
+	widget.Order, _ = m["order"].(int64)
+	widget.Refresh, _ = m["refresh"].(int64)
+	return widget
+}
+
+// MapToModel get the dashboard model from given map.
+func (t DashboardModel) MapToModel(m map[string]interface{}) DashboardModel {
+	t.Id, _ = m["id"].(int64)
+	t.Title, _ = m["title"].(string)
+	t.Description, _ = m["description"].(string)
+	t.CreatedAt, _ = m["created_at"].(string)
+	t.UpdatedAt, _ = m["updated_at"].(string)
+	return t
+}
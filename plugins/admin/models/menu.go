@@ -39,6 +39,28 @@ func (t MenuModel) SetConn(con db.Connection) MenuModel {
 	return t
 }
 
+// menuChangedHooks are called after the menus or the roles of the menus are
+// changed.
+var menuChangedHooks []func()
+
+// OnMenuChanged add the hook which is called after the menus or the roles of
+// the menus are changed, such as to invalidate the cached menus.
+func OnMenuChanged(hook func()) {
+	menuChangedHooks = append(menuChangedHooks, hook)
+}
+
+func menuChanged() {
+	for _, hook := range menuChangedHooks {
+		hook()
+	}
+}
+
+// MenuChanged call the hooks of the changed menus, which is called after the
+// roles of the menus are changed within a transaction and committed.
+func MenuChanged() {
+	menuChanged()
+}
+
 // Find return a default menu model of given id.
 func (t MenuModel) Find(id interface{}) MenuModel {
 	item, _ := t.Table(t.TableName).Find(id)
@@ -65,6 +87,8 @@ func (t MenuModel) New(title, icon, uri, header, pluginName string, parentId, or
 	t.Uri = uri
 	t.Header = header
 
+	menuChanged()
+
 	return t, err
 }
 
@@ -83,10 +107,13 @@ func (t MenuModel) Delete() {
 	}
 
 	_ = t.Table(t.TableName).Where("parent_id", "=", t.Id).Delete()
+
+	menuChanged()
 }
 
 // Update update the menu model.
 func (t MenuModel) Update(title, icon, uri, header, pluginName string, parentId int64) (int64, error) {
+	defer menuChanged()
 	return t.Table(t.TableName).
 		Where("id", "=", t.Id).
 		Update(dialect.H{
@@ -110,6 +137,8 @@ type OrderItem struct {
 // ResetOrder update the order of menu models.
 func (t MenuModel) ResetOrder(data []byte) {
 
+	defer menuChanged()
+
 	var items OrderItems
 	_ = json.Unmarshal(data, &items)
 
@@ -177,6 +206,7 @@ func (t MenuModel) CheckRole(roleId string) bool {
 func (t MenuModel) AddRole(roleId string) (int64, error) {
 	if roleId != "" {
 		if !t.CheckRole(roleId) {
+			defer menuChanged()
 			return t.Table("goadmin_role_menu").
 				Insert(dialect.H{
 					"role_id": roleId,
@@ -189,6 +219,7 @@ func (t MenuModel) AddRole(roleId string) (int64, error) {
 
 // DeleteRoles delete roles with menu.
 func (t MenuModel) DeleteRoles() error {
+	defer menuChanged()
 	return t.Table("goadmin_role_menu").
 		Where("menu_id", "=", t.Id).
 		Delete()
@@ -0,0 +1,197 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/modules/db/dialect"
+)
+
+// PermissionModel is permission model structure.
+type PermissionModel struct {
+	Base
+
+	Id         int64
+	Name       string
+	Slug       string
+	HttpMethod []string
+	HttpPath   []string
+	CreatedAt  string
+	UpdatedAt  string
+}
+
+// Permission return a default permission model.
+func Permission() PermissionModel {
+	return PermissionModel{Base: Base{TableName: "goadmin_permissions"}}
+}
+
+// PermissionWithId return a default permission model of given id.
+func PermissionWithId(id string) PermissionModel {
+	idInt, _ := strconv.Atoi(id)
+	return PermissionModel{Base: Base{TableName: "goadmin_permissions"}, Id: int64(idInt)}
+}
+
+func (t PermissionModel) SetConn(con db.Connection) PermissionModel {
+	t.Conn = con
+	return t
+}
+
+// Find return the permission model of given id.
+func (t PermissionModel) Find(id interface{}) PermissionModel {
+	item, _ := t.Table(t.TableName).Find(id)
+	return t.MapToModel(item)
+}
+
+// FindBySlug return the permission model of given slug.
+func (t PermissionModel) FindBySlug(slug string) PermissionModel {
+	item, _ := t.Table(t.TableName).Where("slug", "=", slug).First()
+	return t.MapToModel(item)
+}
+
+// IsEmpty check the permission model is empty or not.
+func (t PermissionModel) IsEmpty() bool {
+	return t.Id == int64(0)
+}
+
+// IsSlugExist check the slug is exist or not.
+func (t PermissionModel) IsSlugExist(slug string, id string) bool {
+	if id == "" {
+		check, _ := t.Table(t.TableName).Where("slug", "=", slug).First()
+		return check != nil
+	}
+	check, _ := t.Table(t.TableName).
+		Where("slug", "=", slug).
+		Where("id", "!=", id).
+		First()
+	return check != nil
+}
+
+// Delete delete the permission model.
+func (t PermissionModel) Delete() error {
+	err := t.Table(t.TableName).Where("id", "=", t.Id).Delete()
+	if db.CheckError(err, db.DELETE) {
+		return err
+	}
+	err = t.Table("goadmin_role_permissions").Where("permission_id", "=", t.Id).Delete()
+	if db.CheckError(err, db.DELETE) {
+		return err
+	}
+	return nil
+}
+
+// Update update the permission model.
+func (t PermissionModel) Update(name, slug string, httpMethod, httpPath []string) (int64, error) {
+	return t.Table(t.TableName).
+		Where("id", "=", t.Id).
+		Update(dialect.H{
+			"name":        name,
+			"slug":        slug,
+			"http_method": strings.Join(httpMethod, ","),
+			"http_path":   strings.Join(httpPath, "\n"),
+			"updated_at":  time.Now().Format("2006-01-02 15:04:05"),
+		})
+}
+
+// New create a new permission model.
+func (t PermissionModel) New(name, slug string, httpMethod, httpPath []string) (PermissionModel, error) {
+
+	id, err := t.Table(t.TableName).Insert(dialect.H{
+		"name":        name,
+		"slug":        slug,
+		"http_method": strings.Join(httpMethod, ","),
+		"http_path":   strings.Join(httpPath, "\n"),
+	})
+
+	t.Id = id
+	t.Name = name
+	t.Slug = slug
+	t.HttpMethod = httpMethod
+	t.HttpPath = httpPath
+
+	return t, err
+}
+
+// MapToModel get the permission model from given map.
+func (t PermissionModel) MapToModel(m map[string]interface{}) PermissionModel {
+	t.Id, _ = m["id"].(int64)
+	t.Name, _ = m["name"].(string)
+	t.Slug, _ = m["slug"].(string)
+
+	methods, _ := m["http_method"].(string)
+	if methods != "" {
+		t.HttpMethod = strings.Split(methods, ",")
+	} else {
+		t.HttpMethod = []string{""}
+	}
+
+	path, _ := m["http_path"].(string)
+	t.HttpPath = strings.Split(strings.ReplaceAll(path, "\r\n", "\n"), "\n")
+	t.CreatedAt, _ = m["created_at"].(string)
+	t.UpdatedAt, _ = m["updated_at"].(string)
+	return t
+}
+
+// permissionCacheTTL is the max age of the cached permissions of a role, in
+// which the changes made by the other instances of the application are seen.
+const permissionCacheTTL = time.Minute
+
+type cachedPermissions struct {
+	permissions []PermissionModel
+	expires     time.Time
+}
+
+// permissionCache is the cache of the permissions by role, which is shared by
+// the users of the role, so the cached values must not be changed. The
+// generation is increased when it is cleared, so that a value queried before
+// the clearing is not cached.
+type permissionCache struct {
+	lock       sync.RWMutex
+	generation uint64
+	roles      map[int64]cachedPermissions
+}
+
+var rolePermissions = &permissionCache{roles: make(map[int64]cachedPermissions)}
+
+// get return the cached permissions of the role, they are loaded by the load
+// function and cached if they are not. The permissions are not cached if the
+// loading fails.
+func (c *permissionCache) get(role int64, load func() ([]PermissionModel, error)) []PermissionModel {
+	c.lock.RLock()
+	item, ok := c.roles[role]
+	generation := c.generation
+	c.lock.RUnlock()
+
+	if ok && time.Now().Before(item.expires) {
+		return item.permissions
+	}
+
+	permissions, err := load()
+	if err != nil {
+		return permissions
+	}
+
+	c.lock.Lock()
+	if c.generation == generation {
+		c.roles[role] = cachedPermissions{permissions: permissions, expires: time.Now().Add(permissionCacheTTL)}
+	}
+	c.lock.Unlock()
+
+	return permissions
+}
+
+func (c *permissionCache) clear() {
+	c.lock.Lock()
+	c.generation++
+	c.roles = make(map[int64]cachedPermissions)
+	c.lock.Unlock()
+}
+
+// PermissionChanged clear the cached permissions of all the roles, which is
+// called after the permissions or the permissions of the roles are changed
+// and committed.
+func PermissionChanged() {
+	rolePermissions.clear()
+}
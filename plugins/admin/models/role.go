@@ -0,0 +1,160 @@
+package models
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/modules/db/dialect"
+)
+
+// RoleModel is role model structure. The id of a role is the role value of
+// the portal user.
+type RoleModel struct {
+	Base
+
+	Id        int64
+	Name      string
+	Slug      string
+	CreatedAt string
+	UpdatedAt string
+}
+
+// Role return a default role model.
+func Role() RoleModel {
+	return RoleModel{Base: Base{TableName: "goadmin_roles"}}
+}
+
+// RoleWithId return a default role model of given id.
+func RoleWithId(id string) RoleModel {
+	idInt, _ := strconv.Atoi(id)
+	return RoleModel{Base: Base{TableName: "goadmin_roles"}, Id: int64(idInt)}
+}
+
+func (t RoleModel) SetConn(con db.Connection) RoleModel {
+	t.Conn = con
+	return t
+}
+
+func (t RoleModel) WithTx(tx *sql.Tx) RoleModel {
+	t.Tx = tx
+	return t
+}
+
+func (t RoleModel) table(table string) *db.SQL {
+	return t.Table(table).WithTx(t.Tx)
+}
+
+// Find return the role model of given id.
+func (t RoleModel) Find(id interface{}) RoleModel {
+	item, _ := t.Table(t.TableName).Find(id)
+	return t.MapToModel(item)
+}
+
+// IsEmpty check the role model is empty or not.
+func (t RoleModel) IsEmpty() bool {
+	return t.Slug == ""
+}
+
+// IsSlugExist check the slug is exist or not.
+func (t RoleModel) IsSlugExist(slug string, id string) bool {
+	if id == "" {
+		check, _ := t.Table(t.TableName).Where("slug", "=", slug).First()
+		return check != nil
+	}
+	check, _ := t.Table(t.TableName).
+		Where("slug", "=", slug).
+		Where("id", "!=", id).
+		First()
+	return check != nil
+}
+
+// New create a role model.
+func (t RoleModel) New(id int64, name, slug string) (RoleModel, error) {
+
+	_, err := t.table(t.TableName).Insert(dialect.H{
+		"id":   id,
+		"name": name,
+		"slug": slug,
+	})
+
+	t.Id = id
+	t.Name = name
+	t.Slug = slug
+
+	return t, err
+}
+
+// Update update the role model.
+func (t RoleModel) Update(name, slug string) (int64, error) {
+	return t.table(t.TableName).
+		Where("id", "=", t.Id).
+		Update(dialect.H{
+			"name":       name,
+			"slug":       slug,
+			"updated_at": time.Now().Format("2006-01-02 15:04:05"),
+		})
+}
+
+// CheckPermission check the permission of role.
+func (t RoleModel) CheckPermission(permissionId string) bool {
+	checkPermission, _ := t.table("goadmin_role_permissions").
+		Where("permission_id", "=", permissionId).
+		Where("role_id", "=", t.Id).
+		First()
+	return checkPermission != nil
+}
+
+// AddPermission add the permission to the role.
+func (t RoleModel) AddPermission(permissionId string) (int64, error) {
+	if permissionId != "" {
+		if !t.CheckPermission(permissionId) {
+			return t.table("goadmin_role_permissions").
+				Insert(dialect.H{
+					"permission_id": permissionId,
+					"role_id":       t.Id,
+				})
+		}
+	}
+	return 0, nil
+}
+
+// DeletePermissions delete all the permissions of the role.
+func (t RoleModel) DeletePermissions() error {
+	return t.table("goadmin_role_permissions").
+		Where("role_id", "=", t.Id).
+		Delete()
+}
+
+// Delete delete the role model together with its permissions and menus. The
+// hooks of the changed menus are not called within a transaction, MenuChanged
+// should be called after it is committed.
+func (t RoleModel) Delete() error {
+	err := t.table(t.TableName).Where("id", "=", t.Id).Delete()
+	if db.CheckError(err, db.DELETE) {
+		return err
+	}
+	err = t.DeletePermissions()
+	if db.CheckError(err, db.DELETE) {
+		return err
+	}
+	err = t.table("goadmin_role_menu").Where("role_id", "=", t.Id).Delete()
+	if db.CheckError(err, db.DELETE) {
+		return err
+	}
+	if t.Tx == nil {
+		menuChanged()
+	}
+	return nil
+}
+
+// MapToModel get the role model from given map.
+func (t RoleModel) MapToModel(m map[string]interface{}) RoleModel {
+	t.Id, _ = m["id"].(int64)
+	t.Name, _ = m["name"].(string)
+	t.Slug, _ = m["slug"].(string)
+	t.CreatedAt, _ = m["created_at"].(string)
+	t.UpdatedAt, _ = m["updated_at"].(string)
+	return t
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/modules/db/dialect"
+)
+
+// SavedViewModel is saved view model structure. A saved view is the query
+// parameters of the info page of a table, which are the filters, the sort,
+// the visible columns and the page size. It is shared with the users of the
+// role if RoleId is not empty.
+type SavedViewModel struct {
+	Base
+
+	Id        int64
+	UserId    int64
+	Prefix    string
+	Name      string
+	Params    string
+	RoleId    int64
+	Shared    bool
+	CreatedAt string
+	UpdatedAt string
+}
+
+// SavedView return a default saved view model.
+func SavedView() SavedViewModel {
+	return SavedViewModel{Base: Base{TableName: "goadmin_saved_views"}}
+}
+
+func (t SavedViewModel) SetConn(con db.Connection) SavedViewModel {
+	t.Conn = con
+	return t
+}
+
+// New create a new saved view model of the user. The view is shared with
+// the role if shared is true.
+func (t SavedViewModel) New(userId int64, prefix, name, params string, shared bool, roleId int64) (SavedViewModel, error) {
+
+	var role interface{}
+	if shared {
+		role = roleId
+	}
+
+	id, err := t.Table(t.TableName).Insert(dialect.H{
+		"user_id": userId,
+		"prefix":  prefix,
+		"name":    name,
+		"params":  params,
+		"role_id": role,
+	})
+
+	t.Id = id
+	t.UserId = userId
+	t.Prefix = prefix
+	t.Name = name
+	t.Params = params
+	t.Shared = shared
+	if shared {
+		t.RoleId = roleId
+	}
+
+	return t, err
+}
+
+// List return the saved views of the table of the prefix, which are the
+// views of the user and the views shared with the role of the user.
+func (t SavedViewModel) List(userId, roleId int64, prefix string) []SavedViewModel {
+	items, _ := t.Table(t.TableName).
+		Where("prefix", "=", prefix).
+		WhereRaw("(user_id = ? or role_id = ?)", userId, roleId).
+		OrderBy("name", "asc").
+		All()
+
+	views := make([]SavedViewModel, len(items))
+	for i, item := range items {
+		views[i] = t.MapToModel(item)
+	}
+	return views
+}
+
+// Delete delete the saved view of the id which is of the user.
+func (t SavedViewModel) Delete(id, userId int64) error {
+	return t.Table(t.TableName).
+		Where("id", "=", id).
+		Where("user_id", "=", userId).
+		Delete()
+}
+
+// MapToModel get the saved view model from given map.
+func (t SavedViewModel) MapToModel(m map[string]interface{}) SavedViewModel {
+	t.Id, _ = m["id"].(int64)
+	t.UserId, _ = m["user_id"].(int64)
+	t.Prefix, _ = m["prefix"].(string)
+	t.Name, _ = m["name"].(string)
+	t.Params, _ = m["params"].(string)
+	t.RoleId, t.Shared = m["role_id"].(int64)
+	t.CreatedAt, _ = m["created_at"].(string)
+	t.UpdatedAt, _ = m["updated_at"].(string)
+	return t
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavedViewMapToModel(t *testing.T) {
+	view := SavedView().MapToModel(map[string]interface{}{
+		"id":      int64(1),
+		"user_id": int64(2),
+		"prefix":  "users",
+		"name":    "Active",
+		"params":  "status=1",
+		"role_id": nil,
+	})
+	assert.Equal(t, view.Id, int64(1))
+	assert.Equal(t, view.Params, "status=1")
+	assert.False(t, view.Shared)
+
+	view = SavedView().MapToModel(map[string]interface{}{"id": int64(1), "role_id": int64(0)})
+	assert.True(t, view.Shared)
+	assert.Equal(t, view.RoleId, int64(0))
+}
@@ -1,15 +1,26 @@
 package models
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/base64"
+	"errors"
 	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/GoAdminGroup/go-admin/modules/config"
 	"github.com/GoAdminGroup/go-admin/modules/db"
 	"github.com/GoAdminGroup/go-admin/modules/db/dialect"
+	"github.com/GoAdminGroup/go-admin/modules/logger"
+	"github.com/GoAdminGroup/go-admin/modules/utils"
+	constant2 "github.com/GoAdminGroup/go-admin/plugins/admin/modules/constant"
 	"github.com/dypflying/chime-common/constant"
 )
 
@@ -27,6 +38,15 @@ type UserModel struct {
 	UpdatedAt string `json:"updated_at"`
 	LevelName string `json:"level_name"`
 
+	Permissions []PermissionModel `json:"permissions"`
+
+	TOTPSecret      string   `json:"-"`
+	TOTPEnabled     bool     `json:"totp_enabled"`
+	RecoveryCodes   []string `json:"-"`
+	TOTPLastStep    int64    `json:"-"`
+	TOTPTries       int64    `json:"-"`
+	TOTPLockedUntil int64    `json:"-"`
+
 	//no use
 	Id            int64          `json:"id"`
 	RememberToken string         `json:"remember_token"`
@@ -94,21 +114,155 @@ func (t UserModel) Template(str string) string {
 	return t.cacheReplacer.Replace(str)
 }
 
+// CheckPermissionByUrlMethod check the path and method against the
+// permissions of the user's role. A permission matches when its http method
+// is empty or contains the method, and one of its http paths equals the path
+// or matches it as a regular expression, with the query parameters of the
+// http path, if any, present in the request. Paths of the menus which are not
+// assigned to the role are always denied, and the paths declared by
+// AllowPaths are always allowed. The other paths are denied if the role has
+// any permission. The users without a permission are denied them as well if
+// config PermissionDefaultDeny is set, otherwise they are allowed as the
+// menus are the only restrictions.
 func (t UserModel) CheckPermissionByUrlMethod(path, method string, formParams url.Values) bool {
 
 	if t.IsSuperAdmin() {
 		return true
 	}
+
+	if path == "" {
+		return false
+	}
+
 	originalPath := strings.Split(path, "?")[0]
 	if strings.Index(originalPath, config.Prefix()) == 0 {
 		originalPath = originalPath[len(config.Prefix()):]
 	}
+	if pathAllowed(originalPath) {
+		return true
+	}
 	if _, ok := t.BlackMenuMap[originalPath]; ok {
 		return false
 	}
+
+	path = utils.ReplaceAll(path, constant2.EditPKKey, "id", constant2.DetailPKKey, "id")
+
+	path, params := getParam(path)
+
+	if len(path) > 1 && path[len(path)-1] == '/' {
+		path = path[:len(path)-1]
+	}
+	for key, value := range formParams {
+		if len(value) > 0 {
+			params.Add(key, value[0])
+		}
+	}
+
+	for _, v := range t.Permissions {
+
+		if v.HttpMethod[0] != "" && !inMethodArr(v.HttpMethod, method) {
+			continue
+		}
+
+		if strings.TrimSpace(v.HttpPath[0]) == "*" {
+			return true
+		}
+
+		for i := 0; i < len(v.HttpPath); i++ {
+
+			if strings.TrimSpace(v.HttpPath[i]) == "" {
+				continue
+			}
+
+			matchPath := config.Url(t.Template(strings.TrimSpace(v.HttpPath[i])))
+			matchPath, matchParam := getParam(matchPath)
+
+			if matchPath == path && t.checkParam(params, matchParam) {
+				return true
+			}
+
+			reg, err := regexp.Compile("^" + matchPath + "$")
+
+			if err != nil {
+				logger.Error("CheckPermissions error: ", err)
+				continue
+			}
+
+			if reg.MatchString(path) && t.checkParam(params, matchParam) {
+				return true
+			}
+		}
+	}
+
+	return len(t.Permissions) == 0 && !config.GetPermissionDefaultDeny()
+}
+
+var (
+	allowedPaths     []*regexp.Regexp
+	allowedPathsLock sync.RWMutex
+)
+
+// AllowPaths let all the login users reach the paths, which are the regular
+// expressions of the paths without the url prefix. They are declared along
+// with the routes of the pages which only serve the login user or check the
+// access themselves, such as the logout.
+func AllowPaths(paths ...string) {
+	allowedPathsLock.Lock()
+	defer allowedPathsLock.Unlock()
+	for _, path := range paths {
+		allowedPaths = append(allowedPaths, regexp.MustCompile("^"+path+"$"))
+	}
+}
+
+// pathAllowed return true if the path without the url prefix is declared by
+// AllowPaths.
+func pathAllowed(path string) bool {
+	if len(path) > 1 && path[len(path)-1] == '/' {
+		path = path[:len(path)-1]
+	}
+	allowedPathsLock.RLock()
+	defer allowedPathsLock.RUnlock()
+	for _, reg := range allowedPaths {
+		if reg.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+func getParam(u string) (string, url.Values) {
+	m := make(url.Values)
+	urr := strings.Split(u, "?")
+	if len(urr) > 1 {
+		m, _ = url.ParseQuery(urr[1])
+	}
+	return urr[0], m
+}
+
+func (t UserModel) checkParam(src, comp url.Values) bool {
+	for key, value := range comp {
+		v, find := src[key]
+		if !find {
+			return false
+		}
+		for i := 0; i < len(value); i++ {
+			if i >= len(v) || v[i] != t.Template(value[i]) {
+				return false
+			}
+		}
+	}
 	return true
 }
 
+func inMethodArr(arr []string, str string) bool {
+	for i := 0; i < len(arr); i++ {
+		if strings.EqualFold(strings.TrimSpace(arr[i]), str) {
+			return true
+		}
+	}
+	return false
+}
+
 func (t UserModel) HideUserCenterEntrance() bool {
 	return false
 }
@@ -124,8 +278,24 @@ func (t UserModel) UpdateAvatar(avatar string) {
 	t.Avatar = avatar
 }
 
+// MenusLoader load the menu ids and the black menu map of the role of the
+// user, which are shared by the users of the role and must not be changed.
+// It is set by the menu module to cache them, WithMenus queries them if it
+// is nil.
+var MenusLoader func(t UserModel) ([]int64, map[string]any)
+
 // WithMenus query the menu info of the user.
 func (t UserModel) WithMenus() UserModel {
+	if MenusLoader != nil {
+		t.MenuIds, t.BlackMenuMap = MenusLoader(t)
+		return t
+	}
+	return t.QueryMenus()
+}
+
+// QueryMenus query the menu info of the user from the database without the
+// cache of the MenusLoader.
+func (t UserModel) QueryMenus() UserModel {
 
 	var menuIdsModel []map[string]interface{}
 
@@ -188,6 +358,51 @@ func (t UserModel) WithMenus() UserModel {
 	return t
 }
 
+// WithPermissions query the permissions of the user's role, which are cached
+// by role until they are changed.
+func (t UserModel) WithPermissions() UserModel {
+
+	t.Permissions = rolePermissions.get(t.Role, func() ([]PermissionModel, error) {
+		permissions, err := t.Table("goadmin_role_permissions").
+			LeftJoin("goadmin_permissions", "goadmin_permissions.id", "=", "goadmin_role_permissions.permission_id").
+			Select("goadmin_permissions.http_method", "goadmin_permissions.http_path",
+				"goadmin_permissions.id", "goadmin_permissions.name", "goadmin_permissions.slug",
+				"goadmin_permissions.created_at", "goadmin_permissions.updated_at").
+			Where("goadmin_role_permissions.role_id", "=", t.Role).
+			All()
+		if err != nil {
+			return nil, err
+		}
+
+		res := make([]PermissionModel, len(permissions))
+		for i := 0; i < len(permissions); i++ {
+			res[i] = Permission().MapToModel(permissions[i])
+		}
+		return res, nil
+	})
+
+	return t
+}
+
+// WithRole query the role of the user.
+func (t UserModel) WithRole() UserModel {
+	item, _ := t.Table("goadmin_role_users").
+		Select("role_id").
+		Where("user_id", "=", t.Id).
+		First()
+	t.Role, _ = item["role_id"].(int64)
+	return t
+}
+
+// AddRole add a role to the user model.
+func (t UserModel) AddRole(roleId int64) (int64, error) {
+	return t.WithTx(t.Tx).Table("goadmin_role_users").
+		Insert(dialect.H{
+			"role_id": roleId,
+			"user_id": t.Id,
+		})
+}
+
 // New create a user model.
 func (t UserModel) New(username, password, name, avatar string) (UserModel, error) {
 
@@ -242,6 +457,133 @@ func (t UserModel) UpdatePwd(password string) UserModel {
 	return t
 }
 
+// SetTOTPSecret set a new secret of the user which is not enabled until
+// the user confirms it with a code. The secret is encrypted with the secret
+// key of the TOTP config.
+func (t UserModel) SetTOTPSecret(secret string) (UserModel, error) {
+	encrypted, err := encryptTOTPSecret(secret)
+	if err != nil {
+		return t, err
+	}
+
+	_, err = t.Table(t.TableName).
+		Where("id", "=", t.Id).
+		Update(dialect.H{
+			"totp_secret":         encrypted,
+			"totp_enabled":        0,
+			"totp_recovery_codes": "",
+			"totp_last_step":      0,
+		})
+
+	t.TOTPSecret = secret
+	t.TOTPEnabled = false
+	t.RecoveryCodes = nil
+	t.TOTPLastStep = 0
+	return t, err
+}
+
+// EnableTOTP enable the secret of the user with the hashes of the recovery
+// codes.
+func (t UserModel) EnableTOTP(recoveryCodes []string) (UserModel, error) {
+	_, err := t.Table(t.TableName).
+		Where("id", "=", t.Id).
+		Update(dialect.H{
+			"totp_enabled":        1,
+			"totp_recovery_codes": strings.Join(recoveryCodes, ","),
+		})
+
+	t.TOTPEnabled = true
+	t.RecoveryCodes = recoveryCodes
+	return t, err
+}
+
+// DisableTOTP remove the secret and the recovery codes of the user.
+func (t UserModel) DisableTOTP() (UserModel, error) {
+	return t.SetTOTPSecret("")
+}
+
+// UpdateRecoveryCodes replace the hashes of the recovery codes of the user.
+func (t UserModel) UpdateRecoveryCodes(recoveryCodes []string) (UserModel, error) {
+	_, err := t.Table(t.TableName).
+		Where("id", "=", t.Id).
+		Update(dialect.H{
+			"totp_recovery_codes": strings.Join(recoveryCodes, ","),
+		})
+
+	t.RecoveryCodes = recoveryCodes
+	return t, err
+}
+
+// UseRecoveryCode check the hash of a recovery code of the user and remove
+// it when matched, so that every recovery code can only be used once.
+func (t UserModel) UseRecoveryCode(hash string) bool {
+	for i, code := range t.RecoveryCodes {
+		if code != hash {
+			continue
+		}
+		codes := make([]string, 0, len(t.RecoveryCodes)-1)
+		codes = append(codes, t.RecoveryCodes[:i]...)
+		codes = append(codes, t.RecoveryCodes[i+1:]...)
+		// the hash is removed only if no other request has used it, or the
+		// update returns the error of no affected row.
+		_, err := t.Table(t.TableName).
+			Where("id", "=", t.Id).
+			Where("totp_recovery_codes", "=", strings.Join(t.RecoveryCodes, ",")).
+			Update(dialect.H{
+				"totp_recovery_codes": strings.Join(codes, ","),
+			})
+		return err == nil
+	}
+	return false
+}
+
+// UseTOTPStep record the time step of a used code of the user. It returns
+// false when the step or a later one has been used, so that every code can
+// only be used once.
+func (t UserModel) UseTOTPStep(step int64) bool {
+	_, err := t.Table(t.TableName).
+		Where("id", "=", t.Id).
+		Where("totp_last_step", "<", step).
+		Update(dialect.H{
+			"totp_last_step": step,
+			"totp_tries":     0,
+		})
+	return err == nil
+}
+
+// FailTOTP count a wrong code of the user, and lock the user until the
+// given time after the max tries. It returns true when the user is locked.
+func (t UserModel) FailTOTP(maxTries int64, until time.Time) (bool, error) {
+	_, err := t.Table(t.TableName).
+		Where("id", "=", t.Id).
+		UpdateRaw("totp_tries = totp_tries + 1").
+		Update(dialect.H{})
+	if db.CheckError(err, db.UPDATE) {
+		return false, err
+	}
+
+	item, err := t.Table(t.TableName).Select("totp_tries").Find(t.Id)
+	if err != nil {
+		return false, err
+	}
+	if tries, _ := item["totp_tries"].(int64); tries < maxTries {
+		return false, nil
+	}
+
+	_, err = t.Table(t.TableName).
+		Where("id", "=", t.Id).
+		Update(dialect.H{
+			"totp_tries":        0,
+			"totp_locked_until": until.Unix(),
+		})
+	return true, err
+}
+
+// TOTPLocked check the user is locked by the wrong codes.
+func (t UserModel) TOTPLocked() bool {
+	return time.Now().Unix() < t.TOTPLockedUntil
+}
+
 // MapToModel get the user model from given map.
 func (t UserModel) MapToModel(m map[string]interface{}) UserModel {
 	t.Id, _ = m["id"].(int64)
@@ -252,5 +594,74 @@ func (t UserModel) MapToModel(m map[string]interface{}) UserModel {
 	t.RememberToken, _ = m["remember_token"].(string)
 	t.CreatedAt, _ = m["created_at"].(string)
 	t.UpdatedAt, _ = m["updated_at"].(string)
+	if secret, _ := m["totp_secret"].(string); secret != "" {
+		var err error
+		if t.TOTPSecret, err = decryptTOTPSecret(secret); err != nil {
+			logger.Error("decrypt totp secret error: ", err)
+		}
+	}
+	enabled, _ := m["totp_enabled"].(int64)
+	t.TOTPEnabled = enabled == 1
+	if codes, _ := m["totp_recovery_codes"].(string); codes != "" {
+		t.RecoveryCodes = strings.Split(codes, ",")
+	}
+	t.TOTPLastStep, _ = m["totp_last_step"].(int64)
+	t.TOTPTries, _ = m["totp_tries"].(int64)
+	t.TOTPLockedUntil, _ = m["totp_locked_until"].(int64)
 	return t
 }
+
+const totpSecretPrefix = "aes:"
+
+// totpCipher return the cipher of the secret key of the TOTP config.
+func totpCipher() (cipher.AEAD, error) {
+	key := config.GetAuth().TOTP.SecretKey
+	if key == "" {
+		return nil, errors.New("no secret key of the totp config")
+	}
+	sum := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func encryptTOTPSecret(secret string) (string, error) {
+	if secret == "" {
+		return "", nil
+	}
+	gcm, err := totpCipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return totpSecretPrefix + base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(secret), nil)), nil
+}
+
+// decryptTOTPSecret decrypt the stored secret. The secrets stored before
+// the encryption are returned as they are.
+func decryptTOTPSecret(value string) (string, error) {
+	if !strings.HasPrefix(value, totpSecretPrefix) {
+		return value, nil
+	}
+	gcm, err := totpCipher()
+	if err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(value[len(totpSecretPrefix):])
+	if err != nil {
+		return "", err
+	}
+	if len(data) < gcm.NonceSize() {
+		return "", errors.New("wrong totp secret")
+	}
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserModel_CheckPermissionByUrlMethod(t *testing.T) {
+	cfg := config.Initialize(&config.Config{UrlPrefix: "admin", PermissionDefaultDeny: true})
+
+	user := UserModel{
+		Id: 3,
+		Permissions: []PermissionModel{
+			Permission().MapToModel(map[string]interface{}{
+				"http_method": "GET",
+				"http_path":   "/info/users\n/info/posts/.*",
+			}),
+			Permission().MapToModel(map[string]interface{}{
+				"http_method": "",
+				"http_path":   "/info/normal_manager/edit?id={{.AuthId}}",
+			}),
+		},
+		BlackMenuMap: map[string]any{"/info/posts/hidden": struct{}{}},
+	}
+
+	AllowPaths("/logout", "/info/api_tokens", "/delete/api_tokens", "/saved_views/[^/]+",
+		"/search", "/dashboard_widgets/[^/]+/data")
+
+	assert.True(t, user.CheckPermissionByUrlMethod("/admin/info/users", "GET", url.Values{}))
+	assert.True(t, user.CheckPermissionByUrlMethod("/admin/info/users/?__page=2", "get", url.Values{}))
+	assert.False(t, user.CheckPermissionByUrlMethod("/admin/info/users", "POST", url.Values{}))
+	assert.False(t, user.CheckPermissionByUrlMethod("/admin/info/users/new", "GET", url.Values{}))
+	assert.True(t, user.CheckPermissionByUrlMethod("/admin/info/posts/detail", "GET", url.Values{}))
+	assert.False(t, user.CheckPermissionByUrlMethod("/admin/info/posts/hidden", "GET", url.Values{}))
+	assert.True(t, user.CheckPermissionByUrlMethod("/admin/info/normal_manager/edit?__goadmin_edit_pk=3", "POST", url.Values{}))
+	assert.False(t, user.CheckPermissionByUrlMethod("/admin/info/normal_manager/edit?__goadmin_edit_pk=4", "POST", url.Values{}))
+	assert.True(t, user.CheckPermissionByUrlMethod("/admin/logout", "GET", url.Values{}))
+	assert.True(t, user.CheckPermissionByUrlMethod("/admin/info/api_tokens", "GET", url.Values{}))
+	assert.True(t, user.CheckPermissionByUrlMethod("/admin/delete/api_tokens", "POST", url.Values{}))
+	assert.False(t, user.CheckPermissionByUrlMethod("/admin/api/v1/api_tokens", "POST", url.Values{}))
+	assert.True(t, user.CheckPermissionByUrlMethod("/admin/saved_views/normal_manager", "POST", url.Values{}))
+	assert.True(t, user.CheckPermissionByUrlMethod("/admin/search?q=admin", "GET", url.Values{}))
+	assert.True(t, user.CheckPermissionByUrlMethod("/admin/dashboard_widgets/1/data", "GET", url.Values{}))
+	assert.False(t, user.CheckPermissionByUrlMethod("/admin/searches", "GET", url.Values{}))
+
+	user.Permissions = append(user.Permissions, Permission().MapToModel(map[string]interface{}{
+		"http_path": "*",
+	}))
+	assert.True(t, user.CheckPermissionByUrlMethod("/admin/info/users/new", "POST", url.Values{}))
+	assert.False(t, user.CheckPermissionByUrlMethod("/admin/info/posts/hidden", "GET", url.Values{}))
+
+	// without the default deny, the rules still deny the paths they do not
+	// match, and only the menus restrict the users without rules.
+	cfg.PermissionDefaultDeny = false
+
+	user.Permissions = user.Permissions[:2]
+	assert.True(t, user.CheckPermissionByUrlMethod("/admin/info/users", "GET", url.Values{}))
+	assert.False(t, user.CheckPermissionByUrlMethod("/admin/info/users/new", "GET", url.Values{}))
+	assert.True(t, user.CheckPermissionByUrlMethod("/admin/logout", "GET", url.Values{}))
+
+	user.Permissions = nil
+	assert.True(t, user.CheckPermissionByUrlMethod("/admin/info/users/new", "POST", url.Values{}))
+	assert.False(t, user.CheckPermissionByUrlMethod("/admin/info/posts/hidden", "GET", url.Values{}))
+}
+
+func TestPermissionCache(t *testing.T) {
+	c := &permissionCache{roles: make(map[int64]cachedPermissions)}
+
+	loads := 0
+	load := func() ([]PermissionModel, error) {
+		loads++
+		return []PermissionModel{{Id: int64(loads)}}, nil
+	}
+
+	assert.Equal(t, int64(1), c.get(2, load)[0].Id)
+	assert.Equal(t, int64(1), c.get(2, load)[0].Id)
+	assert.Equal(t, int64(2), c.get(3, load)[0].Id)
+
+	c.clear()
+	assert.Equal(t, int64(3), c.get(2, load)[0].Id)
+
+	// the failed loading is not cached.
+	assert.Nil(t, c.get(4, func() ([]PermissionModel, error) {
+		return nil, errors.New("failed")
+	}))
+	assert.Equal(t, int64(4), c.get(4, load)[0].Id)
+}
@@ -13,10 +13,14 @@ const (
 
 	EditPKKey   = "__goadmin_edit_pk"
 	DetailPKKey = "__goadmin_detail_pk"
+	RestPKKey   = "__goadmin_rest_pk"
 	PrefixKey   = "__prefix"
 
+	ExportFormatKey = "__goadmin_export_format"
+
 	IframeKey   = "__goadmin_iframe"
 	IframeIDKey = "__goadmin_iframe_id"
 
 	ContextNodeNeedAuth = constant.ContextNodeNeedAuth
+	ContextNodeAction   = constant.ContextNodeAction
 )
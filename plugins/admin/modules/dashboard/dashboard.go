@@ -0,0 +1,380 @@
+package dashboard
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+)
+
+// The types of the widgets.
+const (
+	Line  = "line"
+	Bar   = "bar"
+	Pie   = "pie"
+	Radar = "radar"
+	Stat  = "stat"
+	Table = "table"
+)
+
+// The sources of the data of the widgets.
+const (
+	SourceSQL      = "sql"
+	SourceFunction = "function"
+)
+
+// Types are the types of the widgets.
+var Types = []string{Line, Bar, Pie, Radar, Stat, Table}
+
+// Data is the data of a widget. The first column of a chart is the labels and
+// the others are the data sets, the value of a stat is the first column of the
+// first row.
+type Data struct {
+	Columns []string
+	Rows    [][]interface{}
+}
+
+// DataFn return the data of a widget.
+type DataFn func(ctx context.Context, conn db.Connection) (Data, error)
+
+var List = make(map[string]DataFn)
+
+// Add register the data function which the widgets of the function source use
+// by the name.
+func Add(name string, fn DataFn) {
+	if _, exist := List[name]; exist {
+		panic("dashboard data function exist")
+	}
+	List[name] = fn
+}
+
+func Get(name string) (DataFn, bool) {
+	fn, ok := List[name]
+	return fn, ok
+}
+
+// Aggregate return the data function of the time buckets of the table, which
+// is the count of the rows, or the sum or the average of the field of each
+// hour, day or month of the time field from the last span to now. The data
+// has the columns of the labels and the values, and the missing buckets are
+// zero.
+func Aggregate(table, fn, field, timeField, unit string, span time.Duration) DataFn {
+	return func(ctx context.Context, conn db.Connection) (Data, error) {
+		var (
+			to   = time.Now()
+			from = to.Add(-span)
+		)
+
+		buckets, err := db.WithDriver(conn).WithContext(ctx).Table(table).
+			Where(timeField, ">=", from.Format("2006-01-02 15:04:05")).
+			Aggregate(fn, field, timeField, unit)
+		if err != nil {
+			return Data{}, err
+		}
+
+		buckets = buckets.Fill(from, to, unit)
+		data := Data{Columns: []string{unit, fn}, Rows: make([][]interface{}, len(buckets))}
+		for i, bucket := range buckets {
+			data.Rows[i] = []interface{}{bucket.Label, bucket.Value}
+		}
+		return data, nil
+	}
+}
+
+// Widget is a widget of a dashboard.
+type Widget struct {
+	Title  string
+	Type   string
+	Source string
+	Query  string
+	Width  int
+}
+
+// GetData return the data of the widget from the query or the data function.
+func (w Widget) GetData(ctx context.Context, conn db.Connection) (Data, error) {
+	if w.Source == SourceFunction {
+		fn, ok := Get(strings.TrimSpace(w.Query))
+		if !ok {
+			return Data{}, errors.New("dashboard data function not found: " + w.Query)
+		}
+		return fn(ctx, conn)
+	}
+	return Query(ctx, conn, w.Query)
+}
+
+// Connection is the name of the database connection which the queries of the
+// widgets use if it is configured, which should be of a read only database
+// user. The default connection is used otherwise.
+const Connection = "dashboard"
+
+// writeKeywords are the keywords which change the database or write files,
+// which are not allowed in the queries of the widgets.
+var writeKeywords = map[string]bool{
+	"insert": true, "update": true, "delete": true, "merge": true, "upsert": true,
+	"drop": true, "alter": true, "create": true, "truncate": true, "rename": true,
+	"grant": true, "revoke": true, "into": true, "outfile": true, "dumpfile": true,
+	"call": true, "exec": true, "execute": true, "copy": true, "lock": true,
+	"attach": true, "detach": true, "pragma": true, "vacuum": true,
+}
+
+// systemTablePrefix is the prefix of the tables of the admin, such as the
+// users and the sessions, which are not allowed in the queries of the
+// widgets.
+const systemTablePrefix = "goadmin_"
+
+// CheckQuery check the query is a single select statement, which does not
+// write anything and does not query the system tables.
+func CheckQuery(query string) error {
+	query = strings.TrimSuffix(strings.TrimSpace(query), ";")
+	words, err := queryWords(query)
+	if err != nil {
+		return err
+	}
+	if len(words) == 0 || (words[0] != "select" && words[0] != "with") {
+		return errors.New("the query of a widget must be a select statement")
+	}
+	for _, word := range words {
+		if word == ";" {
+			return errors.New("the query of a widget must be a single statement")
+		}
+		if writeKeywords[word] {
+			return errors.New("the query of a widget must not write: " + word)
+		}
+	}
+	if strings.Contains(strings.ToLower(query), systemTablePrefix) {
+		return errors.New("the query of a widget must not query the system tables")
+	}
+	return nil
+}
+
+// queryWords return the lower case words and the semicolons of the query out
+// of the string literals, the quoted identifiers and the comments. The
+// backslashes, the # and the executable comments of mysql are not allowed, as
+// the databases do not agree with the ends of the literals and the comments
+// with them.
+func queryWords(query string) ([]string, error) {
+	if strings.Contains(query, "\\") || strings.Contains(query, "/*!") {
+		return nil, errors.New("the query of a widget must not contain backslashes or executable comments")
+	}
+
+	var (
+		words = make([]string, 0)
+		word  strings.Builder
+		flush = func() {
+			if word.Len() > 0 {
+				words = append(words, strings.ToLower(word.String()))
+				word.Reset()
+			}
+		}
+	)
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		var end string
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end = string(c)
+		case c == '[':
+			end = "]"
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			end = "\n"
+		case c == '#':
+			return nil, errors.New("the query of a widget must not contain # out of the literals")
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end = "*/"
+			i++
+		case c == ';':
+			flush()
+			words = append(words, ";")
+			continue
+		case c == '_' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80:
+			word.WriteByte(c)
+			continue
+		default:
+			flush()
+			continue
+		}
+		flush()
+		j := strings.Index(query[i+1:], end)
+		if j == -1 {
+			if end == "\n" {
+				break
+			}
+			return nil, errors.New("the query of a widget has an unclosed literal or comment")
+		}
+		i += j + len(end)
+	}
+	flush()
+
+	return words, nil
+}
+
+// Query run the select query with the dashboard connection, or the default
+// one, in a read only transaction which is rolled back, and return the rows
+// in the order of the columns.
+func Query(ctx context.Context, conn db.Connection, query string) (Data, error) {
+
+	if err := CheckQuery(query); err != nil {
+		return Data{}, err
+	}
+
+	sqlDB := conn.GetDB(Connection)
+	if sqlDB == nil {
+		sqlDB = conn.GetDB("default")
+	}
+
+	tx, rollback, err := readOnlyTx(ctx, sqlDB, conn.Name())
+	if err != nil {
+		return Data{}, err
+	}
+	defer rollback()
+
+	rows, err := tx.QueryContext(ctx, strings.TrimSuffix(strings.TrimSpace(query), ";"))
+	if err != nil {
+		return Data{}, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return Data{}, err
+	}
+
+	data := Data{Columns: columns, Rows: make([][]interface{}, 0)}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return Data{}, err
+		}
+		for i, value := range values {
+			if b, ok := value.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+		data.Rows = append(data.Rows, values)
+	}
+
+	return data, rows.Err()
+}
+
+// readOnlyTx begin a read only transaction of the driver, which is rolled
+// back by the returned function. A transaction of sqlite is made read only
+// by the query only pragma of its connection, which is reset when it is
+// rolled back, and the one of mssql which has no read only transactions is
+// only rolled back.
+func readOnlyTx(ctx context.Context, sqlDB *sql.DB, driver string) (*sql.Tx, func(), error) {
+	switch driver {
+	case db.DriverMssql:
+		tx, err := sqlDB.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		return tx, func() { _ = tx.Rollback() }, nil
+	case db.DriverSqlite:
+		conn, err := sqlDB.Conn(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		reset := func() {
+			_, _ = conn.ExecContext(context.Background(), "PRAGMA query_only = 0")
+			_ = conn.Close()
+		}
+		if _, err := conn.ExecContext(ctx, "PRAGMA query_only = 1"); err != nil {
+			reset()
+			return nil, nil, err
+		}
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			reset()
+			return nil, nil, err
+		}
+		return tx, func() {
+			_ = tx.Rollback()
+			reset()
+		}, nil
+	default:
+		tx, err := sqlDB.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+		if err != nil {
+			return nil, nil, err
+		}
+		return tx, func() { _ = tx.Rollback() }, nil
+	}
+}
+
+// Labels return the first column of the rows.
+func (d Data) Labels() []string {
+	labels := make([]string, len(d.Rows))
+	for i, row := range d.Rows {
+		if len(row) > 0 {
+			labels[i] = String(row[0])
+		}
+	}
+	return labels
+}
+
+// DataSets return the names and the values of the columns except the first.
+func (d Data) DataSets() ([]string, [][]float64) {
+	if len(d.Columns) < 2 {
+		return []string{}, [][]float64{}
+	}
+	names := d.Columns[1:]
+	values := make([][]float64, len(names))
+	for i := range names {
+		values[i] = make([]float64, len(d.Rows))
+		for j, row := range d.Rows {
+			if i+1 < len(row) {
+				values[i][j] = Float(row[i+1])
+			}
+		}
+	}
+	return names, values
+}
+
+// Value return the first column of the first row.
+func (d Data) Value() string {
+	if len(d.Rows) == 0 || len(d.Rows[0]) == 0 {
+		return ""
+	}
+	return String(d.Rows[0][0])
+}
+
+// String return the string of the value of a column.
+func String(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// Float return the number of the value of a column, which is zero if it is
+// not a number.
+func Float(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	default:
+		f, _ := strconv.ParseFloat(String(v), 64)
+		return f
+	}
+}
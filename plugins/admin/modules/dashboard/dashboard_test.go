@@ -0,0 +1,122 @@
+package dashboard
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	_ "github.com/GoAdminGroup/go-admin/modules/db/drivers/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckQuery(t *testing.T) {
+	assert.NoError(t, CheckQuery("select count(*) from posts"))
+	assert.NoError(t, CheckQuery(" SELECT 1; "))
+	assert.NoError(t, CheckQuery("with t as (select 1 as a) select a from t"))
+	assert.Error(t, CheckQuery("delete from posts"))
+	assert.Error(t, CheckQuery("select 1; drop table posts"))
+	assert.Error(t, CheckQuery(""))
+
+	// the writes hidden in the common table expressions or the select.
+	assert.Error(t, CheckQuery("with x as (delete from posts returning *) select * from x"))
+	assert.Error(t, CheckQuery("select * from posts into outfile '/tmp/posts'"))
+	assert.Error(t, CheckQuery("select * into posts_copy from posts"))
+	assert.Error(t, CheckQuery("select * from posts for update"))
+	assert.Error(t, CheckQuery("select 1 -- '\n; drop table posts"))
+	assert.Error(t, CheckQuery("select 'a\\' into outfile '/tmp/posts' -- '"))
+	assert.Error(t, CheckQuery("select 1 /*! into outfile '/tmp/posts' */"))
+	assert.Error(t, CheckQuery("select 'a"))
+	assert.Error(t, CheckQuery("select 1 # '\n into outfile '/tmp/posts' -- '"))
+	assert.NoError(t, CheckQuery("select '#1' from posts"))
+
+	// the words in the literals, the quoted identifiers and the comments.
+	assert.NoError(t, CheckQuery("select 'delete; into' as `update`, \"insert\" from posts -- drop"))
+	assert.NoError(t, CheckQuery("select count(*) as [into] from posts /* update */ where updated_at > created_at"))
+
+	// the system tables of the admin.
+	assert.Error(t, CheckQuery("select password from goadmin_users"))
+	assert.Error(t, CheckQuery("select * from \"GOADMIN_SESSION\""))
+}
+
+func TestReadOnlyTx(t *testing.T) {
+	sqlDB, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "dashboard.db"))
+	assert.NoError(t, err)
+	defer func() {
+		_ = sqlDB.Close()
+	}()
+	sqlDB.SetMaxOpenConns(1)
+	_, err = sqlDB.Exec("create table posts (id integer primary key, title varchar(50))")
+	assert.NoError(t, err)
+
+	tx, rollback, err := readOnlyTx(context.Background(), sqlDB, db.DriverSqlite)
+	assert.NoError(t, err)
+	_, err = tx.Exec("insert into posts (title) values ('a')")
+	assert.Error(t, err)
+	rows, err := tx.Query("select count(*) from posts")
+	assert.NoError(t, err)
+	_ = rows.Close()
+	rollback()
+
+	// the connection is writable again after the transaction.
+	_, err = sqlDB.Exec("insert into posts (title) values ('a')")
+	assert.NoError(t, err)
+}
+
+func TestData(t *testing.T) {
+	data := Data{
+		Columns: []string{"day", "visits", "orders"},
+		Rows: [][]interface{}{
+			{"mon", int64(10), "3"},
+			{"tue", 12.5, nil},
+		},
+	}
+
+	assert.Equal(t, []string{"mon", "tue"}, data.Labels())
+
+	names, values := data.DataSets()
+	assert.Equal(t, []string{"visits", "orders"}, names)
+	assert.Equal(t, [][]float64{{10, 12.5}, {3, 0}}, values)
+
+	assert.Equal(t, "mon", data.Value())
+	assert.Equal(t, "", Data{}.Value())
+
+	names, values = Data{Columns: []string{"day"}}.DataSets()
+	assert.Empty(t, names)
+	assert.Empty(t, values)
+}
+
+func TestAggregate(t *testing.T) {
+	conn := db.GetConnectionByDriver(db.DriverSqlite).InitDB(map[string]config.Database{
+		"default": {Driver: db.DriverSqlite, File: filepath.Join(t.TempDir(), "admin.db")},
+	})
+	_, err := conn.Exec("CREATE TABLE IF NOT EXISTS orders (`id` integer PRIMARY KEY autoincrement, " +
+		"`amount` integer, `created_at` TIMESTAMP)")
+	assert.NoError(t, err)
+	_, err = conn.Exec("DELETE FROM orders")
+	assert.NoError(t, err)
+
+	now := time.Now()
+	for _, row := range []struct {
+		days   int
+		amount int
+	}{{0, 3}, {0, 2}, {2, 4}, {9, 100}} {
+		_, err := conn.Exec("INSERT INTO orders (`amount`, `created_at`) VALUES (?, ?)",
+			row.amount, now.AddDate(0, 0, -row.days).Format("2006-01-02 15:04:05"))
+		assert.NoError(t, err)
+	}
+
+	data, err := Aggregate("orders", db.AggregateSum, "amount", "created_at", db.BucketDay,
+		3*24*time.Hour)(context.Background(), conn)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{now.AddDate(0, 0, -3).Format("2006-01-02"), now.AddDate(0, 0, -2).Format("2006-01-02"),
+		now.AddDate(0, 0, -1).Format("2006-01-02"), now.Format("2006-01-02")}, data.Labels())
+	_, values := data.DataSets()
+	assert.Equal(t, [][]float64{{0, 4, 0, 5}}, values)
+
+	_, err = Aggregate("orders", "max", "amount", "created_at", db.BucketDay, time.Hour)(context.Background(), conn)
+	assert.Error(t, err)
+}
@@ -0,0 +1,37 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+type csvExporter struct{}
+
+func (*csvExporter) ContentType() string { return "text/csv; charset=utf-8" }
+func (*csvExporter) Extension() string   { return CSV }
+
+func (*csvExporter) NewWriter(w io.Writer, _ string) Writer {
+	return &csvWriter{w: w, cw: csv.NewWriter(w)}
+}
+
+type csvWriter struct {
+	w  io.Writer
+	cw *csv.Writer
+}
+
+func (c *csvWriter) WriteHeader(heads []string) error {
+	// The byte order mark makes spreadsheet applications read the file as utf-8.
+	if _, err := c.w.Write([]byte("\xEF\xBB\xBF")); err != nil {
+		return err
+	}
+	return c.cw.Write(heads)
+}
+
+func (c *csvWriter) WriteRow(values []string) error {
+	return c.cw.Write(values)
+}
+
+func (c *csvWriter) Close() error {
+	c.cw.Flush()
+	return c.cw.Error()
+}
@@ -0,0 +1,50 @@
+package exporter
+
+import (
+	"io"
+)
+
+const (
+	XLSX  = "xlsx"
+	CSV   = "csv"
+	JSONL = "jsonl"
+	ODS   = "ods"
+
+	// DefaultPageSize is the number of rows fetched from the table for each page
+	// when exporting all the data.
+	DefaultPageSize = 500
+)
+
+// Exporter creates the Writer of an export format.
+type Exporter interface {
+	ContentType() string
+	Extension() string
+	NewWriter(w io.Writer, sheet string) Writer
+}
+
+// Writer writes the header and rows to the underlying io.Writer as they come,
+// Close must be called to finish the output.
+type Writer interface {
+	WriteHeader(heads []string) error
+	WriteRow(values []string) error
+	Close() error
+}
+
+var List = map[string]Exporter{
+	XLSX:  new(xlsxExporter),
+	CSV:   new(csvExporter),
+	JSONL: new(jsonlExporter),
+	ODS:   new(odsExporter),
+}
+
+func Add(key string, exporter Exporter) {
+	if _, exist := List[key]; exist {
+		panic("exporter exist")
+	}
+	List[key] = exporter
+}
+
+func Get(key string) (Exporter, bool) {
+	exporter, ok := List[key]
+	return exporter, ok
+}
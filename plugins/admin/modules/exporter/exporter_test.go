@@ -0,0 +1,67 @@
+package exporter
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/magiconair/properties/assert"
+)
+
+func export(t *testing.T, format string) []byte {
+	e, ok := Get(format)
+	assert.Equal(t, ok, true)
+
+	buf := new(bytes.Buffer)
+	w := e.NewWriter(buf, "users")
+	assert.Equal(t, w.WriteHeader([]string{"ID", "Name"}), nil)
+	assert.Equal(t, w.WriteRow([]string{"1", `a,"b"`}), nil)
+	assert.Equal(t, w.WriteRow([]string{"2", "<c>"}), nil)
+	assert.Equal(t, w.Close(), nil)
+	return buf.Bytes()
+}
+
+func TestCSV(t *testing.T) {
+	assert.Equal(t, string(export(t, CSV)), "\xEF\xBB\xBFID,Name\n1,\"a,\"\"b\"\"\"\n2,<c>\n")
+}
+
+func TestJSONL(t *testing.T) {
+	assert.Equal(t, string(export(t, JSONL)), `{"ID":"1","Name":"a,\"b\""}`+"\n"+
+		`{"ID":"2","Name":"<c>"}`+"\n")
+}
+
+func TestODS(t *testing.T) {
+	data := export(t, ODS)
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, r.File[0].Name, "mimetype")
+	assert.Equal(t, r.File[0].Method, zip.Store)
+
+	rc, err := r.File[2].Open()
+	assert.Equal(t, err, nil)
+	content, _ := io.ReadAll(rc)
+	assert.Equal(t, strings.Contains(string(content), `<table:table table:name="users">`), true)
+	assert.Equal(t, strings.Contains(string(content), "<text:p>&lt;c&gt;</text:p>"), true)
+}
+
+func TestXLSX(t *testing.T) {
+	data := export(t, XLSX)
+	_, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	assert.Equal(t, err, nil)
+
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, f.GetSheetName(1), "users")
+	assert.Equal(t, f.GetRows("users"), [][]string{{"ID", "Name"}, {"1", `a,"b"`}, {"2", "<c>"}})
+}
+
+func TestXLSXColumn(t *testing.T) {
+	assert.Equal(t, xlsxColumn(0), "A")
+	assert.Equal(t, xlsxColumn(25), "Z")
+	assert.Equal(t, xlsxColumn(26), "AA")
+	assert.Equal(t, xlsxColumn(701), "ZZ")
+	assert.Equal(t, xlsxColumn(702), "AAA")
+}
@@ -0,0 +1,62 @@
+package exporter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+type jsonlExporter struct{}
+
+func (*jsonlExporter) ContentType() string { return "application/x-ndjson; charset=utf-8" }
+func (*jsonlExporter) Extension() string   { return JSONL }
+
+func (*jsonlExporter) NewWriter(w io.Writer, _ string) Writer {
+	jw := &jsonlWriter{w: bufio.NewWriter(w), buf: new(bytes.Buffer)}
+	jw.enc = json.NewEncoder(jw.buf)
+	jw.enc.SetEscapeHTML(false)
+	return jw
+}
+
+type jsonlWriter struct {
+	w     *bufio.Writer
+	buf   *bytes.Buffer
+	enc   *json.Encoder
+	heads []string
+}
+
+func (j *jsonlWriter) WriteHeader(heads []string) error {
+	j.heads = heads
+	return nil
+}
+
+// WriteRow writes the row as one json object, the keys keep the order of the header.
+func (j *jsonlWriter) WriteRow(values []string) error {
+	_ = j.w.WriteByte('{')
+	for i, head := range j.heads {
+		if i > 0 {
+			_ = j.w.WriteByte(',')
+		}
+		j.writeString(head)
+		_ = j.w.WriteByte(':')
+		value := ""
+		if i < len(values) {
+			value = values[i]
+		}
+		j.writeString(value)
+	}
+	_, err := j.w.WriteString("}\n")
+	return err
+}
+
+func (j *jsonlWriter) writeString(s string) {
+	j.buf.Reset()
+	_ = j.enc.Encode(s)
+	// Encode always ends the value with a newline.
+	_, _ = j.w.Write(bytes.TrimRight(j.buf.Bytes(), "\n"))
+}
+
+func (j *jsonlWriter) Close() error {
+	return j.w.Flush()
+}
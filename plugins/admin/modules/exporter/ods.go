@@ -0,0 +1,104 @@
+package exporter
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+)
+
+const (
+	odsMimeType = "application/vnd.oasis.opendocument.spreadsheet"
+
+	odsManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="1.2">
+<manifest:file-entry manifest:full-path="/" manifest:media-type="` + odsMimeType + `"/>
+<manifest:file-entry manifest:full-path="content.xml" manifest:media-type="text/xml"/>
+</manifest:manifest>`
+
+	odsContentHeader = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" ` +
+		`xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" ` +
+		`xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" office:version="1.2">` +
+		`<office:body><office:spreadsheet><table:table table:name="`
+
+	odsContentFooter = `</table:table></office:spreadsheet></office:body></office:document-content>`
+)
+
+type odsExporter struct{}
+
+func (*odsExporter) ContentType() string { return odsMimeType }
+func (*odsExporter) Extension() string   { return ODS }
+
+// NewWriter returns a Writer of OpenDocument spreadsheet. The content.xml entry
+// of the zip archive is written row by row.
+func (*odsExporter) NewWriter(w io.Writer, sheet string) Writer {
+	return &odsWriter{zw: zip.NewWriter(w), sheet: sheet}
+}
+
+type odsWriter struct {
+	zw      *zip.Writer
+	content io.Writer
+	sheet   string
+}
+
+func (o *odsWriter) WriteHeader(heads []string) error {
+	// The mimetype entry must be the first one and stored without compression.
+	mw, err := o.zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err = io.WriteString(mw, odsMimeType); err != nil {
+		return err
+	}
+
+	manifest, err := o.zw.Create("META-INF/manifest.xml")
+	if err != nil {
+		return err
+	}
+	if _, err = io.WriteString(manifest, odsManifest); err != nil {
+		return err
+	}
+
+	if o.content, err = o.zw.Create("content.xml"); err != nil {
+		return err
+	}
+	if _, err = io.WriteString(o.content, odsContentHeader); err != nil {
+		return err
+	}
+	if err = xml.EscapeText(o.content, []byte(o.sheet)); err != nil {
+		return err
+	}
+	if _, err = io.WriteString(o.content, `">`); err != nil {
+		return err
+	}
+
+	return o.WriteRow(heads)
+}
+
+func (o *odsWriter) WriteRow(values []string) error {
+	if _, err := io.WriteString(o.content, "<table:table-row>"); err != nil {
+		return err
+	}
+	for _, value := range values {
+		if _, err := io.WriteString(o.content, `<table:table-cell office:value-type="string"><text:p>`); err != nil {
+			return err
+		}
+		if err := xml.EscapeText(o.content, []byte(value)); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(o.content, "</text:p></table:table-cell>"); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(o.content, "</table:table-row>")
+	return err
+}
+
+func (o *odsWriter) Close() error {
+	if o.content != nil {
+		if _, err := io.WriteString(o.content, odsContentFooter); err != nil {
+			return err
+		}
+	}
+	return o.zw.Close()
+}
@@ -0,0 +1,140 @@
+package exporter
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"strconv"
+)
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ` +
+		`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ` +
+		`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" ` +
+		`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" ` +
+		`Target="xl/workbook.xml"/></Relationships>`
+
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" ` +
+		`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" ` +
+		`Target="worksheets/sheet1.xml"/></Relationships>`
+
+	xlsxWorkbookHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="`
+
+	xlsxWorkbookFooter = `" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+	xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+	xlsxSheetFooter = `</sheetData></worksheet>`
+)
+
+type xlsxExporter struct{}
+
+func (*xlsxExporter) ContentType() string { return "application/vnd.ms-excel" }
+func (*xlsxExporter) Extension() string   { return XLSX }
+
+// NewWriter returns a Writer of xlsx. The worksheet entry of the zip archive
+// is written row by row with inline strings, so that the rows are not kept in
+// memory.
+func (*xlsxExporter) NewWriter(w io.Writer, sheet string) Writer {
+	return &xlsxWriter{zw: zip.NewWriter(w), name: sheet}
+}
+
+type xlsxWriter struct {
+	zw    *zip.Writer
+	sheet io.Writer
+	name  string
+	row   int
+}
+
+func (x *xlsxWriter) WriteHeader(heads []string) error {
+	for _, entry := range [][2]string{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	} {
+		w, err := x.zw.Create(entry[0])
+		if err != nil {
+			return err
+		}
+		if _, err = io.WriteString(w, entry[1]); err != nil {
+			return err
+		}
+	}
+
+	workbook, err := x.zw.Create("xl/workbook.xml")
+	if err != nil {
+		return err
+	}
+	if _, err = io.WriteString(workbook, xlsxWorkbookHeader); err != nil {
+		return err
+	}
+	if err = xml.EscapeText(workbook, []byte(x.name)); err != nil {
+		return err
+	}
+	if _, err = io.WriteString(workbook, xlsxWorkbookFooter); err != nil {
+		return err
+	}
+
+	if x.sheet, err = x.zw.Create("xl/worksheets/sheet1.xml"); err != nil {
+		return err
+	}
+	if _, err = io.WriteString(x.sheet, xlsxSheetHeader); err != nil {
+		return err
+	}
+
+	return x.WriteRow(heads)
+}
+
+func (x *xlsxWriter) WriteRow(values []string) error {
+	x.row++
+	if _, err := io.WriteString(x.sheet, `<row r="`+strconv.Itoa(x.row)+`">`); err != nil {
+		return err
+	}
+	for i, value := range values {
+		if _, err := io.WriteString(x.sheet, `<c r="`+xlsxColumn(i)+strconv.Itoa(x.row)+
+			`" t="inlineStr"><is><t xml:space="preserve">`); err != nil {
+			return err
+		}
+		if err := xml.EscapeText(x.sheet, []byte(value)); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(x.sheet, "</t></is></c>"); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(x.sheet, "</row>")
+	return err
+}
+
+func (x *xlsxWriter) Close() error {
+	if x.sheet != nil {
+		if _, err := io.WriteString(x.sheet, xlsxSheetFooter); err != nil {
+			return err
+		}
+	}
+	return x.zw.Close()
+}
+
+// xlsxColumn returns the name of the column of the index, such as A, Z, AA.
+func xlsxColumn(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
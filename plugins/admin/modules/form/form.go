@@ -12,6 +12,7 @@ const (
 	PreviousKey = "__go_admin_previous_"
 	TokenKey    = "__go_admin_t_"
 	MethodKey   = "__go_admin_method_"
+	VersionKey  = "__go_admin_version_"
 
 	NoAnimationKey = "__go_admin_no_animation_"
 )
@@ -114,6 +115,7 @@ func (f Values) RemoveSysRemark() Values {
 	f.Delete(PreviousKey)
 	f.Delete(TokenKey)
 	f.Delete(MethodKey)
+	f.Delete(VersionKey)
 	f.Delete(NoAnimationKey)
 	return f
 }
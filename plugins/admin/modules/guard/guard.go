@@ -5,6 +5,7 @@ import (
 	"github.com/GoAdminGroup/go-admin/modules/db"
 	"github.com/GoAdminGroup/go-admin/modules/errors"
 	"github.com/GoAdminGroup/go-admin/modules/service"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/constant"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/response"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/table"
@@ -30,7 +31,12 @@ func New(s service.List, c db.Connection, t table.GeneratorList, b *types.Button
 
 func (g *Guard) table(ctx *context.Context) (table.Table, string) {
 	prefix := ctx.Query(constant.PrefixKey)
-	return g.tableList[prefix](ctx), prefix
+	t := g.tableList[prefix](ctx)
+	if user, ok := ctx.User().(models.UserModel); ok {
+		table.SetAuditUser(t, user)
+	}
+	table.SetWebhookPrefix(t, prefix)
+	return t, prefix
 }
 
 func (g *Guard) CheckPrefix(ctx *context.Context) {
@@ -55,6 +61,7 @@ const (
 	editFormParamKey    = "edit_form_param"
 	deleteParamKey      = "delete_param"
 	exportParamKey      = "export_param"
+	importParamKey      = "import_param"
 	serverLoginParamKey = "server_login_param"
 	deleteMenuParamKey  = "delete_menu_param"
 	editMenuParamKey    = "edit_menu_param"
@@ -63,4 +70,5 @@ const (
 	updateParamKey      = "update_param"
 	showFormParamKey    = "show_form_param"
 	showNewFormParam    = "show_new_form_param"
+	restParamKey        = "rest_param"
 )
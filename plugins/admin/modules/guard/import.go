@@ -0,0 +1,91 @@
+package guard
+
+import (
+	stderrors "errors"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/auth"
+	"github.com/GoAdminGroup/go-admin/modules/errors"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/importer"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/response"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/table"
+)
+
+const (
+	// importMaxSize is the max size of the request of an import.
+	importMaxSize = 32 << 20
+	// importMaxMemory is the size of the upload kept in memory, the rest of
+	// which is stored in the temporary files.
+	importMaxMemory = 8 << 20
+)
+
+type ImportParam struct {
+	Panel    table.Table
+	Prefix   string
+	File     multipart.File
+	Importer importer.Importer
+	DryRun   bool
+}
+
+func (g *Guard) Import(ctx *context.Context) {
+	panel, prefix := g.table(ctx)
+	if !panel.GetImportable() || !panel.GetCanAdd() {
+		alert(ctx, panel, errors.OperationNotAllow, g.conn, g.navBtns)
+		ctx.Abort()
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(nil, ctx.Request.Body, importMaxSize)
+	if err := ctx.Request.ParseMultipartForm(importMaxMemory); err != nil {
+		var tooLarge *http.MaxBytesError
+		if stderrors.As(err, &tooLarge) {
+			response.BadRequest(ctx, errors.FileTooLarge)
+		} else {
+			response.BadRequest(ctx, errors.WrongFile)
+		}
+		ctx.Abort()
+		return
+	}
+
+	file, header, err := ctx.Request.FormFile("file")
+	if err != nil {
+		alert(ctx, panel, errors.WrongFile, g.conn, g.navBtns)
+		ctx.Abort()
+		return
+	}
+
+	imp, ok := importer.Get(strings.ToLower(strings.TrimPrefix(filepath.Ext(header.Filename), ".")))
+	if !ok {
+		_ = file.Close()
+		alert(ctx, panel, errors.WrongFile, g.conn, g.navBtns)
+		ctx.Abort()
+		return
+	}
+
+	// the token is checked after the file, so that it is used only by the
+	// import which responds with a new one.
+	if !auth.GetTokenService(g.services.Get(auth.TokenServiceKey)).CheckToken(ctx.FormValue(form.TokenKey)) {
+		_ = file.Close()
+		alert(ctx, panel, errors.CreateFailWrongToken, g.conn, g.navBtns)
+		ctx.Abort()
+		return
+	}
+
+	ctx.SetUserValue(importParamKey, &ImportParam{
+		Panel:    panel,
+		Prefix:   prefix,
+		File:     file,
+		Importer: imp,
+		DryRun:   ctx.FormValue("dry_run") == "true",
+	})
+	ctx.Next()
+}
+
+func GetImportParam(ctx *context.Context) *ImportParam {
+	return ctx.UserValue[importParamKey].(*ImportParam)
+}
@@ -0,0 +1,101 @@
+package guard
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/errors"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/constant"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/resource"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/response"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/table"
+	"github.com/GoAdminGroup/go-admin/template/types"
+)
+
+type RestParam struct {
+	Panel  table.Table
+	Prefix string
+	Id     string
+	Values form.Values
+}
+
+// Rest checks the requests of the JSON api of the tables. The bodies of the
+// create and the update must be JSON, which also keeps the api from the
+// cross site form posts as there is no token.
+func (g *Guard) Rest(ctx *context.Context) {
+
+	if _, ok := g.tableList[ctx.Query(constant.PrefixKey)]; !ok {
+		response.NotFound(ctx, "not found")
+		ctx.Abort()
+		return
+	}
+
+	var (
+		panel, prefix = g.table(ctx)
+		id            = ctx.Query(constant.RestPKKey)
+		method        = ctx.Method()
+		allow         = true
+		fields        = panel.GetForm().FieldList
+	)
+
+	switch method {
+	case "POST":
+		allow = panel.GetCanAdd()
+		fields = panel.GetActualNewForm().FieldList
+	case "PUT":
+		allow = panel.GetEditable()
+	case "DELETE":
+		allow = panel.GetDeletable()
+	}
+
+	if !allow {
+		response.Forbidden(ctx, errors.OperationNotAllow)
+		ctx.Abort()
+		return
+	}
+
+	param := &RestParam{
+		Panel:  panel,
+		Prefix: prefix,
+		Id:     id,
+	}
+
+	if method == "POST" || method == "PUT" {
+		if !strings.HasPrefix(ctx.GetContentType(), "application/json") {
+			response.BadRequest(ctx, errors.WrongContentType)
+			ctx.Abort()
+			return
+		}
+
+		var body map[string]interface{}
+		decoder := json.NewDecoder(ctx.Request.Body)
+		decoder.UseNumber()
+		if err := decoder.Decode(&body); err != nil {
+			response.BadRequest(ctx, errors.WrongJSONBody)
+			ctx.Abort()
+			return
+		}
+
+		param.Values = resource.FormValues(body, resource.WritableFields(fields))
+
+		// The update only changes the fields in the body, and the version of
+		// the optimistic lock is the value of its field.
+		if method == "PUT" {
+			param.Values.Add(form.PostIsSingleUpdateKey, "1")
+			param.Values.Add(panel.GetPrimaryKey().Name, id)
+			if lock := panel.GetForm().OptimisticLock; lock.Enabled() {
+				version := resource.FormValues(body, types.FormFields{{Field: lock.Field}})
+				param.Values.Add(form.VersionKey, version.Get(lock.Field))
+			}
+		}
+	}
+
+	ctx.SetUserValue(restParamKey, param)
+	ctx.Next()
+}
+
+func GetRestParam(ctx *context.Context) *RestParam {
+	return ctx.UserValue[restParamKey].(*RestParam)
+}
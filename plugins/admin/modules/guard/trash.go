@@ -0,0 +1,31 @@
+package guard
+
+import (
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/errors"
+)
+
+// Trash checks the restore and the purge of the rows in the trash of a soft
+// deleted table, the parameter is got by GetDeleteParam.
+func (g *Guard) Trash(ctx *context.Context) {
+	panel, prefix := g.table(ctx)
+	if !panel.GetDeletable() || !panel.GetSoftDelete() {
+		alert(ctx, panel, errors.OperationNotAllow, g.conn, g.navBtns)
+		ctx.Abort()
+		return
+	}
+
+	id := ctx.FormValue("id")
+	if id == "" {
+		alert(ctx, panel, errors.WrongID, g.conn, g.navBtns)
+		ctx.Abort()
+		return
+	}
+
+	ctx.SetUserValue(deleteParamKey, &DeleteParam{
+		Panel:  panel,
+		Id:     id,
+		Prefix: prefix,
+	})
+	ctx.Next()
+}
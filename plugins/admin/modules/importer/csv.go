@@ -0,0 +1,21 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"io"
+)
+
+type csvImporter struct{}
+
+func (csvImporter) Read(r io.Reader) ([][]string, error) {
+	br := bufio.NewReader(r)
+	// skip the UTF-8 BOM written by spreadsheet applications.
+	if bom, err := br.Peek(3); err == nil && bytes.Equal(bom, []byte("\xEF\xBB\xBF")) {
+		_, _ = br.Discard(3)
+	}
+	reader := csv.NewReader(br)
+	reader.FieldsPerRecord = -1
+	return reader.ReadAll()
+}
@@ -0,0 +1,112 @@
+package importer
+
+import (
+	"io"
+	"strings"
+
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	"github.com/GoAdminGroup/go-admin/template/types"
+)
+
+const (
+	XLSX = "xlsx"
+	CSV  = "csv"
+)
+
+// Importer reads the records of an uploaded file, the first record is the header.
+type Importer interface {
+	Read(r io.Reader) ([][]string, error)
+}
+
+var List = map[string]Importer{
+	XLSX: new(xlsxImporter),
+	CSV:  new(csvImporter),
+}
+
+func Add(key string, importer Importer) {
+	if _, exist := List[key]; exist {
+		panic("importer exist")
+	}
+	List[key] = importer
+}
+
+func Get(key string) (Importer, bool) {
+	importer, ok := List[key]
+	return importer, ok
+}
+
+// Values maps the columns of the records onto the form fields and returns the
+// form values of every record after the header and the header cells which
+// match no field. A header cell matches a field by its name or its head,
+// case-insensitively. Empty cells are left out so that the defaults apply.
+func Values(records [][]string, fields types.FormFields) ([]form.Values, []string) {
+
+	if len(records) == 0 {
+		return []form.Values{}, []string{}
+	}
+
+	var (
+		columns   = make([]*types.FormField, len(records[0]))
+		unmatched = make([]string, 0)
+	)
+
+	for i, head := range records[0] {
+		head = strings.TrimSpace(head)
+		for j := range fields {
+			if strings.EqualFold(fields[j].Field, head) || strings.EqualFold(fields[j].Head, head) {
+				columns[i] = &fields[j]
+				break
+			}
+		}
+		if columns[i] == nil && head != "" {
+			unmatched = append(unmatched, head)
+		}
+	}
+
+	rows := records[1:]
+
+	// spreadsheets often carry blank rows at the end.
+	for len(rows) > 0 && isBlank(rows[len(rows)-1]) {
+		rows = rows[:len(rows)-1]
+	}
+
+	values := make([]form.Values, len(rows))
+
+	for i, record := range rows {
+		values[i] = make(form.Values)
+		for j, cell := range record {
+			if j >= len(columns) || columns[j] == nil {
+				continue
+			}
+			cell = strings.TrimSpace(cell)
+			if cell == "" {
+				continue
+			}
+			field := columns[j]
+			if field.FormType.IsMultiSelect() {
+				delimiter := field.DefaultOptionDelimiter
+				if delimiter == "" {
+					delimiter = ","
+				}
+				options := strings.Split(cell, delimiter)
+				for k := range options {
+					options[k] = strings.TrimSpace(options[k])
+				}
+				values[i][field.Field+"[]"] = options
+			} else {
+				values[i].Add(field.Field, cell)
+			}
+		}
+	}
+
+	return values, unmatched
+}
+
+func isBlank(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
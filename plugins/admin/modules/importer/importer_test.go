@@ -0,0 +1,58 @@
+package importer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/GoAdminGroup/go-admin/template/types"
+	"github.com/GoAdminGroup/go-admin/template/types/form"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestCSV(t *testing.T) {
+	i, ok := Get(CSV)
+	assert.Equal(t, ok, true)
+
+	records, err := i.Read(strings.NewReader("\xEF\xBB\xBFName,Age\n\"a,b\",1\nc\n"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, records, [][]string{{"Name", "Age"}, {"a,b", "1"}, {"c"}})
+}
+
+func TestXLSX(t *testing.T) {
+	f := excelize.NewFile()
+	f.SetCellValue("Sheet1", "A1", "Name")
+	f.SetCellValue("Sheet1", "A2", "a")
+	buf := new(bytes.Buffer)
+	assert.Equal(t, f.Write(buf), nil)
+
+	i, ok := Get(XLSX)
+	assert.Equal(t, ok, true)
+
+	records, err := i.Read(buf)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, records, [][]string{{"Name"}, {"a"}})
+}
+
+func TestValues(t *testing.T) {
+	fields := types.FormFields{
+		{Field: "name", Head: "Name", FormType: form.Text},
+		{Field: "roles", Head: "Roles", FormType: form.Select},
+	}
+
+	values, unmatched := Values([][]string{
+		{"NAME", "roles", "Unknown"},
+		{" jack ", "1, 2", "x"},
+		{"", "", ""},
+		{"rose"},
+		{"", " "},
+	}, fields)
+
+	assert.Equal(t, unmatched, []string{"Unknown"})
+	assert.Equal(t, len(values), 3)
+	assert.Equal(t, values[0].Get("name"), "jack")
+	assert.Equal(t, values[0]["roles[]"], []string{"1", "2"})
+	assert.Equal(t, len(values[1]), 0)
+	assert.Equal(t, values[2].Get("name"), "rose")
+}
@@ -0,0 +1,18 @@
+package importer
+
+import (
+	"io"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+)
+
+type xlsxImporter struct{}
+
+// Read reads the records of the first sheet.
+func (xlsxImporter) Read(r io.Reader) ([][]string, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return f.GetRows(f.GetSheetName(1)), nil
+}
@@ -0,0 +1,410 @@
+// Package install creates the goadmin system tables with the initial data in
+// a database, the first super administrator and the config file of the
+// application, which is the backend of the web install pages.
+package install
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	dbsql "database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/GoAdminGroup/go-admin/data"
+	"github.com/GoAdminGroup/go-admin/modules/auth"
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/modules/db/dialect"
+	"github.com/GoAdminGroup/go-admin/modules/migration"
+	"gopkg.in/yaml.v2"
+)
+
+// LockFile is written after the installation, with which the install pages
+// are locked. They are locked as well if the system tables exist in the
+// connection of the application.
+var LockFile = "goadmin_install.lock"
+
+// AllowedHosts are the hosts of the database servers which the install
+// pages can connect to, which are the local ones by default. Add the host of
+// a database server on the other machines before the pages are served.
+var AllowedHosts = []string{"127.0.0.1", "localhost", "::1"}
+
+// ErrInstalled is returned when the system tables exist in the database.
+var ErrInstalled = errors.New("the system tables exist in the database")
+
+// dsnName is the pattern of the user and the database name, which are put
+// into the dsn.
+var dsnName = regexp.MustCompile(`^[\w.-]*$`)
+
+var (
+	token     string
+	tokenLock sync.Mutex
+)
+
+// drivers are the drivers of the installation in order, with the names of
+// the sql drivers which should be registered.
+var drivers = []struct {
+	Name      string
+	SQLDriver string
+}{
+	{db.DriverMysql, "mysql"},
+	{db.DriverPostgresql, "postgres"},
+	{db.DriverSqlite, "sqlite3"},
+	{db.DriverMssql, "sqlserver"},
+}
+
+// dumps are the dumps of the system tables by driver.
+var dumps = map[string]string{
+	db.DriverMysql:      "admin.sql",
+	db.DriverPostgresql: "admin.pgsql",
+	db.DriverSqlite:     "admin.sqlite",
+	db.DriverMssql:      "admin.mssql",
+}
+
+// Param is the parameter of the installation.
+type Param struct {
+	Database   config.Database
+	UserName   string
+	Name       string
+	Password   string
+	ConfigPath string
+}
+
+// Drivers return the drivers of which the sql drivers are imported.
+func Drivers() []string {
+	registered := dbsql.Drivers()
+	list := make([]string, 0)
+	for _, driver := range drivers {
+		for _, name := range registered {
+			if name == driver.SQLDriver {
+				list = append(list, driver.Name)
+				break
+			}
+		}
+	}
+	return list
+}
+
+// NewToken generate the one-time token of the install pages, which should be
+// printed to the log of the server at the startup, so that only who can read
+// the log is able to install. The token is cleared once installed.
+func NewToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	tokenLock.Lock()
+	defer tokenLock.Unlock()
+	token = hex.EncodeToString(b)
+	return token
+}
+
+// CheckToken check the token of the install pages.
+func CheckToken(t string) bool {
+	tokenLock.Lock()
+	defer tokenLock.Unlock()
+	return token != "" && subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1
+}
+
+// Locked check if the installation is done. It is locked as well when that
+// can not be told, such as the lock file can not be read or the database of
+// the application can not be connected.
+func Locked(conn db.Connection) bool {
+	if _, err := os.Stat(LockFile); !os.IsNotExist(err) {
+		return true
+	}
+	if conn == nil {
+		return false
+	}
+	if _, err := conn.Query("SELECT 1"); err != nil {
+		return true
+	}
+	return installed(conn)
+}
+
+// Connect return the connection of the database config, and the error if the
+// database can not be connected.
+func Connect(cfg config.Database) (conn db.Connection, err error) {
+
+	if !validDriver(cfg.Driver) {
+		return nil, errors.New("wrong driver: " + cfg.Driver)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			if conn != nil {
+				_ = conn.Close()
+			}
+			conn, err = nil, fmt.Errorf("%v", p)
+		}
+	}()
+
+	conn = db.GetConnectionByDriver(cfg.Driver)
+	conn.InitDB(map[string]config.Database{"default": cfg})
+	return conn, nil
+}
+
+// Check the database can be connected and has no system tables. The host
+// should be one of AllowedHosts, and the file of sqlite should be a new or an
+// empty one in the directory of the application.
+func Check(cfg config.Database) error {
+
+	if cfg.Driver == db.DriverSqlite {
+		if cfg.File == "" {
+			return errors.New("the sqlite file can not be empty")
+		}
+		if _, err := localPath(cfg.File); err != nil {
+			return err
+		}
+		if info, err := os.Lstat(cfg.File); err == nil && (info.Size() > 0 || !info.Mode().IsRegular()) {
+			return errors.New("the sqlite file should be a new one")
+		}
+		return nil
+	}
+
+	if err := checkServer(cfg); err != nil {
+		return err
+	}
+
+	conn, err := Connect(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	if installed(conn) {
+		return ErrInstalled
+	}
+	return nil
+}
+
+// Install create the system tables with the initial data, replace the users
+// of the data with the super administrator of the param, write the config
+// file in the directory of the application and then the lock file.
+func Install(param Param) error {
+
+	if param.UserName == "" || param.Password == "" {
+		return errors.New("the username and the password can not be empty")
+	}
+	if param.Name == "" {
+		param.Name = param.UserName
+	}
+
+	content, err := configContent(param)
+	if err != nil {
+		return err
+	}
+	if _, err := localPath(param.ConfigPath); err != nil {
+		return err
+	}
+	if _, err := os.Lstat(param.ConfigPath); err == nil {
+		return errors.New("the config file exists: " + param.ConfigPath)
+	}
+
+	if err := Check(param.Database); err != nil {
+		return err
+	}
+
+	dump, err := fs.ReadFile(data.Dumps, dumps[param.Database.Driver])
+	if err != nil {
+		return err
+	}
+
+	conn, err := Connect(param.Database)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	if err := createTables(conn, string(dump)); err != nil {
+		return err
+	}
+
+	migrator, err := migration.New(conn)
+	if err != nil {
+		return err
+	}
+	if _, err := migrator.Up(); err != nil {
+		return err
+	}
+
+	if err := createSuperAdmin(conn, param); err != nil {
+		return err
+	}
+
+	// the config has the password of the database.
+	file, err := os.OpenFile(param.ConfigPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(LockFile, []byte(param.ConfigPath), 0644); err != nil {
+		return err
+	}
+
+	tokenLock.Lock()
+	token = ""
+	tokenLock.Unlock()
+
+	return nil
+}
+
+// localPath return the absolute path of the file, which should be in the
+// directory of the application.
+func localPath(file string) (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return "", err
+	}
+	if rel, err := filepath.Rel(dir, abs); err != nil || rel == "." || rel == ".." ||
+		strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.New("the file should be in the directory of the application: " + file)
+	}
+	return abs, nil
+}
+
+// checkServer check the database server is one of AllowedHosts, and the
+// parameters of the dsn of it.
+func checkServer(cfg config.Database) error {
+	allowed := false
+	for _, host := range AllowedHosts {
+		if strings.EqualFold(cfg.Host, host) {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return errors.New("the host is not allowed: " + cfg.Host)
+	}
+	if cfg.Port != "" && strings.Trim(cfg.Port, "0123456789") != "" {
+		return errors.New("wrong port: " + cfg.Port)
+	}
+	if !dsnName.MatchString(cfg.User) || !dsnName.MatchString(cfg.Name) {
+		return errors.New("the user and the database name should be letters, digits, _, . or -")
+	}
+	return nil
+}
+
+// createTables exec the statements of the dump in a transaction, which keeps
+// them in one connection and reverts them all in postgresql if any fails.
+func createTables(conn db.Connection, dump string) (err error) {
+	_, err = db.WithDriver(conn).WithTransaction(func(tx *dbsql.Tx) (error, map[string]interface{}) {
+		for _, statement := range migration.Statements(conn.Name(), dump) {
+			if _, err := conn.ExecWithTx(tx, statement); err != nil {
+				return err, nil
+			}
+		}
+		return nil, nil
+	})
+	return
+}
+
+// createSuperAdmin replace the users of the initial data with the super
+// administrator of the param, who has the role administrator.
+func createSuperAdmin(conn db.Connection, param Param) (err error) {
+	_, err = db.WithDriver(conn).WithTransaction(func(tx *dbsql.Tx) (error, map[string]interface{}) {
+
+		for _, table := range []string{"goadmin_role_users", "goadmin_user_permissions", "goadmin_users"} {
+			if err := db.WithDriver(conn).WithTx(tx).Table(table).Delete(); db.CheckError(err, db.DELETE) {
+				return err, nil
+			}
+		}
+
+		role, err := db.WithDriver(conn).WithTx(tx).Table("goadmin_roles").
+			Where("slug", "=", "administrator").First()
+		roleId, ok := role["id"].(int64)
+		if err != nil || !ok {
+			return errors.New("role administrator not found"), nil
+		}
+
+		_, err = db.WithDriver(conn).WithTx(tx).Table("goadmin_users").Insert(dialect.H{
+			"username": param.UserName,
+			"password": auth.EncodePassword([]byte(param.Password)),
+			"name":     param.Name,
+			"avatar":   "",
+		})
+		if db.CheckError(err, db.INSERT) {
+			return err, nil
+		}
+
+		// the last insert id is not supported by all the drivers.
+		user, err := db.WithDriver(conn).WithTx(tx).Table("goadmin_users").
+			Where("username", "=", param.UserName).First()
+		if err != nil {
+			return err, nil
+		}
+
+		_, err = db.WithDriver(conn).WithTx(tx).Table("goadmin_role_users").Insert(dialect.H{
+			"role_id": roleId,
+			"user_id": user["id"],
+		})
+		if db.CheckError(err, db.INSERT) {
+			return err, nil
+		}
+		return nil, nil
+	})
+	return
+}
+
+// configContent return the content of the config file in json or yaml by the
+// extension, of which the database is the installed one and the others are
+// the same as the running application.
+func configContent(param Param) ([]byte, error) {
+
+	cfg := &config.Config{
+		Databases:   config.DatabaseList{"default": param.Database},
+		UrlPrefix:   config.GetUrlPrefix(),
+		Theme:       config.GetTheme(),
+		Language:    config.GetLanguage(),
+		Title:       config.GetTitle(),
+		Store:       config.GetStore(),
+		Debug:       config.GetDebug(),
+		AutoMigrate: true,
+	}
+
+	switch strings.ToLower(filepath.Ext(param.ConfigPath)) {
+	case ".json":
+		return json.MarshalIndent(cfg, "", "  ")
+	case ".yml", ".yaml":
+		return yaml.Marshal(cfg)
+	default:
+		return nil, errors.New("the config file should be a json or yaml file")
+	}
+}
+
+func installed(conn db.Connection) bool {
+	_, err := db.WithDriver(conn).Table("goadmin_users").Count()
+	return err == nil
+}
+
+func validDriver(name string) bool {
+	for _, driver := range Drivers() {
+		if driver == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package install
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	_ "github.com/GoAdminGroup/go-admin/modules/db/drivers/sqlite"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestInstall(t *testing.T) {
+
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	assert.Nil(t, err)
+	assert.Nil(t, os.Chdir(dir))
+	defer func() {
+		_ = os.Chdir(wd)
+	}()
+	LockFile = "goadmin_install.lock"
+
+	param := Param{
+		Database:   config.Database{Driver: db.DriverSqlite, File: "./admin.db"},
+		UserName:   "root",
+		Password:   "secret",
+		ConfigPath: "./config.json",
+	}
+
+	assert.Contains(t, Drivers(), db.DriverSqlite)
+	assert.False(t, Locked(nil))
+	assert.Nil(t, Check(param.Database))
+	assert.NotNil(t, Check(config.Database{Driver: db.DriverSqlite, File: "../admin.db"}))
+	assert.NotNil(t, Install(Param{Database: param.Database, ConfigPath: param.ConfigPath}))
+	assert.NotNil(t, Install(Param{Database: param.Database, UserName: "root", Password: "secret",
+		ConfigPath: "./config.ini"}))
+	assert.NotNil(t, Install(Param{Database: param.Database, UserName: "root", Password: "secret",
+		ConfigPath: filepath.Join(os.TempDir(), "config.json")}))
+
+	assert.False(t, CheckToken(""))
+	installToken := NewToken()
+	assert.True(t, CheckToken(installToken))
+	assert.False(t, CheckToken(installToken+"0"))
+
+	assert.Nil(t, Install(param))
+	assert.False(t, CheckToken(installToken))
+	assert.True(t, Locked(nil))
+	assert.NotNil(t, Check(param.Database))
+	assert.NotNil(t, Install(param))
+
+	info, err := os.Stat(param.ConfigPath)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	cfg := config.ReadFromJson(param.ConfigPath)
+	assert.Equal(t, param.Database, cfg.Databases.GetDefault())
+	assert.True(t, cfg.AutoMigrate)
+
+	conn, err := Connect(param.Database)
+	assert.Nil(t, err)
+	assert.True(t, installed(conn))
+
+	count, err := db.WithDriver(conn).Table("goadmin_users").Count()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), count)
+
+	user := models.User().SetConn(conn)
+	user.TableName = "goadmin_users"
+	user = user.FindByUserName("root")
+	assert.Equal(t, "root", user.Name)
+	assert.Nil(t, bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("secret")))
+
+	role, err := db.WithDriver(conn).Table("goadmin_role_users").
+		LeftJoin("goadmin_roles", "goadmin_roles.id", "=", "goadmin_role_users.role_id").
+		Where("user_id", "=", user.Id).First()
+	assert.Nil(t, err)
+	assert.Equal(t, "administrator", role["slug"])
+
+	os.Remove(LockFile)
+	assert.True(t, Locked(conn))
+}
+
+func TestCheckServer(t *testing.T) {
+	assert.Nil(t, checkServer(config.Database{Driver: db.DriverMysql, Host: "localhost", Port: "3306",
+		User: "root", Name: "go-admin"}))
+	assert.NotNil(t, checkServer(config.Database{Driver: db.DriverMysql, Host: "169.254.169.254", Port: "80"}))
+	assert.NotNil(t, checkServer(config.Database{Driver: db.DriverMysql, Host: "127.0.0.1", Port: "3306)/x?"}))
+	assert.NotNil(t, checkServer(config.Database{Driver: db.DriverMysql, Host: "127.0.0.1",
+		Name: "goadmin?allowAllFiles=true"}))
+	assert.NotNil(t, Check(config.Database{Driver: db.DriverMysql, Host: "10.0.0.1"}))
+}
+
+func TestConfigContent(t *testing.T) {
+	content, err := configContent(Param{
+		Database:   config.Database{Driver: db.DriverMysql, Host: "127.0.0.1", Name: "goadmin"},
+		ConfigPath: "./config.yml",
+	})
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), "driver: mysql")
+	assert.Contains(t, string(content), "auto_migrate: true")
+}
@@ -18,12 +18,24 @@ type Config struct {
 	Size         int
 	Param        parameter.Parameters
 	PageSizeList []string
+
+	// Cursor is true for the cursor pagination, which has only the links of
+	// the previous and the next page. Count is the rows of the page, and Size
+	// is an approximate total or zero.
+	Cursor         bool
+	Count          int
+	PreviousCursor string
+	NextCursor     string
 }
 
 func Get(cfg Config) types.PaginatorAttribute {
 
 	paginator := template2.Default().Paginator().(*components.PaginatorAttribute)
 
+	if cfg.Cursor {
+		return getCursor(paginator, cfg)
+	}
+
 	totalPage := int(math.Ceil(float64(cfg.Size) / float64(cfg.Param.PageSizeInt)))
 
 	if cfg.Param.PageInt == 1 {
@@ -137,6 +149,50 @@ func Get(cfg Config) types.PaginatorAttribute {
 	return paginator.SetPageSizeList(cfg.PageSizeList)
 }
 
+func getCursor(paginator *components.PaginatorAttribute, cfg Config) types.PaginatorAttribute {
+
+	if cfg.PreviousCursor == "" {
+		paginator.PreviousClass = "disabled"
+		paginator.PreviousUrl = cfg.Param.URLPath
+	} else {
+		paginator.PreviousClass = ""
+		paginator.PreviousUrl = cfg.Param.CursorURL(cfg.PreviousCursor)
+	}
+
+	if cfg.NextCursor == "" {
+		paginator.NextClass = "disabled"
+		paginator.NextUrl = cfg.Param.URLPath
+	} else {
+		paginator.NextClass = ""
+		paginator.NextUrl = cfg.Param.CursorURL(cfg.NextCursor)
+	}
+
+	paginator.Url = cfg.Param.URLPath + cfg.Param.GetRouteParamStrWithoutPageSize("1") + "&" + form.NoAnimationKey + "=true"
+	paginator.Total = strconv.Itoa(cfg.Size)
+
+	if len(cfg.PageSizeList) == 0 {
+		cfg.PageSizeList = []string{"10", "20", "50", "100"}
+	}
+
+	paginator.Option = make(map[string]template.HTML, len(cfg.PageSizeList))
+	for i := 0; i < len(cfg.PageSizeList); i++ {
+		paginator.Option[cfg.PageSizeList[i]] = template.HTML("")
+	}
+
+	paginator.Option[cfg.Param.PageSize] = template.HTML("selected")
+
+	paginator.Pages = []map[string]string{}
+
+	if cfg.Size > 0 {
+		paginator.SetEntriesInfo(template.HTML(fmt.Sprintf(language.Get("showing <b>%d</b> of about <b>%d</b> entries"),
+			cfg.Count, cfg.Size)))
+	} else {
+		paginator.SetEntriesInfo(template.HTML(fmt.Sprintf(language.Get("showing <b>%d</b> entries"), cfg.Count)))
+	}
+
+	return paginator.SetPageSizeList(cfg.PageSizeList)
+}
+
 func addPageLink(arr []map[string]string, params parameter.Parameters, page int, active string) []map[string]string {
 
 	pageStr := strconv.Itoa(page)
@@ -0,0 +1,59 @@
+package parameter
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// Cursor is the position of a page of the cursor pagination, which is the
+// values of the sort fields and the primary key of the last row of the
+// previous page, or of the first row of the next page when it is backward.
+// Nulls tells which of the values are null.
+type Cursor struct {
+	Values   []string `json:"v,omitempty"`
+	Nulls    []bool   `json:"n,omitempty"`
+	PK       string   `json:"k"`
+	Backward bool     `json:"b,omitempty"`
+}
+
+// IsNull return true if the value of the sort field of index i is null.
+func (c Cursor) IsNull(i int) bool {
+	return i < len(c.Nulls) && c.Nulls[i]
+}
+
+// Encode return the opaque string of the cursor used in the urls.
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor return the cursor of the opaque string.
+func DecodeCursor(s string) (Cursor, bool) {
+	var c Cursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, false
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return c, false
+	}
+	return c, true
+}
+
+// GetCursor return the cursor of the parameters, it is false for the first
+// page.
+func (param Parameters) GetCursor() (Cursor, bool) {
+	if param.Cursor == "" {
+		return Cursor{}, false
+	}
+	return DecodeCursor(param.Cursor)
+}
+
+// CursorURL return the url of the page of the cursor.
+func (param Parameters) CursorURL(cursor string) string {
+	p := param.GetFixedParamStr()
+	if cursor != "" {
+		p.Add(CursorKey, cursor)
+	}
+	return param.URLPath + "?" + p.Encode()
+}
@@ -1,6 +1,7 @@
 package parameter
 
 import (
+	"context"
 	"net/url"
 	"strconv"
 	"strings"
@@ -18,12 +19,17 @@ type Parameters struct {
 	SortField    string
 	Columns      []string
 	SortType     string
+	Sorts        []SortItem
 	Animation    bool
 	URLPath      string
+	Cursor       string
 	Fields       map[string][]string
 	OrConditions map[string]string
 
 	cacheFixedStr url.Values
+	ctx           context.Context
+	search        string
+	keyset        bool
 }
 
 const (
@@ -35,11 +41,14 @@ const (
 	Prefix   = "__prefix"
 	Pjax     = "_pjax"
 
+	CursorKey = "__cursor"
+
 	sortTypeDesc = "desc"
 	sortTypeAsc  = "asc"
 
 	IsAll      = "__is_all"
 	PrimaryKey = "__pk"
+	Trash      = "__trash"
 
 	True  = "true"
 	False = "false"
@@ -64,7 +73,13 @@ var operators = map[string]string{
 	"free": "free",
 }
 
-var keys = []string{Page, PageSize, Sort, Columns, Prefix, Pjax, form.NoAnimationKey}
+var keys = []string{Page, PageSize, Sort, SortType, Columns, Prefix, Pjax, CursorKey, form.NoAnimationKey}
+
+// SortItem is a field of the multi-column sorting with its sort type.
+type SortItem struct {
+	Field string
+	Type  string
+}
 
 func BaseParam() Parameters {
 	return Parameters{Page: "1", PageSize: "10", PageInt: 1, PageSizeInt: 10, Fields: make(map[string][]string)}
@@ -83,8 +98,7 @@ func GetParam(u *url.URL, defaultPageSize int, p ...string) Parameters {
 
 	page := getDefault(values, Page, "1")
 	pageSize := getDefault(values, PageSize, strconv.Itoa(defaultPageSize))
-	sortField := getDefault(values, Sort, primaryKey)
-	sortType := getDefault(values, SortType, defaultSortType)
+	sorts := getSorts(values[Sort], values[SortType], primaryKey, defaultSortType)
 	columns := getDefault(values, Columns, "")
 
 	animation := true
@@ -96,17 +110,11 @@ func GetParam(u *url.URL, defaultPageSize int, p ...string) Parameters {
 
 	for key, value := range values {
 		if !modules.InArray(keys, key) && len(value) > 0 && value[0] != "" {
-			if key == SortType {
-				if value[0] != sortTypeDesc && value[0] != sortTypeAsc {
-					fields[key] = []string{sortTypeDesc}
-				}
-			} else {
-				if strings.Contains(key, FilterParamOperatorSuffix) &&
-					values.Get(strings.ReplaceAll(key, FilterParamOperatorSuffix, "")) == "" {
-					continue
-				}
-				fields[strings.ReplaceAll(key, "[]", "")] = value
+			if strings.Contains(key, FilterParamOperatorSuffix) &&
+				values.Get(strings.ReplaceAll(key, FilterParamOperatorSuffix, "")) == "" {
+				continue
 			}
+			fields[strings.ReplaceAll(key, "[]", "")] = value
 		}
 	}
 
@@ -125,8 +133,10 @@ func GetParam(u *url.URL, defaultPageSize int, p ...string) Parameters {
 		PageSizeInt:  pageSizeInt,
 		PageInt:      pageInt,
 		URLPath:      u.Path,
-		SortField:    sortField,
-		SortType:     sortType,
+		Cursor:       values.Get(CursorKey),
+		SortField:    sorts[0].Field,
+		SortType:     sorts[0].Type,
+		Sorts:        sorts,
 		Fields:       fields,
 		OrConditions: map[string]string{},
 		Animation:    animation,
@@ -134,6 +144,46 @@ func GetParam(u *url.URL, defaultPageSize int, p ...string) Parameters {
 	}
 }
 
+// getSorts return the sort fields in order, the sort types of which are
+// given by the sort type parameters of the same positions. An invalid sort
+// type is treated as desc.
+func getSorts(fields, types []string, primaryKey, defaultSortType string) []SortItem {
+	sorts := make([]SortItem, 0, len(fields))
+	for i, field := range fields {
+		if field == "" || containsSortField(sorts, field) {
+			continue
+		}
+		typ := defaultSortType
+		if i < len(types) && types[i] != "" {
+			typ = types[i]
+		}
+		if typ != sortTypeAsc {
+			typ = sortTypeDesc
+		}
+		sorts = append(sorts, SortItem{Field: field, Type: typ})
+	}
+	if len(sorts) == 0 {
+		typ := defaultSortType
+		if len(types) > 0 && types[0] != "" {
+			typ = types[0]
+		}
+		if typ != sortTypeAsc {
+			typ = sortTypeDesc
+		}
+		sorts = append(sorts, SortItem{Field: primaryKey, Type: typ})
+	}
+	return sorts
+}
+
+func containsSortField(sorts []SortItem, field string) bool {
+	for _, sort := range sorts {
+		if sort.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
 func GetParamFromURL(urlStr string, defaultPageSize int, defaultSortType, primaryKey string) Parameters {
 
 	u, err := url.Parse(urlStr)
@@ -145,6 +195,63 @@ func GetParamFromURL(urlStr string, defaultPageSize int, defaultSortType, primar
 	return GetParam(u, defaultPageSize, primaryKey, defaultSortType)
 }
 
+// WithContext set the request context which the data queries of the table will carry.
+func (param Parameters) WithContext(ctx context.Context) Parameters {
+	param.ctx = ctx
+	return param
+}
+
+// Context return the request context, or context.Background if it is not set.
+func (param Parameters) Context() context.Context {
+	if param.ctx == nil {
+		return context.Background()
+	}
+	return param.ctx
+}
+
+// GetSorts return the sort fields in order, which is the single sort field
+// if the parameters are not parsed from an url or the sort field is changed.
+func (param Parameters) GetSorts() []SortItem {
+	if len(param.Sorts) == 0 || param.Sorts[0].Field != param.SortField {
+		return []SortItem{{Field: param.SortField, Type: param.SortType}}
+	}
+	return param.Sorts
+}
+
+// addSorts add the sort fields and their sort types into the url values.
+func (param Parameters) addSorts(p url.Values) {
+	for _, sort := range param.GetSorts() {
+		p.Add(Sort, sort.Field)
+		p.Add(SortType, sort.Type)
+	}
+}
+
+// WithSearch set the keyword of the global search, which is matched by the
+// searchable fields of the table.
+func (param Parameters) WithSearch(keyword string) Parameters {
+	param.search = keyword
+	return param
+}
+
+// SearchKeyword return the keyword of the global search.
+func (param Parameters) SearchKeyword() string {
+	return param.search
+}
+
+// WithKeyset page the rows of the table by the cursors of the keyset of the
+// primary key instead of the offsets of the pages, which is used to go through
+// all the rows such as exporting them. The tables of the cursor pagination are
+// paged by their own sort fields.
+func (param Parameters) WithKeyset() Parameters {
+	param.keyset = true
+	return param
+}
+
+// IsKeyset return true if the rows are paged by the keyset of the primary key.
+func (param Parameters) IsKeyset() bool {
+	return param.keyset
+}
+
 func (param Parameters) WithPKs(id ...string) Parameters {
 	param.Fields[PrimaryKey] = []string{strings.Join(id, ",")}
 	return param
@@ -175,6 +282,12 @@ func (param Parameters) IsAll() bool {
 	return param.GetFieldValue(IsAll) == True
 }
 
+// IsTrash return true if the parameters are of the trash of a soft deleted
+// table.
+func (param Parameters) IsTrash() bool {
+	return param.GetFieldValue(Trash) == True
+}
+
 func (param *Parameters) WithURLPath(path string) Parameters {
 	param.URLPath = path
 	return *param
@@ -290,9 +403,8 @@ func (param Parameters) URLNoAnimation(page string) string {
 
 func (param Parameters) GetRouteParamStrWithoutPageSize(page string) string {
 	p := make(url.Values)
-	p.Add(Sort, param.SortField)
+	param.addSorts(p)
 	p.Add(Page, page)
-	p.Add(SortType, param.SortType)
 	if len(param.Columns) > 0 {
 		p.Add(Columns, strings.Join(param.Columns, ","))
 	}
@@ -336,9 +448,8 @@ func (param Parameters) GetNextPageRouteParamStr(cache ...bool) string {
 
 func (param Parameters) GetFixedParamStr() url.Values {
 	p := make(url.Values)
-	p.Add(Sort, param.SortField)
+	param.addSorts(p)
 	p.Add(PageSize, param.PageSize)
-	p.Add(SortType, param.SortType)
 	if len(param.Columns) > 0 {
 		p.Add(Columns, strings.Join(param.Columns, ","))
 	}
@@ -350,12 +461,11 @@ func (param Parameters) GetFixedParamStr() url.Values {
 
 func (param Parameters) GetFixedParamStrWithoutColumnsAndPage() string {
 	p := make(url.Values)
-	p.Add(Sort, param.SortField)
+	param.addSorts(p)
 	p.Add(PageSize, param.PageSize)
 	if len(param.Columns) > 0 {
 		p.Add(Columns, strings.Join(param.Columns, ","))
 	}
-	p.Add(SortType, param.SortType)
 	return "?" + p.Encode()
 }
 
@@ -2,6 +2,8 @@ package parameter
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -14,3 +16,57 @@ func TestParameters_PKs(t *testing.T) {
 	pks := BaseParam().PKs()
 	fmt.Println("pks", pks, "len", len(pks))
 }
+
+func TestParameters_IsTrash(t *testing.T) {
+	if GetParamFromURL("/admin/info/user?__page=1", 1, "asc", "id").IsTrash() {
+		t.Fatal("should not be the trash")
+	}
+	param := GetParamFromURL("/admin/info/user?__page=1&__trash=true", 1, "asc", "id")
+	if !param.IsTrash() {
+		t.Fatal("should be the trash")
+	}
+	if !strings.Contains(param.GetRouteParamStr(), "__trash=true") {
+		t.Fatal("the trash should be kept in the route parameters")
+	}
+}
+
+func TestCursor(t *testing.T) {
+	cursor := Cursor{Values: []string{"2020-01-01 00:00:00", ""}, Nulls: []bool{false, true}, PK: "12", Backward: true}
+	param := GetParamFromURL("/admin/info/user?__pageSize=10&"+CursorKey+"="+cursor.Encode(), 1, "asc", "id")
+	c, ok := param.GetCursor()
+	if !ok || !reflect.DeepEqual(c, cursor) {
+		t.Fatal("wrong cursor", c)
+	}
+	if c.IsNull(0) || !c.IsNull(1) || c.IsNull(2) {
+		t.Fatal("wrong null values of the cursor", c)
+	}
+	if _, ok := DecodeCursor("wrong"); ok {
+		t.Fatal("should be a wrong cursor")
+	}
+	if strings.Contains(param.GetRouteParamStr(), CursorKey) {
+		t.Fatal("the cursor should not be kept in the route parameters")
+	}
+	if !strings.Contains(param.CursorURL("abc"), CursorKey+"=abc") {
+		t.Fatal("wrong cursor url")
+	}
+}
+
+func TestParameters_Sorts(t *testing.T) {
+	param := GetParamFromURL("/admin/info/user?__sort=status&__sort_type=asc&__sort=created_at&__sort_type=wrong&__sort=status",
+		1, "asc", "id")
+	if param.SortField != "status" || param.SortType != "asc" {
+		t.Fatal("wrong sort field", param.SortField, param.SortType)
+	}
+	sorts := param.GetSorts()
+	if len(sorts) != 2 || sorts[1] != (SortItem{Field: "created_at", Type: "desc"}) {
+		t.Fatal("wrong sorts", sorts)
+	}
+	if !strings.Contains(param.GetRouteParamStr(), "__sort=status&__sort=created_at&__sort_type=asc&__sort_type=desc") {
+		t.Fatal("the sorts should be kept in the route parameters", param.GetRouteParamStr())
+	}
+
+	param = GetParamFromURL("/admin/info/user", 1, "asc", "id")
+	if len(param.GetSorts()) != 1 || param.SortField != "id" || param.SortType != "asc" {
+		t.Fatal("wrong default sort", param.GetSorts())
+	}
+}
@@ -0,0 +1,231 @@
+package resource
+
+import (
+	"sort"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/parameter"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/table"
+)
+
+// OpenAPIVersion is the version of the OpenAPI specification of the Document.
+const OpenAPIVersion = "3.0.3"
+
+// Allow reports whether the caller is permitted to request the path with
+// the method.
+type Allow func(path, method string) bool
+
+// Document return the OpenAPI document of the JSON API of the tables, the
+// keys of which are the prefixes. The paths are under the given path. Only
+// the operations permitted by allow are documented, the tables without any
+// are left out, allow can be nil to document all.
+func Document(title, version, path string, tables map[string]table.Table, allow Allow) map[string]interface{} {
+
+	if allow == nil {
+		allow = func(string, string) bool { return true }
+	}
+
+	var (
+		prefixes = make([]string, 0, len(tables))
+		paths    = make(map[string]interface{})
+		schemas  = make(map[string]interface{})
+	)
+
+	for prefix := range tables {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	for _, prefix := range prefixes {
+		var (
+			t        = tables[prefix]
+			tag      = []string{prefix}
+			record   = ref(prefix)
+			input    = ref(prefix + "_input")
+			list     = map[string]interface{}{}
+			item     = map[string]interface{}{}
+			pkName   = t.GetPrimaryKey().Name
+			pkSchema = Schema(t.GetPrimaryKey().Type)
+			listPath = path + "/" + prefix
+			itemPath = listPath + "/{id}"
+		)
+
+		if allow(listPath, "GET") {
+			list["get"] = operation(prefix+"_list", tag, t.GetInfo().Title, listParameters(),
+				nil, dataSchema(map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"items":     map[string]interface{}{"type": "array", "items": record},
+						"total":     map[string]interface{}{"type": "integer"},
+						"page":      map[string]interface{}{"type": "integer"},
+						"page_size": map[string]interface{}{"type": "integer"},
+
+						"previous_cursor": map[string]interface{}{"type": "string"},
+						"next_cursor":     map[string]interface{}{"type": "string"},
+					},
+				}))
+		}
+		if t.GetCanAdd() && allow(listPath, "POST") {
+			list["post"] = operation(prefix+"_create", tag, t.GetForm().Title, nil,
+				input, dataSchema(nil))
+		}
+
+		pkParameter := []interface{}{map[string]interface{}{
+			"name":        "id",
+			"in":          "path",
+			"required":    true,
+			"description": pkName,
+			"schema":      pkSchema,
+		}}
+		if allow(itemPath, "GET") {
+			item["get"] = operation(prefix+"_detail", tag, t.GetDetail().Title, pkParameter,
+				nil, dataSchema(record))
+		}
+		if t.GetEditable() && allow(itemPath, "PUT") {
+			item["put"] = operation(prefix+"_update", tag, t.GetForm().Title, pkParameter,
+				input, dataSchema(record))
+		}
+		if t.GetDeletable() && allow(itemPath, "DELETE") {
+			item["delete"] = operation(prefix+"_delete", tag, t.GetInfo().Title, pkParameter,
+				nil, dataSchema(nil))
+		}
+
+		if len(list) == 0 && len(item) == 0 {
+			continue
+		}
+
+		schemas[prefix] = recordSchema(t)
+		schemas[prefix+"_input"] = inputSchema(t)
+		if len(list) > 0 {
+			paths[listPath] = list
+		}
+		if len(item) > 0 {
+			paths[itemPath] = item
+		}
+	}
+
+	return map[string]interface{}{
+		"openapi": OpenAPIVersion,
+		"info": map[string]interface{}{
+			"title":   title,
+			"version": version,
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+			"securitySchemes": map[string]interface{}{
+				"bearerAuth": map[string]interface{}{"type": "http", "scheme": "bearer"},
+				"cookieAuth": map[string]interface{}{"type": "apiKey", "in": "cookie", "name": "go_admin_session"},
+			},
+		},
+		"security": []interface{}{
+			map[string]interface{}{"bearerAuth": []string{}},
+			map[string]interface{}{"cookieAuth": []string{}},
+		},
+	}
+}
+
+// Schema return the schema of the values of the type.
+func Schema(typ db.DatabaseType) map[string]interface{} {
+	switch {
+	case db.Contains(typ, db.IntTypeList):
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case db.Contains(typ, db.FloatTypeList), db.Contains(typ, db.UintTypeList):
+		return map[string]interface{}{"type": "number"}
+	case db.Contains(typ, db.BoolTypeList):
+		return map[string]interface{}{"type": "boolean"}
+	case typ == db.Date:
+		return map[string]interface{}{"type": "string", "format": "date"}
+	case typ == db.Datetime, typ == db.Timestamp, typ == db.Timestamptz:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	default:
+		return map[string]interface{}{"type": "string"}
+	}
+}
+
+func recordSchema(t table.Table) map[string]interface{} {
+	properties := make(map[string]interface{})
+	for _, field := range Fields(t) {
+		s := Schema(field.Type)
+		s["title"] = field.Head
+		s["nullable"] = true
+		properties[field.Name] = s
+	}
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
+
+func inputSchema(t table.Table) map[string]interface{} {
+	properties := make(map[string]interface{})
+	for _, field := range WritableFields(t.GetForm().FieldList) {
+		s := Schema(field.TypeName)
+		if field.FormType.IsMultiSelect() {
+			s = map[string]interface{}{"type": "array", "items": s}
+		}
+		s["title"] = field.Head
+		properties[field.Field] = s
+	}
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
+
+func listParameters() []interface{} {
+	query := func(name, typ, desc string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":        name,
+			"in":          "query",
+			"description": desc,
+			"schema":      map[string]interface{}{"type": typ},
+		}
+	}
+	return []interface{}{
+		query(parameter.Page, "integer", "page, which is reached by the cursors of the pages before it for the tables with cursor pagination"),
+		query(parameter.PageSize, "integer", "page size"),
+		query(parameter.Sort, "string", "sort field, which is repeated to sort by more fields"),
+		query(parameter.SortType, "string", "asc or desc of the sort field of the same position"),
+		query(parameter.CursorKey, "string", "cursor of the page of the tables with cursor pagination"),
+	}
+}
+
+func operation(id string, tags []string, summary string, parameters []interface{},
+	body, data map[string]interface{}) map[string]interface{} {
+	op := map[string]interface{}{
+		"operationId": id,
+		"tags":        tags,
+		"summary":     summary,
+		"responses": map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": "ok",
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{"schema": data},
+				},
+			},
+		},
+	}
+	if len(parameters) > 0 {
+		op["parameters"] = parameters
+	}
+	if body != nil {
+		op["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": body},
+			},
+		}
+	}
+	return op
+}
+
+// dataSchema return the schema of the response envelope of the data.
+func dataSchema(data map[string]interface{}) map[string]interface{} {
+	properties := map[string]interface{}{
+		"code": map[string]interface{}{"type": "integer"},
+		"msg":  map[string]interface{}{"type": "string"},
+	}
+	if data != nil {
+		properties["data"] = data
+	}
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
+
+func ref(name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
@@ -0,0 +1,153 @@
+// Package resource turns the rows of a table.Table into plain JSON records
+// and JSON request bodies into form values, typed by the db.DatabaseType of
+// the fields.
+package resource
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/parameter"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/table"
+	"github.com/GoAdminGroup/go-admin/template/types"
+)
+
+// Field is a field of the records of a table.
+type Field struct {
+	Name string
+	Head string
+	Type db.DatabaseType
+}
+
+// Fields return the fields of the records of the table, which are the
+// primary key and the shown fields of the InfoPanel.
+func Fields(t table.Table) []Field {
+	pk := t.GetPrimaryKey()
+	fields := []Field{{Name: pk.Name, Head: pk.Name, Type: pk.Type}}
+	for _, field := range t.GetInfo().FieldList {
+		if field.Field == pk.Name {
+			fields[0].Head = field.Head
+			continue
+		}
+		if field.Hide {
+			continue
+		}
+		name, typ := field.Field, field.TypeName
+		if field.Joins.Valid() {
+			name = field.Joins.Last().GetTableName() + parameter.FilterParamJoinInfix + field.Field
+			typ = db.Varchar
+		}
+		fields = append(fields, Field{Name: name, Head: field.Head, Type: typ})
+	}
+	return fields
+}
+
+// WritableFields return the fields of the form which can be written, the
+// join fields are not.
+func WritableFields(fields types.FormFields) types.FormFields {
+	writable := make(types.FormFields, 0, len(fields))
+	for _, field := range fields {
+		if field.Field == "" || field.Joins.Valid() {
+			continue
+		}
+		writable = append(writable, field)
+	}
+	return writable
+}
+
+// Record return the record of a row of the table data.
+func Record(fields []Field, item map[string]types.InfoItem) map[string]interface{} {
+	record := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		if v, ok := item[field.Name]; ok {
+			record[field.Name] = Value(field.Type, v.Value)
+		}
+	}
+	return record
+}
+
+// Records return the records of the table data.
+func Records(fields []Field, list types.InfoList) []map[string]interface{} {
+	records := make([]map[string]interface{}, len(list))
+	for i, item := range list {
+		records[i] = Record(fields, item)
+	}
+	return records
+}
+
+// Value return the typed value of the string value of the type.
+func Value(typ db.DatabaseType, value string) interface{} {
+	switch {
+	case db.Contains(typ, db.IntTypeList):
+		if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return v
+		}
+	case db.Contains(typ, db.UintTypeList):
+		// the integral values are kept as unsigned integers, so that the
+		// large ones are not rounded as floats.
+		if v, err := strconv.ParseUint(value, 10, 64); err == nil {
+			return v
+		}
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			return v
+		}
+	case db.Contains(typ, db.FloatTypeList):
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			return v
+		}
+	case db.Contains(typ, db.BoolTypeList):
+		if v, err := strconv.ParseBool(value); err == nil {
+			return v
+		}
+	default:
+		return value
+	}
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// FormValues return the form values of the fields of the JSON body, the
+// keys which are not fields are dropped.
+func FormValues(body map[string]interface{}, fields types.FormFields) form.Values {
+	values := make(form.Values)
+	for _, field := range fields {
+		v, ok := body[field.Field]
+		if !ok {
+			continue
+		}
+		if arr, ok := v.([]interface{}); ok {
+			list := make([]string, len(arr))
+			for i, item := range arr {
+				list[i] = formValue(item)
+			}
+			values[field.Field+"[]"] = list
+			continue
+		}
+		values[field.Field] = []string{formValue(v)}
+	}
+	return values
+}
+
+func formValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case bool:
+		if value {
+			return "1"
+		}
+		return "0"
+	case json.Number:
+		return value.String()
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
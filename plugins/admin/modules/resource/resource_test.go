@@ -0,0 +1,108 @@
+package resource
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/table"
+	"github.com/GoAdminGroup/go-admin/template/types"
+	"github.com/GoAdminGroup/go-admin/template/types/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func testTable() table.Table {
+	tb := table.NewDefaultTable(table.DefaultConfigWithDriver(db.DriverMysql))
+	info := tb.GetInfo()
+	info.AddField("ID", "id", db.Int)
+	info.AddField("Name", "name", db.Varchar)
+	info.AddField("Score", "score", db.Decimal)
+	info.AddField("Password", "password", db.Varchar).FieldHide()
+	f := tb.GetForm()
+	f.AddField("ID", "id", db.Int, form.Default).FieldNotAllowAdd()
+	f.AddField("Name", "name", db.Varchar, form.Text)
+	f.AddField("Tags", "tags", db.Varchar, form.Select)
+	return tb
+}
+
+func TestFields(t *testing.T) {
+	fields := Fields(testTable())
+	assert.Equal(t, fields, []Field{
+		{Name: "id", Head: "ID", Type: db.Int},
+		{Name: "name", Head: "Name", Type: db.Varchar},
+		{Name: "score", Head: "Score", Type: db.Decimal},
+	})
+}
+
+func TestValue(t *testing.T) {
+	assert.Equal(t, Value(db.Int, "12"), int64(12))
+	assert.Equal(t, Value(db.Int, ""), nil)
+	assert.Equal(t, Value(db.Decimal, "1.500000"), 1.5)
+	assert.Equal(t, Value(db.Decimal, "18446744073709551615"), uint64(18446744073709551615))
+	assert.Equal(t, Value(db.Bit, "1"), uint64(1))
+	assert.Equal(t, Value(db.Float, "2"), float64(2))
+	assert.Equal(t, Value(db.Bool, "true"), true)
+	assert.Equal(t, Value(db.Varchar, ""), "")
+	assert.Equal(t, Value(db.Int, "abc"), "abc")
+}
+
+func TestRecord(t *testing.T) {
+	record := Record(Fields(testTable()), map[string]types.InfoItem{
+		"id":       {Value: "1"},
+		"name":     {Value: "Jack"},
+		"password": {Value: "secret"},
+	})
+	assert.Equal(t, record, map[string]interface{}{"id": int64(1), "name": "Jack"})
+}
+
+func TestFormValues(t *testing.T) {
+	var body map[string]interface{}
+	decoder := json.NewDecoder(strings.NewReader(`{"id":12345678901234567,"name":"Jack","tags":["a",true],"other":1}`))
+	decoder.UseNumber()
+	assert.Nil(t, decoder.Decode(&body))
+
+	values := FormValues(body, testTable().GetForm().FieldList)
+	assert.Equal(t, values.Get("id"), "12345678901234567")
+	assert.Equal(t, values.Get("name"), "Jack")
+	assert.Equal(t, values["tags[]"], []string{"a", "1"})
+	_, ok := values["other"]
+	assert.Equal(t, ok, false)
+}
+
+func TestDocument(t *testing.T) {
+	doc := Document("GoAdmin", "1", "/admin/api/v1", map[string]table.Table{"users": testTable()}, nil)
+	paths := doc["paths"].(map[string]interface{})
+	assert.Contains(t, paths, "/admin/api/v1/users")
+	assert.Contains(t, paths["/admin/api/v1/users/{id}"], "put")
+
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	properties := schemas["users"].(map[string]interface{})["properties"].(map[string]interface{})
+	assert.Equal(t, properties["id"].(map[string]interface{})["type"], "integer")
+	assert.Equal(t, properties["score"].(map[string]interface{})["type"], "number")
+	assert.NotContains(t, properties, "password")
+
+	input := schemas["users_input"].(map[string]interface{})["properties"].(map[string]interface{})
+	assert.Equal(t, input["tags"].(map[string]interface{})["type"], "array")
+
+	// only the permitted operations are documented.
+	doc = Document("GoAdmin", "1", "/admin/api/v1", map[string]table.Table{"users": testTable(), "posts": testTable()},
+		func(path, method string) bool {
+			return path == "/admin/api/v1/users/{id}" && method == "GET"
+		})
+	paths = doc["paths"].(map[string]interface{})
+	assert.Equal(t, 1, len(paths))
+	assert.Equal(t, []string{"get"}, keys(paths["/admin/api/v1/users/{id}"].(map[string]interface{})))
+	schemas = doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	assert.NotContains(t, schemas, "posts")
+}
+
+func keys(m map[string]interface{}) []string {
+	list := make([]string, 0, len(m))
+	for key := range m {
+		list = append(list, key)
+	}
+	sort.Strings(list)
+	return list
+}
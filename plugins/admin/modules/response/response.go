@@ -43,6 +43,13 @@ func BadRequest(ctx *context.Context, msg string) {
 	})
 }
 
+func NotFound(ctx *context.Context, msg string) {
+	ctx.JSON(http.StatusNotFound, map[string]interface{}{
+		"code": http.StatusNotFound,
+		"msg":  language.Get(msg),
+	})
+}
+
 func Alert(ctx *context.Context, desc, title, msg string, conn db.Connection, btns *types.Buttons,
 	pageType ...template.PageType) {
 	user := auth.Auth(ctx)
@@ -92,6 +99,13 @@ func Denied(ctx *context.Context, msg string) {
 	})
 }
 
+func Forbidden(ctx *context.Context, msg string) {
+	ctx.JSON(http.StatusForbidden, map[string]interface{}{
+		"code": http.StatusForbidden,
+		"msg":  language.Get(msg),
+	})
+}
+
 var OffLineHandler = func(ctx *context.Context) {
 	if config.GetSiteOff() {
 		if ctx.WantHTML() {
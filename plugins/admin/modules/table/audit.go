@@ -0,0 +1,153 @@
+package table
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/modules/db/dialect"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
+	"github.com/GoAdminGroup/go-admin/template/types"
+	"github.com/GoAdminGroup/go-admin/template/types/form"
+)
+
+// auditMask replaces the values of the password fields in the audit log.
+const auditMask = "******"
+
+// auditSecrets are the parts of the names of the columns which are masked in
+// the audit log besides the password fields of the form, so that the secrets
+// of a deleted record, which has no posted fields, are not recorded.
+var auditSecrets = []string{"password", "secret", "token", "recovery_code"}
+
+// auditEntry is an entry of the audit log of a record.
+type auditEntry struct {
+	recordId string
+	action   string
+	changes  []models.AuditChange
+}
+
+// Auditable is a Table which records the changes of its data in the audit
+// log on behalf of the user.
+type Auditable interface {
+	SetAuditUser(id int64, name string)
+}
+
+// SetAuditUser set the user of the changes of the table if the table is
+// Auditable.
+func SetAuditUser(t Table, user models.UserModel) {
+	if a, ok := t.(Auditable); ok {
+		a.SetAuditUser(user.Id, user.Name)
+	}
+}
+
+// SetAuditUser implements the Auditable.SetAuditUser.
+func (tb *DefaultTable) SetAuditUser(id int64, name string) {
+	tb.auditUserId = id
+	tb.auditUserName = name
+}
+
+// audit write the entries of the audit log and queue the deliveries of the
+// webhooks within tx, the transaction of the change, so that the change is
+// rolled back if they fail. The audit log and the outbox are in the default
+// connection, the entries of a table of the other connections are written
+// out of tx.
+func (tb *DefaultTable) audit(tx *sql.Tx, table string, entries ...auditEntry) error {
+	conn := db.GetConnection(services)
+	if tb.db() != conn || (tb.connection != "" && tb.connection != DefaultConnectionName) {
+		tx = nil
+	}
+	for _, entry := range entries {
+		if entry.action == models.AuditActionUpdate && len(entry.changes) == 0 {
+			continue
+		}
+		_, err := models.AuditLog().SetConn(conn).WithTx(tx).
+			New(tb.auditUserId, tb.auditUserName, table, entry.recordId, entry.action, entry.changes)
+		if err != nil {
+			return err
+		}
+		if err := tb.notify(conn, tx, table, entry.recordId, entry.action, entry.changes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// auditRows return the records of the table before the change within tx.
+func (tb *DefaultTable) auditRows(tx *sql.Tx, table string, ids []string) ([]map[string]interface{}, error) {
+	return tb.sql().WithTx(tx).Table(table).WhereIn(tb.PrimaryKey.Name, stringsToArgs(ids)).All()
+}
+
+// auditDiff return the changes of the fields from old to new, sorted by the
+// field names. The fields only in old are recorded as removed when all is
+// true, which is used for the deletion.
+func auditDiff(old map[string]interface{}, new dialect.H, fields types.FormFields, all bool) []models.AuditChange {
+	keys := make([]string, 0, len(new)+len(old))
+	for key := range new {
+		keys = append(keys, key)
+	}
+	if all {
+		for key := range old {
+			if _, ok := new[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	changes := make([]models.AuditChange, 0)
+	for _, key := range keys {
+		oldValue, newValue := auditValue(old[key]), auditValue(new[key])
+		if oldValue == newValue {
+			continue
+		}
+		if auditMasked(key, fields) {
+			oldValue, newValue = maskValue(oldValue), maskValue(newValue)
+		}
+		changes = append(changes, models.AuditChange{Field: key, Old: oldValue, New: newValue})
+	}
+	return changes
+}
+
+// auditMasked return true if the values of the column are masked in the
+// audit log.
+func auditMasked(key string, fields types.FormFields) bool {
+	if field := fields.FindByFieldName(key); field != nil && field.FormType == form.Password {
+		return true
+	}
+	key = strings.ToLower(key)
+	for _, secret := range auditSecrets {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+func maskValue(v string) string {
+	if v == "" {
+		return ""
+	}
+	return auditMask
+}
+
+// auditValue return the value of a column as a string.
+func auditValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case []byte:
+		return string(value)
+	case time.Time:
+		return value.Format("2006-01-02 15:04:05")
+	case int64:
+		return strconv.FormatInt(value, 10)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
@@ -0,0 +1,46 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/modules/db/dialect"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
+	"github.com/GoAdminGroup/go-admin/template/types"
+	"github.com/GoAdminGroup/go-admin/template/types/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditValue(t *testing.T) {
+	assert.Equal(t, auditValue(nil), "")
+	assert.Equal(t, auditValue([]byte("a")), "a")
+	assert.Equal(t, auditValue(int64(12)), "12")
+	assert.Equal(t, auditValue(1.5), "1.5")
+	assert.Equal(t, auditValue(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)), "2020-01-02 03:04:05")
+}
+
+func TestAuditDiff(t *testing.T) {
+	fields := types.FormFields{{Field: "password", FormType: form.Password}}
+	old := map[string]interface{}{"id": int64(1), "name": "a", "age": int64(10), "password": "x"}
+
+	changes := auditDiff(old, dialect.H{"name": "b", "age": "10", "password": "y"}, fields, false)
+	assert.Equal(t, changes, []models.AuditChange{
+		{Field: "name", Old: "a", New: "b"},
+		{Field: "password", Old: auditMask, New: auditMask},
+	})
+
+	changes = auditDiff(nil, dialect.H{"name": "a", "age": ""}, fields, false)
+	assert.Equal(t, changes, []models.AuditChange{{Field: "name", Old: "", New: "a"}})
+
+	changes = auditDiff(old, dialect.H{}, fields, true)
+	assert.Equal(t, len(changes), 4)
+	assert.Equal(t, changes[0], models.AuditChange{Field: "age", Old: "10", New: ""})
+
+	// the secrets of a deleted record are masked by the names of the columns.
+	changes = auditDiff(map[string]interface{}{"name": "a", "remember_token": "t", "totp_secret": "s"}, dialect.H{}, nil, true)
+	assert.Equal(t, changes, []models.AuditChange{
+		{Field: "name", Old: "a", New: ""},
+		{Field: "remember_token", Old: auditMask, New: ""},
+		{Field: "totp_secret", Old: auditMask, New: ""},
+	})
+}
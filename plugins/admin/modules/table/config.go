@@ -12,6 +12,8 @@ type Config struct {
 	Editable       bool
 	Deletable      bool
 	Exportable     bool
+	Importable     bool
+	SoftDelete     bool
 	PrimaryKey     PrimaryKey
 	SourceURL      string
 	GetDataFun     GetDataFun
@@ -102,6 +104,18 @@ func (config Config) SetExportable(exportable bool) Config {
 	return config
 }
 
+func (config Config) SetImportable(importable bool) Config {
+	config.Importable = importable
+	return config
+}
+
+// SetSoftDelete makes the table set the deleted_at column instead of
+// deleting the rows.
+func (config Config) SetSoftDelete(softDelete bool) Config {
+	config.SoftDelete = softDelete
+	return config
+}
+
 func (config Config) SetConnection(connection string) Config {
 	config.Connection = connection
 	return config
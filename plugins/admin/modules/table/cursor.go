@@ -0,0 +1,244 @@
+package table
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/modules/db/dialect"
+	"github.com/GoAdminGroup/go-admin/modules/logger"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/parameter"
+)
+
+// cursorSortKey is the prefix of the aliases of the sort fields selected by
+// the cursor pagination, which are kept even if the fields are not shown.
+const cursorSortKey = "__goadmin_cursor"
+
+// cursorKey is a column the rows of the cursor pagination are ordered by.
+type cursorKey struct {
+	column   string
+	alias    string
+	sortType string
+	nullable bool
+}
+
+// cursorKeys return the sort fields of the parameters which are the columns
+// of the table, followed by the primary key, so that the rows of the same
+// values are not lost.
+func (tb *DefaultTable) cursorKeys(params parameter.Parameters, table string, columns Columns) []cursorKey {
+	var (
+		connection = tb.db()
+		sorts      = params.GetSorts()
+		keys       = make([]cursorKey, 0, len(sorts)+1)
+		pk         = cursorKey{
+			column:   table + "." + modules.Delimiter(connection.GetDelimiter(), connection.GetDelimiter2(), tb.PrimaryKey.Name),
+			alias:    tb.PrimaryKey.Name,
+			sortType: sorts[0].Type,
+		}
+	)
+	for _, sort := range sorts {
+		if sort.Field == tb.PrimaryKey.Name {
+			pk.sortType = sort.Type
+			break
+		}
+		if !modules.InArray(columns, sort.Field) {
+			continue
+		}
+		keys = append(keys, cursorKey{
+			column:   table + "." + modules.Delimiter(connection.GetDelimiter(), connection.GetDelimiter2(), sort.Field),
+			alias:    cursorSortKey + strconv.Itoa(len(keys)),
+			sortType: sort.Type,
+			nullable: true,
+		})
+	}
+	return append(keys, pk)
+}
+
+// cursorDirection return the order of the query of the page, which is the
+// reverse of the sort type when going backward.
+func cursorDirection(sortType string, backward bool) string {
+	if (sortType == "asc") != backward {
+		return "asc"
+	}
+	return "desc"
+}
+
+// getCursor return the cursor of the parameters, it is false for the first
+// page or a cursor of other sort fields.
+func getCursor(params parameter.Parameters, keys []cursorKey) (parameter.Cursor, bool) {
+	cursor, ok := params.GetCursor()
+	if !ok || len(cursor.Values) != len(keys)-1 {
+		return parameter.Cursor{}, false
+	}
+	return cursor, true
+}
+
+// cursorWhere return the keyset condition of the cursor of the parameters,
+// which is empty for the first page. The null values are ordered before the
+// others, so a row is after the cursor if it is after it by a key and equal
+// to it by all the keys before.
+func cursorWhere(params parameter.Parameters, keys []cursorKey, args []interface{}) (string, []interface{}) {
+
+	cursor, ok := getCursor(params, keys)
+	if !ok {
+		return "", args
+	}
+
+	pk := keys[len(keys)-1]
+	op := ">"
+	if cursorDirection(pk.sortType, cursor.Backward) == "desc" {
+		op = "<"
+	}
+	where, whereArgs := pk.column+" "+op+" ?", []interface{}{cursor.PK}
+
+	for i := len(keys) - 2; i >= 0; i-- {
+		var (
+			key     = keys[i]
+			asc     = cursorDirection(key.sortType, cursor.Backward) == "asc"
+			after   string
+			equal   = key.column + " = ?"
+			keyArgs []interface{}
+		)
+		switch {
+		case cursor.IsNull(i) && asc:
+			after, equal = key.column+" is not null", key.column+" is null"
+		case cursor.IsNull(i):
+			equal = key.column + " is null"
+		case asc:
+			after, keyArgs = key.column+" > ?", []interface{}{cursor.Values[i], cursor.Values[i]}
+		default:
+			after, keyArgs = key.column+" < ? or "+key.column+" is null", []interface{}{cursor.Values[i], cursor.Values[i]}
+		}
+		if after == "" {
+			where = "(" + equal + " and " + where + ")"
+		} else {
+			where = "(" + after + " or (" + equal + " and " + where + "))"
+		}
+		whereArgs = append(keyArgs, whereArgs...)
+	}
+
+	return where, append(args, whereArgs...)
+}
+
+// cursorOrder return the order by clause of the cursor pagination of driver,
+// which puts the null values first in the ascending order.
+func cursorOrder(params parameter.Parameters, keys []cursorKey, driver string) string {
+	cursor, _ := getCursor(params, keys)
+	orders := make([]string, len(keys))
+	for i, key := range keys {
+		orders[i] = key.column + " " + cursorDirection(key.sortType, cursor.Backward)
+		if key.nullable && driver == db.DriverPostgresql {
+			if cursorDirection(key.sortType, cursor.Backward) == "asc" {
+				orders[i] += " nulls first"
+			} else {
+				orders[i] += " nulls last"
+			}
+		}
+	}
+	return strings.Join(orders, ", ")
+}
+
+// cursorPage trim the extra row queried to know whether there is one more
+// page, and return the rows in the order of the page with the cursors of the
+// previous and the next page.
+func cursorPage(res []map[string]interface{}, params parameter.Parameters,
+	keys []cursorKey) ([]map[string]interface{}, string, string) {
+
+	cursor, ok := getCursor(params, keys)
+	backward := ok && cursor.Backward
+
+	more := len(res) > params.PageSizeInt
+	if more {
+		res = res[:params.PageSizeInt]
+	}
+
+	if backward {
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+	}
+
+	if len(res) == 0 {
+		return res, "", ""
+	}
+
+	var previous, next string
+	if (ok && !backward) || (backward && more) {
+		previous = rowCursor(res[0], keys, true).Encode()
+	}
+	if more || backward {
+		next = rowCursor(res[len(res)-1], keys, false).Encode()
+	}
+
+	return res, previous, next
+}
+
+func rowCursor(row map[string]interface{}, keys []cursorKey, backward bool) parameter.Cursor {
+	cursor := parameter.Cursor{
+		Values:   make([]string, len(keys)-1),
+		PK:       cursorValue(row[keys[len(keys)-1].alias]),
+		Backward: backward,
+	}
+	for i, key := range keys[:len(keys)-1] {
+		if cursorNull(row[key.alias]) {
+			if cursor.Nulls == nil {
+				cursor.Nulls = make([]bool, len(cursor.Values))
+			}
+			cursor.Nulls[i] = true
+			continue
+		}
+		cursor.Values[i] = cursorValue(row[key.alias])
+	}
+	return cursor
+}
+
+// cursorNull return true if the value is null, which is a nil slice of
+// bytes when the type of the column is not known.
+func cursorNull(v interface{}) bool {
+	b, ok := v.([]byte)
+	return v == nil || (ok && b == nil)
+}
+
+func cursorValue(v interface{}) string {
+	switch value := v.(type) {
+	case time.Time:
+		return value.Format("2006-01-02 15:04:05.999999999")
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return auditValue(v)
+	}
+}
+
+// approximateCount return the rows of the table estimated from the
+// statistics of the database, which is zero if there is none. The statistics
+// of a table never analyzed can be negative, such as -1 of postgresql.
+func (tb *DefaultTable) approximateCount(params parameter.Parameters) int {
+	countCmd, args := dialect.GetDialectByDriver(tb.connectionDriver).ApproximateCount(tb.Info.Table)
+	if countCmd == "" {
+		return 0
+	}
+
+	logger.LogSQL(countCmd, args)
+
+	res, err := tb.db().QueryWithConnectionContext(params.Context(), tb.connection, countCmd, args...)
+	if err != nil || len(res) == 0 {
+		return 0
+	}
+
+	size, _ := strconv.ParseFloat(cursorValue(res[0]["size"]), 64)
+	if size < 0 {
+		return 0
+	}
+	return int(size)
+}
+
+// cursorLimit return the limit clause of the cursor pagination of driver.
+func cursorLimit(driver string) string {
+	if driver == db.DriverMssql {
+		return "OFFSET 0 ROWS FETCH NEXT ? ROWS ONLY"
+	}
+	return "LIMIT ?"
+}
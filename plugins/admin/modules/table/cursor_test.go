@@ -0,0 +1,198 @@
+package table
+
+import (
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	_ "github.com/GoAdminGroup/go-admin/modules/db/drivers/sqlite"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/parameter"
+	"github.com/stretchr/testify/assert"
+)
+
+func cursorParam(sortField, sortType string, cursor *parameter.Cursor) parameter.Parameters {
+	param := parameter.BaseParam()
+	param.PageSize, param.PageSizeInt = "2", 2
+	param.SortField, param.SortType = sortField, sortType
+	if cursor != nil {
+		param.Cursor = cursor.Encode()
+	}
+	return param
+}
+
+func cursorKeys(sortTypes ...string) []cursorKey {
+	keys := make([]cursorKey, 0, len(sortTypes))
+	for i, sortType := range sortTypes[:len(sortTypes)-1] {
+		keys = append(keys, cursorKey{column: "s" + strconv.Itoa(i), alias: cursorSortKey + strconv.Itoa(i),
+			sortType: sortType, nullable: true})
+	}
+	return append(keys, cursorKey{column: "pk", alias: "id", sortType: sortTypes[len(sortTypes)-1]})
+}
+
+func TestCursorWhere(t *testing.T) {
+	keys := cursorKeys("desc", "desc")
+
+	where, args := cursorWhere(cursorParam("name", "desc", nil), keys, nil)
+	assert.Equal(t, "", where)
+	assert.Equal(t, 0, len(args))
+
+	cursor := &parameter.Cursor{Values: []string{"a"}, PK: "3"}
+	where, args = cursorWhere(cursorParam("name", "desc", cursor), keys, nil)
+	assert.Equal(t, "(s0 < ? or s0 is null or (s0 = ? and pk < ?))", where)
+	assert.Equal(t, []interface{}{"a", "a", "3"}, args)
+	assert.Equal(t, "s0 desc, pk desc", cursorOrder(cursorParam("name", "desc", cursor), keys, db.DriverMysql))
+	assert.Equal(t, "s0 desc nulls last, pk desc", cursorOrder(cursorParam("name", "desc", cursor), keys, db.DriverPostgresql))
+
+	backward := &parameter.Cursor{Values: []string{"a"}, PK: "3", Backward: true}
+	where, _ = cursorWhere(cursorParam("name", "desc", backward), keys, nil)
+	assert.Equal(t, "(s0 > ? or (s0 = ? and pk > ?))", where)
+	assert.Equal(t, "s0 asc, pk asc", cursorOrder(cursorParam("name", "desc", backward), keys, db.DriverMysql))
+
+	// the null values are ordered before the others.
+	null := &parameter.Cursor{Values: []string{""}, Nulls: []bool{true}, PK: "3"}
+	where, args = cursorWhere(cursorParam("name", "asc", null), cursorKeys("asc", "asc"), nil)
+	assert.Equal(t, "(s0 is not null or (s0 is null and pk > ?))", where)
+	assert.Equal(t, []interface{}{"3"}, args)
+	where, args = cursorWhere(cursorParam("name", "desc", null), keys, nil)
+	assert.Equal(t, "(s0 is null and pk < ?)", where)
+	assert.Equal(t, []interface{}{"3"}, args)
+
+	// the keys of the multiple sort fields are compared in order.
+	multi := &parameter.Cursor{Values: []string{"a", "b"}, PK: "3"}
+	where, args = cursorWhere(cursorParam("name", "asc", multi), cursorKeys("asc", "desc", "asc"), nil)
+	assert.Equal(t, "(s0 > ? or (s0 = ? and (s1 < ? or s1 is null or (s1 = ? and pk > ?))))", where)
+	assert.Equal(t, []interface{}{"a", "a", "b", "b", "3"}, args)
+
+	// a cursor of other sort fields is the first page.
+	where, _ = cursorWhere(cursorParam("name", "asc", multi), keys, nil)
+	assert.Equal(t, "", where)
+
+	where, args = cursorWhere(cursorParam("id", "asc", &parameter.Cursor{PK: "3"}), cursorKeys("asc"), nil)
+	assert.Equal(t, "pk > ?", where)
+	assert.Equal(t, []interface{}{"3"}, args)
+}
+
+func TestCursorPage(t *testing.T) {
+	keys := cursorKeys("asc")
+	rows := func(ids ...int64) []map[string]interface{} {
+		res := make([]map[string]interface{}, 0)
+		for _, id := range ids {
+			res = append(res, map[string]interface{}{"id": id})
+		}
+		return res
+	}
+
+	res, previous, next := cursorPage(rows(1, 2, 3), cursorParam("id", "asc", nil), keys)
+	assert.Equal(t, 2, len(res))
+	assert.Equal(t, "", previous)
+	c, _ := parameter.DecodeCursor(next)
+	assert.Equal(t, parameter.Cursor{PK: "2"}, c)
+
+	res, previous, next = cursorPage(rows(2, 1), cursorParam("id", "asc", &parameter.Cursor{PK: "3", Backward: true}), keys)
+	assert.Equal(t, rows(1, 2), res)
+	assert.Equal(t, "", previous)
+	c, _ = parameter.DecodeCursor(next)
+	assert.Equal(t, parameter.Cursor{PK: "2"}, c)
+
+	res, previous, next = cursorPage(rows(3), cursorParam("id", "asc", &parameter.Cursor{PK: "2"}), keys)
+	assert.Equal(t, 1, len(res))
+	assert.Equal(t, "", next)
+	c, _ = parameter.DecodeCursor(previous)
+	assert.Equal(t, parameter.Cursor{PK: "3", Backward: true}, c)
+
+	c = rowCursor(map[string]interface{}{"id": int64(4), cursorSortKey + "0": nil, cursorSortKey + "1": "b"},
+		cursorKeys("asc", "asc", "asc"), false)
+	assert.Equal(t, parameter.Cursor{Values: []string{"", "b"}, Nulls: []bool{true, false}, PK: "4"}, c)
+}
+
+func TestGetDataKeyset(t *testing.T) {
+	conn := db.GetConnectionByDriver(db.DriverSqlite).InitDB(map[string]config.Database{
+		"default": {Driver: db.DriverSqlite, File: filepath.Join(t.TempDir(), "admin.db")},
+	})
+	_, err := conn.Exec("CREATE TABLE posts (`id` integer PRIMARY KEY autoincrement, `title` varchar(50))")
+	assert.Nil(t, err)
+	for _, title := range []string{"c", "a", "b", "a", "c"} {
+		_, err := conn.Exec("INSERT INTO posts (`title`) VALUES (?)", title)
+		assert.Nil(t, err)
+	}
+
+	tb := NewDefaultTable(DefaultConfigWithDriver(db.DriverSqlite)).(*DefaultTable)
+	tb.dbObj = conn
+	tb.GetInfo().SetTable("posts")
+	tb.GetInfo().AddField("ID", "id", db.Int)
+	tb.GetInfo().AddField("Title", "title", db.Varchar).FieldSortable()
+
+	// the rows are paged by the primary key whatever the sort field is.
+	params := cursorParam("title", "asc", nil).WithKeyset()
+	ids := make([]string, 0)
+	for pages := 0; pages < 5; pages++ {
+		info, err := tb.GetData(params)
+		assert.Nil(t, err)
+		assert.True(t, info.CursorMode)
+		for _, row := range info.InfoList {
+			ids = append(ids, row["id"].Value)
+		}
+		if info.NextCursor == "" {
+			break
+		}
+		params.Cursor = info.NextCursor
+	}
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, ids)
+}
+
+func TestGetDataCursor(t *testing.T) {
+	conn := db.GetConnectionByDriver(db.DriverSqlite).InitDB(map[string]config.Database{
+		"default": {Driver: db.DriverSqlite, File: filepath.Join(t.TempDir(), "admin.db")},
+	})
+	_, err := conn.Exec("CREATE TABLE IF NOT EXISTS cursor_posts (`id` integer PRIMARY KEY autoincrement, " +
+		"`title` varchar(50), `views` integer)")
+	assert.Nil(t, err)
+	_, err = conn.Exec("DELETE FROM cursor_posts")
+	assert.Nil(t, err)
+	for i, row := range [][]interface{}{{"b", 1}, {nil, 2}, {"a", 1}, {nil, 1}, {"b", 2}, {"a", 2}, {nil, 1}} {
+		_, err := conn.Exec("INSERT INTO cursor_posts (`id`, `title`, `views`) VALUES (?, ?, ?)", i+1, row[0], row[1])
+		assert.Nil(t, err)
+	}
+
+	tb := NewDefaultTable(DefaultConfigWithDriver(db.DriverSqlite)).(*DefaultTable)
+	tb.dbObj = conn
+	tb.GetInfo().SetTable("cursor_posts").SetCursorPagination(false)
+	tb.GetInfo().AddField("ID", "id", db.Int)
+	tb.GetInfo().AddField("Title", "title", db.Varchar).FieldSortable()
+	tb.GetInfo().AddField("Views", "views", db.Int).FieldSortable()
+
+	// the null titles are first, and the rows of the same title are ordered
+	// by the views.
+	params := cursorParam("title", "asc", nil).WithKeyset()
+	params.Sorts = []parameter.SortItem{{Field: "title", Type: "asc"}, {Field: "views", Type: "desc"}}
+	ids := make([]string, 0)
+	var info PanelInfo
+	for pages := 0; pages < 5; pages++ {
+		info, err = tb.GetData(params)
+		assert.Nil(t, err)
+		for _, row := range info.InfoList {
+			ids = append(ids, row["id"].Value)
+		}
+		if info.NextCursor == "" {
+			break
+		}
+		params.Cursor = info.NextCursor
+	}
+	assert.Equal(t, []string{"2", "4", "7", "6", "3", "5", "1"}, ids)
+
+	// the pages are the same when going backward.
+	ids = make([]string, 0)
+	for pages := 0; pages < 5 && info.PreviousCursor != ""; pages++ {
+		params.Cursor = info.PreviousCursor
+		info, err = tb.GetData(params)
+		assert.Nil(t, err)
+		page := make([]string, 0)
+		for _, row := range info.InfoList {
+			page = append(page, row["id"].Value)
+		}
+		ids = append(page, ids...)
+	}
+	assert.Equal(t, []string{"2", "4", "7", "6", "3", "5"}, ids)
+}
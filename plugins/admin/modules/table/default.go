@@ -1,6 +1,7 @@
 package table
 
 import (
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,12 +19,14 @@ import (
 	errs "github.com/GoAdminGroup/go-admin/modules/errors"
 	"github.com/GoAdminGroup/go-admin/modules/language"
 	"github.com/GoAdminGroup/go-admin/modules/logger"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/constant"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/paginator"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/parameter"
 	"github.com/GoAdminGroup/go-admin/template/types"
+	form2 "github.com/GoAdminGroup/go-admin/template/types/form"
 )
 
 // DefaultTable is an implementation of table.Table
@@ -35,6 +38,11 @@ type DefaultTable struct {
 	sourceURL            string
 	getDataFun           GetDataFun
 
+	auditUserId   int64
+	auditUserName string
+
+	webhookPrefix string
+
 	dbObj db.Connection
 }
 
@@ -60,6 +68,8 @@ func NewDefaultTable(cfgs ...Config) Table {
 			Editable:       cfg.Editable,
 			Deletable:      cfg.Deletable,
 			Exportable:     cfg.Exportable,
+			Importable:     cfg.Importable,
+			SoftDelete:     cfg.SoftDelete,
 			PrimaryKey:     cfg.PrimaryKey,
 			OnlyNewForm:    cfg.OnlyNewForm,
 			OnlyUpdateForm: cfg.OnlyUpdateForm,
@@ -96,6 +106,8 @@ func (tb *DefaultTable) Copy() Table {
 			Editable:   tb.Editable,
 			Deletable:  tb.Deletable,
 			Exportable: tb.Exportable,
+			Importable: tb.Importable,
+			SoftDelete: tb.SoftDelete,
 			PrimaryKey: tb.PrimaryKey,
 		},
 		connectionDriver:     tb.connectionDriver,
@@ -103,6 +115,9 @@ func (tb *DefaultTable) Copy() Table {
 		connection:           tb.connection,
 		sourceURL:            tb.sourceURL,
 		getDataFun:           tb.getDataFun,
+		auditUserId:          tb.auditUserId,
+		auditUserName:        tb.auditUserName,
+		webhookPrefix:        tb.webhookPrefix,
 	}
 }
 
@@ -168,6 +183,7 @@ func (tb *DefaultTable) GetData(params parameter.Parameters) (PanelInfo, error)
 	return PanelInfo{
 		Thead:    thead,
 		InfoList: infoList,
+		Total:    size,
 		Paginator: paginator.Get(paginator.Config{
 			Size:         size,
 			Param:        params,
@@ -192,7 +208,13 @@ func (tb *DefaultTable) getDataFromURL(params parameter.Parameters) ([]map[strin
 	} else {
 		u = tb.sourceURL + "?" + params.Join()
 	}
-	res, err := http.Get(u + "&pk=" + strings.Join(params.PKs(), ","))
+	req, err := http.NewRequestWithContext(params.Context(), http.MethodGet, u+"&pk="+strings.Join(params.PKs(), ","), nil)
+
+	if err != nil {
+		return []map[string]interface{}{}, 0
+	}
+
+	res, err := http.DefaultClient.Do(req)
 
 	if err != nil {
 		return []map[string]interface{}{}, 0
@@ -251,6 +273,7 @@ func (tb *DefaultTable) GetDataWithIds(params parameter.Parameters) (PanelInfo,
 	return PanelInfo{
 		Thead:    thead,
 		InfoList: infoList,
+		Total:    size,
 		Paginator: paginator.Get(paginator.Config{
 			Size:         size,
 			Param:        params,
@@ -376,7 +399,7 @@ func (tb *DefaultTable) getTempModelData(res map[string]interface{}, params para
 func (tb *DefaultTable) getAllDataFromDatabase(params parameter.Parameters) (PanelInfo, error) {
 	var (
 		connection     = tb.db()
-		queryStatement = "select %s from %s %s %s %s order by " + modules.Delimiter(connection.GetDelimiter(), connection.GetDelimiter2(), "%s") + " %s"
+		queryStatement = "select %s from %s %s %s %s order by %s"
 	)
 
 	columns, _ := tb.getColumns(tb.Info.Table)
@@ -406,20 +429,19 @@ func (tb *DefaultTable) getAllDataFromDatabase(params parameter.Parameters) (Pan
 		tb.Info.FieldList.GetFieldFilterProcessValue)
 	wheres, whereArgs = tb.Info.Wheres.Statement(wheres, connection.GetDelimiter(), connection.GetDelimiter2(), whereArgs, existKeys, columns)
 	wheres, whereArgs = tb.Info.WhereRaws.Statement(wheres, whereArgs)
+	wheres = andWhere(wheres, tb.softDeleteWhere(tb.Info.Table, params.IsTrash()))
 
 	if wheres != "" {
 		wheres = " where " + wheres
 	}
 
-	if !modules.InArray(columns, params.SortField) {
-		params.SortField = tb.PrimaryKey.Name
-	}
+	orderBy := tb.orderBy(params, modules.Delimiter(connection.GetDelimiter(), connection.GetDelimiter2(), tb.Info.Table), columns)
 
-	queryCmd := fmt.Sprintf(queryStatement, fields, tb.Info.Table, joins, wheres, groupBy, params.SortField, params.SortType)
+	queryCmd := fmt.Sprintf(queryStatement, fields, tb.Info.Table, joins, wheres, groupBy, orderBy)
 
 	logger.LogSQL(queryCmd, []interface{}{})
 
-	res, err := connection.QueryWithConnection(tb.connection, queryCmd, whereArgs...)
+	res, err := connection.QueryWithConnectionContext(params.Context(), tb.connection, queryCmd, whereArgs...)
 
 	if err != nil {
 		return PanelInfo{}, err
@@ -433,6 +455,7 @@ func (tb *DefaultTable) getAllDataFromDatabase(params parameter.Parameters) (Pan
 
 	return PanelInfo{
 		InfoList:    infoList,
+		Total:       len(infoList),
 		Thead:       thead,
 		Title:       tb.Info.Title,
 		Description: tb.Info.Description,
@@ -452,29 +475,43 @@ func (tb *DefaultTable) getDataFromDatabase(params parameter.Parameters) (PanelI
 		ids            = params.PKs()
 		table          = modules.Delimiter(delimiter, delimiter2, tb.Info.Table)
 		pk             = table + "." + modules.Delimiter(delimiter, delimiter2, tb.PrimaryKey.Name)
+		softDelete     = tb.softDeleteWhere(tb.Info.Table, params.IsTrash())
+		cursorMode     = (tb.Info.CursorPagination || params.IsKeyset()) && len(ids) == 0
 	)
 
+	if params.IsKeyset() && !tb.Info.CursorPagination {
+		params.SortField = tb.PrimaryKey.Name
+		params.Sorts = nil
+	}
+
 	beginTime := time.Now()
 
 	if len(ids) > 0 {
+		inIds := pk + " in (%s)"
+		if softDelete != "" {
+			inIds += " and " + softDelete
+		}
 		countExtra := ""
 		if connection.Name() == db.DriverMssql {
 			countExtra = "as [size]"
 		}
-		// %s means: fields, table, join table, pk values, group by, order by field,  order by type
-		queryStatement = "select %s from " + placeholder + " %s where " + pk + " in (%s) %s ORDER BY %s." + placeholder + " %s"
+		// %s means: fields, table, join table, pk values, group by, order by
+		queryStatement = "select %s from " + placeholder + " %s where " + inIds + " %s ORDER BY %s"
 		// %s means: table, join table, pk values
-		countStatement = "select count(*) " + countExtra + " from " + placeholder + " %s where " + pk + " in (%s)"
+		countStatement = "select count(*) " + countExtra + " from " + placeholder + " %s where " + inIds
+	} else if cursorMode {
+		// %s means: fields, table, join table, wheres, group by, order by
+		queryStatement = "select %s from " + placeholder + "%s %s %s order by %s " + cursorLimit(connection.Name())
 	} else {
 		if connection.Name() == db.DriverMssql {
-			// %s means: order by field, order by type, fields, table, join table, wheres, group by
-			queryStatement = "SELECT * FROM (SELECT ROW_NUMBER() OVER (ORDER BY %s." + placeholder + " %s) as ROWNUMBER_, %s from " +
+			// %s means: order by, fields, table, join table, wheres, group by
+			queryStatement = "SELECT * FROM (SELECT ROW_NUMBER() OVER (ORDER BY %s) as ROWNUMBER_, %s from " +
 				placeholder + "%s %s %s ) as TMP_ WHERE TMP_.ROWNUMBER_ > ? AND TMP_.ROWNUMBER_ <= ?"
 			// %s means: table, join table, wheres
 			countStatement = "select count(*) as [size] from (select count(*) as [size] from " + placeholder + " %s %s %s) src"
 		} else {
-			// %s means: fields, table, join table, wheres, group by, order by field, order by type
-			queryStatement = "select %s from " + placeholder + "%s %s %s order by " + placeholder + "." + placeholder + " %s LIMIT ? OFFSET ?"
+			// %s means: fields, table, join table, wheres, group by, order by
+			queryStatement = "select %s from " + placeholder + "%s %s %s order by %s LIMIT ? OFFSET ?"
 			// %s means: table, join table, wheres
 			countStatement = "select count(*) from (select " + pk + " from " + placeholder + " %s %s %s) src"
 		}
@@ -503,15 +540,29 @@ func (tb *DefaultTable) getDataFromDatabase(params parameter.Parameters) (PanelI
 		}
 	}
 
+	orderBy := tb.orderBy(params, table, columns)
+
 	if !modules.InArray(columns, params.SortField) {
 		params.SortField = tb.PrimaryKey.Name
 	}
 
+	var keys []cursorKey
+	if cursorMode {
+		keys = tb.cursorKeys(params, table, columns)
+		for _, key := range keys[:len(keys)-1] {
+			allFields += "," + key.column + " as " + modules.Delimiter(delimiter, delimiter2, key.alias)
+			groupFields += "," + key.column
+		}
+	}
+
 	var (
 		wheres    = ""
 		whereArgs = make([]interface{}, 0)
 		args      = make([]interface{}, 0)
 		existKeys = make([]string, 0)
+		// filtered is true if the rows are filtered, the estimated count of
+		// the table is not used then.
+		filtered = false
 	)
 
 	if len(ids) > 0 {
@@ -530,12 +581,26 @@ func (tb *DefaultTable) getDataFromDatabase(params parameter.Parameters) (PanelI
 		// pre query
 		wheres, whereArgs = tb.Info.Wheres.Statement(wheres, connection.GetDelimiter(), connection.GetDelimiter2(), whereArgs, existKeys, columns)
 		wheres, whereArgs = tb.Info.WhereRaws.Statement(wheres, whereArgs)
+		wheres = andWhere(wheres, softDelete)
+
+		var search string
+		search, whereArgs = tb.searchWhere(params.SearchKeyword(), table, columns, whereArgs)
+		wheres = andWhere(wheres, search)
+		filtered = wheres != ""
+
+		if cursorMode {
+			var keyset string
+			keyset, whereArgs = cursorWhere(params, keys, whereArgs)
+			wheres = andWhere(wheres, keyset)
+		}
 
 		if wheres != "" {
 			wheres = " where " + wheres
 		}
 
-		if connection.Name() == db.DriverMssql {
+		if cursorMode {
+			args = append(whereArgs, params.PageSizeInt+1)
+		} else if connection.Name() == db.DriverMssql {
 			args = append(whereArgs, (params.PageInt-1)*params.PageSizeInt, params.PageInt*params.PageSizeInt)
 		} else {
 			args = append(whereArgs, params.PageSizeInt, (params.PageInt-1)*params.PageSizeInt)
@@ -552,22 +617,28 @@ func (tb *DefaultTable) getDataFromDatabase(params parameter.Parameters) (PanelI
 	}
 
 	queryCmd := ""
-	if connection.Name() == db.DriverMssql && len(ids) == 0 {
-		queryCmd = fmt.Sprintf(queryStatement, tb.Info.Table, params.SortField, params.SortType,
-			allFields, tb.Info.Table, joins, wheres, groupBy)
-	} else {
+	if cursorMode {
 		queryCmd = fmt.Sprintf(queryStatement, allFields, tb.Info.Table, joins, wheres, groupBy,
-			tb.Info.Table, params.SortField, params.SortType)
+			cursorOrder(params, keys, connection.Name()))
+	} else if connection.Name() == db.DriverMssql && len(ids) == 0 {
+		queryCmd = fmt.Sprintf(queryStatement, orderBy, allFields, tb.Info.Table, joins, wheres, groupBy)
+	} else {
+		queryCmd = fmt.Sprintf(queryStatement, allFields, tb.Info.Table, joins, wheres, groupBy, orderBy)
 	}
 
 	logger.LogSQL(queryCmd, args)
 
-	res, err := connection.QueryWithConnection(tb.connection, queryCmd, args...)
+	res, err := connection.QueryWithConnectionContext(params.Context(), tb.connection, queryCmd, args...)
 
 	if err != nil {
 		return PanelInfo{}, err
 	}
 
+	var previousCursor, nextCursor string
+	if cursorMode {
+		res, previousCursor, nextCursor = cursorPage(res, params, keys)
+	}
+
 	infoList := make([]map[string]types.InfoItem, 0)
 
 	for i := 0; i < len(res); i++ {
@@ -577,10 +648,14 @@ func (tb *DefaultTable) getDataFromDatabase(params parameter.Parameters) (PanelI
 	// TODO: use the dialect
 	var size int
 
-	if len(ids) == 0 {
+	if cursorMode {
+		if tb.Info.ApproximateCount && !filtered {
+			size = tb.approximateCount(params)
+		}
+	} else if len(ids) == 0 {
 		countCmd := fmt.Sprintf(countStatement, tb.Info.Table, joins, wheres, groupBy)
 
-		total, err := connection.QueryWithConnection(tb.connection, countCmd, whereArgs...)
+		total, err := connection.QueryWithConnectionContext(params.Context(), tb.connection, countCmd, whereArgs...)
 
 		if err != nil {
 			return PanelInfo{}, err
@@ -605,18 +680,61 @@ func (tb *DefaultTable) getDataFromDatabase(params parameter.Parameters) (PanelI
 
 	endTime := time.Now()
 
+	queryTime := template.HTML(fmt.Sprintf("<b>" + language.Get("query time") + ": </b>" +
+		fmt.Sprintf("%.3fms", endTime.Sub(beginTime).Seconds()*1000)))
+
+	var paginator types.PaginatorAttribute
+	switch {
+	case params.IsKeyset():
+		// the rows of the keyset are gone through rather than shown.
+	case cursorMode:
+		paginator = tb.GetCursorPaginator(size, len(infoList), params, previousCursor, nextCursor, queryTime)
+	default:
+		paginator = tb.GetPaginator(size, params, queryTime)
+	}
+
 	return PanelInfo{
-		Thead:    thead,
-		InfoList: infoList,
-		Paginator: tb.GetPaginator(size, params,
-			template.HTML(fmt.Sprintf("<b>"+language.Get("query time")+": </b>"+
-				fmt.Sprintf("%.3fms", endTime.Sub(beginTime).Seconds()*1000)))),
+		Thead:          thead,
+		InfoList:       infoList,
+		Total:          size,
+		PreviousCursor: previousCursor,
+		NextCursor:     nextCursor,
+		CursorMode:     cursorMode,
+		Paginator:      paginator,
 		Title:          tb.Info.Title,
 		FilterFormData: filterForm,
 		Description:    tb.Info.Description,
 	}, nil
 }
 
+// orderBy return the order by clause of the sort fields which are the columns
+// of the table in order, or of the primary key if there is none of them.
+func (tb *DefaultTable) orderBy(params parameter.Parameters, table string, columns Columns) string {
+	var (
+		connection = tb.db()
+		sorts      = params.GetSorts()
+		orders     = make([]string, 0, len(sorts))
+	)
+	for _, sort := range sorts {
+		if modules.InArray(columns, sort.Field) {
+			orders = append(orders, table+"."+modules.Delimiter(connection.GetDelimiter(), connection.GetDelimiter2(), sort.Field)+
+				" "+sortType(sort.Type))
+		}
+	}
+	if len(orders) == 0 {
+		orders = append(orders, table+"."+modules.Delimiter(connection.GetDelimiter(), connection.GetDelimiter2(), tb.PrimaryKey.Name)+
+			" "+sortType(sorts[0].Type))
+	}
+	return strings.Join(orders, ", ")
+}
+
+func sortType(typ string) string {
+	if typ == "asc" || typ == "desc" {
+		return typ
+	}
+	return ""
+}
+
 func getDataRes(list []map[string]interface{}, _ int) map[string]interface{} {
 	if len(list) > 0 {
 		return list[0]
@@ -656,7 +774,7 @@ func (tb *DefaultTable) GetDataWithId(param parameter.Parameters) (FormInfo, err
 			delimiter2     = connection.GetDelimiter2()
 			tableName      = modules.Delimiter(delimiter, delimiter2, tb.GetForm().Table)
 			pk             = tableName + "." + modules.Delimiter(delimiter, delimiter2, tb.PrimaryKey.Name)
-			queryStatement = "select %s from %s %s where " + pk + " = ? %s "
+			queryStatement = "select %s from %s %s where " + pk + " = ? %s %s "
 		)
 
 		for i := 0; i < len(tb.Form.FieldList); i++ {
@@ -684,6 +802,10 @@ func (tb *DefaultTable) GetDataWithId(param parameter.Parameters) (FormInfo, err
 			}
 		}
 
+		if lock := tb.Form.OptimisticLock; lock.Enabled() && tb.Form.FieldList.FindByFieldName(lock.Field) == nil {
+			fields += tableName + "." + modules.FilterField(lock.Field, delimiter, delimiter2) + ","
+		}
+
 		fields += pk
 		groupFields := fields
 
@@ -709,11 +831,16 @@ func (tb *DefaultTable) GetDataWithId(param parameter.Parameters) (FormInfo, err
 			}
 		}
 
-		queryCmd := fmt.Sprintf(queryStatement, fields, tableName, joins, groupBy)
+		softDelete := ""
+		if cond := tb.softDeleteWhere(tb.GetForm().Table, false); cond != "" {
+			softDelete = "and " + cond
+		}
+
+		queryCmd := fmt.Sprintf(queryStatement, fields, tableName, joins, softDelete, groupBy)
 
 		logger.LogSQL(queryCmd, args)
 
-		result, err := connection.QueryWithConnection(tb.connection, queryCmd, args...)
+		result, err := connection.QueryWithConnectionContext(param.Context(), tb.connection, queryCmd, args...)
 
 		if err != nil {
 			return FormInfo{Title: tb.Form.Title, Description: tb.Form.Description}, err
@@ -739,6 +866,7 @@ func (tb *DefaultTable) GetDataWithId(param parameter.Parameters) (FormInfo, err
 			GroupFieldHeaders: groupHeaders,
 			Title:             tb.Form.Title,
 			Description:       tb.Form.Description,
+			Version:           tb.optimisticLock().Value(res[tb.Form.OptimisticLock.Field]),
 		}, nil
 	}
 
@@ -750,6 +878,7 @@ func (tb *DefaultTable) GetDataWithId(param parameter.Parameters) (FormInfo, err
 		GroupFieldHeaders: groupHeaders,
 		Title:             tb.Form.Title,
 		Description:       tb.Form.Description,
+		Version:           tb.optimisticLock().Value(res[tb.Form.OptimisticLock.Field]),
 	}, nil
 }
 
@@ -782,6 +911,11 @@ func (tb *DefaultTable) UpdateData(dataList form.Values) error {
 		}()
 	}
 
+	if err := tb.Form.Validate(dataList, tb.PrimaryKey.Name, tb.sql); err != nil {
+		errMsg = "post error: " + err.Error()
+		return err
+	}
+
 	if tb.Form.Validator != nil {
 		if err := tb.Form.Validator(dataList); err != nil {
 			errMsg = "post error: " + err.Error()
@@ -793,39 +927,178 @@ func (tb *DefaultTable) UpdateData(dataList form.Values) error {
 		dataList = tb.Form.PreProcessFn(dataList)
 	}
 
+	var (
+		pk      = dataList.Get(tb.PrimaryKey.Name)
+		lock    = tb.optimisticLock()
+		version = dataList.Get(form.VersionKey)
+	)
+
+	if lock.Enabled() && version == "" {
+		errMsg = "post error: the version of the record is required"
+		return errors.New(language.Get("the version of the record is required"))
+	}
+
 	if tb.Form.UpdateFn != nil {
+		// the version is taken before the custom update, which can not be
+		// in the transaction of it.
+		if lock.Enabled() {
+			_, err = tb.sql().WithTransaction(func(tx *sql.Tx) (error, map[string]interface{}) {
+				return tb.lockUpdate(tx, pk, version, dialect.H{}), nil
+			})
+			if err == errUpdateConflict {
+				err = tb.conflict(pk, dataList)
+			}
+			if err != nil {
+				errMsg = "post error: " + err.Error()
+				return err
+			}
+		}
 		dataList.Delete(form.PostTypeKey)
 		err = tb.Form.UpdateFn(tb.PreProcessValue(dataList, types.PostTypeUpdate))
 		if err != nil {
 			errMsg = "post error: " + err.Error()
+			return err
 		}
-		return err
+		tb.notifyCustom(tb.Form.Table, pk, models.AuditActionUpdate, dataList, tb.Form.FieldList)
+		return nil
 	}
 
 	if len(dataList) == 0 {
 		return nil
 	}
 
-	_, err = tb.sql().Table(tb.Form.Table).
-		Where(tb.PrimaryKey.Name, "=", dataList.Get(tb.PrimaryKey.Name)).
-		Update(tb.getInjectValueFromFormValue(dataList, types.PostTypeUpdate))
+	var (
+		values  = tb.getInjectValueFromFormValue(dataList, types.PostTypeUpdate)
+		entries = make([]auditEntry, 0, 1)
+	)
 
-	// NOTE: some errors should be ignored.
-	if db.CheckError(err, db.UPDATE) {
+	_, err = tb.sql().WithTransaction(func(tx *sql.Tx) (error, map[string]interface{}) {
+		old, err := tb.auditRows(tx, tb.Form.Table, []string{pk})
 		if err != nil {
-			errMsg = "post error: " + err.Error()
+			return err, nil
 		}
+
+		// the rows in the trash are not updated.
+		if lock.Enabled() {
+			err = tb.lockUpdate(tx, pk, version, values)
+		} else {
+			_, err = tb.sql().WithTx(tx).Table(tb.Form.Table).Where(tb.PrimaryKey.Name, "=", pk).
+				WhereRaw(tb.softDeleteWhere(tb.Form.Table, false)).Update(values)
+			if err == db.ErrNoAffectRow && tb.SoftDelete {
+				// the row of the same values is not affected either.
+				if _, err := tb.sql().WithTx(tx).Table(tb.Form.Table).Where(tb.PrimaryKey.Name, "=", pk).
+					WhereRaw(tb.softDeleteWhere(tb.Form.Table, false)).First(); err != nil {
+					return errors.New(errs.WrongID), nil
+				}
+			}
+		}
+
+		// NOTE: some errors should be ignored.
+		if err == errUpdateConflict || db.CheckError(err, db.UPDATE) {
+			return err, nil
+		}
+
+		if len(old) > 0 {
+			entries = append(entries, auditEntry{recordId: pk, action: models.AuditActionUpdate,
+				changes: auditDiff(old[0], values, tb.Form.FieldList, false)})
+		}
+
+		return tb.audit(tx, tb.Form.Table, entries...), nil
+	})
+
+	if err == errUpdateConflict {
+		err = tb.conflict(pk, dataList)
+	}
+
+	if err != nil {
+		errMsg = "post error: " + err.Error()
+	}
+
+	return err
+}
+
+// optimisticLock return the optimistic lock of the form with the driver of
+// the connection.
+func (tb *DefaultTable) optimisticLock() types.OptimisticLock {
+	return tb.Form.OptimisticLock.WithDriver(tb.connectionDriver)
+}
+
+// errUpdateConflict makes the update transaction roll back when the record
+// has been changed by others.
+var errUpdateConflict = errors.New("update conflict")
+
+// lockUpdate update the values of the record of the version, along with the
+// column of the optimistic lock, in the transaction. When no row is affected,
+// the record is read again, and errUpdateConflict is returned if it has
+// another version. The record in the trash is not updated.
+func (tb *DefaultTable) lockUpdate(tx *sql.Tx, pk, version string, values dialect.H) error {
+
+	var (
+		lock      = tb.optimisticLock()
+		untrashed = tb.softDeleteWhere(tb.Form.Table, false)
+		stmt      = tb.sql().WithTx(tx).Table(tb.Form.Table).
+				Where(tb.PrimaryKey.Name, "=", pk).Where(lock.Field, "=", version).WhereRaw(untrashed)
+	)
+
+	if lock.IsVersion() {
+		delete(values, lock.Field)
+		field := modules.Delimiter(tb.db().GetDelimiter(), tb.db().GetDelimiter2(), lock.Field)
+		stmt = stmt.UpdateRaw(field + " = " + field + " + 1")
+	} else {
+		values[lock.Field] = lock.Next(version, time.Now())
+	}
+
+	_, err := stmt.Update(values)
+	if err != db.ErrNoAffectRow {
 		return err
 	}
 
+	row, err := tb.sql().WithTx(tx).Table(tb.Form.Table).Select(lock.Field).
+		Where(tb.PrimaryKey.Name, "=", pk).WhereRaw(untrashed).First()
+	if err != nil {
+		return errors.New(errs.WrongID)
+	}
+	if lock.Value(row[lock.Field]) != version {
+		return errUpdateConflict
+	}
 	return nil
 }
 
+// conflict return the conflict of the update of the record, with the latest
+// values of the posted fields which have been changed.
+func (tb *DefaultTable) conflict(pk string, dataList form.Values) error {
+
+	lock := tb.optimisticLock()
+
+	row, err := tb.sql().Table(tb.Form.Table).Where(tb.PrimaryKey.Name, "=", pk).First()
+	if err != nil {
+		return errors.New(errs.WrongID)
+	}
+
+	conflict := &types.ConflictError{
+		Version: lock.Value(row[lock.Field]),
+		Latest:  make(map[string]string),
+		Fields:  make(types.FieldErrors),
+	}
+
+	for _, field := range tb.Form.FieldList {
+		value, ok := row[field.Field]
+		if !ok || field.Field == lock.Field || !dataList.Has(field.Field) ||
+			field.FormType.IsArray() || field.FormType.IsFile() || field.FormType == form2.Password {
+			continue
+		}
+		if latest := lock.Value(value); latest != dataList.Get(field.Field) {
+			conflict.Latest[field.Field] = latest
+			conflict.Fields[field.Field] = language.Get("changed by others, the latest value is") + ": " + latest
+		}
+	}
+
+	return conflict
+}
+
 // InsertData insert data.
 func (tb *DefaultTable) InsertData(dataList form.Values) error {
 
-	dataList.Add(form.PostTypeKey, "1")
-
 	var (
 		id     = int64(0)
 		err    error
@@ -835,29 +1108,71 @@ func (tb *DefaultTable) InsertData(dataList form.Values) error {
 
 	if f.PostHook != nil {
 		defer func() {
-			dataList.Add(form.PostTypeKey, "1")
-			dataList.Add(tb.GetPrimaryKey().Name, strconv.Itoa(int(id)))
-			dataList.Add(form.PostResultKey, errMsg)
+			if err != nil {
+				errMsg = "post error: " + err.Error()
+			}
+			tb.runInsertPostHook(f, dataList, id, errMsg)
+		}()
+	}
 
-			go func() {
-				defer func() {
-					if err := recover(); err != nil {
-						logger.Error(err)
-					}
-				}()
+	if f.InsertFn != nil {
+		dataList, id, err = tb.insertData(dataList, nil)
+		if err == nil {
+			tb.notifyCustom(f.Table, dataList.Get(tb.PrimaryKey.Name), models.AuditActionCreate, dataList, f.FieldList)
+		}
+		return err
+	}
 
-				err := f.PostHook(dataList)
-				if err != nil {
-					logger.Error(err)
-				}
-			}()
-		}()
+	_, err = tb.sql().WithTransaction(func(tx *sql.Tx) (error, map[string]interface{}) {
+		var err error
+		dataList, id, err = tb.insertData(dataList, tx)
+		if err != nil {
+			return err, nil
+		}
+		return tb.audit(tx, f.Table, tb.auditInsert(f, dataList, id)...), nil
+	})
+
+	return err
+}
+
+// auditInsert return the entries of the audit log of the new record, the
+// values are read from the form values again as the pre process function
+// has been applied to them.
+func (tb *DefaultTable) auditInsert(f *types.FormPanel, dataList form.Values, id int64) []auditEntry {
+	if f.InsertFn != nil || len(dataList) == 0 {
+		return nil
+	}
+	recordId := dataList.Get(tb.PrimaryKey.Name)
+	if id != 0 {
+		recordId = strconv.FormatInt(id, 10)
+	}
+	return []auditEntry{{recordId: recordId, action: models.AuditActionCreate,
+		changes: auditDiff(nil, tb.getInjectValueFromFormValue(dataList, types.PostTypeCreate), f.FieldList, false)}}
+}
+
+// insertData runs the validator and the pre process function of the new form
+// and inserts the values, within the transaction if tx is not nil. It returns
+// the processed values and the id of the new record.
+func (tb *DefaultTable) insertData(dataList form.Values, tx *sql.Tx) (form.Values, int64, error) {
+
+	dataList.Add(form.PostTypeKey, "1")
+
+	f := tb.GetActualNewForm()
+
+	// the rules read the rows within tx, so that the ones inserted before are
+	// seen and the connection held by tx is not waited for.
+	stmt := tb.sql
+	if tx != nil {
+		stmt = func() *db.SQL { return tb.sql().WithTx(tx) }
+	}
+
+	if err := f.Validate(dataList, tb.PrimaryKey.Name, stmt); err != nil {
+		return dataList, 0, err
 	}
 
 	if f.Validator != nil {
 		if err := f.Validator(dataList); err != nil {
-			errMsg = "post error: " + err.Error()
-			return err
+			return dataList, 0, err
 		}
 	}
 
@@ -867,26 +1182,118 @@ func (tb *DefaultTable) InsertData(dataList form.Values) error {
 
 	if f.InsertFn != nil {
 		dataList.Delete(form.PostTypeKey)
-		err = f.InsertFn(tb.PreProcessValue(dataList, types.PostTypeCreate))
-		if err != nil {
-			errMsg = "post error: " + err.Error()
-		}
-		return err
+		return dataList, 0, f.InsertFn(tb.PreProcessValue(dataList, types.PostTypeCreate))
 	}
 
 	if len(dataList) == 0 {
-		return nil
+		return dataList, 0, nil
 	}
 
-	id, err = tb.sql().Table(f.Table).Insert(tb.getInjectValueFromFormValue(dataList, types.PostTypeCreate))
+	id, err := tb.sql().WithTx(tx).Table(f.Table).Insert(tb.getInjectValueFromFormValue(dataList, types.PostTypeCreate))
 
 	// NOTE: some errors should be ignored.
 	if db.CheckError(err, db.INSERT) {
-		errMsg = "post error: " + err.Error()
-		return err
+		return dataList, id, err
 	}
 
-	return nil
+	return dataList, id, nil
+}
+
+func (tb *DefaultTable) runInsertPostHook(f *types.FormPanel, dataList form.Values, id int64, errMsg string) {
+	dataList.Add(form.PostTypeKey, "1")
+	dataList.Add(tb.GetPrimaryKey().Name, strconv.Itoa(int(id)))
+	dataList.Add(form.PostResultKey, errMsg)
+
+	go func() {
+		defer func() {
+			if err := recover(); err != nil {
+				logger.Error(err)
+			}
+		}()
+
+		err := f.PostHook(dataList)
+		if err != nil {
+			logger.Error(err)
+		}
+	}()
+}
+
+// errImportRollback makes the import transaction roll back.
+var errImportRollback = errors.New("import rollback")
+
+// ImportData inserts the rows within one transaction. Every row goes through
+// the validator and the pre process function of the new form. A failed row
+// is rolled back to a savepoint, so that the other rows still get reported,
+// and the whole import is rolled back if any row fails or dryRun is set.
+// The post hook is called for every row once the transaction is committed.
+func (tb *DefaultTable) ImportData(rows []form.Values, dryRun bool) (ImportResult, error) {
+
+	var (
+		f   = tb.GetActualNewForm()
+		res = ImportResult{Total: len(rows), DryRun: dryRun, Errors: make([]ImportError, 0)}
+	)
+
+	if f.InsertFn != nil || !tb.getDataFromDB() {
+		return res, errors.New("import is not supported by the table")
+	}
+
+	savepoint, rollbackTo, release := "SAVEPOINT goadmin_import", "ROLLBACK TO SAVEPOINT goadmin_import", "RELEASE SAVEPOINT goadmin_import"
+	if tb.connectionDriver == db.DriverMssql {
+		savepoint, rollbackTo, release = "SAVE TRANSACTION goadmin_import", "ROLLBACK TRANSACTION goadmin_import", ""
+	}
+
+	var (
+		inserted = make([]form.Values, 0, len(rows))
+		ids      = make([]int64, 0, len(rows))
+		entries  = make([]auditEntry, 0, len(rows))
+	)
+
+	_, err := tb.sql().WithTransaction(func(tx *sql.Tx) (error, map[string]interface{}) {
+		for i, row := range rows {
+			if _, err := tx.Exec(savepoint); err != nil {
+				return err, nil
+			}
+			values, id, err := tb.insertData(row, tx)
+			if err != nil {
+				if _, err := tx.Exec(rollbackTo); err != nil {
+					return err, nil
+				}
+				res.Errors = append(res.Errors, ImportError{Row: i + 1, Msg: err.Error()})
+				continue
+			}
+			if release != "" {
+				if _, err := tx.Exec(release); err != nil {
+					return err, nil
+				}
+			}
+			inserted = append(inserted, values)
+			ids = append(ids, id)
+		}
+		if dryRun || len(res.Errors) > 0 {
+			return errImportRollback, nil
+		}
+		for i := range inserted {
+			entries = append(entries, tb.auditInsert(f, inserted[i], ids[i])...)
+		}
+		return tb.audit(tx, f.Table, entries...), nil
+	})
+
+	if err != nil && err != errImportRollback {
+		return res, err
+	}
+
+	// Inserted counts the rows that would be inserted in a dry run.
+	if err == nil || dryRun {
+		res.Inserted = len(inserted)
+	}
+
+	if err == nil && f.PostHook != nil {
+		for i := range inserted {
+			tb.runInsertPostHook(f, inserted[i], ids[i], "")
+		}
+	}
+
+	return res, nil
 }
 
 func (tb *DefaultTable) getInjectValueFromFormValue(dataList form.Values, typ types.PostType) dialect.H {
@@ -1038,7 +1445,11 @@ func (tb *DefaultTable) DeleteData(id string) error {
 	}
 
 	if tb.Info.DeleteFn != nil {
-		err = tb.Info.DeleteFn(idArr)
+		if err = tb.Info.DeleteFn(idArr); err == nil {
+			for _, id := range idArr {
+				tb.notifyCustom(tb.Info.Table, id, models.AuditActionDelete, nil, nil)
+			}
+		}
 		return err
 	}
 
@@ -1047,7 +1458,27 @@ func (tb *DefaultTable) DeleteData(id string) error {
 		return err
 	}
 
-	err = tb.delete(tb.Info.Table, tb.PrimaryKey.Name, idArr)
+	if tb.SoftDelete {
+		err = tb.softDelete(tb.Info.Table, idArr)
+		return err
+	}
+
+	_, err = tb.sql().WithTransaction(func(tx *sql.Tx) (error, map[string]interface{}) {
+		old, err := tb.auditRows(tx, tb.Info.Table, idArr)
+		if err != nil {
+			return err, nil
+		}
+		if err := tb.delete(tx, tb.Info.Table, tb.PrimaryKey.Name, idArr); err != nil {
+			return err, nil
+		}
+		entries := make([]auditEntry, 0, len(old))
+		for _, row := range old {
+			entries = append(entries, auditEntry{recordId: auditValue(row[tb.PrimaryKey.Name]),
+				action: models.AuditActionDelete, changes: auditDiff(row, dialect.H{}, tb.Form.FieldList, true)})
+		}
+		return tb.audit(tx, tb.Info.Table, entries...), nil
+	})
+
 	return err
 }
 
@@ -1068,14 +1499,14 @@ func (tb *DefaultTable) GetNewFormInfo() FormInfo {
 // helper function for database operation
 // ***************************************
 
-func (tb *DefaultTable) delete(table, key string, values []string) error {
+func (tb *DefaultTable) delete(tx *sql.Tx, table, key string, values []string) error {
 
 	var vals = make([]interface{}, len(values))
 	for i, v := range values {
 		vals[i] = v
 	}
 
-	return tb.sql().Table(table).
+	return tb.sql().WithTx(tx).Table(table).
 		WhereIn(key, vals).
 		Delete()
 }
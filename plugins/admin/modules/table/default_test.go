@@ -1 +1,107 @@
 package table
+
+import (
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoAdminGroup/go-admin/data"
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	_ "github.com/GoAdminGroup/go-admin/modules/db/drivers/sqlite"
+	"github.com/GoAdminGroup/go-admin/modules/migration"
+	"github.com/GoAdminGroup/go-admin/modules/service"
+	form2 "github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/parameter"
+	"github.com/GoAdminGroup/go-admin/template/types/form"
+	"github.com/stretchr/testify/assert"
+)
+
+// testMigrate run the sqlite migrations of the versions, the audit log of
+// 2026_10_18_120000 is needed by the changes of the data.
+func testMigrate(t *testing.T, conn db.Connection, versions ...string) {
+	for _, version := range append([]string{"2026_10_18_120000"}, versions...) {
+		content, err := fs.ReadFile(data.Migrations, "migrations/admin_"+version+"_sqlite.sql")
+		assert.Nil(t, err)
+		for _, statement := range migration.Statements(db.DriverSqlite, string(content)) {
+			_, err := conn.Exec(statement)
+			assert.Nil(t, err)
+		}
+	}
+}
+
+func TestOrderBy(t *testing.T) {
+	tb := NewDefaultTable(DefaultConfigWithDriver(db.DriverMysql)).(*DefaultTable)
+	tb.dbObj = db.GetConnectionByDriver(db.DriverMysql)
+	columns := Columns{"id", "status", "created_at"}
+
+	param := parameter.GetParamFromURL("/admin/info/user?__sort=status&__sort_type=asc&__sort=wrong&__sort=created_at",
+		1, "desc", "id")
+	assert.Equal(t, tb.orderBy(param, "`users`", columns), "`users`.`status` asc, `users`.`created_at` desc")
+
+	param = parameter.GetParamFromURL("/admin/info/user?__sort=wrong&__sort_type=asc", 1, "desc", "id")
+	assert.Equal(t, tb.orderBy(param, "`users`", columns), "`users`.`id` asc")
+}
+
+func TestImportData(t *testing.T) {
+	conn := db.GetConnectionByDriver(db.DriverSqlite).InitDB(map[string]config.Database{
+		"default": {Driver: db.DriverSqlite, File: filepath.Join(t.TempDir(), "admin.db")},
+	})
+	testMigrate(t, conn)
+	_, err := conn.Exec("CREATE TABLE posts (`id` integer PRIMARY KEY autoincrement, `title` varchar(50) NOT NULL UNIQUE)")
+	assert.Nil(t, err)
+
+	services = service.List{config.GetDatabases().GetDefault().Driver: conn}
+
+	tb := NewDefaultTable(DefaultConfigWithDriver(db.DriverSqlite)).(*DefaultTable)
+	tb.dbObj = conn
+	tb.GetForm().SetTable("posts").AddField("Title", "title", db.Varchar, form.Text)
+
+	count := func() int64 {
+		n, err := db.WithDriver(conn).Table("posts").Count()
+		assert.Nil(t, err)
+		return n
+	}
+	rows := func(titles ...string) []form2.Values {
+		res := make([]form2.Values, 0, len(titles))
+		for _, title := range titles {
+			res = append(res, form2.Values{"title": {title}})
+		}
+		return res
+	}
+
+	// the failed row is rolled back to its savepoint, and the rows after it
+	// are still inserted in the dry run.
+	res, err := tb.ImportData(rows("a", "a", "b"), true)
+	assert.Nil(t, err)
+	assert.Equal(t, ImportResult{Total: 3, Inserted: 2, DryRun: true, Errors: res.Errors}, res)
+	assert.Equal(t, 1, len(res.Errors))
+	assert.Equal(t, 2, res.Errors[0].Row)
+	assert.Equal(t, int64(0), count())
+
+	// nothing is inserted if any row fails.
+	res, err = tb.ImportData(rows("a", "b", "a"), false)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, res.Inserted)
+	assert.Equal(t, 1, len(res.Errors))
+	assert.Equal(t, 3, res.Errors[0].Row)
+	assert.Equal(t, int64(0), count())
+
+	res, err = tb.ImportData(rows("a", "b"), false)
+	assert.Nil(t, err)
+	assert.Equal(t, ImportResult{Total: 2, Inserted: 2, Errors: []ImportError{}}, res)
+	assert.Equal(t, int64(2), count())
+	// the rules of the fields see the rows inserted before in the import.
+	_, err = conn.Exec("CREATE TABLE import_tags (`id` integer PRIMARY KEY autoincrement, `name` varchar(50))")
+	assert.Nil(t, err)
+
+	tags := NewDefaultTable(DefaultConfigWithDriver(db.DriverSqlite)).(*DefaultTable)
+	tags.dbObj = conn
+	tags.GetForm().SetTable("import_tags").AddField("Name", "name", db.Varchar, form.Text).FieldUnique()
+
+	res, err = tags.ImportData([]form2.Values{{"name": {"x"}}, {"name": {"y"}}, {"name": {"x"}}}, true)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, res.Inserted)
+	assert.Equal(t, 1, len(res.Errors))
+	assert.Equal(t, 3, res.Errors[0].Row)
+}
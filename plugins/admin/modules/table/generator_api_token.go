@@ -0,0 +1,149 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"strconv"
+	"sync"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/auth"
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
+	form2 "github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	"github.com/GoAdminGroup/go-admin/template/types"
+	"github.com/GoAdminGroup/go-admin/template/types/form"
+)
+
+// newApiTokens keeps the api token generated for a user by the id until it
+// is shown on the next list of the api tokens of the user.
+var newApiTokens sync.Map
+
+// GetApiTokensTable list the api tokens of the login user, or all of them
+// for a super admin. The tokens are generated by the server and shown once
+// when they are created, only their hashes are stored, so they are revoked
+// and created again instead of edited. Only a super admin can create the
+// service accounts.
+func (s *SystemTable) GetApiTokensTable(ctx *context.Context) (apiTokensTable Table) {
+	apiTokensTable = NewDefaultTable(Config{
+		Driver:     config.GetDatabases().GetDefault().Driver,
+		CanAdd:     true,
+		Editable:   false,
+		Deletable:  true,
+		Exportable: false,
+		Connection: "default",
+		PrimaryKey: PrimaryKey{
+			Type: db.Int,
+			Name: DefaultPrimaryKeyName,
+		},
+	})
+
+	loginUser, _ := ctx.User().(models.UserModel)
+	isSuper := loginUser.IsSuperAdmin()
+
+	info := apiTokensTable.GetInfo().AddXssJsFilter().
+		HideFilterArea().HideDetailButton().HideEditButton().HideExportButton()
+
+	info.AddField("ID", "id", db.Int).FieldSortable()
+	if isSuper {
+		names := make(map[string]string)
+		users, _ := s.table(config.GetAuthUserTable()).Select("id", "name").All()
+		for _, user := range users {
+			names[fmt.Sprintf("%v", user["id"])] = fmt.Sprintf("%v", user["name"])
+		}
+		info.AddField(lg("user"), "user_id", db.Int).
+			FieldDisplay(func(value types.FieldModel) interface{} {
+				return names[value.Value]
+			})
+	} else {
+		info.Where("user_id", "=", loginUser.Id)
+	}
+	info.AddField(lg("token name"), "name", db.Varchar)
+	info.AddField(lg("token type"), "service_account", db.Tinyint).
+		FieldDisplay(func(value types.FieldModel) interface{} {
+			if value.Value == "1" {
+				return lg("service account")
+			}
+			return lg("personal token")
+		})
+	info.AddField(lg("scopes"), "scopes", db.Varchar)
+	info.AddField(lg("last used"), "last_used_at", db.Timestamp)
+	info.AddField(lg("expires at"), "expires_at", db.Timestamp)
+	info.AddField(lg("createdAt"), "created_at", db.Timestamp)
+
+	if token, ok := newApiTokens.LoadAndDelete(loginUser.Id); ok {
+		info.SetHeaderHtml(newApiTokenHtml(token.(string)))
+	}
+
+	info.SetTable("goadmin_api_tokens").
+		SetTitle(lg("api tokens")).
+		SetDescription(lg("api tokens")).
+		SetDeleteFn(func(idArr []string) error {
+			sql := s.table("goadmin_api_tokens").WhereIn("id", interfaces(idArr))
+			if !isSuper {
+				sql = sql.Where("user_id", "=", loginUser.Id)
+			}
+			return sql.Delete()
+		})
+
+	formList := apiTokensTable.GetForm().AddXssJsFilter()
+
+	formList.AddField(lg("token name"), "name", db.Varchar, form.Text).FieldMust()
+	formList.AddField(lg("scopes"), "scopes", db.Varchar, form.Text).
+		FieldMust().
+		FieldHelpMsg(template.HTML(lg("scopes are separated by commas, such as users:read,posts:write or *:read")))
+	formList.AddField(lg("expires at"), "expires_at", db.Timestamp, form.Datetime).
+		FieldHelpMsg(template.HTML(lg("leave it empty for a token which never expires")))
+	if isSuper {
+		formList.AddField(lg("token type"), "service_account", db.Tinyint, form.Radio).
+			FieldOptions(types.FieldOptions{
+				{Text: lg("personal token"), Value: "0"},
+				{Text: lg("service account"), Value: "1"},
+			}).
+			FieldDefault("0").
+			FieldHelpMsg(template.HTML(lg("a service account has the permissions of its creator within the scopes of the token")))
+	}
+
+	formList.SetTable("goadmin_api_tokens").
+		SetTitle(lg("api tokens")).
+		SetDescription(lg("api tokens"))
+
+	formList.SetInsertFn(func(values form2.Values) error {
+		scopes, err := models.ParseApiTokenScopes(values.Get("scopes"))
+		if err != nil {
+			return err
+		}
+
+		if loginUser.Id == 0 {
+			return errors.New(lg("api tokens need a local user"))
+		}
+
+		token := auth.GenerateApiToken()
+		_, err = models.ApiToken().SetConn(s.conn).New(loginUser.Id, values.Get("name"),
+			auth.HashApiToken(token), scopes, isSuper && values.Get("service_account") == "1",
+			values.Get("expires_at"))
+		if err == nil {
+			newApiTokens.Store(loginUser.Id, token)
+		}
+		return err
+	})
+
+	return
+}
+
+// newApiTokenHtml return the alert of the list of the api tokens which shows
+// the new token.
+func newApiTokenHtml(token string) template.HTML {
+	return template.HTML(`<div class="alert alert-warning">` +
+		template.HTMLEscapeString(lg("copy the token now, it will not be shown again")) +
+		`<pre style="margin: 10px 0 0;">` + template.HTMLEscapeString(token) + `</pre></div>`)
+}
+
+// apiTokensHtml return the link of the personal page to the api tokens.
+func apiTokensHtml() template.HTML {
+	return template.HTML(`<p><a class="btn btn-sm btn-default" href=` +
+		strconv.Quote(config.Url("/info/api_tokens")) + `>` +
+		template.HTMLEscapeString(lg("api tokens")) + `</a></p>`)
+}
@@ -0,0 +1,209 @@
+package table
+
+import (
+	"database/sql"
+	"errors"
+	"html/template"
+	"strconv"
+	"strings"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/dashboard"
+	form2 "github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	"github.com/GoAdminGroup/go-admin/template/types"
+	"github.com/GoAdminGroup/go-admin/template/types/form"
+)
+
+// GetDashboardsTable list the dashboards, the widgets of which are managed
+// by the dashboard widgets table. A dashboard is shown at /dashboards/:id.
+func (s *SystemTable) GetDashboardsTable(ctx *context.Context) (dashboardsTable Table) {
+	dashboardsTable = NewDefaultTable(DefaultConfigWithDriver(config.GetDatabases().GetDefault().Driver))
+
+	info := dashboardsTable.GetInfo().AddXssJsFilter().HideFilterArea()
+
+	info.AddField("ID", "id", db.Int).FieldSortable()
+	info.AddField(lg("dashboard title"), "title", db.Varchar).FieldSearchable().
+		FieldDisplay(func(value types.FieldModel) interface{} {
+			return template.HTML(`<a href="` + config.Url("/dashboards/"+value.ID) + `">` +
+				template.HTMLEscapeString(value.Value) + `</a>`)
+		})
+	info.AddField(lg("description"), "description", db.Varchar)
+	info.AddField(lg("createdAt"), "created_at", db.Timestamp)
+	info.AddField(lg("updatedAt"), "updated_at", db.Timestamp)
+
+	info.SetTable("goadmin_dashboards").
+		SetTitle(lg("dashboards")).
+		SetDescription(lg("dashboards")).
+		SetDeleteFn(func(idArr []string) error {
+
+			var ids = interfaces(idArr)
+
+			_, txErr := s.connection().WithTransaction(func(tx *sql.Tx) (e error, i map[string]interface{}) {
+
+				deleteWidgetsErr := s.connection().WithTx(tx).
+					Table("goadmin_dashboard_widgets").
+					WhereIn("dashboard_id", ids).
+					Delete()
+
+				if db.CheckError(deleteWidgetsErr, db.DELETE) {
+					return deleteWidgetsErr, nil
+				}
+
+				deleteDashboardsErr := s.connection().WithTx(tx).
+					Table("goadmin_dashboards").
+					WhereIn("id", ids).
+					Delete()
+
+				if db.CheckError(deleteDashboardsErr, db.DELETE) {
+					return deleteDashboardsErr, nil
+				}
+
+				return nil, nil
+			})
+
+			return txErr
+		})
+
+	formList := dashboardsTable.GetForm().AddXssJsFilter()
+
+	formList.AddField("ID", "id", db.Int, form.Default).FieldDisplayButCanNotEditWhenUpdate().FieldDisableWhenCreate()
+	formList.AddField(lg("dashboard title"), "title", db.Varchar, form.Text).FieldMust()
+	formList.AddField(lg("description"), "description", db.Varchar, form.Text)
+	formList.AddField(lg("updatedAt"), "updated_at", db.Timestamp, form.Default).FieldDisableWhenCreate()
+	formList.AddField(lg("createdAt"), "created_at", db.Timestamp, form.Default).FieldDisableWhenCreate()
+
+	formList.SetTable("goadmin_dashboards").
+		SetTitle(lg("dashboards")).
+		SetDescription(lg("dashboards"))
+
+	return
+}
+
+// GetDashboardWidgetsTable list the widgets of the dashboards. The data of a
+// widget is queried by a select statement or a data function registered by
+// dashboard.Add, so only a super admin can edit the widgets.
+func (s *SystemTable) GetDashboardWidgetsTable(ctx *context.Context) (widgetsTable Table) {
+	loginUser, _ := ctx.User().(models.UserModel)
+	isSuper := loginUser.IsSuperAdmin()
+
+	widgetsTable = NewDefaultTable(Config{
+		Driver:     config.GetDatabases().GetDefault().Driver,
+		CanAdd:     isSuper,
+		Editable:   isSuper,
+		Deletable:  isSuper,
+		Exportable: true,
+		Connection: DefaultConnectionName,
+		PrimaryKey: PrimaryKey{
+			Type: db.Int,
+			Name: DefaultPrimaryKeyName,
+		},
+	})
+
+	dashboardOptions := make(types.FieldOptions, 0)
+	dashboards, _ := s.table("goadmin_dashboards").Select("id", "title").OrderBy("id", "asc").All()
+	for _, item := range dashboards {
+		dashboardOptions = append(dashboardOptions, types.FieldOption{
+			Text:  item["title"].(string),
+			Value: strconv.FormatInt(item["id"].(int64), 10),
+		})
+	}
+
+	typeOptions := make(types.FieldOptions, len(dashboard.Types))
+	for i, typ := range dashboard.Types {
+		typeOptions[i] = types.FieldOption{Text: lg("widget " + typ), Value: typ}
+	}
+
+	sourceOptions := types.FieldOptions{
+		{Text: lg("sql query"), Value: dashboard.SourceSQL},
+		{Text: lg("data function"), Value: dashboard.SourceFunction},
+	}
+
+	info := widgetsTable.GetInfo().AddXssJsFilter()
+
+	info.AddField("ID", "id", db.Int).FieldSortable()
+	info.AddField(lg("dashboard"), "dashboard_id", db.Int).
+		FieldFilterable(types.FilterType{FormType: form.SelectSingle}).
+		FieldFilterOptions(dashboardOptions).
+		FieldDisplay(func(value types.FieldModel) interface{} {
+			for _, option := range dashboardOptions {
+				if option.Value == value.Value {
+					return option.Text
+				}
+			}
+			return value.Value
+		})
+	info.AddField(lg("widget title"), "title", db.Varchar)
+	info.AddField(lg("widget type"), "type", db.Varchar).FieldDisplay(func(value types.FieldModel) interface{} {
+		return lg("widget " + value.Value)
+	})
+	info.AddField(lg("data source"), "source", db.Varchar).FieldDisplay(func(value types.FieldModel) interface{} {
+		if value.Value == dashboard.SourceFunction {
+			return lg("data function")
+		}
+		return lg("sql query")
+	})
+	info.AddField(lg("width"), "width", db.Int)
+	info.AddField(lg("order"), "order", db.Int).FieldSortable()
+	info.AddField(lg("refresh interval"), "refresh", db.Int)
+
+	info.SetTable("goadmin_dashboard_widgets").
+		SetTitle(lg("dashboard widgets")).
+		SetDescription(lg("dashboard widgets"))
+
+	formList := widgetsTable.GetForm().AddXssJsFilter()
+
+	formList.AddField("ID", "id", db.Int, form.Default).FieldDisplayButCanNotEditWhenUpdate().FieldDisableWhenCreate()
+	formList.AddField(lg("dashboard"), "dashboard_id", db.Int, form.SelectSingle).
+		FieldOptions(dashboardOptions).FieldMust()
+	formList.AddField(lg("widget title"), "title", db.Varchar, form.Text)
+	formList.AddField(lg("widget type"), "type", db.Varchar, form.SelectSingle).
+		FieldOptions(typeOptions).FieldDefault(dashboard.Line).FieldMust()
+	formList.AddField(lg("data source"), "source", db.Varchar, form.Radio).
+		FieldOptions(sourceOptions).FieldDefault(dashboard.SourceSQL)
+	formList.AddField(lg("query or function"), "query", db.Text, form.TextArea).
+		FieldPostFilterFn(func(model types.PostFieldModel) interface{} {
+			return strings.TrimSpace(model.Value.Value())
+		}).
+		FieldHelpMsg(template.HTML(lg("the first column of a chart is the labels and the others are the data sets, " +
+			"a stat shows the first column of the first row"))).
+		FieldMust()
+	formList.AddField(lg("width"), "width", db.Int, form.Number).FieldDefault("6").
+		FieldHelpMsg(template.HTML(lg("the width of the twelve columns of a row")))
+	formList.AddField(lg("order"), "order", db.Int, form.Number).FieldDefault("0")
+	formList.AddField(lg("refresh interval"), "refresh", db.Int, form.Number).FieldDefault("0").
+		FieldHelpMsg(template.HTML(lg("the seconds to reload the data of a chart, zero means never")))
+	formList.AddField(lg("updatedAt"), "updated_at", db.Timestamp, form.Default).FieldDisableWhenCreate()
+	formList.AddField(lg("createdAt"), "created_at", db.Timestamp, form.Default).FieldDisableWhenCreate()
+
+	formList.SetTable("goadmin_dashboard_widgets").
+		SetTitle(lg("dashboard widgets")).
+		SetDescription(lg("dashboard widgets")).
+		SetPostValidator(func(values form2.Values) error {
+
+			if !isSuper {
+				return errors.New(lg("only a super admin can edit the widgets"))
+			}
+
+			width, err := strconv.Atoi(values.Get("width"))
+			if err != nil || width < 1 || width > 12 {
+				return errors.New(lg("the width should be from 1 to 12"))
+			}
+
+			if refresh, err := strconv.Atoi(values.Get("refresh")); err != nil || refresh < 0 {
+				return errors.New(lg("the refresh interval should not be negative"))
+			}
+
+			if values.Get("source") == dashboard.SourceFunction {
+				if _, ok := dashboard.Get(strings.TrimSpace(values.Get("query"))); !ok {
+					return errors.New(lg("data function not found"))
+				}
+				return nil
+			}
+			return dashboard.CheckQuery(values.Get("query"))
+		})
+
+	return
+}
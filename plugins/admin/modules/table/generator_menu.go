@@ -10,6 +10,7 @@ import (
 	"github.com/GoAdminGroup/go-admin/modules/config"
 	"github.com/GoAdminGroup/go-admin/modules/db"
 	"github.com/GoAdminGroup/go-admin/modules/language"
+	"github.com/GoAdminGroup/go-admin/modules/menu"
 	"github.com/GoAdminGroup/go-admin/template/types"
 	"github.com/GoAdminGroup/go-admin/template/types/form"
 )
@@ -57,6 +58,7 @@ func (s *SystemTable) GetMenuTable(ctx *context.Context) (menuTable Table) {
 				return nil, map[string]interface{}{}
 			})
 
+			menu.Invalidate()
 			return txErr
 		})
 
@@ -0,0 +1,141 @@
+package table
+
+import (
+	"database/sql"
+	"errors"
+	"html/template"
+	"strings"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
+	form2 "github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	"github.com/GoAdminGroup/go-admin/template/types"
+	"github.com/GoAdminGroup/go-admin/template/types/form"
+)
+
+// GetPermissionTable list the permissions of the method and path rules,
+// which only a super admin can see and change.
+func (s *SystemTable) GetPermissionTable(ctx *context.Context) (permissionTable Table) {
+	isSuper := isSuperAdmin(ctx)
+	permissionTable = NewDefaultTable(superAdminOnly(DefaultConfigWithDriver(config.GetDatabases().GetDefault().Driver), isSuper))
+
+	info := permissionTable.GetInfo().AddXssJsFilter().HideFilterArea()
+
+	info.AddField("ID", "id", db.Int).FieldSortable()
+	info.AddField(lg("permission"), "name", db.Varchar).FieldFilterable()
+	info.AddField(lg("slug"), "slug", db.Varchar).FieldFilterable()
+	info.AddField(lg("method"), "http_method", db.Varchar).FieldDisplay(func(value types.FieldModel) interface{} {
+		if value.Value == "" {
+			return "All methods"
+		}
+		return value.Value
+	})
+	info.AddField(lg("path"), "http_path", db.Varchar).
+		FieldDisplay(func(model types.FieldModel) interface{} {
+			pathArr := strings.Split(model.Value, "\n")
+			res := template.HTML("")
+			for i := 0; i < len(pathArr); i++ {
+				if i == len(pathArr)-1 {
+					res += label().SetContent(template.HTML(escape(pathArr[i]))).GetContent()
+				} else {
+					res += label().SetContent(template.HTML(escape(pathArr[i]))).GetContent() + "<br><br>"
+				}
+			}
+			return res
+		})
+	info.AddField(lg("createdAt"), "created_at", db.Timestamp)
+	info.AddField(lg("updatedAt"), "updated_at", db.Timestamp)
+
+	info.SetTable("goadmin_permissions").
+		SetTitle(lg("Permission Manage")).
+		SetDescription(lg("Permission Manage")).
+		SetDeleteFn(func(idArr []string) error {
+
+			var ids = interfaces(idArr)
+
+			_, txErr := s.connection().WithTransaction(func(tx *sql.Tx) (e error, i map[string]interface{}) {
+
+				deleteRolePermissionErr := s.connection().WithTx(tx).
+					Table("goadmin_role_permissions").
+					WhereIn("permission_id", ids).
+					Delete()
+
+				if db.CheckError(deleteRolePermissionErr, db.DELETE) {
+					return deleteRolePermissionErr, nil
+				}
+
+				deletePermissionsErr := s.connection().WithTx(tx).
+					Table("goadmin_permissions").
+					WhereIn("id", ids).
+					Delete()
+
+				if db.CheckError(deletePermissionsErr, db.DELETE) {
+					return deletePermissionsErr, nil
+				}
+
+				return nil, nil
+			})
+
+			if txErr == nil {
+				models.PermissionChanged()
+			}
+
+			return txErr
+		})
+
+	formList := permissionTable.GetForm().AddXssJsFilter()
+
+	formList.AddField("ID", "id", db.Int, form.Default).FieldDisplayButCanNotEditWhenUpdate().FieldDisableWhenCreate()
+	formList.AddField(lg("permission"), "name", db.Varchar, form.Text).FieldMust()
+	formList.AddField(lg("slug"), "slug", db.Varchar, form.Text).FieldHelpMsg(template.HTML(lg("should be unique"))).FieldMust().FieldUnique()
+	formList.AddField(lg("method"), "http_method", db.Varchar, form.Select).
+		FieldOptions(types.FieldOptions{
+			{Value: "GET", Text: "GET"},
+			{Value: "PUT", Text: "PUT"},
+			{Value: "POST", Text: "POST"},
+			{Value: "DELETE", Text: "DELETE"},
+			{Value: "PATCH", Text: "PATCH"},
+			{Value: "OPTIONS", Text: "OPTIONS"},
+			{Value: "HEAD", Text: "HEAD"},
+		}).
+		FieldDisplay(func(model types.FieldModel) interface{} {
+			return strings.Split(model.Value, ",")
+		}).
+		FieldPostFilterFn(func(model types.PostFieldModel) interface{} {
+			return strings.Join(model.Value, ",")
+		}).
+		FieldHelpMsg(template.HTML(lg("all method if empty")))
+
+	formList.AddField(lg("path"), "http_path", db.Text, form.TextArea).
+		FieldPostFilterFn(func(model types.PostFieldModel) interface{} {
+			return strings.TrimSpace(model.Value.Value())
+		}).
+		FieldHelpMsg(template.HTML(lg("a path a line, without global prefix"))).FieldMust()
+	formList.AddField(lg("updatedAt"), "updated_at", db.Timestamp, form.Default).FieldDisableWhenCreate()
+	formList.AddField(lg("createdAt"), "created_at", db.Timestamp, form.Default).FieldDisableWhenCreate()
+
+	formList.SetTable("goadmin_permissions").
+		SetTitle(lg("Permission Manage")).
+		SetDescription(lg("Permission Manage")).
+		SetPostValidator(func(values form2.Values) error {
+
+			if models.Permission().SetConn(s.conn).IsSlugExist(values.Get("slug"), values.Get("id")) {
+				return errors.New(lg("slug exists"))
+			}
+			return nil
+		}).
+		SetPostHook(func(values form2.Values) error {
+			if values.PostError() == nil {
+				models.PermissionChanged()
+			}
+			return nil
+		})
+
+	if !isSuper {
+		hideData(permissionTable)
+	}
+
+	return
+}
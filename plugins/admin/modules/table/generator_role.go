@@ -0,0 +1,170 @@
+package table
+
+import (
+	"database/sql"
+	"errors"
+	"html/template"
+	"strconv"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
+	form2 "github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	"github.com/GoAdminGroup/go-admin/template/types"
+	"github.com/GoAdminGroup/go-admin/template/types/form"
+)
+
+// GetRolesTable list the roles, which only a super admin can see and change
+// as the roles grant the permissions.
+func (s *SystemTable) GetRolesTable(ctx *context.Context) (roleTable Table) {
+	isSuper := isSuperAdmin(ctx)
+	roleTable = NewDefaultTable(superAdminOnly(DefaultConfigWithDriver(config.GetDatabases().GetDefault().Driver), isSuper))
+
+	info := roleTable.GetInfo().AddXssJsFilter().HideFilterArea()
+
+	info.AddField("ID", "id", db.Int).FieldSortable()
+	info.AddField(lg("role"), "name", db.Varchar).FieldFilterable()
+	info.AddField(lg("slug"), "slug", db.Varchar).FieldFilterable()
+	info.AddField(lg("createdAt"), "created_at", db.Timestamp)
+	info.AddField(lg("updatedAt"), "updated_at", db.Timestamp)
+
+	info.SetTable("goadmin_roles").
+		SetTitle(lg("Roles Manage")).
+		SetDescription(lg("Roles Manage")).
+		SetDeleteFn(func(idArr []string) error {
+
+			_, txErr := s.connection().WithTransaction(func(tx *sql.Tx) (e error, i map[string]interface{}) {
+
+				for _, id := range idArr {
+					if err := models.RoleWithId(id).SetConn(s.conn).WithTx(tx).Delete(); err != nil {
+						return err, nil
+					}
+				}
+
+				return nil, nil
+			})
+
+			if txErr == nil {
+				models.PermissionChanged()
+				models.MenuChanged()
+			}
+
+			return txErr
+		})
+
+	formList := roleTable.GetForm().AddXssJsFilter()
+
+	// the id of a role is the role value of the portal user, so it is set
+	// when creating instead of being generated.
+	formList.AddField("ID", "id", db.Int, form.Number).FieldDisplayButCanNotEditWhenUpdate().
+		FieldHelpMsg(template.HTML(lg("the role value of the portal user"))).FieldMust()
+	formList.AddField(lg("role"), "name", db.Varchar, form.Text).FieldMust()
+	formList.AddField(lg("slug"), "slug", db.Varchar, form.Text).FieldHelpMsg(template.HTML(lg("should be unique"))).FieldMust().FieldUnique()
+	formList.AddField(lg("permission"), "permission_id", db.Varchar, form.SelectBox).
+		FieldOptionsFromTable("goadmin_permissions", "name", "id").
+		FieldDisplay(func(model types.FieldModel) interface{} {
+			var permissions = make([]string, 0)
+
+			if model.ID == "" {
+				return permissions
+			}
+			perModel, _ := s.table("goadmin_role_permissions").
+				Select("permission_id").
+				Where("role_id", "=", model.ID).
+				All()
+			for _, v := range perModel {
+				permissions = append(permissions, strconv.FormatInt(v["permission_id"].(int64), 10))
+			}
+			return permissions
+		}).FieldHelpMsg(template.HTML(lg("no corresponding options?")) +
+		link(config.Url("/info/permission/new"), "create here."))
+
+	formList.AddField(lg("updatedAt"), "updated_at", db.Timestamp, form.Default).FieldDisableWhenCreate()
+	formList.AddField(lg("createdAt"), "created_at", db.Timestamp, form.Default).FieldDisableWhenCreate()
+
+	formList.SetTable("goadmin_roles").
+		SetTitle(lg("Roles Manage")).
+		SetDescription(lg("Roles Manage"))
+
+	formList.SetUpdateFn(func(values form2.Values) error {
+
+		if models.Role().SetConn(s.conn).IsSlugExist(values.Get("slug"), values.Get("id")) {
+			return errors.New(lg("slug exists"))
+		}
+
+		role := models.RoleWithId(values.Get("id")).SetConn(s.conn)
+
+		_, txErr := s.connection().WithTransaction(func(tx *sql.Tx) (e error, i map[string]interface{}) {
+
+			_, updateRoleErr := role.WithTx(tx).Update(values.Get("name"), values.Get("slug"))
+
+			if db.CheckError(updateRoleErr, db.UPDATE) {
+				return updateRoleErr, nil
+			}
+
+			delPermissionErr := role.WithTx(tx).DeletePermissions()
+
+			if db.CheckError(delPermissionErr, db.DELETE) {
+				return delPermissionErr, nil
+			}
+
+			for i := 0; i < len(values["permission_id[]"]); i++ {
+				_, addPermissionErr := role.WithTx(tx).AddPermission(values["permission_id[]"][i])
+				if db.CheckError(addPermissionErr, db.INSERT) {
+					return addPermissionErr, nil
+				}
+			}
+
+			return nil, nil
+		})
+
+		if txErr == nil {
+			models.PermissionChanged()
+		}
+
+		return txErr
+	})
+
+	formList.SetInsertFn(func(values form2.Values) error {
+
+		id, err := strconv.ParseInt(values.Get("id"), 10, 64)
+		if err != nil {
+			return errors.New(lg("wrong id"))
+		}
+
+		if models.Role().SetConn(s.conn).IsSlugExist(values.Get("slug"), "") {
+			return errors.New(lg("slug exists"))
+		}
+
+		_, txErr := s.connection().WithTransaction(func(tx *sql.Tx) (e error, i map[string]interface{}) {
+
+			role, createRoleErr := models.Role().SetConn(s.conn).WithTx(tx).New(id, values.Get("name"), values.Get("slug"))
+
+			if db.CheckError(createRoleErr, db.INSERT) {
+				return createRoleErr, nil
+			}
+
+			for i := 0; i < len(values["permission_id[]"]); i++ {
+				_, addPermissionErr := role.WithTx(tx).AddPermission(values["permission_id[]"][i])
+				if db.CheckError(addPermissionErr, db.INSERT) {
+					return addPermissionErr, nil
+				}
+			}
+
+			return nil, nil
+		})
+
+		if txErr == nil {
+			models.PermissionChanged()
+		}
+
+		return txErr
+	})
+
+	if !isSuper {
+		hideData(roleTable)
+	}
+
+	return
+}
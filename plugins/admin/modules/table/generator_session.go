@@ -0,0 +1,168 @@
+package table
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strconv"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/auth"
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	errs "github.com/GoAdminGroup/go-admin/modules/errors"
+	"github.com/GoAdminGroup/go-admin/modules/logger"
+	form2 "github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/parameter"
+	"github.com/GoAdminGroup/go-admin/template/types/action"
+)
+
+// GetSessionsTable list the login sessions of the store of the session
+// driver, which can be revoked by a super admin only.
+func (s *SystemTable) GetSessionsTable(ctx *context.Context) (sessionsTable Table) {
+	isSuper := isSuperAdmin(ctx)
+	sessionsTable = NewDefaultTable(superAdminOnly(Config{
+		Driver:     config.GetDatabases().GetDefault().Driver,
+		CanAdd:     false,
+		Editable:   false,
+		Deletable:  true,
+		Exportable: false,
+		Connection: "default",
+		PrimaryKey: PrimaryKey{
+			Type: db.Varchar,
+			Name: "sid",
+		},
+	}, isSuper))
+
+	info := sessionsTable.GetInfo().AddXssJsFilter().
+		HideFilterArea().HideDetailButton().HideEditButton().HideNewButton().HideExportButton()
+
+	info.AddField("Sid", "sid", db.Varchar).FieldHide()
+	info.AddField(lg("user"), "name", db.Varchar)
+	info.AddField(lg("ip"), "ip", db.Varchar)
+	info.AddField(lg("user agent"), "user_agent", db.Varchar).FieldWidth(300)
+	info.AddField(lg("createdAt"), "created_at", db.Timestamp)
+	info.AddField(lg("last seen"), "last_seen", db.Timestamp)
+
+	info.AddActionButton(template.HTML(lg("revoke")), action.Ajax("session_revoke",
+		func(ctx *context.Context) (success bool, msg string, data interface{}) {
+			if !isSuperAdmin(ctx) {
+				return false, lg(errs.PermissionDenied), ""
+			}
+			if err := auth.RevokeSessions(s.conn, ctx.FormValue("id")); err != nil {
+				return false, err.Error(), ""
+			}
+			return true, "success", ""
+		}).WithAlert().SetSuccessJS(`if (data.code === 0) {
+                                    swal(data.msg, '', 'success');
+                                    $.pjax.reload('#pjax-container');
+                                } else {
+                                    swal(data.msg, '', 'error');
+                                }`))
+
+	info.SetTable("goadmin_session").
+		SetTitle(lg("sessions")).
+		SetDescription(lg("sessions")).
+		SetDeleteFn(func(idArr []string) error {
+			return auth.RevokeSessions(s.conn, idArr...)
+		}).
+		SetGetDataFn(func(param parameter.Parameters) ([]map[string]interface{}, int) {
+			sessions, err := auth.ListSessions(s.conn)
+			if err != nil {
+				logger.Error("list sessions error: ", err)
+				return []map[string]interface{}{}, 0
+			}
+
+			names := make(map[string]string)
+			users, _ := s.table(config.GetAuthUserTable()).Select("id", "name").All()
+			for _, user := range users {
+				names[fmt.Sprintf("%v", user["id"])] = fmt.Sprintf("%v", user["name"])
+			}
+
+			start, end := pageRange(param, len(sessions))
+			data := make([]map[string]interface{}, 0, end-start)
+			for _, ses := range sessions[start:end] {
+				data = append(data, map[string]interface{}{
+					"sid":        ses.Sid,
+					"name":       names[fmt.Sprintf("%d", ses.UserId)],
+					"ip":         ses.IP,
+					"user_agent": ses.UserAgent,
+					"created_at": formatSessionTime(ses.CreatedAt),
+					"last_seen":  formatSessionTime(ses.LastSeen),
+				})
+			}
+			return data, len(sessions)
+		})
+
+	sessionsTable.GetForm().SetTable("goadmin_session").
+		SetTitle(lg("sessions")).
+		SetDescription(lg("sessions"))
+
+	if !isSuper {
+		hideData(sessionsTable)
+	}
+
+	return
+}
+
+// pageRange return the range of the items of the page in a list of the
+// given size.
+func pageRange(param parameter.Parameters, size int) (start, end int) {
+	if param.PageSizeInt <= 0 {
+		return 0, size
+	}
+	start = (param.PageInt - 1) * param.PageSizeInt
+	if start < 0 {
+		start = 0
+	}
+	if start > size {
+		start = size
+	}
+	end = start + param.PageSizeInt
+	if end > size {
+		end = size
+	}
+	return
+}
+
+func formatSessionTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+// logoutAllHtml return the button of the personal page which logs out all
+// the sessions of the login user, the post carries a csrf token.
+func logoutAllHtml() template.HTML {
+	token := auth.GetTokenService(services.Get(auth.TokenServiceKey)).AddToken()
+	alert, _ := json.Marshal(action.AlertData{
+		Title:              lg("log out all your sessions?"),
+		Type:               "warning",
+		ShowCancelButton:   true,
+		ConfirmButtonColor: "#DD6B55",
+		ConfirmButtonText:  lg("yes"),
+		CloseOnConfirm:     false,
+		CancelButtonText:   lg("cancel"),
+	})
+	return template.HTML(`<p><button type="button" class="btn btn-sm btn-danger" id="logout-all">` +
+		template.HTMLEscapeString(lg("log out everywhere")) + `</button></p>
+<script>
+$('#logout-all').on('click', function () {
+	swal(` + string(alert) + `, function () {
+		$.ajax({
+			method: 'post',
+			url: ` + strconv.Quote(config.Url("/logout/all")) + `,
+			data: {` + strconv.Quote(form2.TokenKey) + `: ` + strconv.Quote(token) + `},
+			success: function (data) {
+				location.href = data.data.url;
+			},
+			error: function (data) {
+				swal(data.responseJSON ? data.responseJSON.msg : 'error', '', 'error');
+			}
+		});
+	});
+});
+</script>`)
+}
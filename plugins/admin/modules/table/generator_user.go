@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/auth"
 	"github.com/GoAdminGroup/go-admin/modules/config"
 	"github.com/GoAdminGroup/go-admin/modules/db"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
@@ -282,6 +283,9 @@ func (s *SystemTable) GetPersonalTable(ctx *context.Context) (personalTable Tabl
 			$.pjax.reload('#pjax-container');	
 		`)
 	formList.SetPrimaryKey("uuid", db.Varchar)
+	if config.GetAuth().Provider != auth.ProviderPortal {
+		formList.SetHeaderHtml(logoutAllHtml() + apiTokensHtml())
+	}
 	formList.HideBackButton()
 	formList.HideContinueEditCheckBox()
 	formList.HideContinueNewCheckBox()
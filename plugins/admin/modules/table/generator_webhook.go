@@ -0,0 +1,193 @@
+package table
+
+import (
+	"errors"
+	"html/template"
+	"strings"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	errs "github.com/GoAdminGroup/go-admin/modules/errors"
+	form2 "github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/webhook"
+	"github.com/GoAdminGroup/go-admin/template/types"
+	"github.com/GoAdminGroup/go-admin/template/types/action"
+	"github.com/GoAdminGroup/go-admin/template/types/form"
+)
+
+// GetWebhooksTable list the webhooks, which subscribe the events of the
+// records of a table prefix. The payloads are signed with the secret, and
+// the deliveries are listed by the webhook deliveries table.
+func (s *SystemTable) GetWebhooksTable(ctx *context.Context) (webhooksTable Table) {
+	isSuper := isSuperAdmin(ctx)
+	webhooksTable = NewDefaultTable(superAdminOnly(DefaultConfigWithDriver(config.GetDatabases().GetDefault().Driver), isSuper))
+
+	enabledOptions := types.FieldOptions{
+		{Text: lg("enabled"), Value: "1"},
+		{Text: lg("disabled"), Value: "0"},
+	}
+
+	info := webhooksTable.GetInfo().AddXssJsFilter().HideFilterArea()
+
+	info.AddField("ID", "id", db.Int).FieldSortable()
+	info.AddField(lg("table prefix"), "prefix", db.Varchar).FieldSearchable()
+	info.AddField(lg("event"), "event", db.Varchar)
+	info.AddField(lg("url"), "url", db.Varchar).FieldSearchable()
+	info.AddField(lg("status"), "enabled", db.Tinyint).
+		FieldDisplay(func(value types.FieldModel) interface{} {
+			if value.Value == "1" {
+				return lg("enabled")
+			}
+			return lg("disabled")
+		})
+	info.AddField(lg("createdAt"), "created_at", db.Timestamp)
+
+	info.AddActionButton(template.HTML(lg("deliveries")),
+		action.Jump(config.Url("/info/webhook_deliveries?webhook_id={%id}")))
+
+	info.SetTable(webhook.WebhookTable).
+		SetTitle(lg("webhooks")).
+		SetDescription(lg("webhooks"))
+
+	formList := webhooksTable.GetForm().AddXssJsFilter()
+
+	formList.AddField("ID", "id", db.Int, form.Default).FieldDisplayButCanNotEditWhenUpdate().FieldDisableWhenCreate()
+	formList.AddField(lg("table prefix"), "prefix", db.Varchar, form.Text).
+		FieldDefault(webhook.Any).
+		FieldMust().
+		FieldHelpMsg(template.HTML(lg("the prefix of the table such as manager, * means all the tables")))
+	formList.AddField(lg("event"), "event", db.Varchar, form.Text).
+		FieldDefault(webhook.Any).
+		FieldMust().
+		FieldHelpMsg(template.HTML(lg("created, updated, deleted, restored or the event of a custom action, " +
+			"* means all the events")))
+	formList.AddField(lg("url"), "url", db.Varchar, form.Url).FieldMust()
+	formList.AddField(lg("secret"), "secret", db.Varchar, form.Text).
+		FieldDefault(webhook.GenerateSecret()).
+		FieldHelpMsg(template.HTML(lg("the payloads are signed with the secret in the header X-GoAdmin-Signature")))
+	formList.AddField(lg("status"), "enabled", db.Tinyint, form.Radio).
+		FieldOptions(enabledOptions).
+		FieldDefault("1")
+	formList.AddField(lg("updatedAt"), "updated_at", db.Timestamp, form.Default).FieldDisableWhenCreate()
+	formList.AddField(lg("createdAt"), "created_at", db.Timestamp, form.Default).FieldDisableWhenCreate()
+
+	formList.SetTable(webhook.WebhookTable).
+		SetTitle(lg("webhooks")).
+		SetDescription(lg("webhooks")).
+		SetPostValidator(func(values form2.Values) error {
+			switch webhook.CheckURL(values.Get("url")) {
+			case webhook.ErrScheme:
+				return errors.New(lg("the url should be a http or https one"))
+			case webhook.ErrAddress:
+				return errors.New(lg("the url should not be a local or private address"))
+			}
+			if strings.TrimSpace(values.Get("prefix")) == "" || strings.TrimSpace(values.Get("event")) == "" {
+				return errors.New(lg("the table prefix and the event can not be empty"))
+			}
+			return nil
+		})
+
+	if !isSuper {
+		hideData(webhooksTable)
+	}
+
+	return
+}
+
+// GetWebhookDeliveriesTable list the deliveries of the webhooks in the
+// outbox. The failed deliveries can be retried, and the payload of a
+// delivery is shown in the detail page.
+func (s *SystemTable) GetWebhookDeliveriesTable(ctx *context.Context) (deliveriesTable Table) {
+	isSuper := isSuperAdmin(ctx)
+	deliveriesTable = NewDefaultTable(superAdminOnly(Config{
+		Driver:     config.GetDatabases().GetDefault().Driver,
+		CanAdd:     false,
+		Editable:   false,
+		Deletable:  true,
+		Exportable: true,
+		Connection: "default",
+		PrimaryKey: PrimaryKey{
+			Type: db.Int,
+			Name: DefaultPrimaryKeyName,
+		},
+	}, isSuper))
+
+	statusOptions := types.FieldOptions{
+		{Text: lg("pending"), Value: webhook.StatusPending},
+		{Text: lg("success"), Value: webhook.StatusSuccess},
+		{Text: lg("failed"), Value: webhook.StatusFailed},
+	}
+
+	info := deliveriesTable.GetInfo().AddXssJsFilter().HideEditButton().HideNewButton()
+
+	info.AddField("ID", "id", db.Int).FieldSortable()
+	info.AddField(lg("webhook"), "webhook_id", db.Int).FieldFilterable()
+	info.AddField(lg("table prefix"), "prefix", db.Varchar).FieldFilterable()
+	info.AddField(lg("event"), "event", db.Varchar).FieldFilterable()
+	info.AddField(lg("status"), "status", db.Varchar).
+		FieldFilterable(types.FilterType{FormType: form.SelectSingle}).
+		FieldFilterOptions(statusOptions).
+		FieldDisplay(func(value types.FieldModel) interface{} {
+			return lg(value.Value)
+		})
+	info.AddField(lg("attempts"), "attempts", db.Int)
+	info.AddField(lg("response code"), "response_code", db.Int)
+	info.AddField(lg("error"), "error", db.Varchar).FieldWidth(230)
+	info.AddField(lg("next attempt at"), "next_attempt_at", db.Timestamp)
+	info.AddField(lg("createdAt"), "created_at", db.Timestamp).FieldSortable()
+
+	info.AddActionButton(template.HTML(lg("retry")), action.Ajax("webhook_delivery_retry",
+		func(ctx *context.Context) (success bool, msg string, data interface{}) {
+			if !isSuperAdmin(ctx) {
+				return false, lg(errs.PermissionDenied), ""
+			}
+			if err := webhook.Retry(s.conn, ctx.FormValue("id")); err != nil {
+				return false, err.Error(), ""
+			}
+			return true, "success", ""
+		}).WithAlert().SetSuccessJS(`if (data.code === 0) {
+                                    swal(data.msg, '', 'success');
+                                    $.pjax.reload('#pjax-container');
+                                } else {
+                                    swal(data.msg, '', 'error');
+                                }`))
+
+	info.SetTable(webhook.DeliveryTable).
+		SetTitle(lg("webhook deliveries")).
+		SetDescription(lg("webhook deliveries"))
+
+	detail := deliveriesTable.GetDetail()
+	detail.AddField("ID", "id", db.Int)
+	detail.AddField(lg("webhook"), "webhook_id", db.Int)
+	detail.AddField(lg("table prefix"), "prefix", db.Varchar)
+	detail.AddField(lg("event"), "event", db.Varchar)
+	detail.AddField(lg("status"), "status", db.Varchar).
+		FieldDisplay(func(value types.FieldModel) interface{} {
+			return lg(value.Value)
+		})
+	detail.AddField(lg("attempts"), "attempts", db.Int)
+	detail.AddField(lg("response code"), "response_code", db.Int)
+	detail.AddField(lg("error"), "error", db.Varchar)
+	detail.AddField(lg("payload"), "payload", db.Text).
+		FieldDisplay(func(value types.FieldModel) interface{} {
+			return template.HTML(`<pre>` + template.HTMLEscapeString(value.Value) + `</pre>`)
+		})
+	detail.AddField(lg("next attempt at"), "next_attempt_at", db.Timestamp)
+	detail.AddField(lg("createdAt"), "created_at", db.Timestamp)
+	detail.AddField(lg("updatedAt"), "updated_at", db.Timestamp)
+
+	detail.SetTable(webhook.DeliveryTable).
+		SetTitle(lg("webhook deliveries")).
+		SetDescription(lg("webhook deliveries"))
+
+	deliveriesTable.GetForm().SetTable(webhook.DeliveryTable).
+		SetTitle(lg("webhook deliveries")).
+		SetDescription(lg("webhook deliveries"))
+
+	if !isSuper {
+		hideData(deliveriesTable)
+	}
+
+	return
+}
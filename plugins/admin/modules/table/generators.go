@@ -42,9 +42,9 @@ func (s *SystemTable) GetNormalManagerTable(ctx *context.Context) (managerTable
 
 	info := managerTable.GetInfo().AddXssJsFilter().HideFilterArea()
 
-	info.AddField("ID", "id", db.Int).FieldSortable()
-	info.AddField(lg("Name"), "username", db.Varchar).FieldFilterable()
-	info.AddField(lg("Nickname"), "name", db.Varchar).FieldFilterable()
+	info.AddField("ID", "id", db.Int).FieldSortable().FieldSearchable()
+	info.AddField(lg("Name"), "username", db.Varchar).FieldFilterable().FieldSearchable()
+	info.AddField(lg("Nickname"), "name", db.Varchar).FieldFilterable().FieldSearchable()
 	info.AddField(lg("role"), "name", db.Varchar).
 		FieldJoin(types.Join{
 			Table:     "goadmin_role_users",
@@ -106,6 +106,15 @@ func (s *SystemTable) GetNormalManagerTable(ctx *context.Context) (managerTable
 					return deleteUserPermissionErr, nil
 				}
 
+				deleteUserIdentityErr := s.connection().WithTx(tx).
+					Table("goadmin_user_identities").
+					WhereIn("user_id", ids).
+					Delete()
+
+				if db.CheckError(deleteUserIdentityErr, db.DELETE) {
+					return deleteUserIdentityErr, nil
+				}
+
 				deleteUserErr := s.connection().WithTx(tx).
 					Table("goadmin_users").
 					WhereIn("id", ids).
@@ -687,6 +696,30 @@ func (s *SystemTable) connection() *db.SQL {
 	return db.WithDriver(s.conn)
 }
 
+// isSuperAdmin return true if the login user is a super admin.
+func isSuperAdmin(ctx *context.Context) bool {
+	user, _ := ctx.User().(models.UserModel)
+	return user.IsSuperAdmin()
+}
+
+// superAdminOnly return the config of a table which only a super admin can
+// change. The rows are hidden from the others by hideData.
+func superAdminOnly(cfg Config, isSuper bool) Config {
+	if !isSuper {
+		cfg.CanAdd, cfg.Editable, cfg.Deletable, cfg.Exportable = false, false, false, false
+	}
+	return cfg
+}
+
+// hideData hide the rows of the table and of its detail page.
+func hideData(t Table) {
+	noData := func(param parameter.Parameters) ([]map[string]interface{}, int) {
+		return []map[string]interface{}{}, 0
+	}
+	t.GetInfo().SetGetDataFn(noData)
+	t.GetDetail().SetGetDataFn(noData)
+}
+
 func interfaces(arr []string) []interface{} {
 	var iarr = make([]interface{}, len(arr))
 
@@ -0,0 +1,127 @@
+package table
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	_ "github.com/GoAdminGroup/go-admin/modules/db/drivers/sqlite"
+	"github.com/GoAdminGroup/go-admin/modules/service"
+	form2 "github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/parameter"
+	"github.com/GoAdminGroup/go-admin/template/types"
+	"github.com/GoAdminGroup/go-admin/template/types/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptimisticLock(t *testing.T) {
+
+	conn := db.GetConnectionByDriver(db.DriverSqlite).InitDB(map[string]config.Database{
+		"default": {Driver: db.DriverSqlite, File: filepath.Join(t.TempDir(), "admin.db")},
+	})
+	testMigrate(t, conn)
+	_, err := conn.Exec("CREATE TABLE posts (`id` integer PRIMARY KEY autoincrement, `title` varchar(50), `version` integer DEFAULT 1)")
+	assert.Nil(t, err)
+	_, err = conn.Exec("INSERT INTO posts (`title`) VALUES ('a')")
+	assert.Nil(t, err)
+
+	// the audit log is written with the connection of the services.
+	services = service.List{config.GetDatabases().GetDefault().Driver: conn}
+
+	tb := NewDefaultTable(DefaultConfigWithDriver(db.DriverSqlite)).(*DefaultTable)
+	tb.dbObj = conn
+	tb.GetForm().SetTable("posts").SetOptimisticLock("version", db.Int).
+		AddField("Title", "title", db.Varchar, form.Text)
+
+	info, err := tb.GetDataWithId(parameter.BaseParam().WithPKs("1"))
+	assert.Nil(t, err)
+	assert.Equal(t, "1", info.Version)
+
+	// an update without the version is rejected.
+	assert.NotNil(t, tb.UpdateData(form2.Values{"id": {"1"}, "title": {"b"}}))
+
+	assert.Nil(t, tb.UpdateData(form2.Values{"id": {"1"}, "title": {"b"}, form2.VersionKey: {"1"}}))
+
+	err = tb.UpdateData(form2.Values{"id": {"1"}, "title": {"c"}, form2.VersionKey: {"1"}})
+	conflict, ok := err.(*types.ConflictError)
+	assert.True(t, ok)
+	assert.Equal(t, "2", conflict.Version)
+	assert.Equal(t, map[string]string{"title": "b"}, conflict.Latest)
+
+	errs, ok := types.GetFieldErrors(err)
+	assert.True(t, ok)
+	assert.Contains(t, errs["title"], "b")
+
+	assert.Nil(t, tb.UpdateData(form2.Values{"id": {"1"}, "title": {"c"}, form2.VersionKey: {conflict.Version}}))
+
+	row, err := db.WithDriver(conn).Table("posts").Find(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "c", row["title"])
+	assert.Equal(t, int64(3), row["version"])
+}
+
+func TestOptimisticLockTimestamp(t *testing.T) {
+
+	conn := db.GetConnectionByDriver(db.DriverSqlite).InitDB(map[string]config.Database{
+		"default": {Driver: db.DriverSqlite, File: filepath.Join(t.TempDir(), "admin.db")},
+	})
+	testMigrate(t, conn)
+	_, err := conn.Exec("CREATE TABLE IF NOT EXISTS lock_notes (`id` integer PRIMARY KEY autoincrement, " +
+		"`title` varchar(50), `updated_at` TIMESTAMP)")
+	assert.Nil(t, err)
+	_, err = conn.Exec("DELETE FROM lock_notes")
+	assert.Nil(t, err)
+	_, err = conn.Exec("INSERT INTO lock_notes (`id`, `title`, `updated_at`) VALUES (1, 'a', '2020-01-02 03:04:05.123456')")
+	assert.Nil(t, err)
+
+	services = service.List{config.GetDatabases().GetDefault().Driver: conn}
+
+	tb := NewDefaultTable(DefaultConfigWithDriver(db.DriverSqlite)).(*DefaultTable)
+	tb.dbObj = conn
+	tb.GetForm().SetTable("lock_notes").SetOptimisticLock("updated_at", db.Timestamp).
+		AddField("Title", "title", db.Varchar, form.Text)
+
+	// the fractional seconds are kept in the version.
+	info, err := tb.GetDataWithId(parameter.BaseParam().WithPKs("1"))
+	assert.Nil(t, err)
+	assert.Equal(t, "2020-01-02 03:04:05.123456", info.Version)
+
+	assert.Nil(t, tb.UpdateData(form2.Values{"id": {"1"}, "title": {"b"}, form2.VersionKey: {info.Version}}))
+
+	// the version posted again conflicts.
+	err = tb.UpdateData(form2.Values{"id": {"1"}, "title": {"c"}, form2.VersionKey: {info.Version}})
+	conflict, ok := err.(*types.ConflictError)
+	assert.True(t, ok)
+	assert.NotEqual(t, info.Version, conflict.Version)
+	assert.Equal(t, map[string]string{"title": "b"}, conflict.Latest)
+
+	assert.Nil(t, tb.UpdateData(form2.Values{"id": {"1"}, "title": {"c"}, form2.VersionKey: {conflict.Version}}))
+
+	// a version in seconds is changed by the updates in the same second.
+	version := time.Now().Add(time.Hour).Format("2006-01-02 15:04:05")
+	_, err = conn.Exec("UPDATE lock_notes SET `updated_at` = ?", version)
+	assert.Nil(t, err)
+	assert.Nil(t, tb.UpdateData(form2.Values{"id": {"1"}, "title": {"d"}, form2.VersionKey: {version}}))
+	err = tb.UpdateData(form2.Values{"id": {"1"}, "title": {"e"}, form2.VersionKey: {version}})
+	conflict, ok = err.(*types.ConflictError)
+	assert.True(t, ok)
+	assert.Len(t, conflict.Version, len(version))
+	assert.Greater(t, conflict.Version, version)
+
+	// the version is taken before the custom update.
+	updated := 0
+	tb.GetForm().SetUpdateFn(func(values form2.Values) error {
+		updated++
+		return nil
+	})
+	_, ok = tb.UpdateData(form2.Values{"id": {"1"}, "title": {"f"}, form2.VersionKey: {version}}).(*types.ConflictError)
+	assert.True(t, ok)
+	assert.Equal(t, 0, updated)
+
+	info, err = tb.GetDataWithId(parameter.BaseParam().WithPKs("1"))
+	assert.Nil(t, err)
+	assert.Nil(t, tb.UpdateData(form2.Values{"id": {"1"}, "title": {"f"}, form2.VersionKey: {info.Version}}))
+	assert.Equal(t, 1, updated)
+}
@@ -0,0 +1,93 @@
+package table
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules"
+)
+
+// searchTextTypes are the types of the fields matched by like, the other
+// types of the db.StringTypeList such as the dates can not be matched by
+// like in some databases.
+var searchTextTypes = []db.DatabaseType{db.Varchar, db.Char, db.Mediumtext, db.Longtext,
+	db.Tinytext, db.Text, db.Bpchar, db.Character, db.Varyingcharacter, db.Nchar,
+	db.Nativecharacter, db.Nvarchar, db.Clob, db.Enum, db.Name}
+
+// searchEscape is the escape character of the like patterns of the keyword.
+const searchEscape = "!"
+
+// escapeLike escape the wildcards of the like pattern of driver in the
+// keyword, so that they are matched as they are.
+func escapeLike(keyword, driver string) string {
+	wildcards := []string{searchEscape, "%", "_"}
+	if driver == db.DriverMssql {
+		wildcards = append(wildcards, "[")
+	}
+	for _, wildcard := range wildcards {
+		keyword = strings.ReplaceAll(keyword, wildcard, searchEscape+wildcard)
+	}
+	return keyword
+}
+
+// Searchable return true if the table can be queried by the global search,
+// which is a table queried from the database with the searchable fields.
+func Searchable(t Table) bool {
+	tb, ok := t.(*DefaultTable)
+	if !ok || !tb.getDataFromDB() {
+		return false
+	}
+	for _, field := range tb.Info.FieldList {
+		if field.Searchable {
+			return true
+		}
+	}
+	return false
+}
+
+// searchWhere return the condition of the keyword of the global search. The
+// string fields are matched by like, and the number fields are matched by
+// equal when the keyword is a number. The joined fields are ignored.
+func (tb *DefaultTable) searchWhere(keyword, table string, columns Columns,
+	args []interface{}) (string, []interface{}) {
+
+	if keyword == "" {
+		return "", args
+	}
+
+	var (
+		connection = tb.db()
+		conds      = make([]string, 0)
+		_, intErr  = strconv.ParseInt(keyword, 10, 64)
+		_, fltErr  = strconv.ParseFloat(keyword, 64)
+	)
+
+	for _, field := range tb.Info.FieldList {
+		if !field.Searchable || field.Joins.Valid() || !modules.InArray(columns, field.Field) {
+			continue
+		}
+		column := table + "." + modules.FilterField(field.Field, connection.GetDelimiter(), connection.GetDelimiter2())
+		switch {
+		case db.Contains(field.TypeName, searchTextTypes):
+			conds = append(conds, column+" like ? escape '"+searchEscape+"'")
+			args = append(args, "%"+escapeLike(keyword, connection.Name())+"%")
+		case db.Contains(field.TypeName, db.IntTypeList):
+			if intErr == nil {
+				conds = append(conds, column+" = ?")
+				args = append(args, keyword)
+			}
+		case db.Contains(field.TypeName, db.FloatTypeList), db.Contains(field.TypeName, db.UintTypeList):
+			if fltErr == nil {
+				conds = append(conds, column+" = ?")
+				args = append(args, keyword)
+			}
+		}
+	}
+
+	if len(conds) == 0 {
+		return "1 = 0", args
+	}
+
+	return "(" + strings.Join(conds, " or ") + ")", args
+}
@@ -0,0 +1,50 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchWhere(t *testing.T) {
+	tb := NewDefaultTable(DefaultConfigWithDriver(db.DriverMysql)).(*DefaultTable)
+	tb.dbObj = db.GetConnectionByDriver(db.DriverMysql)
+	columns := Columns{"id", "name", "price", "created_at"}
+
+	info := tb.GetInfo()
+	info.AddField("ID", "id", db.Int).FieldSearchable()
+	info.AddField("Name", "name", db.Varchar).FieldSearchable()
+	info.AddField("Price", "price", db.Decimal).FieldSearchable()
+	info.AddField("Created", "created_at", db.Timestamp).FieldSearchable()
+	info.AddField("Other", "other", db.Varchar).FieldSearchable()
+
+	assert.True(t, Searchable(tb))
+
+	wheres, args := tb.searchWhere("", "`users`", columns, []interface{}{})
+	assert.Equal(t, "", wheres)
+	assert.Equal(t, 0, len(args))
+
+	wheres, args = tb.searchWhere("jack", "`users`", columns, []interface{}{1})
+	assert.Equal(t, "(`users`.`name` like ? escape '!')", wheres)
+	assert.Equal(t, []interface{}{1, "%jack%"}, args)
+
+	// the wildcards of the keyword are matched as they are.
+	_, args = tb.searchWhere("10%_a!", "`users`", columns, []interface{}{})
+	assert.Equal(t, []interface{}{"%10!%!_a!!%"}, args)
+
+	wheres, args = tb.searchWhere("12", "`users`", columns, []interface{}{})
+	assert.Equal(t, "(`users`.`id` = ? or `users`.`name` like ? escape '!' or `users`.`price` = ?)", wheres)
+	assert.Equal(t, []interface{}{"12", "%12%", "12"}, args)
+
+	wheres, _ = tb.searchWhere("1.5", "`users`", Columns{"id", "price"}, []interface{}{})
+	assert.Equal(t, "(`users`.`price` = ?)", wheres)
+
+	wheres, _ = tb.searchWhere("jack", "`users`", Columns{"id"}, []interface{}{})
+	assert.Equal(t, "1 = 0", wheres)
+
+	assert.False(t, Searchable(NewDefaultTable(DefaultConfigWithDriver(db.DriverMysql))))
+
+	assert.Equal(t, "a![b", escapeLike("a[b", db.DriverMssql))
+	assert.Equal(t, "a[b", escapeLike("a[b", db.DriverSqlite))
+}
@@ -0,0 +1,143 @@
+package table
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/modules/db/dialect"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules"
+)
+
+// softDeleteWhere return the condition of the rows in the trash, or of the
+// others when trash is false. It is empty if the table is not soft deleted.
+func (tb *DefaultTable) softDeleteWhere(table string, trash bool) string {
+	if !tb.SoftDelete {
+		return ""
+	}
+	var (
+		connection = tb.db()
+		field      = modules.Delimiter(connection.GetDelimiter(), connection.GetDelimiter2(), table) + "." +
+			modules.FilterField(SoftDeleteField, connection.GetDelimiter(), connection.GetDelimiter2())
+	)
+	if trash {
+		return field + " is not null"
+	}
+	return field + " is null"
+}
+
+// andWhere join the condition to the where statement.
+func andWhere(wheres, cond string) string {
+	if cond == "" {
+		return wheres
+	}
+	if wheres == "" {
+		return cond
+	}
+	return wheres + " and " + cond
+}
+
+// softDelete move the rows into the trash.
+func (tb *DefaultTable) softDelete(table string, ids []string) error {
+	now := time.Now()
+	return tb.trashChange(table, ids, false, models.AuditActionDelete, func(stmt *db.SQL) error {
+		_, err := stmt.Update(dialect.H{SoftDeleteField: now})
+		return ignoreError(err, db.UPDATE)
+	}, dialect.H{SoftDeleteField: now})
+}
+
+// RestoreData move the rows out of the trash.
+func (tb *DefaultTable) RestoreData(id string) error {
+
+	var (
+		idArr = strings.Split(id, ",")
+		table = tb.Info.Table
+	)
+
+	if !tb.SoftDelete || table == "" || id == "" {
+		return errors.New("restore error: wrong parameter")
+	}
+
+	return tb.trashChange(table, idArr, true, models.AuditActionRestore, func(stmt *db.SQL) error {
+		_, err := stmt.Update(dialect.H{SoftDeleteField: nil})
+		return ignoreError(err, db.UPDATE)
+	}, dialect.H{SoftDeleteField: nil})
+}
+
+// PurgeData delete the rows in the trash.
+func (tb *DefaultTable) PurgeData(id string) error {
+
+	var (
+		idArr = strings.Split(id, ",")
+		table = tb.Info.Table
+	)
+
+	if !tb.SoftDelete || table == "" || id == "" {
+		return errors.New("purge error: wrong parameter")
+	}
+
+	return tb.trashChange(table, idArr, true, models.AuditActionDelete, func(stmt *db.SQL) error {
+		return ignoreError(stmt.Delete(), db.DELETE)
+	}, nil)
+}
+
+// trashChange run the change of the rows in the trash, or of the others when
+// trash is false, and write the audit log and the webhook deliveries of the
+// changed rows within one transaction. The changed values are recorded, or
+// all the values of the rows when values is nil.
+func (tb *DefaultTable) trashChange(table string, ids []string, trash bool, action string,
+	change func(stmt *db.SQL) error, values dialect.H) error {
+
+	_, err := tb.sql().WithTransaction(func(tx *sql.Tx) (error, map[string]interface{}) {
+		var entries []auditEntry
+
+		rows, err := tb.auditRows(tx, table, ids)
+		if err != nil {
+			return err, nil
+		}
+
+		err = change(tb.sql().WithTx(tx).Table(table).
+			WhereIn(tb.PrimaryKey.Name, stringsToArgs(ids)).
+			WhereRaw(tb.softDeleteWhere(table, trash)))
+		if err != nil {
+			return err, nil
+		}
+
+		for _, row := range rows {
+			if (row[SoftDeleteField] != nil) != trash {
+				continue
+			}
+			changes := auditDiff(row, dialect.H{}, tb.Form.FieldList, true)
+			if values != nil {
+				changes = auditDiff(row, values, tb.Form.FieldList, false)
+			}
+			entries = append(entries, auditEntry{recordId: auditValue(row[tb.PrimaryKey.Name]),
+				action: action, changes: changes})
+		}
+
+		return tb.audit(tx, table, entries...), nil
+	})
+
+	return err
+}
+
+// ignoreError return nil if the error of the statement of the type t should
+// be ignored.
+func ignoreError(err error, t int) error {
+	// NOTE: some errors should be ignored.
+	if db.CheckError(err, t) {
+		return err
+	}
+	return nil
+}
+
+func stringsToArgs(values []string) []interface{} {
+	args := make([]interface{}, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return args
+}
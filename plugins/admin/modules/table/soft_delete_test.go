@@ -0,0 +1,74 @@
+package table
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	_ "github.com/GoAdminGroup/go-admin/modules/db/drivers/sqlite"
+	"github.com/GoAdminGroup/go-admin/modules/service"
+	form2 "github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	"github.com/GoAdminGroup/go-admin/template/types/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSoftDeleteWhere(t *testing.T) {
+	tb := NewDefaultTable(DefaultConfigWithDriver(db.DriverMysql)).(*DefaultTable)
+	tb.dbObj = db.GetConnectionByDriver(db.DriverMysql)
+	assert.Equal(t, tb.softDeleteWhere("users", false), "")
+
+	tb = NewDefaultTable(DefaultConfigWithDriver(db.DriverMysql).SetSoftDelete(true)).(*DefaultTable)
+	tb.dbObj = db.GetConnectionByDriver(db.DriverMysql)
+	assert.Equal(t, tb.softDeleteWhere("users", false), "`users`.`deleted_at` is null")
+	assert.Equal(t, tb.softDeleteWhere("users", true), "`users`.`deleted_at` is not null")
+	assert.Equal(t, tb.Copy().GetSoftDelete(), true)
+}
+
+func TestAndWhere(t *testing.T) {
+	assert.Equal(t, andWhere("", ""), "")
+	assert.Equal(t, andWhere("a = ?", ""), "a = ?")
+	assert.Equal(t, andWhere("", "b is null"), "b is null")
+	assert.Equal(t, andWhere("a = ?", "b is null"), "a = ? and b is null")
+}
+
+func TestUpdateDataSoftDelete(t *testing.T) {
+	conn := db.GetConnectionByDriver(db.DriverSqlite).InitDB(map[string]config.Database{
+		"default": {Driver: db.DriverSqlite, File: filepath.Join(t.TempDir(), "admin.db")},
+	})
+	testMigrate(t, conn)
+	_, err := conn.Exec("CREATE TABLE IF NOT EXISTS trash_notes (`id` integer PRIMARY KEY autoincrement, " +
+		"`title` varchar(50), `version` integer DEFAULT 1, `deleted_at` TIMESTAMP)")
+	assert.Nil(t, err)
+	_, err = conn.Exec("DELETE FROM trash_notes")
+	assert.Nil(t, err)
+	_, err = conn.Exec("INSERT INTO trash_notes (`id`, `title`, `deleted_at`) VALUES (1, 'a', NULL), (2, 'a', '2020-01-02 03:04:05')")
+	assert.Nil(t, err)
+
+	services = service.List{config.GetDatabases().GetDefault().Driver: conn}
+
+	tb := NewDefaultTable(DefaultConfigWithDriver(db.DriverSqlite).SetSoftDelete(true)).(*DefaultTable)
+	tb.dbObj = conn
+	tb.GetForm().SetTable("trash_notes").AddField("Title", "title", db.Varchar, form.Text)
+
+	title := func(id int) string {
+		row, err := db.WithDriver(conn).Table("trash_notes").Find(id)
+		assert.Nil(t, err)
+		return row["title"].(string)
+	}
+
+	assert.Nil(t, tb.UpdateData(form2.Values{"id": {"1"}, "title": {"b"}}))
+	assert.Equal(t, "b", title(1))
+	// the same values are not an error.
+	assert.Nil(t, tb.UpdateData(form2.Values{"id": {"1"}, "title": {"b"}}))
+
+	// the rows in the trash can not be edited.
+	assert.NotNil(t, tb.UpdateData(form2.Values{"id": {"2"}, "title": {"b"}}))
+	assert.Equal(t, "a", title(2))
+
+	tb.GetForm().SetOptimisticLock("version", db.Int)
+	assert.NotNil(t, tb.UpdateData(form2.Values{"id": {"2"}, "title": {"b"}, form2.VersionKey: {"1"}}))
+	assert.Equal(t, "a", title(2))
+	assert.Nil(t, tb.UpdateData(form2.Values{"id": {"1"}, "title": {"c"}, form2.VersionKey: {"1"}}))
+	assert.Equal(t, "c", title(1))
+}
@@ -54,6 +54,8 @@ type Table interface {
 	GetEditable() bool
 	GetDeletable() bool
 	GetExportable() bool
+	GetImportable() bool
+	GetSoftDelete() bool
 
 	GetPrimaryKey() PrimaryKey
 
@@ -63,6 +65,9 @@ type Table interface {
 	UpdateData(dataList form.Values) error
 	InsertData(dataList form.Values) error
 	DeleteData(pk string) error
+	ImportData(rows []form.Values, dryRun bool) (ImportResult, error)
+	RestoreData(pk string) error
+	PurgeData(pk string) error
 
 	GetNewFormInfo() FormInfo
 
@@ -83,6 +88,8 @@ type BaseTable struct {
 	Editable       bool
 	Deletable      bool
 	Exportable     bool
+	Importable     bool
+	SoftDelete     bool
 	OnlyInfo       bool
 	OnlyDetail     bool
 	OnlyNewForm    bool
@@ -128,6 +135,8 @@ func (base *BaseTable) GetPrimaryKey() PrimaryKey { return base.PrimaryKey }
 func (base *BaseTable) GetEditable() bool         { return base.Editable }
 func (base *BaseTable) GetDeletable() bool        { return base.Deletable }
 func (base *BaseTable) GetExportable() bool       { return base.Exportable }
+func (base *BaseTable) GetImportable() bool       { return base.Importable }
+func (base *BaseTable) GetSoftDelete() bool       { return base.SoftDelete }
 func (base *BaseTable) GetOnlyInfo() bool         { return base.OnlyInfo }
 func (base *BaseTable) GetOnlyDetail() bool       { return base.OnlyDetail }
 func (base *BaseTable) GetOnlyNewForm() bool      { return base.OnlyNewForm }
@@ -148,9 +157,35 @@ func (base *BaseTable) GetPaginator(size int, params parameter.Parameters, extra
 	}).SetExtraInfo(eh)
 }
 
+// GetCursorPaginator return the paginator of the cursor pagination, size is
+// the approximate total which is zero if it is unknown.
+func (base *BaseTable) GetCursorPaginator(size, count int, params parameter.Parameters, previous, next string,
+	extraHtml ...template.HTML) types.PaginatorAttribute {
+
+	var eh template.HTML
+
+	if len(extraHtml) > 0 {
+		eh = extraHtml[0]
+	}
+
+	return paginator.Get(paginator.Config{
+		Size:           size,
+		Param:          params,
+		PageSizeList:   base.Info.GetPageSizeList(),
+		Cursor:         true,
+		Count:          count,
+		PreviousCursor: previous,
+		NextCursor:     next,
+	}).SetExtraInfo(eh)
+}
+
 type PanelInfo struct {
 	Thead          types.Thead              `json:"thead"`
 	InfoList       types.InfoList           `json:"info_list"`
+	Total          int                      `json:"total"`
+	PreviousCursor string                   `json:"previous_cursor,omitempty"`
+	NextCursor     string                   `json:"next_cursor,omitempty"`
+	CursorMode     bool                     `json:"-"`
 	FilterFormData types.FormFields         `json:"filter_form_data"`
 	Paginator      types.PaginatorAttribute `json:"-"`
 	Title          string                   `json:"title"`
@@ -163,6 +198,23 @@ type FormInfo struct {
 	GroupFieldHeaders types.GroupFieldHeaders `json:"group_field_headers"`
 	Title             string                  `json:"title"`
 	Description       string                  `json:"description"`
+
+	// Version is the value of the optimistic lock of the record.
+	Version string `json:"version,omitempty"`
+}
+
+// ImportResult is the report of an import. Row numbers of the errors are
+// 1-based indexes of the imported rows.
+type ImportResult struct {
+	Total    int           `json:"total"`
+	Inserted int           `json:"inserted"`
+	DryRun   bool          `json:"dry_run"`
+	Errors   []ImportError `json:"errors"`
+}
+
+type ImportError struct {
+	Row int    `json:"row"`
+	Msg string `json:"msg"`
 }
 
 type PrimaryKey struct {
@@ -173,6 +225,10 @@ type PrimaryKey struct {
 const (
 	DefaultPrimaryKeyName = "id"
 	DefaultConnectionName = "default"
+
+	// SoftDeleteField is the column of the deleted time of the rows of a
+	// soft deleted table.
+	SoftDeleteField = "deleted_at"
 )
 
 var (
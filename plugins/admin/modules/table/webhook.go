@@ -0,0 +1,154 @@
+package table
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/modules/db/dialect"
+	"github.com/GoAdminGroup/go-admin/modules/logger"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/webhook"
+	"github.com/GoAdminGroup/go-admin/template/types"
+)
+
+// webhookEvents are the events of the webhooks by the actions of the audit
+// log.
+var webhookEvents = map[string]string{
+	models.AuditActionCreate:  webhook.Created,
+	models.AuditActionUpdate:  webhook.Updated,
+	models.AuditActionDelete:  webhook.Deleted,
+	models.AuditActionRestore: webhook.Restored,
+}
+
+// Notifiable is a Table which notifies the webhooks of the prefix of the
+// changes of its data.
+type Notifiable interface {
+	SetWebhookPrefix(prefix string)
+}
+
+// SetWebhookPrefix set the prefix of the webhooks of the table if the table
+// is Notifiable.
+func SetWebhookPrefix(t Table, prefix string) {
+	if n, ok := t.(Notifiable); ok {
+		n.SetWebhookPrefix(prefix)
+	}
+}
+
+// SetWebhookPrefix implements the Notifiable.SetWebhookPrefix.
+func (tb *DefaultTable) SetWebhookPrefix(prefix string) {
+	tb.webhookPrefix = prefix
+}
+
+// WebhookData is the data of the payloads of the events of the records. The
+// changes of a created record are its values, and the ones of a deleted
+// record are its last values. The password fields are masked.
+type WebhookData struct {
+	Table    string               `json:"table"`
+	Id       string               `json:"id"`
+	UserId   int64                `json:"user_id"`
+	UserName string               `json:"user_name"`
+	Changes  []models.AuditChange `json:"changes"`
+}
+
+// notify queue the deliveries of the change to the webhooks in the outbox
+// of the connection within tx. The changes of the webhooks are not notified,
+// which would send the secrets to the other webhooks.
+func (tb *DefaultTable) notify(conn db.Connection, tx *sql.Tx, table, recordId, action string, changes []models.AuditChange) error {
+	event, ok := webhookEvents[action]
+	if tb.webhookPrefix == "" || !ok || table == webhook.WebhookTable || table == webhook.DeliveryTable {
+		return nil
+	}
+	_, err := webhook.EnqueueTx(conn, tx, tb.webhookPrefix, event, WebhookData{
+		Table:    table,
+		Id:       recordId,
+		UserId:   tb.auditUserId,
+		UserName: tb.auditUserName,
+		Changes:  changes,
+	})
+	return err
+}
+
+// notifyCustom notify the webhooks of a change made by a custom insert,
+// update or delete function, which is out of a transaction, so the error is
+// logged only. The changes are the posted values as the old ones are not
+// known, with the secrets masked.
+func (tb *DefaultTable) notifyCustom(table, recordId, action string, dataList form.Values, fields types.FormFields) {
+	values := make(dialect.H)
+	for key := range dataList {
+		if !strings.HasPrefix(key, "__") {
+			values[key] = dataList.Get(key)
+		}
+	}
+	err := tb.notify(db.GetConnection(services), nil, table, recordId, action, auditDiff(nil, values, fields, false))
+	if err != nil {
+		logger.Error("webhook enqueue error: ", err)
+	}
+}
+
+// ActionData is the data of the payloads of the events of the custom
+// actions, which are named by the ids of the actions.
+type ActionData struct {
+	Table    string   `json:"table"`
+	Ids      []string `json:"ids"`
+	UserId   int64    `json:"user_id"`
+	UserName string   `json:"user_name"`
+}
+
+// ActionNotifier return the handler which notifies the webhooks of the prefix
+// of the custom action of the path of the table once the action succeeds,
+// that is the response is a 2xx one with the code 0 if it is a json. The
+// action is done out of a transaction, so the error is logged only.
+func ActionNotifier(t Table, prefix, path string) context.Handler {
+	var (
+		event = strings.TrimPrefix(path, config.Url("/operation/"))
+		table = t.GetInfo().Table
+	)
+	return func(ctx *context.Context) {
+		if table == webhook.WebhookTable || table == webhook.DeliveryTable || !actionSucceeded(ctx) {
+			return
+		}
+		data := ActionData{Table: table, Ids: make([]string, 0)}
+		ids := ctx.FormValue("id")
+		if ids == "" {
+			ids = ctx.FormValue("ids")
+		}
+		for _, id := range strings.Split(ids, ",") {
+			if id != "" {
+				data.Ids = append(data.Ids, id)
+			}
+		}
+		if user, ok := ctx.User().(models.UserModel); ok {
+			data.UserId, data.UserName = user.Id, user.Name
+		}
+		if _, err := webhook.Enqueue(db.GetConnection(services), prefix, event, data); err != nil {
+			logger.Error("webhook enqueue error: ", err)
+		}
+	}
+}
+
+// actionSucceeded return true if the response of the action is a 2xx one,
+// and its code is 0 if it is a json, as the one of types.Handler.Wrap.
+func actionSucceeded(ctx *context.Context) bool {
+	if ctx.Response == nil || ctx.Response.StatusCode < 200 || ctx.Response.StatusCode >= 300 {
+		return false
+	}
+	if ctx.Response.Body == nil || !strings.Contains(ctx.Response.Header.Get(context.HeaderContentType), "json") {
+		return true
+	}
+	body, err := io.ReadAll(ctx.Response.Body)
+	if err != nil {
+		return false
+	}
+	ctx.Response.Body = io.NopCloser(bytes.NewReader(body))
+	var res struct {
+		Code int `json:"code"`
+	}
+	return json.Unmarshal(body, &res) == nil && res.Code == 0
+}
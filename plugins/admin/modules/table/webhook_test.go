@@ -0,0 +1,190 @@
+package table
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GoAdminGroup/go-admin/context"
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/modules/db/dialect"
+	_ "github.com/GoAdminGroup/go-admin/modules/db/drivers/sqlite"
+	"github.com/GoAdminGroup/go-admin/modules/service"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
+	form2 "github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/webhook"
+	"github.com/GoAdminGroup/go-admin/template/types"
+	"github.com/GoAdminGroup/go-admin/template/types/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotify(t *testing.T) {
+
+	conn := db.GetConnectionByDriver(db.DriverSqlite).InitDB(map[string]config.Database{
+		"default": {Driver: db.DriverSqlite, File: filepath.Join(t.TempDir(), "admin.db")},
+	})
+	testMigrate(t, conn, "2026_10_18_190000")
+	_, err := conn.Exec("CREATE TABLE posts (`id` integer PRIMARY KEY autoincrement, `title` varchar(50), `password` varchar(50))")
+	assert.Nil(t, err)
+	_, err = conn.Exec("INSERT INTO posts (`title`, `password`) VALUES ('a', 'x')")
+	assert.Nil(t, err)
+	_, err = db.WithDriver(conn).Table(webhook.WebhookTable).Insert(dialect.H{
+		"prefix": "posts", "event": webhook.Any, "url": "http://127.0.0.1", "enabled": 1,
+	})
+	assert.Nil(t, err)
+
+	// the deliveries are queued with the connection of the services.
+	services = service.List{config.GetDatabases().GetDefault().Driver: conn}
+
+	newTable := func() *DefaultTable {
+		tb := NewDefaultTable(DefaultConfigWithDriver(db.DriverSqlite)).(*DefaultTable)
+		tb.dbObj = conn
+		tb.GetInfo().SetTable("posts")
+		tb.GetForm().SetTable("posts").
+			AddField("Title", "title", db.Varchar, form.Text).
+			AddField("Password", "password", db.Varchar, form.Password)
+		return tb
+	}
+
+	assert.Nil(t, newTable().UpdateData(form2.Values{"id": {"1"}, "title": {"b"}}))
+
+	count, err := db.WithDriver(conn).Table(webhook.DeliveryTable).Count()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), count)
+
+	tb := newTable()
+	SetAuditUser(tb, models.UserModel{Id: 1, Name: "admin"})
+	SetWebhookPrefix(tb, "posts")
+
+	assert.Nil(t, tb.UpdateData(form2.Values{"id": {"1"}, "title": {"c"}, "password": {"y"}}))
+	assert.Nil(t, tb.DeleteData("1"))
+
+	rows, err := db.WithDriver(conn).Table(webhook.DeliveryTable).OrderBy("id", "asc").All()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(rows))
+	assert.Equal(t, webhook.Updated, rows[0]["event"])
+	assert.Equal(t, webhook.Deleted, rows[1]["event"])
+
+	var payload struct {
+		Event  string      `json:"event"`
+		Prefix string      `json:"prefix"`
+		Data   WebhookData `json:"data"`
+	}
+	assert.Nil(t, json.Unmarshal([]byte(rows[0]["payload"].(string)), &payload))
+	assert.Equal(t, "posts", payload.Prefix)
+	assert.Equal(t, WebhookData{
+		Table:    "posts",
+		Id:       "1",
+		UserId:   1,
+		UserName: "admin",
+		Changes: []models.AuditChange{
+			{Field: "password", Old: auditMask, New: auditMask},
+			{Field: "title", Old: "b", New: "c"},
+		},
+	}, payload.Data)
+
+	// the secrets of the deleted record are masked.
+	assert.Nil(t, json.Unmarshal([]byte(rows[1]["payload"].(string)), &payload))
+	assert.Equal(t, []models.AuditChange{
+		{Field: "id", Old: "1", New: ""},
+		{Field: "password", Old: auditMask, New: ""},
+		{Field: "title", Old: "c", New: ""},
+	}, payload.Data.Changes)
+
+	// the change is rolled back if the deliveries can not be queued.
+	_, err = conn.Exec("INSERT INTO posts (`id`, `title`) VALUES (2, 'a')")
+	assert.Nil(t, err)
+	_, err = conn.Exec("ALTER TABLE " + webhook.DeliveryTable + " RENAME TO posts_deliveries")
+	assert.Nil(t, err)
+	assert.NotNil(t, tb.UpdateData(form2.Values{"id": {"2"}, "title": {"b"}}))
+	_, err = conn.Exec("ALTER TABLE posts_deliveries RENAME TO " + webhook.DeliveryTable)
+	assert.Nil(t, err)
+
+	row, err := db.WithDriver(conn).Table("posts").Where("id", "=", 2).First()
+	assert.Nil(t, err)
+	assert.Equal(t, "a", row["title"])
+
+	// the changes of the custom functions are notified once they succeed.
+	tb = newTable()
+	SetWebhookPrefix(tb, "posts")
+	tb.GetForm().SetInsertFn(func(values form2.Values) error { return nil })
+	tb.GetInfo().SetDeleteFn(func(ids []string) error { return nil })
+
+	assert.Nil(t, tb.InsertData(form2.Values{"title": {"d"}, "password": {"z"}}))
+	assert.Nil(t, tb.DeleteData("3,4"))
+
+	rows, err = db.WithDriver(conn).Table(webhook.DeliveryTable).Where("id", ">", rows[1]["id"]).
+		OrderBy("id", "asc").All()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(rows))
+	assert.Equal(t, webhook.Created, rows[0]["event"])
+	assert.Equal(t, webhook.Deleted, rows[1]["event"])
+	assert.Equal(t, webhook.Deleted, rows[2]["event"])
+
+	assert.Nil(t, json.Unmarshal([]byte(rows[0]["payload"].(string)), &payload))
+	assert.Equal(t, []models.AuditChange{
+		{Field: "password", Old: "", New: auditMask},
+		{Field: "title", Old: "", New: "d"},
+	}, payload.Data.Changes)
+
+	assert.Nil(t, json.Unmarshal([]byte(rows[2]["payload"].(string)), &payload))
+	assert.Equal(t, "4", payload.Data.Id)
+}
+
+func TestActionNotifier(t *testing.T) {
+
+	conn := db.GetConnectionByDriver(db.DriverSqlite).InitDB(map[string]config.Database{
+		"default": {Driver: db.DriverSqlite, File: filepath.Join(t.TempDir(), "admin.db")},
+	})
+	testMigrate(t, conn, "2026_10_18_190000")
+	_, err := conn.Exec("DELETE FROM " + webhook.DeliveryTable)
+	assert.Nil(t, err)
+	_, err = conn.Exec("DELETE FROM " + webhook.WebhookTable)
+	assert.Nil(t, err)
+	_, err = db.WithDriver(conn).Table(webhook.WebhookTable).Insert(dialect.H{
+		"prefix": "posts", "event": "publish", "url": "http://127.0.0.1", "enabled": 1,
+	})
+	assert.Nil(t, err)
+
+	services = service.List{config.GetDatabases().GetDefault().Driver: conn}
+
+	tb := NewDefaultTable(DefaultConfigWithDriver(db.DriverSqlite))
+	tb.GetInfo().SetTable("posts")
+
+	notifier := ActionNotifier(tb, "posts", config.Url("/operation/publish"))
+
+	run := func(success bool) {
+		req := httptest.NewRequest("POST", "/", strings.NewReader("ids=1,2"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		ctx := context.NewContext(req)
+		ctx.SetUserValue("user", models.UserModel{Id: 1, Name: "admin"})
+		types.Handler(func(ctx *context.Context) (bool, string, interface{}) {
+			return success, "", ""
+		}).Wrap()(ctx)
+		notifier(ctx)
+
+		// the response is kept.
+		body, err := io.ReadAll(ctx.Response.Body)
+		assert.Nil(t, err)
+		assert.Contains(t, string(body), `"code"`)
+	}
+
+	run(false)
+	run(true)
+
+	rows, err := db.WithDriver(conn).Table(webhook.DeliveryTable).All()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(rows))
+
+	var payload struct {
+		Event string     `json:"event"`
+		Data  ActionData `json:"data"`
+	}
+	assert.Nil(t, json.Unmarshal([]byte(rows[0]["payload"].(string)), &payload))
+	assert.Equal(t, "publish", payload.Event)
+	assert.Equal(t, ActionData{Table: "posts", Ids: []string{"1", "2"}, UserId: 1, UserName: "admin"}, payload.Data)
+}
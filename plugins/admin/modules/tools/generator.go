@@ -52,6 +52,11 @@ type Param struct {
 	ExtraImport string `json:"extra_import"`
 	ExtraCode   string `json:"extra_code"`
 
+	Header         string `json:"header"`
+	ImportTypes    bool   `json:"import_types"`
+	KeepCustomCode bool   `json:"keep_custom_code"`
+	CustomCode     string `json:"custom_code"`
+
 	Fields       Fields `json:"fields"`
 	FormFields   Fields `json:"form_fields"`
 	DetailFields Fields `json:"detail_fields"`
@@ -249,23 +254,35 @@ type Field struct {
 	CanAdd       bool   `json:"can_add"`
 	ExtraFun     string `json:"extra_fun"`
 	IsPrimaryKey bool   `json:"is_primary_key"`
+	FilterType   string `json:"filter_type"`
+	Join         string `json:"join"`
 }
 
 func Generate(param *Param) error {
-	t, err := template.New("table_model").Parse(tableModelTmpl)
+	c, err := GenerateContent(param)
 	if err != nil {
 		return err
 	}
-	buf := new(bytes.Buffer)
-	err = t.Execute(buf, param)
+	return ioutil.WriteFile(param.Path(), c, 0644)
+}
+
+// GenerateContent return the formatted content of the table model file.
+func GenerateContent(param *Param) ([]byte, error) {
+	t, err := template.New("table_model").Parse(tableModelTmpl)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	c, err := format.Source(buf.Bytes())
+	buf := new(bytes.Buffer)
+	err = t.Execute(buf, param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return ioutil.WriteFile(filepath.FromSlash(param.Output)+"/"+param.RowTable+".go", c, 0644)
+	return format.Source(buf.Bytes())
+}
+
+// Path return the path of the table model file.
+func (param *Param) Path() string {
+	return filepath.FromSlash(param.Output) + "/" + param.RowTable + ".go"
 }
 
 const (
@@ -274,16 +291,31 @@ const (
 )
 
 func GenerateTables(outputPath, packageName string, tables []string, isNew bool) error {
+	c, err := GenerateTablesContent(outputPath, packageName, tables, isNew)
+	if err != nil || c == nil {
+		return err
+	}
+	return ioutil.WriteFile(TablesPath(outputPath), c, 0644)
+}
 
+// TablesPath return the path of the tables.go file in the output path.
+func TablesPath(outputPath string) string {
 	if len(outputPath) > 0 && outputPath[len(outputPath)-1] == '/' {
 		outputPath = outputPath[:len(outputPath)-1]
 	}
+	return filepath.FromSlash(outputPath) + "/tables.go"
+}
 
-	outputPath = filepath.FromSlash(outputPath)
-	fileExist := utils.FileExist(outputPath + "/tables.go")
+// GenerateTablesContent return the content of the tables.go file, in which
+// the generators of the tables are added if not exist. It is nil if the file
+// does not exist and isNew is false.
+func GenerateTablesContent(outputPath, packageName string, tables []string, isNew bool) ([]byte, error) {
+
+	tablesPath := TablesPath(outputPath)
+	fileExist := utils.FileExist(tablesPath)
 
 	if !isNew && !fileExist {
-		return nil
+		return nil, nil
 	}
 
 	var (
@@ -294,9 +326,9 @@ func GenerateTables(outputPath, packageName string, tables []string, isNew bool)
 		err               error
 	)
 	if fileExist {
-		tablesContentByte, err = ioutil.ReadFile(outputPath + "/tables.go")
+		tablesContentByte, err = ioutil.ReadFile(tablesPath)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		tablesContent = string(tablesContentByte)
 	}
@@ -364,12 +396,7 @@ var Generators = map[string]table.Generator{
 `, packageName, commentStr, tableStr)
 	}
 
-	c, err := format.Source([]byte(content))
-
-	if err != nil {
-		return err
-	}
-	return ioutil.WriteFile(outputPath+"/tables.go", c, 0644)
+	return format.Source([]byte(content))
 }
 
 func camelcase(s string) string {
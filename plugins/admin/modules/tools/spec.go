@@ -0,0 +1,478 @@
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/modules/utils"
+	"github.com/GoAdminGroup/go-admin/template/types/form"
+	"gopkg.in/yaml.v2"
+)
+
+// Spec is the declarative spec of the table model files, which is a checked
+// in YAML or JSON file. The files are generated from the spec only without a
+// database connection, so that the output is the same every time.
+type Spec struct {
+	Package    string      `json:"package" yaml:"package"`
+	Output     string      `json:"output" yaml:"output"`
+	Connection string      `json:"connection" yaml:"connection"`
+	Driver     string      `json:"driver" yaml:"driver"`
+	Schema     string      `json:"schema" yaml:"schema"`
+	Tables     []TableSpec `json:"tables" yaml:"tables"`
+}
+
+// TableSpec is the spec of a table model file.
+type TableSpec struct {
+	Table       string `json:"table" yaml:"table"`
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+	PrimaryKey  string `json:"primary_key" yaml:"primary_key"`
+
+	// PrimaryKeyType is the type of the field of the primary key by default.
+	PrimaryKeyType string `json:"primary_key_type" yaml:"primary_key_type"`
+
+	HideFilterArea   bool `json:"hide_filter_area" yaml:"hide_filter_area"`
+	HideNewButton    bool `json:"hide_new_button" yaml:"hide_new_button"`
+	HideExportButton bool `json:"hide_export_button" yaml:"hide_export_button"`
+	HideEditButton   bool `json:"hide_edit_button" yaml:"hide_edit_button"`
+	HideDeleteButton bool `json:"hide_delete_button" yaml:"hide_delete_button"`
+	HideDetailButton bool `json:"hide_detail_button" yaml:"hide_detail_button"`
+	HideFilterButton bool `json:"hide_filter_button" yaml:"hide_filter_button"`
+	HideRowSelector  bool `json:"hide_row_selector" yaml:"hide_row_selector"`
+	HidePagination   bool `json:"hide_pagination" yaml:"hide_pagination"`
+	HideQueryInfo    bool `json:"hide_query_info" yaml:"hide_query_info"`
+
+	// Imports are the extra imports of the custom code.
+	Imports []string    `json:"imports" yaml:"imports"`
+	Fields  []FieldSpec `json:"fields" yaml:"fields"`
+}
+
+// FieldSpec is the spec of a field of the table and the form. A joined field
+// is shown in the table only.
+type FieldSpec struct {
+	Name           string    `json:"name" yaml:"name"`
+	Label          string    `json:"label" yaml:"label"`
+	Type           string    `json:"type" yaml:"type"`
+	FormType       string    `json:"form_type" yaml:"form_type"`
+	Filterable     bool      `json:"filterable" yaml:"filterable"`
+	FilterFormType string    `json:"filter_form_type" yaml:"filter_form_type"`
+	FilterOperator string    `json:"filter_operator" yaml:"filter_operator"`
+	Sortable       bool      `json:"sortable" yaml:"sortable"`
+	Editable       bool      `json:"editable" yaml:"editable"`
+	Hide           bool      `json:"hide" yaml:"hide"`
+	Join           *JoinSpec `json:"join" yaml:"join"`
+
+	OmitForm          bool   `json:"omit_form" yaml:"omit_form"`
+	FormHide          bool   `json:"form_hide" yaml:"form_hide"`
+	EditHide          bool   `json:"edit_hide" yaml:"edit_hide"`
+	CreateHide        bool   `json:"create_hide" yaml:"create_hide"`
+	DisableWhenCreate bool   `json:"disable_when_create" yaml:"disable_when_create"`
+	DisableWhenUpdate bool   `json:"disable_when_update" yaml:"disable_when_update"`
+	Default           string `json:"default" yaml:"default"`
+}
+
+// JoinSpec is the spec of the join of a field, the field of the table is
+// joined with the join field of the join table.
+type JoinSpec struct {
+	Table     string `json:"table" yaml:"table"`
+	Field     string `json:"field" yaml:"field"`
+	JoinField string `json:"join_field" yaml:"join_field"`
+}
+
+// GeneratedFile is a file generated from the spec.
+type GeneratedFile struct {
+	Path    string
+	Content []byte
+}
+
+const (
+	customCodeBegin = "// custom code begin"
+	customCodeEnd   = "// custom code end"
+)
+
+var filterOperators = map[string]string{
+	"like": "FilterOperatorLike",
+	">":    "FilterOperatorGreater",
+	">=":   "FilterOperatorGreaterOrEqual",
+	"=":    "FilterOperatorEqual",
+	"!=":   "FilterOperatorNotEqual",
+	"<":    "FilterOperatorLess",
+	"<=":   "FilterOperatorLessOrEqual",
+	"free": "FilterOperatorFree",
+}
+
+// LoadSpec load the spec from the YAML or JSON file by the extension.
+func LoadSpec(path string) (Spec, error) {
+	var spec Spec
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return spec, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(content, &spec)
+	case ".yml", ".yaml":
+		err = yaml.UnmarshalStrict(content, &spec)
+	default:
+		return spec, errors.New("the spec should be a yaml or json file")
+	}
+
+	if err != nil {
+		return spec, err
+	}
+
+	return spec, spec.Validate()
+}
+
+// Validate check the spec and set the defaults.
+func (s *Spec) Validate() error {
+
+	s.Package = utils.SetDefault(s.Package, "", "main")
+	s.Output = utils.SetDefault(s.Output, "", "./")
+	s.Connection = utils.SetDefault(s.Connection, "", "default")
+
+	if s.Driver == "" {
+		return errors.New("the driver of the spec can not be empty")
+	}
+
+	if len(s.Tables) == 0 {
+		return errors.New("no tables in the spec")
+	}
+
+	tables := make(map[string]bool, len(s.Tables))
+
+	for i := range s.Tables {
+		table := &s.Tables[i]
+
+		if table.Table == "" {
+			return fmt.Errorf("the name of table %d can not be empty", i+1)
+		}
+		if tables[table.Table] {
+			return fmt.Errorf("table %s is duplicated", table.Table)
+		}
+		tables[table.Table] = true
+
+		formFields := 0
+		for j := range table.Fields {
+			field := &table.Fields[j]
+
+			if field.Name == "" {
+				return fmt.Errorf("table %s: the name of field %d can not be empty", table.Table, j+1)
+			}
+
+			typ, err := specDBType(field.Type)
+			if err != nil {
+				return fmt.Errorf("table %s: field %s: %s", table.Table, field.Name, err)
+			}
+			field.Type = typ
+
+			if field.FormType == "" {
+				field.FormType = form.GetFormTypeFromFieldType(db.DT(strings.ToUpper(typ)), field.Name)
+			} else if !isFormTypeName(field.FormType) {
+				return fmt.Errorf("table %s: field %s: wrong form type %s", table.Table, field.Name, field.FormType)
+			}
+
+			if field.FilterFormType != "" && !isFormTypeName(field.FilterFormType) {
+				return fmt.Errorf("table %s: field %s: wrong filter form type %s", table.Table, field.Name,
+					field.FilterFormType)
+			}
+
+			if _, ok := filterOperators[field.FilterOperator]; field.FilterOperator != "" && !ok {
+				return fmt.Errorf("table %s: field %s: wrong filter operator %s", table.Table, field.Name,
+					field.FilterOperator)
+			}
+
+			if field.Join != nil {
+				if field.Join.Table == "" || field.Join.Field == "" || field.Join.JoinField == "" {
+					return fmt.Errorf("table %s: field %s: the table, field and join field of the join "+
+						"can not be empty", table.Table, field.Name)
+				}
+			} else if !field.OmitForm {
+				formFields++
+			}
+		}
+
+		if formFields == 0 {
+			return fmt.Errorf("table %s has no form fields", table.Table)
+		}
+
+		if table.PrimaryKey != "" {
+			if table.PrimaryKeyType == "" {
+				for _, field := range table.Fields {
+					if field.Name == table.PrimaryKey {
+						table.PrimaryKeyType = field.Type
+					}
+				}
+			}
+			typ, err := specDBType(table.PrimaryKeyType)
+			if err != nil {
+				return fmt.Errorf("table %s: primary key: %s", table.Table, err)
+			}
+			table.PrimaryKeyType = typ
+		}
+	}
+
+	return nil
+}
+
+// Generate return the table model files and the tables.go file generated from
+// the spec in order. The custom code of the existing table model files are
+// kept.
+func (s Spec) Generate() ([]GeneratedFile, error) {
+
+	files := make([]GeneratedFile, 0, len(s.Tables)+1)
+	tables := make([]string, len(s.Tables))
+
+	for i, table := range s.Tables {
+		param := s.param(table)
+
+		existing, err := ioutil.ReadFile(param.Path())
+		if err == nil {
+			param.CustomCode = extractCustomCode(string(existing))
+		}
+
+		content, err := GenerateContent(param)
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %s", table.Table, err)
+		}
+
+		files = append(files, GeneratedFile{Path: param.Path(), Content: content})
+		tables[i] = table.Table
+	}
+
+	content, err := GenerateTablesContent(s.Output, s.Package, tables, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(files, GeneratedFile{Path: TablesPath(s.Output), Content: content}), nil
+}
+
+func (s Spec) param(table TableSpec) *Param {
+
+	output := s.Output
+	if len(output) > 1 && output[len(output)-1] == '/' {
+		output = output[:len(output)-1]
+	}
+
+	dbTable := table.Table
+	if s.Schema != "" {
+		dbTable = s.Schema + "." + table.Table
+	}
+
+	title := strings.Title(camelcase(table.Table))
+
+	param := &Param{
+		Connection:       s.Connection,
+		Driver:           s.Driver,
+		Package:          s.Package,
+		Table:            fixedTable(camelcase(table.Table)),
+		TableTitle:       title,
+		TableName:        dbTable,
+		RowTable:         table.Table,
+		HideFilterArea:   table.HideFilterArea,
+		HideNewButton:    table.HideNewButton,
+		HideExportButton: table.HideExportButton,
+		HideEditButton:   table.HideEditButton,
+		HideDeleteButton: table.HideDeleteButton,
+		HideDetailButton: table.HideDetailButton,
+		HideFilterButton: table.HideFilterButton,
+		HideRowSelector:  table.HideRowSelector,
+		HidePagination:   table.HidePagination,
+		HideQueryInfo:    table.HideQueryInfo,
+		TablePageTitle:   escape(utils.SetDefault(table.Title, "", title)),
+		TableDescription: escape(utils.SetDefault(table.Description, "", utils.SetDefault(table.Title, "", title))),
+		FormTitle:        escape(utils.SetDefault(table.Title, "", title)),
+		FormDescription:  escape(utils.SetDefault(table.Description, "", utils.SetDefault(table.Title, "", title))),
+		PrimaryKey:       table.PrimaryKey,
+		PrimaryKeyType:   table.PrimaryKeyType,
+		Output:           output,
+		Header:           "// This file is generated by GoAdmin CLI adm from the spec, only the code between the custom code comments is kept when regenerating.",
+		KeepCustomCode:   true,
+		Fields:           make(Fields, 0, len(table.Fields)),
+		FormFields:       make(Fields, 0, len(table.Fields)),
+	}
+
+	if len(table.Imports) > 0 {
+		imports := make([]string, len(table.Imports))
+		for i, item := range table.Imports {
+			imports[i] = strconv.Quote(item)
+		}
+		sort.Strings(imports)
+		param.ExtraImport = strings.Join(imports, "\n")
+	}
+
+	for _, item := range table.Fields {
+		field := Field{
+			Head:         escape(utils.SetDefault(item.Label, "", strings.Title(item.Name))),
+			Name:         escape(item.Name),
+			DBType:       item.Type,
+			FormType:     item.FormType,
+			Filterable:   item.Filterable,
+			Sortable:     item.Sortable,
+			InfoEditable: item.Editable,
+			Hide:         item.Hide,
+			FormHide:     item.FormHide,
+			EditHide:     item.EditHide,
+			CreateHide:   item.CreateHide,
+			CanAdd:       !item.DisableWhenCreate,
+			Editable:     !item.DisableWhenUpdate,
+			IsPrimaryKey: item.Name == table.PrimaryKey,
+		}
+
+		if item.Default != "" {
+			field.Default = strconv.Quote(item.Default)
+		}
+
+		if item.FilterFormType != "" || item.FilterOperator != "" {
+			filterType := make([]string, 0, 2)
+			if item.FilterFormType != "" {
+				filterType = append(filterType, "FormType: form."+item.FilterFormType)
+			}
+			if item.FilterOperator != "" {
+				filterType = append(filterType, "Operator: types."+filterOperators[item.FilterOperator])
+			}
+			field.FilterType = "types.FilterType{" + strings.Join(filterType, ", ") + "}"
+			param.ImportTypes = true
+		}
+
+		if item.Join != nil {
+			field.Join = fmt.Sprintf("types.Join{Table: %s, Field: %s, JoinField: %s}",
+				strconv.Quote(item.Join.Table), strconv.Quote(item.Join.Field), strconv.Quote(item.Join.JoinField))
+			param.ImportTypes = true
+		}
+
+		param.Fields = append(param.Fields, field)
+		if item.Join == nil && !item.OmitForm {
+			param.FormFields = append(param.FormFields, field)
+		}
+	}
+
+	return param
+}
+
+// extractCustomCode return the code between the custom code comments.
+func extractCustomCode(content string) string {
+	begin := strings.Index(content, customCodeBegin)
+	if begin == -1 {
+		return ""
+	}
+	begin += len(customCodeBegin)
+	end := strings.Index(content[begin:], customCodeEnd)
+	if end == -1 {
+		return ""
+	}
+	return strings.Trim(content[begin:begin+end], " \t\n")
+}
+
+// specDBTypes is the names of the constants of the database types, which
+// are written into the generated code.
+var specDBTypes = map[db.DatabaseType]string{
+	db.Int:                "Int",
+	db.Tinyint:            "Tinyint",
+	db.Mediumint:          "Mediumint",
+	db.Smallint:           "Smallint",
+	db.Bigint:             "Bigint",
+	db.Bit:                "Bit",
+	db.Int8:               "Int8",
+	db.Int4:               "Int4",
+	db.Int2:               "Int2",
+	db.Integer:            "Integer",
+	db.Numeric:            "Numeric",
+	db.Smallserial:        "Smallserial",
+	db.Serial:             "Serial",
+	db.Bigserial:          "Bigserial",
+	db.Money:              "Money",
+	db.Real:               "Real",
+	db.Float:              "Float",
+	db.Float4:             "Float4",
+	db.Float8:             "Float8",
+	db.Double:             "Double",
+	db.Decimal:            "Decimal",
+	db.Doubleprecision:    "Doubleprecision",
+	db.Date:               "Date",
+	db.Time:               "Time",
+	db.Year:               "Year",
+	db.Datetime:           "Datetime",
+	db.Timestamp:          "Timestamp",
+	db.Text:               "Text",
+	db.Longtext:           "Longtext",
+	db.Mediumtext:         "Mediumtext",
+	db.Tinytext:           "Tinytext",
+	db.Varchar:            "Varchar",
+	db.Char:               "Char",
+	db.Bpchar:             "Bpchar",
+	db.JSON:               "JSON",
+	db.Blob:               "Blob",
+	db.Tinyblob:           "Tinyblob",
+	db.Mediumblob:         "Mediumblob",
+	db.Longblob:           "Longblob",
+	db.Interval:           "Interval",
+	db.Boolean:            "Boolean",
+	db.Bool:               "Bool",
+	db.Point:              "Point",
+	db.Line:               "Line",
+	db.Lseg:               "Lseg",
+	db.Box:                "Box",
+	db.Path:               "Path",
+	db.Polygon:            "Polygon",
+	db.Circle:             "Circle",
+	db.Cidr:               "Cidr",
+	db.Inet:               "Inet",
+	db.Macaddr:            "Macaddr",
+	db.Character:          "Character",
+	db.Varyingcharacter:   "Varyingcharacter",
+	db.Nchar:              "Nchar",
+	db.Nativecharacter:    "Nativecharacter",
+	db.Nvarchar:           "Nvarchar",
+	db.Clob:               "Clob",
+	db.Binary:             "Binary",
+	db.Varbinary:          "Varbinary",
+	db.Enum:               "Enum",
+	db.Set:                "Set",
+	db.Geometry:           "Geometry",
+	db.Multilinestring:    "Multilinestring",
+	db.Multipolygon:       "Multipolygon",
+	db.Linestring:         "Linestring",
+	db.Multipoint:         "Multipoint",
+	db.Geometrycollection: "Geometrycollection",
+	db.Name:               "Name",
+	db.UUID:               "UUID",
+	db.Timestamptz:        "Timestamptz",
+	db.Timetz:             "Timetz",
+}
+
+// specDBType return the name of the constant of the database type.
+func specDBType(typ string) (string, error) {
+	if typ == "" {
+		return "", errors.New("the type can not be empty")
+	}
+	name, ok := specDBTypes[db.DatabaseType(strings.ToUpper(typ))]
+	if !ok {
+		return "", errors.New("wrong type " + typ)
+	}
+	return name, nil
+}
+
+func isFormTypeName(name string) bool {
+	for _, t := range form.AllType {
+		if t.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func escape(s string) string {
+	s = strconv.Quote(s)
+	return s[1 : len(s)-1]
+}
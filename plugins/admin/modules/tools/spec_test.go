@@ -0,0 +1,125 @@
+package tools
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSpec = `
+package: tables
+driver: mysql
+tables:
+  - table: orders
+    title: Orders
+    primary_key: id
+    hide_filter_area: true
+    imports:
+      - strings
+    fields:
+      - name: id
+        type: int
+        sortable: true
+        filterable: true
+        disable_when_create: true
+        disable_when_update: true
+      - name: title
+        label: Order "Title"
+        type: varchar
+        filterable: true
+        filter_operator: like
+      - name: status
+        type: tinyint
+        form_type: SelectSingle
+        filterable: true
+        filter_form_type: SelectSingle
+        default: "1"
+      - name: name
+        label: User
+        type: varchar
+        join:
+          table: users
+          field: user_id
+          join_field: id
+      - name: secret
+        type: varchar
+        hide: true
+        form_hide: true
+      - name: created_at
+        type: timestamp
+        omit_form: true
+`
+
+func TestSpecGenerate(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "spec.yml")
+	assert.NoError(t, ioutil.WriteFile(specPath, []byte(testSpec), 0644))
+
+	spec, err := LoadSpec(specPath)
+	assert.NoError(t, err)
+	spec.Output = dir
+
+	files, err := spec.Generate()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(files))
+	assert.Equal(t, filepath.Join(dir, "orders.go"), files[0].Path)
+	assert.Equal(t, filepath.Join(dir, "tables.go"), files[1].Path)
+
+	content := string(files[0].Content)
+	assert.Contains(t, content, `SetPrimaryKey("id", db.Int)`)
+	assert.Contains(t, content, `info.AddField("Order \"Title\"", "title", db.Varchar).
+		FieldFilterable(types.FilterType{Operator: types.FilterOperatorLike})`)
+	assert.Contains(t, content, `FieldFilterable(types.FilterType{FormType: form.SelectSingle})`)
+	assert.Contains(t, content, `FieldJoin(types.Join{Table: "users", Field: "user_id", JoinField: "id"})`)
+	assert.Contains(t, content, `formList.AddField("Status", "status", db.Tinyint, form.SelectSingle).
+		FieldDefault("1")`)
+	assert.NotContains(t, content, `formList.AddField("User"`)
+	assert.NotContains(t, content, `formList.AddField("Created_at"`)
+	assert.Contains(t, content, `"strings"`)
+
+	again, err := spec.Generate()
+	assert.NoError(t, err)
+	assert.Equal(t, files, again)
+
+	custom := strings.Replace(content, customCodeBegin, customCodeBegin+"\n\tinfo.SetSortField(strings.ToLower(\"ID\"))", 1)
+	assert.NoError(t, ioutil.WriteFile(files[0].Path, []byte(custom), 0644))
+
+	files, err = spec.Generate()
+	assert.NoError(t, err)
+	assert.Equal(t, custom, string(files[0].Content))
+}
+
+func TestSpecValidate(t *testing.T) {
+	spec := Spec{Driver: "mysql", Tables: []TableSpec{{Table: "orders", Fields: []FieldSpec{{Name: "id", Type: "integer2"}}}}}
+	assert.Error(t, spec.Validate())
+
+	spec.Tables[0].Fields[0].Type = "int"
+	spec.Tables[0].Fields[0].FormType = "Texts"
+	assert.Error(t, spec.Validate())
+
+	spec.Tables[0].Fields[0].FormType = ""
+	spec.Tables[0].Fields[0].FilterOperator = "~"
+	assert.Error(t, spec.Validate())
+
+	spec.Tables[0].Fields[0].FilterOperator = ""
+	assert.NoError(t, spec.Validate())
+	assert.Equal(t, "Int", spec.Tables[0].Fields[0].Type)
+	assert.Equal(t, "main", spec.Package)
+
+	spec.Tables = append(spec.Tables, spec.Tables[0])
+	assert.Error(t, spec.Validate())
+}
+
+func TestSpecDBType(t *testing.T) {
+	for typ, name := range map[string]string{"json": "JSON", "uuid": "UUID", "varchar": "Varchar",
+		"Timestamptz": "Timestamptz", "DOUBLEPRECISION": "Doubleprecision"} {
+		got, err := specDBType(typ)
+		assert.NoError(t, err)
+		assert.Equal(t, name, got)
+	}
+	_, err := specDBType("jsonb")
+	assert.Error(t, err)
+}
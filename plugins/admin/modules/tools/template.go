@@ -1,6 +1,9 @@
 package tools
 
 const tableModelTmpl = `{{define "table_model"}}
+{{- if ne .Header ""}}
+{{.Header}}
+{{end}}
 package {{.Package}}
 
 import (
@@ -9,6 +12,9 @@ import (
 	"github.com/GoAdminGroup/go-admin/modules/db"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/table"
 	"github.com/GoAdminGroup/go-admin/template/types/form"
+	{{- if .ImportTypes}}
+	"github.com/GoAdminGroup/go-admin/template/types"
+	{{- end}}
 )
 
 func Get{{.TableTitle}}Table(ctx *context.Context) table.Table {
@@ -42,7 +48,8 @@ func Get{{.TableTitle}}Table(ctx *context.Context) table.Table {
 
 	{{- range $key, $field := .Fields}}
 	info.AddField("{{$field.Head}}", "{{$field.Name}}", db.{{$field.DBType}}){{if $field.Filterable}}.
-		FieldFilterable(){{end -}}{{if $field.Sortable}}.
+		FieldFilterable({{$field.FilterType}}){{end -}}{{if ne $field.Join ""}}.
+		FieldJoin({{$field.Join}}){{end -}}{{if $field.Sortable}}.
 		FieldSortable(){{end -}}{{if $field.InfoEditable}}.
 		FieldEditAble(){{end -}}{{if $field.Hide}}.
 		FieldHide(){{end -}}
@@ -86,6 +93,14 @@ func Get{{.TableTitle}}Table(ctx *context.Context) table.Table {
 
 	{{.ExtraCode}}
 
+	{{if .KeepCustomCode}}
+	// custom code begin
+{{- if ne .CustomCode ""}}
+{{.CustomCode}}
+{{- end}}
+	// custom code end
+	{{end}}
+
 	return {{.Table}}
 }
 {{end}}`
@@ -0,0 +1,105 @@
+package webhook
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var (
+	// ErrScheme is returned when the url of a webhook is not a http or https
+	// one.
+	ErrScheme = errors.New("the url of the webhook should be a http or https one")
+
+	// ErrAddress is returned when the url of a webhook is a local or private
+	// address, which would let the webhooks reach the internal services.
+	ErrAddress = errors.New("the address of the webhook is local or private")
+)
+
+// blockedNets are the networks refused besides the loopback, private, link
+// local and multicast ones, such as the shared address space where some
+// clouds serve their metadata.
+var blockedNets = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),
+	mustParseCIDR("100.64.0.0/10"),
+	mustParseCIDR("192.0.0.0/24"),
+	mustParseCIDR("198.18.0.0/15"),
+}
+
+func mustParseCIDR(s string) *net.IPNet {
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// allowedIP return false for the loopback, private, link local (such as the
+// metadata address 169.254.169.254), unspecified and multicast addresses.
+func allowedIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+	for _, n := range blockedNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckURL check the url of a webhook, which should be a http or https one
+// and not be a local or private address. The host names are checked again
+// by the addresses they are resolved to when the deliveries are posted.
+func CheckURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrScheme
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrAddress
+	}
+	if ip := net.ParseIP(host); ip != nil && !allowedIP(ip) {
+		return ErrAddress
+	}
+	return nil
+}
+
+// dialControl refuse the connections to the addresses which are not allowed,
+// it is called with the resolved address, so a host name resolved to a
+// private address is refused as well.
+func dialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !allowedIP(ip) {
+		return ErrAddress
+	}
+	return nil
+}
+
+// newClient return the client of the deliveries, which connects to the
+// allowed addresses only, without a proxy, and does not follow the
+// redirects, so a redirect is a failed delivery.
+func newClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: dialControl}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -0,0 +1,321 @@
+package webhook
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/modules/db/dialect"
+	"github.com/GoAdminGroup/go-admin/modules/logger"
+)
+
+// errLeased is returned when the delivery is claimed by another dispatcher.
+var errLeased = errors.New("the delivery is claimed by another dispatcher")
+
+// Dispatcher posts the pending deliveries of the outbox. A delivery is
+// claimed by increasing its attempts before it is posted, and is hidden from
+// the other dispatchers for the lease, so that the dispatchers of the
+// instances of an application can share an outbox. The deliveries are at
+// least once, the receivers can tell the redeliveries by the header
+// X-GoAdmin-Delivery.
+type Dispatcher struct {
+	conn db.Connection
+
+	// Client posts the deliveries. The default one refuses the local and
+	// private addresses and does not follow the redirects, replace it to
+	// deliver to the internal receivers.
+	Client *http.Client
+
+	// Interval is the interval of the polls of the outbox.
+	Interval time.Duration
+
+	// Backoff is the delay of the first retry, which is doubled by every
+	// retry up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	// MaxAttempts is the number of the attempts after which a delivery is
+	// failed.
+	MaxAttempts int64
+
+	// BatchSize is the max number of the deliveries of a poll.
+	BatchSize int
+
+	// Lease should be longer than the timeout of the client.
+	Lease time.Duration
+
+	once     sync.Once
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewDispatcher return a dispatcher of the outbox of the connection with the
+// default settings.
+func NewDispatcher(conn db.Connection) *Dispatcher {
+	return &Dispatcher{
+		conn:        conn,
+		Client:      newClient(10 * time.Second),
+		Interval:    5 * time.Second,
+		Backoff:     30 * time.Second,
+		MaxBackoff:  6 * time.Hour,
+		MaxAttempts: 10,
+		BatchSize:   20,
+		Lease:       time.Minute,
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+var (
+	defaultDispatcher *Dispatcher
+	defaultLock       sync.Mutex
+)
+
+// Start start the default dispatcher of the connection once, which is not
+// started without a connection.
+func Start(conn db.Connection) *Dispatcher {
+	defaultLock.Lock()
+	defer defaultLock.Unlock()
+	if defaultDispatcher == nil && conn != nil {
+		defaultDispatcher = NewDispatcher(conn)
+		defaultDispatcher.Start()
+	}
+	return defaultDispatcher
+}
+
+// Stop stop the default dispatcher and wait for its poll in progress, the
+// default dispatcher can be started again after it.
+func Stop() {
+	defaultLock.Lock()
+	defer defaultLock.Unlock()
+	if defaultDispatcher != nil {
+		defaultDispatcher.Stop()
+		defaultDispatcher = nil
+	}
+}
+
+// Start poll the outbox in a goroutine until it is stopped.
+func (d *Dispatcher) Start() {
+	d.once.Do(func() {
+		go d.run()
+	})
+}
+
+// Stop stop the polls of the outbox and wait for the poll in progress if it
+// is started. A stopped dispatcher can not be started again.
+func (d *Dispatcher) Stop() {
+	d.stopOnce.Do(func() {
+		close(d.stop)
+	})
+	started := true
+	d.once.Do(func() {
+		started = false
+	})
+	if started {
+		<-d.done
+	}
+}
+
+func (d *Dispatcher) run() {
+	defer close(d.done)
+
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+
+	// the same error such as the missing table is logged once.
+	lastErr := ""
+
+	for {
+		select {
+		case <-d.stop:
+			return
+		case <-ticker.C:
+			_, err := d.Dispatch()
+			if err == nil {
+				lastErr = ""
+			} else if err.Error() != lastErr {
+				lastErr = err.Error()
+				logger.Error("webhook dispatch error: ", err)
+			}
+		}
+	}
+}
+
+// Dispatch post the due deliveries of the outbox, and return the number of
+// the posted ones.
+func (d *Dispatcher) Dispatch() (int, error) {
+
+	now := time.Now()
+
+	rows, err := db.WithDriver(d.conn).Table(DeliveryTable).
+		Select("id", "webhook_id", "event", "payload", "attempts").
+		Where("status", "=", StatusPending).
+		Where("next_attempt_at", "<=", formatTime(now)).
+		OrderBy("id", "asc").
+		Take(d.BatchSize).
+		All()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, row := range rows {
+		err := d.deliver(row)
+		if err == errLeased {
+			continue
+		}
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// deliver claim the delivery, post it and then record the result.
+func (d *Dispatcher) deliver(row map[string]interface{}) error {
+
+	attempts := toInt(row["attempts"]) + 1
+
+	_, err := db.WithDriver(d.conn).Table(DeliveryTable).
+		Where("id", "=", row["id"]).
+		Where("status", "=", StatusPending).
+		Where("attempts", "=", attempts-1).
+		UpdateRaw("attempts = attempts + 1").
+		Update(dialect.H{
+			"next_attempt_at": formatTime(time.Now().Add(d.Lease)),
+		})
+	if err == db.ErrNoAffectRow {
+		return errLeased
+	}
+	if db.CheckError(err, db.UPDATE) {
+		return err
+	}
+
+	code, err := d.post(row)
+
+	values := dialect.H{
+		"response_code": code,
+		"updated_at":    formatTime(time.Now()),
+	}
+
+	switch {
+	case err == nil:
+		values["status"] = StatusSuccess
+		values["error"] = ""
+	case attempts >= d.MaxAttempts:
+		values["status"] = StatusFailed
+		values["error"] = truncate(err.Error(), 255)
+	default:
+		values["error"] = truncate(err.Error(), 255)
+		values["next_attempt_at"] = formatTime(time.Now().Add(d.backoff(attempts)))
+	}
+
+	_, err = db.WithDriver(d.conn).Table(DeliveryTable).
+		Where("id", "=", row["id"]).
+		Update(values)
+	if db.CheckError(err, db.UPDATE) {
+		return err
+	}
+	return nil
+}
+
+// post the payload of the delivery to the url of the webhook, and return the
+// status code of the response.
+func (d *Dispatcher) post(row map[string]interface{}) (int, error) {
+
+	hook, err := db.WithDriver(d.conn).Table(WebhookTable).
+		Where("id", "=", row["webhook_id"]).
+		First()
+	if err != nil || toInt(hook["enabled"]) != 1 {
+		return 0, errors.New("the webhook is removed or disabled")
+	}
+
+	body := []byte(toString(row["payload"]))
+
+	req, err := http.NewRequest(http.MethodPost, toString(hook["url"]), bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "GoAdmin-Webhook")
+	req.Header.Set(HeaderEvent, toString(row["event"]))
+	req.Header.Set(HeaderDelivery, toString(row["id"]))
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	if secret := toString(hook["secret"]); secret != "" {
+		req.Header.Set(HeaderSignature, Sign(secret, timestamp, body))
+	}
+
+	res, err := d.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+	return res.StatusCode, nil
+}
+
+// backoff return the delay of the retry after the attempts.
+func (d *Dispatcher) backoff(attempts int64) time.Duration {
+	delay := d.Backoff
+	for i := int64(1); i < attempts && delay < d.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.MaxBackoff {
+		delay = d.MaxBackoff
+	}
+	return delay
+}
+
+func toInt(v interface{}) int64 {
+	switch value := v.(type) {
+	case int64:
+		return value
+	case int:
+		return int64(value)
+	case []byte:
+		var n int64
+		_, _ = fmt.Sscan(string(value), &n)
+		return n
+	case string:
+		var n int64
+		_, _ = fmt.Sscan(value, &n)
+		return n
+	default:
+		return 0
+	}
+}
+
+func toString(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case []byte:
+		return string(value)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
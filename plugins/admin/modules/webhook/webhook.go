@@ -0,0 +1,173 @@
+// Package webhook notifies the other systems of the events of the records
+// by the webhooks. The deliveries are queued in the outbox table in the
+// database, and then posted with the signatures of the payloads by the
+// dispatcher, which retries the failed ones with a backoff.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/modules/db/dialect"
+)
+
+// The events of the records. The events of the custom actions are named by
+// the ids of the actions.
+const (
+	Created  = "created"
+	Updated  = "updated"
+	Deleted  = "deleted"
+	Restored = "restored"
+)
+
+// Any matches all the prefixes or the events of a webhook.
+const Any = "*"
+
+// The statuses of the deliveries.
+const (
+	StatusPending = "pending"
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+)
+
+// The headers of the requests of the deliveries.
+const (
+	HeaderEvent     = "X-GoAdmin-Event"
+	HeaderDelivery  = "X-GoAdmin-Delivery"
+	HeaderSignature = "X-GoAdmin-Signature"
+	HeaderTimestamp = "X-GoAdmin-Timestamp"
+)
+
+const (
+	WebhookTable  = "goadmin_webhooks"
+	DeliveryTable = "goadmin_webhook_deliveries"
+)
+
+// Events are the events of the records.
+var Events = []string{Created, Updated, Deleted, Restored}
+
+// Payload is the body of the requests of the deliveries.
+type Payload struct {
+	Event     string      `json:"event"`
+	Prefix    string      `json:"prefix"`
+	Data      interface{} `json:"data"`
+	CreatedAt string      `json:"created_at"`
+}
+
+// Enqueue queue the deliveries of the event to the enabled webhooks of the
+// prefix and the event, which are posted by the dispatcher later. It returns
+// the number of the deliveries.
+func Enqueue(conn db.Connection, prefix, event string, data interface{}) (int, error) {
+	return EnqueueTx(conn, nil, prefix, event, data)
+}
+
+// EnqueueTx is Enqueue within tx, the transaction of the change, so that the
+// deliveries are queued only if the change is committed, and the change is
+// rolled back if they fail.
+func EnqueueTx(conn db.Connection, tx *sql.Tx, prefix, event string, data interface{}) (int, error) {
+
+	hooks, err := db.WithDriver(conn).WithTx(tx).Table(WebhookTable).
+		Select("id").
+		Where("enabled", "=", 1).
+		WhereIn("prefix", []interface{}{prefix, Any}).
+		WhereIn("event", []interface{}{event, Any}).
+		All()
+	if err != nil || len(hooks) == 0 {
+		return 0, err
+	}
+
+	now := formatTime(time.Now())
+
+	payload, err := json.Marshal(Payload{
+		Event:     event,
+		Prefix:    prefix,
+		Data:      data,
+		CreatedAt: now,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for i, hook := range hooks {
+		_, err := db.WithDriver(conn).WithTx(tx).Table(DeliveryTable).Insert(dialect.H{
+			"webhook_id":      hook["id"],
+			"prefix":          prefix,
+			"event":           event,
+			"payload":         string(payload),
+			"status":          StatusPending,
+			"attempts":        0,
+			"next_attempt_at": now,
+		})
+		if db.CheckError(err, db.INSERT) {
+			return i, err
+		}
+	}
+
+	return len(hooks), nil
+}
+
+// Retry queue the failed deliveries again.
+func Retry(conn db.Connection, ids ...interface{}) error {
+	_, err := db.WithDriver(conn).Table(DeliveryTable).
+		WhereIn("id", ids).
+		Where("status", "=", StatusFailed).
+		Update(dialect.H{
+			"status":          StatusPending,
+			"attempts":        0,
+			"next_attempt_at": formatTime(time.Now()),
+			"error":           "",
+		})
+	if db.CheckError(err, db.UPDATE) {
+		return err
+	}
+	return nil
+}
+
+// GenerateSecret return a random secret of a webhook.
+func GenerateSecret() string {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// Sign return the signature of the body and the timestamp with the secret,
+// which is the hex of the hmac sha256 of the timestamp, a dot and the body,
+// prefixed with sha256=. The signature is in the header X-GoAdmin-Signature
+// of the requests, and the timestamp, the unix seconds of the attempt, is in
+// the header X-GoAdmin-Timestamp.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify check the signature of the body and the timestamp, which is used by
+// the receivers. The requests whose timestamps are older or newer than the
+// tolerance are refused, so that a captured request can not be replayed
+// later, and a tolerance of zero checks the signature only.
+func Verify(secret, timestamp string, body []byte, signature string, tolerance time.Duration) bool {
+	if tolerance > 0 {
+		seconds, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return false
+		}
+		if age := time.Since(time.Unix(seconds, 0)); age > tolerance || age < -tolerance {
+			return false
+		}
+	}
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+func formatTime(t time.Time) string {
+	return t.Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,321 @@
+package webhook
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/data"
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/modules/db/dialect"
+	_ "github.com/GoAdminGroup/go-admin/modules/db/drivers/sqlite"
+	"github.com/GoAdminGroup/go-admin/modules/migration"
+	"github.com/stretchr/testify/assert"
+)
+
+func testConn(t *testing.T) db.Connection {
+	conn := db.GetConnectionByDriver(db.DriverSqlite).InitDB(map[string]config.Database{
+		"default": {Driver: db.DriverSqlite, File: filepath.Join(t.TempDir(), "admin.db")},
+	})
+	content, err := fs.ReadFile(data.Migrations, "migrations/admin_2026_10_18_190000_sqlite.sql")
+	assert.Nil(t, err)
+	for _, statement := range migration.Statements(db.DriverSqlite, string(content)) {
+		_, err := conn.Exec(statement)
+		assert.Nil(t, err)
+	}
+	return conn
+}
+
+func addWebhook(t *testing.T, conn db.Connection, prefix, event, url string, enabled int) {
+	_, err := db.WithDriver(conn).Table(WebhookTable).Insert(dialect.H{
+		"prefix": prefix, "event": event, "url": url, "secret": "secret", "enabled": enabled,
+	})
+	assert.Nil(t, err)
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"event":"created"}`)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	signature := Sign("secret", now, body)
+	assert.Equal(t, "sha256=", signature[:7])
+	assert.Equal(t, 71, len(signature))
+	assert.True(t, Verify("secret", now, body, signature, time.Minute))
+	assert.False(t, Verify("other", now, body, signature, time.Minute))
+	assert.False(t, Verify("secret", now, []byte(`{}`), signature, time.Minute))
+	assert.False(t, Verify("secret", "1", body, signature, time.Minute))
+
+	// a replayed request is refused by its old timestamp.
+	old := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	signature = Sign("secret", old, body)
+	assert.False(t, Verify("secret", old, body, signature, time.Minute))
+	assert.True(t, Verify("secret", old, body, signature, 0))
+
+	assert.Equal(t, 40, len(GenerateSecret()))
+}
+
+func TestCheckURL(t *testing.T) {
+	assert.Nil(t, CheckURL("https://example.com/hook"))
+	assert.Nil(t, CheckURL("http://8.8.8.8:8080/hook"))
+	assert.Equal(t, ErrScheme, CheckURL("ftp://example.com"))
+	assert.Equal(t, ErrScheme, CheckURL("http://"))
+	for _, u := range []string{
+		"http://localhost/hook",
+		"http://127.0.0.1:8080",
+		"http://[::1]/",
+		"http://10.0.0.1",
+		"http://192.168.1.1",
+		"http://169.254.169.254/latest/meta-data",
+		"http://100.100.100.200",
+		"http://[fd00:ec2::254]",
+		"http://0.0.0.0",
+		"http://[::ffff:127.0.0.1]",
+	} {
+		assert.Equal(t, ErrAddress, CheckURL(u), u)
+	}
+}
+
+func TestEnqueueTx(t *testing.T) {
+	conn := testConn(t)
+
+	addWebhook(t, conn, "posts", Any, "http://127.0.0.1/a", 1)
+
+	// the deliveries are rolled back with the change.
+	_, err := db.WithDriver(conn).WithTransaction(func(tx *sql.Tx) (error, map[string]interface{}) {
+		count, err := EnqueueTx(conn, tx, "posts", Created, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, count)
+		return errors.New("rollback"), nil
+	})
+	assert.NotNil(t, err)
+
+	count, err := db.WithDriver(conn).Table(DeliveryTable).Count()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestEnqueue(t *testing.T) {
+	conn := testConn(t)
+
+	addWebhook(t, conn, "posts", Created, "http://127.0.0.1/a", 1)
+	addWebhook(t, conn, "posts", Any, "http://127.0.0.1/b", 1)
+	addWebhook(t, conn, Any, Deleted, "http://127.0.0.1/c", 1)
+	addWebhook(t, conn, "posts", Any, "http://127.0.0.1/d", 0)
+
+	count, err := Enqueue(conn, "posts", Created, map[string]string{"id": "1"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = Enqueue(conn, "users", Deleted, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	count, err = Enqueue(conn, "users", Updated, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+
+	row, err := db.WithDriver(conn).Table(DeliveryTable).OrderBy("id", "asc").First()
+	assert.Nil(t, err)
+	assert.Equal(t, StatusPending, row["status"])
+
+	var payload Payload
+	assert.Nil(t, json.Unmarshal([]byte(row["payload"].(string)), &payload))
+	assert.Equal(t, Created, payload.Event)
+	assert.Equal(t, "posts", payload.Prefix)
+	assert.Equal(t, map[string]interface{}{"id": "1"}, payload.Data)
+}
+
+func TestDispatch(t *testing.T) {
+	conn := testConn(t)
+
+	var failures int32 = 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !Verify("secret", r.Header.Get(HeaderTimestamp), body, r.Header.Get(HeaderSignature), time.Minute) || r.Header.Get(HeaderEvent) != Updated ||
+			r.Header.Get(HeaderDelivery) == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if atomic.AddInt32(&failures, -1) >= 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	addWebhook(t, conn, "posts", Any, server.URL, 1)
+
+	d := NewDispatcher(conn)
+	d.Client = server.Client()
+	d.Backoff = 0
+	d.MaxAttempts = 3
+
+	_, err := Enqueue(conn, "posts", Updated, nil)
+	assert.Nil(t, err)
+
+	// the first attempt fails and is retried.
+	count, err := d.Dispatch()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	row, err := db.WithDriver(conn).Table(DeliveryTable).First()
+	assert.Nil(t, err)
+	assert.Equal(t, StatusPending, row["status"])
+	assert.Equal(t, int64(1), row["attempts"])
+	assert.Equal(t, int64(500), row["response_code"])
+
+	count, err = d.Dispatch()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	row, err = db.WithDriver(conn).Table(DeliveryTable).First()
+	assert.Nil(t, err)
+	assert.Equal(t, StatusSuccess, row["status"])
+	assert.Equal(t, int64(2), row["attempts"])
+	assert.Equal(t, int64(204), row["response_code"])
+
+	count, err = d.Dispatch()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestDispatchFailed(t *testing.T) {
+	conn := testConn(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	addWebhook(t, conn, "posts", Any, server.URL, 1)
+
+	d := NewDispatcher(conn)
+	d.Client = server.Client()
+	d.Backoff = 0
+	d.MaxAttempts = 2
+
+	_, err := Enqueue(conn, "posts", Deleted, nil)
+	assert.Nil(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = d.Dispatch()
+		assert.Nil(t, err)
+	}
+
+	row, err := db.WithDriver(conn).Table(DeliveryTable).First()
+	assert.Nil(t, err)
+	assert.Equal(t, StatusFailed, row["status"])
+	assert.Equal(t, int64(2), row["attempts"])
+	assert.Equal(t, "unexpected status code 502", row["error"])
+
+	assert.Nil(t, Retry(conn, row["id"]))
+
+	row, err = db.WithDriver(conn).Table(DeliveryTable).First()
+	assert.Nil(t, err)
+	assert.Equal(t, StatusPending, row["status"])
+	assert.Equal(t, int64(0), row["attempts"])
+}
+
+func TestDispatchRefused(t *testing.T) {
+	conn := testConn(t)
+
+	var posted int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&posted, 1)
+		http.Redirect(w, r, "http://169.254.169.254/", http.StatusFound)
+	}))
+	defer server.Close()
+
+	addWebhook(t, conn, "posts", Any, server.URL, 1)
+
+	_, err := Enqueue(conn, "posts", Created, nil)
+	assert.Nil(t, err)
+
+	// the default client refuses the loopback address of the server.
+	d := NewDispatcher(conn)
+	_, err = d.Dispatch()
+	assert.Nil(t, err)
+
+	row, err := db.WithDriver(conn).Table(DeliveryTable).First()
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&posted))
+	assert.Contains(t, row["error"], ErrAddress.Error())
+
+	// the redirects are not followed.
+	_, err = db.WithDriver(conn).Table(DeliveryTable).Update(dialect.H{"status": StatusFailed})
+	assert.Nil(t, err)
+	assert.Nil(t, Retry(conn, row["id"]))
+
+	d = NewDispatcher(conn)
+	d.Client.Transport = server.Client().Transport
+	_, err = d.Dispatch()
+	assert.Nil(t, err)
+
+	row, err = db.WithDriver(conn).Table(DeliveryTable).First()
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&posted))
+	assert.Equal(t, int64(302), row["response_code"])
+}
+
+func TestStop(t *testing.T) {
+	conn := testConn(t)
+
+	d := Start(conn)
+	assert.NotNil(t, d)
+	assert.Equal(t, d, Start(conn))
+
+	Stop()
+	_, ok := <-d.done
+	assert.False(t, ok)
+
+	d = Start(conn)
+	assert.NotNil(t, d)
+	Stop()
+
+	// a dispatcher which is not started can be stopped.
+	NewDispatcher(conn).Stop()
+}
+
+func TestDispatchLeased(t *testing.T) {
+	conn := testConn(t)
+
+	addWebhook(t, conn, "posts", Any, "http://127.0.0.1:1", 1)
+
+	_, err := Enqueue(conn, "posts", Created, nil)
+	assert.Nil(t, err)
+
+	row, err := db.WithDriver(conn).Table(DeliveryTable).First()
+	assert.Nil(t, err)
+
+	// claimed by another dispatcher since it is read.
+	_, err = db.WithDriver(conn).Table(DeliveryTable).UpdateRaw("attempts = attempts + 1").
+		Update(dialect.H{"next_attempt_at": formatTime(time.Now().Add(time.Minute))})
+	assert.Nil(t, err)
+
+	assert.Equal(t, errLeased, NewDispatcher(conn).deliver(row))
+
+	count, err := NewDispatcher(conn).Dispatch()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestBackoff(t *testing.T) {
+	d := NewDispatcher(nil)
+	d.Backoff = time.Second
+	d.MaxBackoff = 5 * time.Second
+	assert.Equal(t, time.Second, d.backoff(1))
+	assert.Equal(t, 2*time.Second, d.backoff(2))
+	assert.Equal(t, 4*time.Second, d.backoff(3))
+	assert.Equal(t, 5*time.Second, d.backoff(4))
+	assert.Equal(t, 5*time.Second, d.backoff(40))
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/GoAdminGroup/go-admin/modules/auth"
 	"github.com/GoAdminGroup/go-admin/modules/config"
 	"github.com/GoAdminGroup/go-admin/modules/utils"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/models"
 	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/response"
 	"github.com/GoAdminGroup/go-admin/template"
 	v1 "github.com/dypflying/chime-portal/v1"
@@ -18,12 +19,18 @@ func (admin *Admin) initRouter() *Admin {
 
 	// auth
 	route.GET(config.GetLoginUrl(), admin.handler.ShowLogin)
-	//route.POST("/signin", admin.handler.Auth)
-	route.POST("/signin", v1.Signin)
+	if config.GetAuth().Provider == auth.ProviderPortal {
+		route.POST("/signin", v1.Signin)
+	} else {
+		route.POST("/signin", admin.handler.Auth)
+		route.POST("/signin/totp", admin.handler.AuthTOTP)
+		route.GET("/signin/callback", admin.handler.AuthCallback)
+	}
 
 	// auto install
 	route.GET("/install", admin.handler.ShowInstall)
 	route.POST("/install/database/check", admin.handler.CheckDatabase)
+	route.POST("/install", admin.handler.Install)
 
 	checkRepeatedPath := make([]string, 0)
 	for _, themeName := range template.Themes() {
@@ -42,8 +49,29 @@ func (admin *Admin) initRouter() *Admin {
 	authRoute := route.Group("/", auth.Middleware(admin.Conn))
 
 	// auth
-	//authRoute.GET("/logout", admin.handler.Logout)
-	authRoute.GET("/logout", v1.Signout)
+	if config.GetAuth().Provider == auth.ProviderPortal {
+		authRoute.GET("/logout", v1.Signout)
+	} else {
+		authRoute.GET("/logout", admin.handler.Logout)
+		authRoute.POST("/logout/all", admin.handler.LogoutAll).Name("logout_all")
+	}
+	models.AllowPaths("/logout", "/logout/all")
+
+	// two-factor authentication
+	authRoute.GET("/totp", admin.handler.ShowTOTP).Name("totp")
+	authRoute.POST("/totp/enable", admin.handler.EnableTOTP).Name("totp_enable")
+	authRoute.POST("/totp/disable", admin.handler.DisableTOTP).Name("totp_disable")
+	authRoute.POST("/totp/recovery_codes", admin.handler.RegenerateRecoveryCodes).Name("totp_recovery_codes")
+	models.AllowPaths("/totp", "/totp/enable", "/totp/disable", "/totp/recovery_codes")
+
+	authRoute.GET("/search", admin.handler.Search).Name("search")
+	authRoute.GET("/dashboards", admin.handler.ShowDashboards).Name("dashboards")
+	authRoute.GET("/dashboards/:id", admin.handler.ShowDashboard).Name("dashboard")
+	authRoute.GET("/dashboard_widgets/:id/data", admin.handler.DashboardWidgetData).Name("dashboard_widget_data")
+
+	// the search checks the tables and the data of a widget checks its
+	// dashboard.
+	models.AllowPaths("/search", "/dashboard_widgets/[^/]+/data")
 
 	authPrefixRoute := route.Group("/", auth.Middleware(admin.Conn), admin.guardian.CheckPrefix)
 
@@ -76,10 +104,19 @@ func (admin *Admin) initRouter() *Admin {
 	authPrefixRoute.POST(formats.Create, admin.guardian.NewForm, admin.handler.NewForm).Name("new")
 	authPrefixRoute.POST(formats.Delete, admin.guardian.Delete, admin.handler.Delete).Name("delete")
 	authPrefixRoute.POST(formats.Export, admin.guardian.Export, admin.handler.Export).Name("export")
+	authPrefixRoute.POST(formats.Import, admin.guardian.Import, admin.handler.Import).Name("import")
+	authPrefixRoute.POST(formats.Restore, admin.guardian.Trash, admin.handler.Restore).Name("restore")
+	authPrefixRoute.POST(formats.Purge, admin.guardian.Trash, admin.handler.Purge).Name("purge")
 	authPrefixRoute.GET(formats.Info, admin.handler.ShowInfo).Name("info")
 
 	authPrefixRoute.POST(formats.Update, admin.guardian.Update, admin.handler.Update).Name("update")
 
+	// saved views of the info pages
+	authPrefixRoute.GET("/saved_views/:__prefix", admin.handler.SavedViews).Name("saved_views")
+	authPrefixRoute.POST("/saved_views/:__prefix", admin.handler.SaveView).Name("saved_view_new")
+	authPrefixRoute.POST("/saved_views/:__prefix/delete", admin.handler.DeleteSavedView).Name("saved_view_delete")
+	models.AllowPaths("/saved_views/[^/]+", "/saved_views/[^/]+/delete")
+
 	authRoute.GET("/application/info", admin.handler.SystemInfo)
 
 	route.ANY("/operation/:__goadmin_op_id", auth.Middleware(admin.Conn), admin.handler.Operation)
@@ -96,7 +133,20 @@ func (admin *Admin) initRouter() *Admin {
 		apiRoute.POST("/create/:__prefix", admin.guardian.NewForm, admin.handler.ApiCreate).Name("api_new")
 		apiRoute.GET("/create/form/:__prefix", admin.guardian.ShowNewForm, admin.handler.ApiCreateForm).Name("api_show_new")
 		apiRoute.POST("/export/:__prefix", admin.guardian.Export, admin.handler.Export).Name("api_export")
+		apiRoute.POST("/import/:__prefix", admin.guardian.Import, admin.handler.Import).Name("api_import")
+		apiRoute.POST("/restore/:__prefix", admin.guardian.Trash, admin.handler.Restore).Name("api_restore")
+		apiRoute.POST("/purge/:__prefix", admin.guardian.Trash, admin.handler.Purge).Name("api_purge")
 		apiRoute.POST("/update/:__prefix", admin.guardian.Update, admin.handler.Update).Name("api_update")
+
+		// versioned json apis of the records
+		restRoute := route.Group("/api/v1", auth.Middleware(admin.Conn), admin.guardian.Rest)
+		restRoute.GET("/:__prefix", admin.handler.RestList).Name("api_v1_list")
+		restRoute.POST("/:__prefix", admin.handler.RestCreate).Name("api_v1_create")
+		restRoute.GET("/:__prefix/:__goadmin_rest_pk", admin.handler.RestDetail).Name("api_v1_detail")
+		restRoute.PUT("/:__prefix/:__goadmin_rest_pk", admin.handler.RestUpdate).Name("api_v1_update")
+		restRoute.DELETE("/:__prefix/:__goadmin_rest_pk", admin.handler.RestDelete).Name("api_v1_delete")
+
+		route.GET("/api/openapi.json", auth.Middleware(admin.Conn), admin.handler.OpenAPI).Name("api_openapi")
 	}
 
 	admin.App = app
@@ -161,6 +161,16 @@ func (l *BarChart) SetHeight(s int) *BarChart {
 	return l
 }
 
+func (l *BarChart) SetDataURL(url string) *BarChart {
+	l.DataURL = url
+	return l
+}
+
+func (l *BarChart) SetRefreshInterval(seconds int) *BarChart {
+	l.RefreshInterval = seconds
+	return l
+}
+
 func (l *BarChart) SetLabels(s []string) *BarChart {
 	l.JsContent.Data.Labels = s
 	return l
@@ -16,6 +16,9 @@ type Chart struct {
 
 	JsContentOptions *Options
 
+	DataURL         string
+	RefreshInterval int
+
 	dataSetIndex int
 }
 
@@ -34,6 +37,20 @@ func (c *Chart) SetHeight(height int) *Chart {
 	return c
 }
 
+// SetDataURL bind the chart to the data endpoint, which returns the Data
+// of the chart. The data is loaded when the chart is rendered without labels.
+func (c *Chart) SetDataURL(url string) *Chart {
+	c.DataURL = url
+	return c
+}
+
+// SetRefreshInterval set the seconds of the interval to reload the data from
+// the data endpoint, zero means never.
+func (c *Chart) SetRefreshInterval(seconds int) *Chart {
+	c.RefreshInterval = seconds
+	return c
+}
+
 func (c *Chart) SetOptionAnimationDuration(duration int) {
 	if c.JsContentOptions == nil {
 		c.JsContentOptions = new(Options)
@@ -0,0 +1,23 @@
+package chartjs
+
+// Data is the data of a chart in the shape of chart.js, which is returned by
+// the data endpoint that the chart is bound to in the data of the response.
+type Data struct {
+	Labels   []string `json:"labels"`
+	DataSets DataSets `json:"datasets"`
+}
+
+func NewData(labels []string) *Data {
+	if labels == nil {
+		labels = make([]string, 0)
+	}
+	return &Data{Labels: labels, DataSets: make(DataSets, 0)}
+}
+
+func (d *Data) AddDataSet(label string, data []float64) *Data {
+	if data == nil {
+		data = make([]float64, 0)
+	}
+	d.DataSets = append(d.DataSets, DataSet{Label: label, Data: data})
+	return d
+}
@@ -296,6 +296,16 @@ func (l *LineChart) SetHeight(s int) *LineChart {
 	return l
 }
 
+func (l *LineChart) SetDataURL(url string) *LineChart {
+	l.DataURL = url
+	return l
+}
+
+func (l *LineChart) SetRefreshInterval(seconds int) *LineChart {
+	l.RefreshInterval = seconds
+	return l
+}
+
 func (l *LineChart) SetLabels(s []string) *LineChart {
 	l.JsContent.Data.Labels = s
 	return l
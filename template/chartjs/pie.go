@@ -143,6 +143,16 @@ func (l *PieChart) SetHeight(s int) *PieChart {
 	return l
 }
 
+func (l *PieChart) SetDataURL(url string) *PieChart {
+	l.DataURL = url
+	return l
+}
+
+func (l *PieChart) SetRefreshInterval(seconds int) *PieChart {
+	l.RefreshInterval = seconds
+	return l
+}
+
 func (l *PieChart) SetLabels(s []string) *PieChart {
 	l.JsContent.Data.Labels = s
 	return l
@@ -1 +1,226 @@
 package chartjs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+
+	template2 "github.com/GoAdminGroup/go-admin/template"
+)
+
+type RadarChart struct {
+	*Chart
+
+	JsContent RadarJsContent
+}
+
+type RadarJsContent struct {
+	JsContent
+
+	Data RadarAttributes `json:"data"`
+}
+
+type RadarAttributes struct {
+	Attributes
+
+	DataSets RadarDataSets `json:"datasets"`
+}
+
+type RadarDataSets []*RadarDataSet
+
+func (l RadarDataSets) Add(ds *RadarDataSet) RadarDataSets {
+	return append(l, ds)
+}
+
+type RadarDataSet struct {
+	Label                string    `json:"label"`
+	Data                 []float64 `json:"data"`
+	BackgroundColor      Color     `json:"backgroundColor,omitempty"`
+	BorderColor          Color     `json:"borderColor,omitempty"`
+	BorderWidth          float64   `json:"borderWidth,omitempty"`
+	Fill                 bool      `json:"fill"`
+	LineTension          float64   `json:"lineTension,omitempty"`
+	PointBackgroundColor Color     `json:"pointBackgroundColor,omitempty"`
+	PointBorderColor     Color     `json:"pointBorderColor,omitempty"`
+	PointRadius          float64   `json:"pointRadius,omitempty"`
+}
+
+func (l *RadarDataSet) SetLabel(label string) *RadarDataSet {
+	l.Label = label
+	return l
+}
+
+func (l *RadarDataSet) SetData(data []float64) *RadarDataSet {
+	l.Data = data
+	return l
+}
+
+func (l *RadarDataSet) SetBackgroundColor(backgroundColor Color) *RadarDataSet {
+	l.BackgroundColor = backgroundColor
+	return l
+}
+
+func (l *RadarDataSet) SetBorderColor(borderColor Color) *RadarDataSet {
+	l.BorderColor = borderColor
+	return l
+}
+
+func (l *RadarDataSet) SetBorderWidth(borderWidth float64) *RadarDataSet {
+	l.BorderWidth = borderWidth
+	return l
+}
+
+func (l *RadarDataSet) SetFill(fill bool) *RadarDataSet {
+	l.Fill = fill
+	return l
+}
+
+func (l *RadarDataSet) SetLineTension(lineTension float64) *RadarDataSet {
+	l.LineTension = lineTension
+	return l
+}
+
+func (l *RadarDataSet) SetPointBackgroundColor(pointBackgroundColor Color) *RadarDataSet {
+	l.PointBackgroundColor = pointBackgroundColor
+	return l
+}
+
+func (l *RadarDataSet) SetPointBorderColor(pointBorderColor Color) *RadarDataSet {
+	l.PointBorderColor = pointBorderColor
+	return l
+}
+
+func (l *RadarDataSet) SetPointRadius(pointRadius float64) *RadarDataSet {
+	l.PointRadius = pointRadius
+	return l
+}
+
+func Radar() *RadarChart {
+	return &RadarChart{
+		Chart: &Chart{
+			BaseComponent: &template2.BaseComponent{
+				Name:     "chartjs",
+				HTMLData: List["chartjs"],
+			},
+			dataSetIndex: -1,
+		},
+		JsContent: RadarJsContent{
+			JsContent: JsContent{
+				Type: "radar",
+			},
+			Data: RadarAttributes{
+				Attributes: Attributes{
+					Labels: make([]string, 0),
+				},
+				DataSets: make(RadarDataSets, 0),
+			},
+		},
+	}
+}
+
+func (l *RadarChart) SetID(s string) *RadarChart {
+	l.ID = s
+	return l
+}
+
+func (l *RadarChart) SetTitle(s template.HTML) *RadarChart {
+	l.Title = s
+	return l
+}
+
+func (l *RadarChart) SetHeight(s int) *RadarChart {
+	l.Height = s
+	return l
+}
+
+func (l *RadarChart) SetDataURL(url string) *RadarChart {
+	l.DataURL = url
+	return l
+}
+
+func (l *RadarChart) SetRefreshInterval(seconds int) *RadarChart {
+	l.RefreshInterval = seconds
+	return l
+}
+
+func (l *RadarChart) SetLabels(s []string) *RadarChart {
+	l.JsContent.Data.Labels = s
+	return l
+}
+
+func (l *RadarChart) AddDataSet(s string) *RadarChart {
+	l.dataSetIndex++
+	l.JsContent.Data.DataSets = l.JsContent.Data.DataSets.Add(&RadarDataSet{
+		Label: s,
+	})
+	return l
+}
+
+func (l *RadarChart) DSLabel(s string) *RadarChart {
+	l.JsContent.Data.DataSets[l.dataSetIndex].SetLabel(s)
+	return l
+}
+
+func (l *RadarChart) DSData(data []float64) *RadarChart {
+	l.JsContent.Data.DataSets[l.dataSetIndex].SetData(data)
+	return l
+}
+
+func (l *RadarChart) DSBackgroundColor(backgroundColor Color) *RadarChart {
+	l.JsContent.Data.DataSets[l.dataSetIndex].SetBackgroundColor(backgroundColor)
+	return l
+}
+
+func (l *RadarChart) DSBorderColor(borderColor Color) *RadarChart {
+	l.JsContent.Data.DataSets[l.dataSetIndex].SetBorderColor(borderColor)
+	return l
+}
+
+func (l *RadarChart) DSBorderWidth(borderWidth float64) *RadarChart {
+	l.JsContent.Data.DataSets[l.dataSetIndex].SetBorderWidth(borderWidth)
+	return l
+}
+
+func (l *RadarChart) DSFill(fill bool) *RadarChart {
+	l.JsContent.Data.DataSets[l.dataSetIndex].SetFill(fill)
+	return l
+}
+
+func (l *RadarChart) DSLineTension(lineTension float64) *RadarChart {
+	l.JsContent.Data.DataSets[l.dataSetIndex].SetLineTension(lineTension)
+	return l
+}
+
+func (l *RadarChart) DSPointBackgroundColor(pointBackgroundColor Color) *RadarChart {
+	l.JsContent.Data.DataSets[l.dataSetIndex].SetPointBackgroundColor(pointBackgroundColor)
+	return l
+}
+
+func (l *RadarChart) DSPointBorderColor(pointBorderColor Color) *RadarChart {
+	l.JsContent.Data.DataSets[l.dataSetIndex].SetPointBorderColor(pointBorderColor)
+	return l
+}
+
+func (l *RadarChart) DSPointRadius(pointRadius float64) *RadarChart {
+	l.JsContent.Data.DataSets[l.dataSetIndex].SetPointRadius(pointRadius)
+	return l
+}
+
+func (l *RadarChart) GetContent() template.HTML {
+	buffer := new(bytes.Buffer)
+	tmpl, defineName := l.GetTemplate()
+
+	if l.JsContentOptions != nil {
+		l.JsContent.Options = l.JsContentOptions
+	}
+
+	jsonByte, _ := json.Marshal(l.JsContent)
+	l.Js = template.JS(string(jsonByte))
+
+	err := tmpl.ExecuteTemplate(buffer, defineName, l)
+	if err != nil {
+		fmt.Println("ComposeHtml Error:", err)
+	}
+	return template.HTML(buffer.String())
+}
@@ -11,7 +11,38 @@ var List = map[string]string{
         <canvas id="{{.ID}}" style="height: {{.Height}}px;"></canvas>
     </div>
     <script>
-        new Chart(document.getElementById('{{.ID}}'), {{.Js}});
+        (function () {
+            let canvas = document.getElementById('{{.ID}}');
+            let chart = new Chart(canvas, {{.Js}});
+            {{if ne .DataURL ""}}
+            let timer = null;
+            let load = function () {
+                if (!document.body.contains(canvas)) {
+                    if (timer) clearInterval(timer);
+                    return;
+                }
+                $.get({{.DataURL}}, function (res) {
+                    let data = res.data || {};
+                    let datasets = data.datasets || [];
+                    chart.data.labels = data.labels || [];
+                    for (let i = 0; i < datasets.length; i++) {
+                        if (chart.data.datasets[i]) {
+                            chart.data.datasets[i].data = datasets[i].data || [];
+                            if (datasets[i].label) chart.data.datasets[i].label = datasets[i].label;
+                        } else {
+                            chart.data.datasets.push(datasets[i]);
+                        }
+                    }
+                    chart.data.datasets.splice(datasets.length);
+                    chart.update();
+                });
+            };
+            if (!chart.data.labels || chart.data.labels.length === 0) load();
+            {{if gt .RefreshInterval 0}}
+            timer = setInterval(load, {{.RefreshInterval}} * 1000);
+            {{end}}
+            {{end}}
+        })();
     </script>
 {{end}}`,
 }
@@ -58,7 +58,7 @@ func (i *Installation) GetTemplate() (*template.Template, string) {
 func (i *Installation) GetAssetList() []string               { return AssetsList }
 func (i *Installation) GetAsset(name string) ([]byte, error) { return Asset(name[1:]) }
 func (i *Installation) IsAPage() bool                        { return true }
-func (i *Installation) GetName() string                      { return "login" }
+func (i *Installation) GetName() string                      { return "installation" }
 
 func (i *Installation) GetContent() template.HTML {
 	buffer := new(bytes.Buffer)
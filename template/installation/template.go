@@ -1,533 +1,180 @@
 package login
 
 var List = map[string]string{"installation": `{{define "installation"}}
+    <!DOCTYPE html>
     <html>
     <head>
-        <title>GoAdmin Install</title>
         <meta charset="utf-8">
-        <meta name="viewport" content="width=device-width, initial-scale=1, user-scalable=no">
-        <link rel="stylesheet" href="../../assets/install/assets/css/main.css">
-        <link rel="stylesheet" href="../../assets/fullpage/fullpage.min.css">
-        <noscript>
-            <link rel="stylesheet" href="../../assets/install/assets/css/noscript.css"/>
-        </noscript>
-    </head>
-    <body class="">
-
-    <div id="fullpage">
-        <div class="section active" id="section1">
-            <div class="slide" id="slide1">
-                <div class="page-wrapper" style="margin-left: auto;margin-right:  auto;width: 1236px;">
-                    <!-- Wrapper -->
-                    <div class="wrapper" style="margin-left: auto;margin-right:  auto;">
-                        <section class="panel color2-alt">
-                            <div class="intro color2">
-                                <h2 class="major">数据库配置</h2>
-                                <p>目前仅支持Mysql</p>
-                            </div>
-                            <div class="inner columns aligned" style="padding: 2.5rem 3.5rem 2rem 3.5rem;">
-                                <div class="span-2-5">
-                                    <form method="post" action="#">
-                                        <div class="field" style="width: calc(73% - 0.75rem);">
-                                            <label for="demo-name">地址</label>
-                                            <input type="text" name="demo-name" id="db-host" value=""
-                                                   placeholder="127.0.0.1">
-                                        </div>
-                                        <div class="field quarter">
-                                            <label for="demo-email">端口</label>
-                                            <input type="email" name="demo-email" id="db-port" value=""
-                                                   placeholder="3306">
-                                        </div>
-                                        <div class="field">
-                                            <label for="demo-name">账号</label>
-                                            <input type="text" name="demo-name" id="db-username" value=""
-                                                   placeholder="root">
-                                        </div>
-                                        <div class="field">
-                                            <label for="demo-email">密码</label>
-                                            <input type="password" name="demo-email" id="db-password" value=""
-                                                   placeholder="root">
-                                        </div>
-                                        <div class="field">
-                                            <label for="demo-email">数据库名</label>
-                                            <input type="text" name="demo-email" id="db-database" value=""
-                                                   placeholder="database">
-                                        </div>
-                                        <div class="field" style="margin-top: 20px;">
-                                            <ul class="actions">
-                                                <li><input id="test-con-btn" type="submit" value="连接测试"
-                                                           class="special color2"></li>
-                                                <li><input class="input-next" type="reset" value="下一步"></li>
-                                            </ul>
-                                        </div>
-                                    </form>
-                                </div>
-                            </div>
-                        </section>
-                        <!-- Copyright -->
-                        <div class="copyright">Copyright © 2018.Company name All rights reserved.<a target="_blank"
-                                                                                                    href="https://github.com/chenhg5/go-admin">GoAdmin</a>
-                        </div>
-                    </div>
-
-                </div>
-            </div>
-            <div class="slide" id="slide2">
-                <div class="page-wrapper" style="margin-left: auto;margin-right:  auto;width: 1236px;">
-                    <!-- Wrapper -->
-                    <div class="wrapper" style="margin-left: auto;margin-right:  auto;">
-                        <section class="panel color2-alt">
-                            <div class="intro color2">
-                                <h2 class="major">选择管理数据表</h2>
-                                <p>选择管理数据表</p>
-                            </div>
-                            <div class="inner columns aligned" style="padding: 2.5rem 3.5rem 2rem 3.5rem;">
-                                <div class="span-2-5">
-                                    <form method="post" action="#" id="table-div">
-                                        <div class="field quarter">
-                                            <input type="checkbox" id="demo-copy" name="demo-copy" class="color2">
-                                            <label for="demo-copy">users</label>
-                                        </div>
-                                        <div class="field quarter">
-                                            <input type="checkbox" id="demo-human" name="demo-human" class="color2"
-                                                   checked="">
-                                            <label for="demo-human">ninepic</label>
-                                        </div>
-                                        <div class="field quarter">
-                                            <input type="checkbox" id="demo-copy1" name="demo-copy1" class="color2">
-                                            <label for="demo-copy1">users</label>
-                                        </div>
-                                        <div class="field quarter">
-                                            <input type="checkbox" id="demo-human1" name="demo-human1" class="color2"
-                                                   checked="">
-                                            <label for="demo-human1">ninepic</label>
-                                        </div>
-                                        <div class="field quarter">
-                                            <input type="checkbox" id="demo-copy2" name="demo-copy2" class="color2">
-                                            <label for="demo-copy2">users</label>
-                                        </div>
-                                        <div class="field quarter">
-                                            <input type="checkbox" id="demo-human2" name="demo-human2" class="color2"
-                                                   checked="">
-                                            <label for="demo-human2">ninepic</label>
-                                        </div>
-                                        <div class="field quarter">
-                                            <input type="checkbox" id="demo-copy3" name="demo-copy3" class="color2">
-                                            <label for="demo-copy3">users</label>
-                                        </div>
-                                        <div class="field quarter">
-                                            <input type="checkbox" id="demo-human3" name="demo-human3" class="color2"
-                                                   checked="">
-                                            <label for="demo-human3">ninepic</label>
-                                        </div>
-                                        <div class="field" style="margin-top: 20px;">
-                                            <ul class="actions">
-                                                <li><input class="input-prev" type="reset" value="上一步"></li>
-                                                <li><input class="input-next" type="reset" value="下一步"></li>
-                                            </ul>
-                                        </div>
-                                    </form>
-                                </div>
-                            </div>
-                        </section>
-                        <!-- Copyright -->
-                        <div class="copyright">Copyright © 2018.Company name All rights reserved.<a target="_blank"
-                                                                                                    href="https://github.com/chenhg5/go-admin">GoAdmin</a>
-                        </div>
-                    </div>
-                </div>
-            </div>
-            <div class="slide" id="slide3">
-                <div class="page-wrapper" style="margin-left: auto;margin-right:  auto;width: 1236px;">
-                    <!-- Wrapper -->
-                    <div class="wrapper" style="margin-left: auto;margin-right:  auto;">
-                        <section class="panel color2-alt">
-                            <div class="intro color2">
-                                <h2 class="major">设置字段</h2>
-                                <p>User表</p>
-                            </div>
-                            <div class="inner columns aligned"
-                                 style="padding: 2.5rem 3.5rem 2rem 3.5rem;overflow-y: scroll;">
-                                <div class="span-2-5">
-                                    <form method="post" action="#">
-                                        <div class="field" style="width: calc(38% - 0.5rem);">
-                                            <label for="demo-name">id</label>
-                                            <input type="text" name="demo-name" id="id" value="" placeholder="字段显示名称">
-                                        </div>
-                                        <div class="field third">
-                                            <label for="demo-category">编辑表单类型</label>
-                                            <div class="select-wrapper">
-                                                <select name="demo-category" id="demo-category-id">
-                                                    <option value="">-</option>
-                                                    <option value="1">显示</option>
-                                                    <option value="1">Text</option>
-                                                    <option value="1">Select</option>
-                                                </select>
-                                            </div>
-                                        </div>
-                                        <div class="field third" style="margin-top: 40px;">
-                                            <input type="checkbox" id="demo1-copy1" name="demo1-copy1" class="color2">
-                                            <label for="demo1-copy1">取消</label>
-                                        </div>
-
-                                        <div class="field" style="width: calc(38% - 0.5rem);">
-                                            <label for="demo-name">name</label>
-                                            <input type="text" name="demo-name" id="name" value="" placeholder="name">
-                                        </div>
-                                        <div class="field third">
-                                            <label for="demo-category">编辑表单类型</label>
-                                            <div class="select-wrapper">
-                                                <select name="demo-category" id="demo-category-name">
-                                                    <option value="">-</option>
-                                                    <option value="1">显示</option>
-                                                    <option value="1">Text</option>
-                                                    <option value="1">Select</option>
-                                                </select>
-                                            </div>
-                                        </div>
-                                        <div class="field third" style="margin-top: 40px;">
-                                            <input type="checkbox" id="demo1-copy2" name="demo1-copy2" class="color2">
-                                            <label for="demo1-copy2">取消</label>
-                                        </div>
-
-                                        <div class="field" style="width: calc(38% - 0.5rem);">
-                                            <label for="demo-name">id</label>
-                                            <input type="text" name="demo-name" value="" placeholder="id">
-                                        </div>
-                                        <div class="field third">
-                                            <label for="demo-category">编辑表单类型</label>
-                                            <div class="select-wrapper">
-                                                <select name="demo-category">
-                                                    <option value="">-</option>
-                                                    <option value="1">显示</option>
-                                                    <option value="1">Text</option>
-                                                    <option value="1">Select</option>
-                                                </select>
-                                            </div>
-                                        </div>
-                                        <div class="field third" style="margin-top: 40px;">
-                                            <input type="checkbox" id="demo1-copy3" name="demo1-copy3" class="color2">
-                                            <label for="demo1-copy3">取消</label>
-                                        </div>
-
-                                        <div class="field" style="width: calc(38% - 0.5rem);">
-                                            <label for="demo-name">name</label>
-                                            <input type="text" name="demo-name" value="" placeholder="name">
-                                        </div>
-                                        <div class="field third">
-                                            <label for="demo-category">编辑表单类型</label>
-                                            <div class="select-wrapper">
-                                                <select name="demo-category">
-                                                    <option value="">-</option>
-                                                    <option value="1">显示</option>
-                                                    <option value="1">Text</option>
-                                                    <option value="1">Select</option>
-                                                </select>
-                                            </div>
-                                        </div>
-                                        <div class="field third" style="margin-top: 40px;">
-                                            <input type="checkbox" id="demo1-copy4" name="demo1-copy4" class="color2">
-                                            <label for="demo1-copy4">取消</label>
-                                        </div>
-
-                                        <div class="field" style="width: calc(38% - 0.5rem);">
-                                            <label for="demo-name">id</label>
-                                            <input type="text" name="demo-name" value="" placeholder="id">
-                                        </div>
-                                        <div class="field third">
-                                            <label for="demo-category">编辑表单类型</label>
-                                            <div class="select-wrapper">
-                                                <select name="demo-category">
-                                                    <option value="">-</option>
-                                                    <option value="1">显示</option>
-                                                    <option value="1">Text</option>
-                                                    <option value="1">Select</option>
-                                                </select>
-                                            </div>
-                                        </div>
-                                        <div class="field third" style="margin-top: 40px;">
-                                            <input type="checkbox" id="demo1-copy5" name="demo1-copy5" class="color2">
-                                            <label for="demo1-copy5">取消</label>
-                                        </div>
-
-                                        <div class="field" style="width: calc(38% - 0.5rem);">
-                                            <label for="demo-name">name</label>
-                                            <input type="text" name="demo-name" value="" placeholder="name">
-                                        </div>
-                                        <div class="field third">
-                                            <label for="demo-category">编辑表单类型</label>
-                                            <div class="select-wrapper">
-                                                <select name="demo-category">
-                                                    <option value="">-</option>
-                                                    <option value="1">显示</option>
-                                                    <option value="1">Text</option>
-                                                    <option value="1">Select</option>
-                                                </select>
-                                            </div>
-                                        </div>
-                                        <div class="field third" style="margin-top: 40px;">
-                                            <input type="checkbox" id="demo1-copy6" name="demo1-copy6" class="color2">
-                                            <label for="demo1-copy6">取消</label>
-                                        </div>
-
-                                        <div class="field" style="width: calc(38% - 0.5rem);">
-                                            <label for="demo-name">id</label>
-                                            <input type="text" name="demo-name" value="" placeholder="id">
-                                        </div>
-                                        <div class="field third">
-                                            <label for="demo-category">编辑表单类型</label>
-                                            <div class="select-wrapper">
-                                                <select name="demo-category">
-                                                    <option value="">-</option>
-                                                    <option value="1">显示</option>
-                                                    <option value="1">Text</option>
-                                                    <option value="1">Select</option>
-                                                </select>
-                                            </div>
-                                        </div>
-                                        <div class="field third" style="margin-top: 40px;">
-                                            <input type="checkbox" id="demo1-copy7" name="demo1-copy7" class="color2">
-                                            <label for="demo1-copy7">取消</label>
-                                        </div>
+        <meta http-equiv="X-UA-Compatible" content="IE=edge">
+        <title>{{.Title}}</title>
+        <meta name="viewport" content="width=device-width, initial-scale=1">
 
-                                        <div class="field" style="width: calc(38% - 0.5rem);">
-                                            <label for="demo-name">name</label>
-                                            <input type="text" name="demo-name" value="" placeholder="name">
-                                        </div>
-                                        <div class="field third">
-                                            <label for="demo-category">编辑表单类型</label>
-                                            <div class="select-wrapper">
-                                                <select name="demo-category">
-                                                    <option value="">-</option>
-                                                    <option value="1">显示</option>
-                                                    <option value="1">Text</option>
-                                                    <option value="1">Select</option>
-                                                </select>
-                                            </div>
-                                        </div>
-                                        <div class="field third" style="margin-top: 40px;">
-                                            <input type="checkbox" id="demo1-copy8" name="demo1-copy8" class="color2">
-                                            <label for="demo1-copy8">取消</label>
-                                        </div>
+        <link rel="stylesheet" href="{{link .CdnUrl .UrlPrefix "/assets/login/dist/all.min.css"}}">
 
-                                        <div class="field" style="width: calc(38% - 0.5rem);">
-                                            <label for="demo-name">id</label>
-                                            <input type="text" name="demo-name" value="" placeholder="id">
-                                        </div>
-                                        <div class="field third">
-                                            <label for="demo-category">编辑表单类型</label>
-                                            <div class="select-wrapper">
-                                                <select name="demo-category">
-                                                    <option value="">-</option>
-                                                    <option value="1">显示</option>
-                                                    <option value="1">Text</option>
-                                                    <option value="1">Select</option>
-                                                </select>
-                                            </div>
-                                        </div>
-                                        <div class="field third" style="margin-top: 40px;">
-                                            <input type="checkbox" id="demo1-copy9" name="demo1-copy9" class="color2">
-                                            <label for="demo1-copy9">取消</label>
-                                        </div>
+        <!--[if lt IE 9]>
+        <script src="{{link .CdnUrl .UrlPrefix "/assets/login/dist/respond.min.js"}}"></script>
+        <![endif]-->
 
-                                        <div class="field" style="width: calc(38% - 0.5rem);">
-                                            <label for="demo-name">name</label>
-                                            <input type="text" name="demo-name" value="" placeholder="name">
-                                        </div>
-                                        <div class="field third">
-                                            <label for="demo-category">编辑表单类型</label>
-                                            <div class="select-wrapper">
-                                                <select name="demo-category">
-                                                    <option value="">-</option>
-                                                    <option value="1">显示</option>
-                                                    <option value="1">Text</option>
-                                                    <option value="1">Select</option>
-                                                </select>
-                                            </div>
-                                        </div>
-                                        <div class="field third" style="margin-top: 40px;">
-                                            <input type="checkbox" id="demo1-copy10" name="demo1-copy10" class="color2">
-                                            <label for="demo1-copy10">取消</label>
-                                        </div>
-
-                                        <div class="field" style="width: calc(38% - 0.5rem);">
-                                            <label for="demo-name">id</label>
-                                            <input type="text" name="demo-name" value="" placeholder="id">
-                                        </div>
-                                        <div class="field third">
-                                            <label for="demo-category">编辑表单类型</label>
-                                            <div class="select-wrapper">
-                                                <select name="demo-category">
-                                                    <option value="">-</option>
-                                                    <option value="1">显示</option>
-                                                    <option value="1">Text</option>
-                                                    <option value="1">Select</option>
-                                                </select>
-                                            </div>
-                                        </div>
-                                        <div class="field third" style="margin-top: 40px;">
-                                            <input type="checkbox" id="demo1-copy11" name="demo1-copy11" class="color2">
-                                            <label for="demo1-copy11">取消</label>
-                                        </div>
-
-                                        <div class="field" style="width: calc(38% - 0.5rem);">
-                                            <label for="demo-name">name</label>
-                                            <input type="text" name="demo-name" value="" placeholder="name">
-                                        </div>
-                                        <div class="field third">
-                                            <label for="demo-category">编辑表单类型</label>
-                                            <div class="select-wrapper">
-                                                <select name="demo-category">
-                                                    <option value="">-</option>
-                                                    <option value="1">显示</option>
-                                                    <option value="1">Text</option>
-                                                    <option value="1">Select</option>
-                                                </select>
-                                            </div>
-                                        </div>
-                                        <div class="field third" style="margin-top: 40px;">
-                                            <input type="checkbox" id="demo1-copy12" name="demo1-copy12" class="color2">
-                                            <label for="demo1-copy12">取消</label>
-                                        </div>
-
-                                        <div class="field" style="margin-top: 20px;">
-                                            <ul class="actions">
-                                                <li><input class="input-prev" type="reset" value="上一步"></li>
-                                                <li><input class="input-next" type="reset" value="下一步"></li>
-                                            </ul>
-                                        </div>
-                                    </form>
-                                </div>
-                            </div>
-                        </section>
-                        <!-- Copyright -->
-                        <div class="copyright">Copyright © 2018.Company name All rights reserved.<a target="_blank"
-                                                                                                    href="https://github.com/chenhg5/go-admin">GoAdmin</a>
-                        </div>
+    </head>
+    <body>
+
+    <div class="container">
+        <div class="row" style="margin-top: 60px;">
+            <div class="col-md-4 col-md-offset-4">
+                {{if .Locked}}
+                    <div class="fh5co-form">
+                        <h2>{{.Title}}</h2>
+                        <p>{{lang "the installation is locked"}}</p>
+                        <p><a href="{{.LoginUrl}}">{{lang "login"}}</a></p>
                     </div>
-                </div>
-            </div>
-            <div class="slide" id="slide4">
-                <div class="page-wrapper" style="margin-left: auto;margin-right:  auto;width: 1236px;">
-                    <!-- Wrapper -->
-                    <div class="wrapper" style="margin-left: auto;margin-right:  auto;">
-                        <section class="panel color2-alt">
-                            <div class="intro color2">
-                                <h2 class="major">设置超级管理员</h2>
-                                <p>超级管理员设置</p>
-                            </div>
-                            <div class="inner columns aligned" style="padding: 2.5rem 3.5rem 2rem 3.5rem;">
-                                <div class="span-2-5">
-                                    <form method="post" action="#">
-                                        <div class="field">
-                                            <label for="demo-name">账号</label>
-                                            <input type="text" name="demo-name" id="username" value=""
-                                                   placeholder="root">
-                                        </div>
-                                        <div class="field">
-                                            <label for="demo-email">密码</label>
-                                            <input type="password" name="demo-email" id="password" value=""
-                                                   placeholder="root">
-                                        </div>
-                                        <div class="field">
-                                            <label for="demo-email">确认密码</label>
-                                            <input type="password" name="demo-password_comfirm" id="password_comfirm"
-                                                   value=""
-                                                   placeholder="root">
-                                        </div>
-                                        <div class="field" style="margin-top: 20px;">
-                                            <ul class="actions">
-                                                <li><input class="input-next" type="reset" value="Enjoy!"></li>
-                                            </ul>
-                                        </div>
-                                    </form>
-                                </div>
-                            </div>
-                        </section>
-                        <!-- Copyright -->
-                        <div class="copyright">Copyright © 2018.Company name All rights reserved.<a target="_blank"
-                                                                                                    href="https://github.com/chenhg5/go-admin">GoAdmin</a>
+                {{else}}
+                    <form action="##" onsubmit="return false" method="post" id="database-form" class="fh5co-form">
+                        <h2>1. {{lang "database"}}</h2>
+                        <div class="form-group">
+                            <input type="password" class="form-control" id="install_token" placeholder="{{lang "install token"}}" autocomplete="off">
+                            <p class="help-block">{{lang "the install token is printed to the log of the server at the startup"}}</p>
                         </div>
-                    </div>
-
-                </div>
+                        <div class="form-group">
+                            <select class="form-control" id="driver" onchange="switchDriver()">
+                                {{range .Drivers}}
+                                    <option value="{{.}}">{{.}}</option>
+                                {{end}}
+                            </select>
+                        </div>
+                        <div class="form-group server">
+                            <input type="text" class="form-control" id="host" placeholder="{{lang "host"}}" value="127.0.0.1">
+                        </div>
+                        <div class="form-group server">
+                            <input type="text" class="form-control" id="port" placeholder="{{lang "port"}}">
+                        </div>
+                        <div class="form-group server">
+                            <input type="text" class="form-control" id="user" placeholder="{{lang "user"}}" autocomplete="off">
+                        </div>
+                        <div class="form-group server">
+                            <input type="password" class="form-control" id="pwd" placeholder="{{lang "password"}}" autocomplete="off">
+                        </div>
+                        <div class="form-group server">
+                            <input type="text" class="form-control" id="name" placeholder="{{lang "database name"}}">
+                        </div>
+                        <div class="form-group file" style="display: none;">
+                            <input type="text" class="form-control" id="file" placeholder="{{lang "file"}}" value="./admin.db">
+                        </div>
+                        <div class="form-group">
+                            <button class="btn btn-primary" onclick="checkDatabase()">{{lang "test connection"}}</button>
+                        </div>
+                    </form>
+                    <form action="##" onsubmit="return false" method="post" id="admin-form" class="fh5co-form" style="display: none;">
+                        <h2>2. {{lang "super administrator"}}</h2>
+                        <div class="form-group">
+                            <input type="text" class="form-control" id="username" placeholder="{{lang "username"}}" autocomplete="off">
+                        </div>
+                        <div class="form-group">
+                            <input type="text" class="form-control" id="nickname" placeholder="{{lang "nickname"}}" autocomplete="off">
+                        </div>
+                        <div class="form-group">
+                            <input type="password" class="form-control" id="password" placeholder="{{lang "password"}}" autocomplete="off">
+                        </div>
+                        <div class="form-group">
+                            <input type="password" class="form-control" id="password_again" placeholder="{{lang "password_again"}}" autocomplete="off">
+                        </div>
+                        <div class="form-group">
+                            <input type="text" class="form-control" id="config" placeholder="{{lang "config file"}}" value="./config.yml">
+                        </div>
+                        <div class="form-group">
+                            <button class="btn btn-default" onclick="$('#admin-form').hide();$('#database-form').show()">{{lang "previous"}}</button>
+                            <button class="btn btn-primary" onclick="install()">{{lang "install"}}</button>
+                        </div>
+                    </form>
+                {{end}}
+            </div>
+        </div>
+        <div class="row" style="padding-top: 60px; clear: both;">
+            <div class="col-md-12 text-center">
+                <p>
+                    <small>&copy; All Rights Reserved. GoAdmin</small>
+                </p>
             </div>
         </div>
     </div>
 
-    <!-- Scripts -->
-    <script src="../../assets/install/assets/js/jquery.min.js"></script>
-    <script src="../../assets/install/assets/js/skel.min.js"></script>
-    <script src="../../assets/install/assets/js/main.js"></script>
-    <script src="../../assets/fullpage/fullpage.min.js"></script>
-    <script type="text/javascript">
-        var myFullpage = new fullpage('#fullpage', {
-            anchors: ['first'],
-            lazyLoad: true,
-            scrollBar: true,
-            autoScrolling: false
-        });
+    <div id="particles-js">
+        <canvas class="particles-js-canvas-el" width="1606" height="1862" style="width: 100%; height: 100%;"></canvas>
+    </div>
 
-        myFullpage.destroy();
+    <script src="{{link .CdnUrl .UrlPrefix "/assets/login/dist/all.min.js"}}"></script>
 
-        $(".input-next").click(function (e) {
-            myFullpage.moveSlideRight()
-        });
-        $(".input-prev").click(function (e) {
-            myFullpage.moveSlideLeft()
-        });
+    {{if not .Locked}}
+    <script>
+        let ports = {"mysql": "3306", "postgresql": "5432", "mssql": "1433"};
+        let token = '{{.Token}}';
 
-        /** 处理逻辑 **/
+        function switchDriver() {
+            let driver = $("#driver").val();
+            $(".server").toggle(driver !== "sqlite");
+            $(".file").toggle(driver === "sqlite");
+            $("#port").val(ports[driver] || "");
+        }
 
-        // 测试连接
-        $('#test-con-btn').on('click', function (e) {
-            e.preventDefault();
+        function database() {
+            return {
+                'driver': $("#driver").val(),
+                'host': $("#host").val(),
+                'port': $("#port").val(),
+                'user': $("#user").val(),
+                'pwd': $("#pwd").val(),
+                'name': $("#name").val(),
+                'file': $("#file").val(),
+                'install_token': $("#install_token").val(),
+                '__go_admin_t_': token
+            }
+        }
+
+        function fail(data) {
+            if (data.responseJSON && data.responseJSON.data) {
+                token = data.responseJSON.data.token;
+            }
+            alert(data.responseJSON ? data.responseJSON.msg : '{{lang "error"}}');
+        }
+
+        function checkDatabase() {
             $.ajax({
                 dataType: 'json',
                 type: 'POST',
-                url: '/install/database/check',
-                async: 'true',
-                data: {
-                    'h': $("#db-host").val(),
-                    'po': $("#db-port").val(),
-                    'u': $("#db-username").val(),
-                    'pa': $("#db-password").val(),
-                    'db': $("#db-database").val()
-                },
+                url: '{{.UrlPrefix}}/install/database/check',
+                data: database(),
                 success: function (data) {
-                    console.log(data)
-                    if (data.code === 0) {
-
-                        // html = "";
-                        //
-                        // for (i = 0; i < data.data.list.length; i++) {
-                        //     html += '<div class="field"><input type="checkbox" id="' +
-                        //             data.data.list[i] +
-                        //             '" name="table-' + i + '" class="color2"><label for="table-' + i + '">' +
-                        //             data.data.list[i]
-                        //             + '</label></div>';
-                        // }
-                        //
-                        // $("#table-div").html(html);
-
-                        alert(data.msg);
+                    token = data.data.token;
+                    $('#database-form').hide();
+                    $('#admin-form').show();
+                },
+                error: fail
+            });
+        }
 
-                    } else {
-                        alert(data.msg);
-                    }
+        function install() {
+            let data = database();
+            data['username'] = $("#username").val();
+            data['nickname'] = $("#nickname").val();
+            data['password'] = $("#password").val();
+            data['password_again'] = $("#password_again").val();
+            data['config'] = $("#config").val();
+            $.ajax({
+                dataType: 'json',
+                type: 'POST',
+                url: '{{.UrlPrefix}}/install',
+                data: data,
+                success: function (data) {
+                    alert('{{lang "install success, the config file is written to"}} ' + data.data.config);
+                    location.href = data.data.url
                 },
-                error: function (data) {
-                    alert("ok");
-                }
+                error: fail
             });
-        });
+        }
 
+        switchDriver();
     </script>
-    <style>
-        .fp-controlArrow {
-            display: none;
-        }
-    </style>
+    {{end}}
+
     </body>
     </html>
 {{end}}`}
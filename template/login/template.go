@@ -42,6 +42,23 @@ const loginTmpl = `{{define "login_theme1"}}
                 <form action="##" onsubmit="return false" method="post" id="sign-up-form" class="fh5co-form animate-box"
                       data-animate-effect="fadeIn">
                     <h2>{{.Title}}</h2>
+                    {{if .TOTP}}
+                    {{if .TOTPQrcode}}
+                    <div class="form-group text-center">
+                        <p>{{lang "scan the qrcode with the authenticator app"}}</p>
+                        <img src="{{.TOTPQrcode}}" style="height:150px;width:150px;">
+                        <p><small>{{.TOTPSecret}}</small></p>
+                    </div>
+                    {{end}}
+                    <div class="form-group">
+                        <label for="totp_code" class="sr-only">Code</label>
+                        <input type="text" class="form-control" id="totp_code" placeholder="{{lang "verification code"}}"
+                               autocomplete="one-time-code">
+                    </div>
+                    <div class="form-group">
+                        <button class="btn btn-primary" onclick="submitTOTP()">{{lang "verify"}}</button>
+                    </div>
+                    {{else}}
                     <div class="form-group">
                         <label for="username" class="sr-only">Username</label>
                         <input type="text" class="form-control" id="username" placeholder="{{lang "username"}}"
@@ -55,6 +72,7 @@ const loginTmpl = `{{define "login_theme1"}}
                     <div class="form-group">
                         <button class="btn btn-primary" onclick="submitData()">{{lang "login"}}</button>
                     </div>
+                    {{end}}
                 </form>
             </div>
         </div>
@@ -95,6 +113,32 @@ const loginTmpl = `{{define "login_theme1"}}
                 }
             });
         }
+
+        function submitTOTP() {
+            $.ajax({
+                dataType: 'json',
+                type: 'POST',
+                url: '{{.UrlPrefix}}/signin/totp',
+                async: 'true',
+                data: {
+                    'code': $("#totp_code").val()
+                },
+                success: function (data) {
+                    if (data.data.recovery_codes) {
+                        alert('{{lang "save the recovery codes"}}\n\n' + data.data.recovery_codes.join('\n'));
+                    }
+                    location.href = data.data.url
+                },
+                error: function (data) {
+                    if (data.responseJSON && data.responseJSON.msg) {
+                        alert(data.responseJSON.msg);
+                    } else {
+                        alert('{{lang "login fail"}}');
+                    }
+                    location.reload();
+                }
+            });
+        }
     </script>
 
     </body>
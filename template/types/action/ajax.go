@@ -149,7 +149,7 @@ func (ajax *AjaxAction) GetCallbacks() context.Node {
 		Path:     ajax.Url,
 		Method:   ajax.Method,
 		Handlers: ajax.Handlers,
-		Value:    map[string]interface{}{constant.ContextNodeNeedAuth: 1},
+		Value:    map[string]interface{}{constant.ContextNodeNeedAuth: 1, constant.ContextNodeAction: 1},
 	}
 }
 
@@ -43,7 +43,7 @@ func (file *FileUploadAction) GetCallbacks() context.Node {
 		Path:     file.Url,
 		Method:   file.Method,
 		Handlers: file.Handlers,
-		Value:    map[string]interface{}{constant.ContextNodeNeedAuth: 1},
+		Value:    map[string]interface{}{constant.ContextNodeNeedAuth: 1, constant.ContextNodeAction: 1},
 	}
 }
 
@@ -296,10 +296,12 @@ func (b Buttons) Callbacks() []context.Node {
 }
 
 const (
-	NavBtnSiteName = "go_admin_site_navbtn"
-	NavBtnInfoName = "go_admin_info_navbtn"
-	NavBtnToolName = "go_admin_tool_navbtn"
-	NavBtnPlugName = "go_admin_plug_navbtn"
+	NavBtnSiteName   = "go_admin_site_navbtn"
+	NavBtnInfoName   = "go_admin_info_navbtn"
+	NavBtnToolName   = "go_admin_tool_navbtn"
+	NavBtnPlugName   = "go_admin_plug_navbtn"
+	NavBtnTOTPName   = "go_admin_totp_navbtn"
+	NavBtnSearchName = "go_admin_search_navbtn"
 )
 
 func (b Buttons) RemoveSiteNavButton() Buttons {
@@ -369,6 +371,45 @@ func (n *NavButton) Content() (template.HTML, template.JS) {
 	return h, n.Action.Js()
 }
 
+// NavSearchButton is the search box of the navbar, which submits the keyword
+// to the global search page.
+type NavSearchButton struct {
+	*BaseButton
+	Placeholder string
+}
+
+func GetNavSearchButton(url, placeholder string, names ...string) *NavSearchButton {
+	name := ""
+
+	if len(names) > 0 {
+		name = names[0]
+	}
+
+	return &NavSearchButton{
+		BaseButton: &BaseButton{
+			Id:     btnUUID(),
+			Action: new(NilAction),
+			Url:    url,
+			Method: "get",
+			Name:   name,
+		},
+		Placeholder: placeholder,
+	}
+}
+
+func (n *NavSearchButton) Content() (template.HTML, template.JS) {
+	return template.HTML(`<li class="hidden-xs">
+    <form class="navbar-form ` + n.Id + `" action="` + template.HTMLEscapeString(n.Url) + `" method="get" style="margin-top: 10px; margin-bottom: 0">
+      <div class="input-group input-group-sm">
+        <input type="text" name="q" class="form-control" placeholder="` + template.HTMLEscapeString(n.Placeholder) + `">
+        <span class="input-group-btn">
+          <button type="submit" class="btn btn-flat"><i class="fa fa-search"></i></button>
+        </span>
+      </div>
+    </form>
+</li>`), ""
+}
+
 type NavDropDownButton struct {
 	*BaseButton
 	Icon  string
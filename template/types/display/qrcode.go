@@ -1,9 +1,12 @@
 package display
 
 import (
+	"encoding/base64"
 	"html/template"
+	"net/url"
 
 	"github.com/GoAdminGroup/go-admin/template/types"
+	"github.com/skip2/go-qrcode"
 )
 
 type Qrcode struct {
@@ -17,7 +20,7 @@ func init() {
 func (q *Qrcode) Get(args ...interface{}) types.FieldFilterFn {
 	return func(value types.FieldModel) interface{} {
 
-		src := `https://api.qrserver.com/v1/create-qr-code/?size=150x150&amp;data=` + value.Value
+		src := template.HTMLEscapeString(QrcodeSrc(value.Value))
 
 		return template.HTML(`
 <a href="javascript:void(0);" class="grid-column-qrcode text-muted" 
@@ -29,6 +32,25 @@ style='height:150px;width:150px;'/>" data-toggle="popover" tabindex="0" data-ori
 	}
 }
 
+// QrcodeAPI is the service which renders the qrcodes, the escaped value is
+// appended to it. The qrcodes are rendered locally when it is empty, which
+// keeps the values, like the secrets of the two-factor authentication, from
+// a third party.
+var QrcodeAPI = ""
+
+// QrcodeSrc return the image url of the qrcode of the value, which is a
+// data url of a png image when QrcodeAPI is empty.
+func QrcodeSrc(value string) string {
+	if QrcodeAPI != "" {
+		return QrcodeAPI + url.QueryEscape(value)
+	}
+	png, err := qrcode.Encode(value, qrcode.Medium, 150)
+	if err != nil {
+		return ""
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(png)
+}
+
 func (q *Qrcode) JS() template.HTML {
 	return template.HTML(`
 $('.grid-column-qrcode').popover({
@@ -180,6 +180,8 @@ type FormField struct {
 
 	FieldDisplay `json:"-"`
 	PostFilterFn PostFieldFilterFn `json:"-"`
+
+	Rules []FieldRule `json:"-"`
 }
 
 func (f *FormField) GetRawValue(columns []string, v interface{}) string {
@@ -386,6 +388,8 @@ type FormPanel struct {
 	PageErrorHTML template.HTML    `json:"page_error_html"`
 
 	NoCompress bool `json:"no_compress"`
+
+	OptimisticLock OptimisticLock `json:"optimistic_lock"`
 }
 
 type Responder func(ctx *context.Context)
@@ -149,6 +149,7 @@ type Field struct {
 
 	Width       int
 	Sortable    bool
+	Searchable  bool
 	EditAble    bool
 	Fixed       bool
 	Filterable  bool
@@ -566,8 +567,14 @@ type InfoPanel struct {
 	PageSizeList    []int
 	DefaultPageSize int
 
+	// CursorPagination pages the rows by the values of the sort field and the
+	// primary key of the last row instead of an offset, and skips the count.
+	CursorPagination bool
+	ApproximateCount bool
+
 	ExportType      int
 	ExportProcessFn ExportProcessFn
+	ExportFormats   []string
 
 	primaryKey primaryKey
 
@@ -865,6 +872,13 @@ func (i *InfoPanel) IsExportValue() bool {
 	return i.ExportType == 1
 }
 
+// SetExportFormats set the formats which the table can be exported as, such as
+// "xlsx", "csv", "jsonl" and "ods". The first one is the default.
+func (i *InfoPanel) SetExportFormats(formats ...string) *InfoPanel {
+	i.ExportFormats = formats
+	return i
+}
+
 func (i *InfoPanel) AddButtonRaw(btn Button, action Action) *InfoPanel {
 	i.Buttons = append(i.Buttons, btn)
 	i.addFooterHTML(action.FooterContent()).addCallback(action.GetCallbacks())
@@ -1205,6 +1219,14 @@ func (i *InfoPanel) FieldSortable() *InfoPanel {
 	return i
 }
 
+// FieldSearchable mark the field to be matched by the keyword of the global
+// search. The string fields are matched by like, and the number fields are
+// matched by equal when the keyword is a number.
+func (i *InfoPanel) FieldSearchable() *InfoPanel {
+	i.FieldList[i.curFieldListIndex].Searchable = true
+	return i
+}
+
 func (i *InfoPanel) FieldEditOptions(options FieldOptions, extra ...map[string]string) *InfoPanel {
 	if i.FieldList[i.curFieldListIndex].EditType.IsSwitch() {
 		if len(extra) == 0 {
@@ -1466,6 +1488,15 @@ func (i *InfoPanel) SetDefaultPageSize(defaultPageSize int) *InfoPanel {
 	return i
 }
 
+// SetCursorPagination set the keyset pagination of the large tables, which
+// only links to the previous and the next page. The total is estimated from
+// the statistics of the database if approximateCount is true.
+func (i *InfoPanel) SetCursorPagination(approximateCount bool) *InfoPanel {
+	i.CursorPagination = true
+	i.ApproximateCount = approximateCount
+	return i
+}
+
 func (i *InfoPanel) GetPageSizeList() []string {
 	var pageSizeList = make([]string, len(i.PageSizeList))
 	for j := 0; j < len(i.PageSizeList); j++ {
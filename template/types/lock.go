@@ -0,0 +1,125 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/modules/language"
+)
+
+// OptimisticLock is the column checked by the updates of the edit form. An
+// integer column is a version increased by every update, otherwise it is a
+// datetime column such as updated_at, which is set to now.
+type OptimisticLock struct {
+	Field string          `json:"field"`
+	Type  db.DatabaseType `json:"type"`
+
+	driver string
+}
+
+// WithDriver return the lock of the column of the database driver, of which
+// the precision of the datetime is limited.
+func (l OptimisticLock) WithDriver(driver string) OptimisticLock {
+	l.driver = driver
+	return l
+}
+
+// Enabled check if the optimistic lock is enabled.
+func (l OptimisticLock) Enabled() bool {
+	return l.Field != ""
+}
+
+// IsVersion check if the column is an integer version.
+func (l OptimisticLock) IsVersion() bool {
+	return db.Contains(l.Type, db.IntTypeList)
+}
+
+// lockTimeLayout is the layout of the datetime column, of which the
+// fractional seconds are kept up to microseconds.
+const lockTimeLayout = "2006-01-02 15:04:05.999999"
+
+// timeLayout return the layout of the datetime column. The fractional
+// seconds of mssql are kept up to milliseconds, since its datetime rejects
+// the string of more digits.
+func (l OptimisticLock) timeLayout() string {
+	if l.driver == db.DriverMssql {
+		return "2006-01-02 15:04:05.999"
+	}
+	return lockTimeLayout
+}
+
+// Value return the value of the column in the edit form, in which a datetime
+// keeps its fractional seconds. The datetime in RFC3339, which is scanned by
+// some drivers such as sqlite, is turned into the layout of the column.
+func (l OptimisticLock) Value(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return value.Format(l.timeLayout())
+	case []byte:
+		return l.Value(string(value))
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil && !l.IsVersion() {
+			return t.Format(l.timeLayout())
+		}
+		return value
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// Next return the value of the datetime column set by the update of the
+// version, which is now in the precision of the version, or the version
+// plus one unit of the precision if now is not after it, so that it always
+// differs from the version. The precision of mssql is 10 milliseconds, since
+// its datetime is rounded to 1/300 of a second, which could round one more
+// millisecond back to the version.
+func (l OptimisticLock) Next(version string, now time.Time) string {
+	unit := time.Second
+	if strings.Contains(version, ".") {
+		unit = time.Microsecond
+		if l.driver == db.DriverMssql {
+			unit = 10 * time.Millisecond
+		}
+	}
+	next := now.Truncate(unit)
+	if last, err := time.ParseInLocation("2006-01-02 15:04:05", version, now.Location()); err == nil &&
+		!next.After(last) {
+		next = last.Truncate(unit).Add(unit)
+	}
+	return next.Format(l.timeLayout())
+}
+
+// SetOptimisticLock enable the optimistic locking of the edits with the
+// field. Its value is put into the edit form, the update without it is
+// rejected, and the update of a record which has been updated since the form
+// was shown fails with a ConflictError. The updates of the api send it as
+// the value of the field, which should be a field of the info table then.
+func (f *FormPanel) SetOptimisticLock(field string, typ db.DatabaseType) *FormPanel {
+	f.OptimisticLock = OptimisticLock{Field: field, Type: typ}
+	return f
+}
+
+// ConflictError is returned by the update of a record which has been changed
+// since the form was shown. The fields which differ from the posted values
+// are reported as the field errors with their latest values, so that the
+// form shown again can be merged and saved with the latest version.
+type ConflictError struct {
+	Version string            `json:"version"`
+	Latest  map[string]string `json:"latest"`
+	Fields  FieldErrors       `json:"-"`
+}
+
+func (e *ConflictError) Error() string {
+	return language.Get("the record has been changed by others, please merge the changes and save again")
+}
+
+func (e *ConflictError) Unwrap() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e.Fields
+}
@@ -0,0 +1,31 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptimisticLockNext(t *testing.T) {
+	lock := OptimisticLock{Field: "updated_at", Type: db.Timestamp}
+	now := time.Date(2020, 1, 2, 3, 4, 5, 600000000, time.UTC)
+
+	assert.Equal(t, "2020-01-02 03:04:05.1234", lock.Value(time.Date(2020, 1, 2, 3, 4, 5, 123400000, time.UTC)))
+	assert.Equal(t, "2020-01-02 03:04:05.1234", lock.Value([]byte("2020-01-02T03:04:05.1234Z")))
+	assert.Equal(t, "2020-01-02 03:04:05", lock.Next("", now))
+	assert.Equal(t, "2020-01-02 03:04:05", lock.Next("2020-01-02 03:04:04", now))
+	assert.Equal(t, "2020-01-02 03:04:06", lock.Next("2020-01-02 03:04:05", now))
+	assert.Equal(t, "2020-01-02 03:04:05.6", lock.Next("2020-01-02 03:04:05.1", now))
+	assert.Equal(t, "2020-01-02 03:04:05.700001", lock.Next("2020-01-02 03:04:05.7", now))
+
+	// the datetime of mssql rejects more than 3 fractional digits.
+	lock = lock.WithDriver(db.DriverMssql)
+	assert.Equal(t, "2020-01-02 03:04:05.123", lock.Value(time.Date(2020, 1, 2, 3, 4, 5, 123333333, time.UTC)))
+	assert.Equal(t, "2020-01-02 03:04:05.123", lock.Value("2020-01-02T03:04:05.1234Z"))
+	assert.Equal(t, "2020-01-02 03:04:06", lock.Next("2020-01-02 03:04:05", now))
+	assert.Equal(t, "2020-01-02 03:04:05.6", lock.Next("2020-01-02 03:04:05.1", now))
+	assert.Equal(t, "2020-01-02 03:04:05.61", lock.Next("2020-01-02 03:04:05.603", now))
+	assert.Equal(t, "2020-01-02 03:04:05.71", lock.Next("2020-01-02 03:04:05.707", now))
+}
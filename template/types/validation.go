@@ -0,0 +1,324 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	"github.com/GoAdminGroup/go-admin/modules/language"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	form2 "github.com/GoAdminGroup/go-admin/template/types/form"
+)
+
+// RuleContext is the context of a field rule.
+type RuleContext struct {
+	Field  *FormField
+	Value  string
+	Values form.Values
+
+	// Table and PrimaryKey are the table of the form and the name of its
+	// primary key, of which the value is posted in an update.
+	Table      string
+	PrimaryKey string
+
+	// SQL return the sql of the connection of the table.
+	SQL func() *db.SQL
+}
+
+// IsUpdate check if the rule is run in an update.
+func (ctx RuleContext) IsUpdate() bool {
+	return ctx.Values.IsUpdatePost()
+}
+
+// FieldRule validate the value of the field, and return the message of the
+// failure as the error. Except the required ones, the rules should pass the
+// empty values.
+type FieldRule func(ctx RuleContext) error
+
+// FieldErrors are the failures of the field rules by field name, which
+// are rendered inline next to the inputs of the form.
+type FieldErrors map[string]string
+
+func (e FieldErrors) Error() string {
+	fields := make([]string, 0, len(e))
+	for field := range e {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	msgs := make([]string, len(fields))
+	for i, field := range fields {
+		msgs[i] = field + ": " + e[field]
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// GetFieldErrors return the field errors of the error if it is.
+func GetFieldErrors(err error) (FieldErrors, bool) {
+	var errs FieldErrors
+	ok := errors.As(err, &errs)
+	return errs, ok
+}
+
+// Validate run the rules of the fields with the posted values, and then the
+// rules of the email, url and ip fields by the form type. The fields which
+// are not posted in an update are skipped, as it may be a partial one.
+func (f *FormPanel) Validate(values form.Values, pk string, sql func() *db.SQL) error {
+
+	errs := make(FieldErrors)
+
+	for i := range f.FieldList {
+		field := &f.FieldList[i]
+
+		if values.IsUpdatePost() && !values.Has(field.Field) {
+			continue
+		}
+
+		ctx := RuleContext{
+			Field:      field,
+			Value:      values.Get(field.Field),
+			Values:     values,
+			Table:      f.Table,
+			PrimaryKey: pk,
+			SQL:        sql,
+		}
+
+		rules := field.Rules
+		if rule := formTypeRule(field.FormType); rule != nil {
+			rules = append([]FieldRule{rule}, rules...)
+		}
+
+		for _, rule := range rules {
+			if err := rule(ctx); err != nil {
+				errs[field.Field] = err.Error()
+				break
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// WithErrors return the fields with the posted values, of which the help
+// messages are replaced with the field errors.
+func (f FormFields) WithErrors(errs FieldErrors, values form.Values) FormFields {
+	for i := range f {
+		if f[i].FormType.IsSelect() || f[i].FormType.IsArray() || f[i].FormType.IsFile() ||
+			f[i].FormType == form2.Password {
+			continue
+		}
+		if values.Has(f[i].Field) {
+			f[i].Value = template.HTML(template.HTMLEscapeString(values.Get(f[i].Field)))
+		}
+		if msg, ok := errs[f[i].Field]; ok {
+			f[i].HelpMsg = template.HTML(`<span class="text-danger">` + template.HTMLEscapeString(msg) + `</span>`)
+		}
+	}
+	return f
+}
+
+func (f *FormPanel) FieldValidate(rule FieldRule) *FormPanel {
+	f.FieldList[f.curFieldListIndex].Rules = append(f.FieldList[f.curFieldListIndex].Rules, rule)
+	return f
+}
+
+// FieldRequiredIf makes the field required when the value of the other field
+// is one of the values.
+func (f *FormPanel) FieldRequiredIf(field string, values ...string) *FormPanel {
+	return f.FieldValidate(func(ctx RuleContext) error {
+		if ctx.Value != "" {
+			return nil
+		}
+		other := ctx.Values.Get(field)
+		for _, value := range values {
+			if other == value {
+				return errors.New(language.Get("is required"))
+			}
+		}
+		return nil
+	})
+}
+
+func (f *FormPanel) FieldMinLength(length int) *FormPanel {
+	return f.FieldValidate(func(ctx RuleContext) error {
+		if ctx.Value != "" && utf8.RuneCountInString(ctx.Value) < length {
+			return fmt.Errorf(language.Get("should be at least %d characters"), length)
+		}
+		return nil
+	})
+}
+
+func (f *FormPanel) FieldMaxLength(length int) *FormPanel {
+	return f.FieldValidate(func(ctx RuleContext) error {
+		if utf8.RuneCountInString(ctx.Value) > length {
+			return fmt.Errorf(language.Get("should be at most %d characters"), length)
+		}
+		return nil
+	})
+}
+
+func (f *FormPanel) FieldMin(min float64) *FormPanel {
+	return f.FieldValidate(func(ctx RuleContext) error {
+		if ctx.Value == "" {
+			return nil
+		}
+		n, err := strconv.ParseFloat(ctx.Value, 64)
+		if err != nil {
+			return errors.New(language.Get("should be a number"))
+		}
+		if n < min {
+			return fmt.Errorf(language.Get("should not be less than %s"), formatNumber(min))
+		}
+		return nil
+	})
+}
+
+func (f *FormPanel) FieldMax(max float64) *FormPanel {
+	return f.FieldValidate(func(ctx RuleContext) error {
+		if ctx.Value == "" {
+			return nil
+		}
+		n, err := strconv.ParseFloat(ctx.Value, 64)
+		if err != nil {
+			return errors.New(language.Get("should be a number"))
+		}
+		if n > max {
+			return fmt.Errorf(language.Get("should not be greater than %s"), formatNumber(max))
+		}
+		return nil
+	})
+}
+
+// FieldRegex check the value with the pattern, and the message is the
+// failure if given. It panics if the pattern can not be compiled.
+func (f *FormPanel) FieldRegex(pattern string, msg ...string) *FormPanel {
+	reg := regexp.MustCompile(pattern)
+	return f.FieldValidate(func(ctx RuleContext) error {
+		if ctx.Value == "" || reg.MatchString(ctx.Value) {
+			return nil
+		}
+		if len(msg) > 0 {
+			return errors.New(language.Get(msg[0]))
+		}
+		return errors.New(language.Get("is in a wrong format"))
+	})
+}
+
+// FieldUnique check the value is not used by the other records of the table
+// of the form.
+func (f *FormPanel) FieldUnique() *FormPanel {
+	return f.FieldValidate(func(ctx RuleContext) error {
+		if ctx.Value == "" || ctx.SQL == nil || ctx.Table == "" {
+			return nil
+		}
+		sql := ctx.SQL().Table(ctx.Table).Where(ctx.Field.Field, "=", ctx.Value)
+		if ctx.IsUpdate() && ctx.PrimaryKey != "" && ctx.Values.Get(ctx.PrimaryKey) != "" {
+			sql = sql.Where(ctx.PrimaryKey, "!=", ctx.Values.Get(ctx.PrimaryKey))
+		}
+		count, err := sql.Count()
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.New(language.Get("already exists"))
+		}
+		return nil
+	})
+}
+
+// FieldCompare compare the value with the value of the other field by the
+// operator of =, !=, >, >=, < and <=. The values are compared as numbers if
+// they are, otherwise as strings, which works for the datetime values.
+func (f *FormPanel) FieldCompare(operator, field string) *FormPanel {
+	head := field
+	if other := f.FieldList.FindByFieldName(field); other != nil {
+		head = other.Head
+	}
+	return f.FieldValidate(func(ctx RuleContext) error {
+		other := ctx.Values.Get(field)
+		if ctx.Value == "" || other == "" {
+			return nil
+		}
+		if !compare(ctx.Value, operator, other) {
+			return fmt.Errorf(language.Get("should be %s %s"), operator, language.Get(head))
+		}
+		return nil
+	})
+}
+
+func formTypeRule(typ form2.Type) FieldRule {
+	switch typ {
+	case form2.Email:
+		return func(ctx RuleContext) error {
+			if ctx.Value == "" {
+				return nil
+			}
+			if addr, err := mail.ParseAddress(ctx.Value); err != nil || addr.Address != ctx.Value {
+				return errors.New(language.Get("should be an email"))
+			}
+			return nil
+		}
+	case form2.Url:
+		return func(ctx RuleContext) error {
+			if ctx.Value == "" {
+				return nil
+			}
+			if u, err := url.ParseRequestURI(ctx.Value); err != nil || u.Scheme == "" || u.Host == "" {
+				return errors.New(language.Get("should be an url"))
+			}
+			return nil
+		}
+	case form2.Ip:
+		return func(ctx RuleContext) error {
+			if ctx.Value != "" && net.ParseIP(ctx.Value) == nil {
+				return errors.New(language.Get("should be an ip"))
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+func compare(a, operator, b string) bool {
+	c := strings.Compare(a, b)
+	if x, err := strconv.ParseFloat(a, 64); err == nil {
+		if y, err := strconv.ParseFloat(b, 64); err == nil {
+			c = 0
+			if x < y {
+				c = -1
+			} else if x > y {
+				c = 1
+			}
+		}
+	}
+	switch operator {
+	case "=", "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	}
+	return false
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/GoAdminGroup/go-admin/modules/config"
+	"github.com/GoAdminGroup/go-admin/modules/db"
+	_ "github.com/GoAdminGroup/go-admin/modules/db/drivers/sqlite"
+	"github.com/GoAdminGroup/go-admin/plugins/admin/modules/form"
+	form2 "github.com/GoAdminGroup/go-admin/template/types/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+
+	f := NewFormPanel()
+	f.AddField("Name", "name", db.Varchar, form2.Text).FieldMinLength(3).FieldMaxLength(5)
+	f.AddField("Type", "type", db.Varchar, form2.Text)
+	f.AddField("Code", "code", db.Varchar, form2.Text).FieldRequiredIf("type", "1").FieldRegex(`^[a-z]+$`)
+	f.AddField("Age", "age", db.Int, form2.Number).FieldMin(1).FieldMax(120)
+	f.AddField("Email", "email", db.Varchar, form2.Email)
+	f.AddField("Url", "url", db.Varchar, form2.Url)
+	f.AddField("Ip", "ip", db.Varchar, form2.Ip)
+	f.AddField("Start", "start", db.Datetime, form2.Datetime)
+	f.AddField("End", "end", db.Datetime, form2.Datetime).FieldCompare(">=", "start")
+
+	values := form.Values{
+		form.PostTypeKey: {"1"},
+		"name":           {"ab"},
+		"type":           {"1"},
+		"age":            {"121"},
+		"email":          {"a@b.com"},
+		"url":            {"example.com"},
+		"ip":             {"1.2.3"},
+		"start":          {"2026-10-18 10:00:00"},
+		"end":            {"2026-10-17 10:00:00"},
+	}
+
+	errs, ok := GetFieldErrors(f.Validate(values, "id", nil))
+	assert.True(t, ok)
+	assert.Equal(t, FieldErrors{
+		"name": "should be at least 3 characters",
+		"code": "is required",
+		"age":  "should not be greater than 120",
+		"url":  "should be an url",
+		"ip":   "should be an ip",
+		"end":  "should be >= Start",
+	}, errs)
+
+	values = form.Values{
+		form.PostTypeKey: {"1"},
+		"name":           {"abcd"},
+		"type":           {"2"},
+		"code":           {"abc"},
+		"age":            {"20"},
+		"email":          {"a@b.com"},
+		"url":            {"https://example.com/a"},
+		"ip":             {"::1"},
+		"start":          {"2026-10-18 10:00:00"},
+		"end":            {"2026-10-18 10:00:00"},
+	}
+	assert.Nil(t, f.Validate(values, "id", nil))
+
+	// the fields which are not posted are skipped in an update.
+	assert.Nil(t, f.Validate(form.Values{form.PostTypeKey: {"0"}, "type": {"1"}}, "id", nil))
+	assert.NotNil(t, f.Validate(form.Values{form.PostTypeKey: {"0"}, "code": {"A"}}, "id", nil))
+
+	errs, _ = GetFieldErrors(f.Validate(form.Values{form.PostTypeKey: {"1"}, "email": {"a b"}}, "id", nil))
+	assert.Equal(t, "should be an email", errs["email"])
+
+	fields := f.FieldList.Copy().WithErrors(FieldErrors{"name": "<b>"}, form.Values{"name": {"ab"}})
+	assert.Equal(t, `<span class="text-danger">&lt;b&gt;</span>`, string(fields[0].HelpMsg))
+	assert.Equal(t, "ab", string(fields[0].Value))
+}
+
+func TestFieldUnique(t *testing.T) {
+
+	conn := db.GetConnectionByDriver(db.DriverSqlite).InitDB(map[string]config.Database{
+		"default": {Driver: db.DriverSqlite, File: filepath.Join(t.TempDir(), "admin.db")},
+	})
+	_, err := conn.Exec("CREATE TABLE users (`id` integer PRIMARY KEY autoincrement, `name` varchar(50))")
+	assert.Nil(t, err)
+	_, err = conn.Exec("INSERT INTO users (`name`) VALUES ('a'), ('b')")
+	assert.Nil(t, err)
+
+	f := NewFormPanel().SetTable("users")
+	f.AddField("Name", "name", db.Varchar, form2.Text).FieldUnique()
+
+	sql := func() *db.SQL { return db.WithDriver(conn) }
+
+	assert.NotNil(t, f.Validate(form.Values{form.PostTypeKey: {"1"}, "name": {"a"}}, "id", sql))
+	assert.Nil(t, f.Validate(form.Values{form.PostTypeKey: {"1"}, "name": {"c"}}, "id", sql))
+	assert.Nil(t, f.Validate(form.Values{form.PostTypeKey: {"0"}, "id": {"1"}, "name": {"a"}}, "id", sql))
+	assert.NotNil(t, f.Validate(form.Values{form.PostTypeKey: {"0"}, "id": {"2"}, "name": {"a"}}, "id", sql))
+}
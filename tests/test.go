@@ -47,6 +47,14 @@ func Cleaner(config config.DatabaseList) {
 		"goadmin_role_menu",
 		"goadmin_permissions",
 		"goadmin_operation_log",
+		"goadmin_audit_log",
+		"goadmin_api_tokens",
+		"goadmin_saved_views",
+		"goadmin_dashboards",
+		"goadmin_dashboard_widgets",
+		"goadmin_webhooks",
+		"goadmin_webhook_deliveries",
+		"goadmin_user_identities",
 		"goadmin_menu",
 	}
 	var autoIncrementTable = [...]string{